@@ -17,6 +17,7 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/controllers"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/controllers/user/sd"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/internal/middleware"
 	"github.com/DIMO-Network/devices-api/internal/middleware/address"
 	"github.com/DIMO-Network/devices-api/internal/middleware/metrics"
@@ -75,6 +76,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	registryClient := registry.Client{
 		Producer:     producer,
 		RequestTopic: "topic.transaction.request.send",
+		DB:           pdb.DBS,
 		Contract: registry.Contract{
 			ChainID: big.NewInt(settings.DIMORegistryChainID),
 			Address: common.HexToAddress(settings.DIMORegistryAddr),
@@ -101,13 +103,15 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Error constructing Tesla Fleet API client.")
 	}
-	autoPiSvc := services.NewAutoPiAPIService(settings, pdb.DBS)
+	autoPiSvc := services.NewAutoPiAPIService(settings, pdb.DBS, &logger)
 	autoPiIngest := services.NewIngestRegistrar(producer)
-	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, pdb.DBS, &logger)
+	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, pdb.DBS, ddSvc, &logger)
+	templateMigrator, err := autopi.NewTemplateMigrator(pdb.DBS, autoPiSvc, hardwareTemplateService, settings, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse template migration settings.")
+	}
 	userDeviceSvc := services.NewUserDeviceService(ddSvc, logger, pdb.DBS)
 
-	openAI := services.NewOpenAI(&logger, *settings)
-
 	natsSvc, err := services.NewNATSService(settings, &logger)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create NATS service")
@@ -120,6 +124,18 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		KeyPrefix: "devices-api",
 	})
 
+	llm, err := services.NewLLMProvider(&logger, settings, redisCache)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't create LLM provider.")
+	}
+
+	sigVerifier, err := services.NewSignatureVerifier(settings, redisCache, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't create signature verifier.")
+	}
+
+	mintBatcher := registry.NewMintBatcher(pdb.DBS, ddSvc, &registryClient, settings, &logger, sigVerifier)
+
 	wallet, err := services.NewSyntheticWalletInstanceService(settings)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Couldn't construct wallet client.")
@@ -135,11 +151,13 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		logger.Fatal().Err(err).Msg("Failed to ping ClickHouse.")
 	}
 
+	teslaTokens := services.NewTeslaTokenManager(pdb.DBS, cipher, teslaFleetAPISvc, teslaTaskService, &logger)
+
 	// controllers
 	userDeviceController := controllers.NewUserDevicesController(settings, pdb.DBS, &logger, ddSvc, ddIntSvc,
 		teslaTaskService, teslaOracle, cipher, autoPiSvc, autoPiIngest,
-		producer, redisCache, openAI,
-		natsSvc, wallet, userDeviceSvc, teslaFleetAPISvc, ipfsSvc, chConn)
+		producer, redisCache, llm,
+		natsSvc, wallet, userDeviceSvc, teslaFleetAPISvc, ipfsSvc, chConn, sigVerifier, teslaTokens)
 	webhooksController := controllers.NewWebhooksController(settings, pdb.DBS, &logger, autoPiSvc, ddIntSvc)
 	documentsController := controllers.NewDocumentsController(settings, &logger, s3ServiceClient, pdb.DBS)
 	countriesController := controllers.NewCountriesController()
@@ -164,7 +182,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	v1.Get("/swagger/*", swagger.HandlerDefault)
 
 	// Device Definitions
-	nftController := controllers.NewNFTController(settings, pdb.DBS, &logger, ddSvc, teslaTaskService, ddIntSvc, teslaOracle)
+	nftController := controllers.NewNFTController(settings, pdb.DBS, &logger, ddSvc, teslaTaskService, ddIntSvc, teslaOracle, sigVerifier)
 
 	v1.Get("/countries", countriesController.GetSupportedCountries)
 	v1.Get("/countries/:countryCode", countriesController.GetCountry)
@@ -192,23 +210,35 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	vPriv := app.Group("/v1/vehicle/:tokenID", privilegeAuth)
 
+	autoPiJobsController := controllers.NewAutoPiJobsController(pdb.DBS, &logger)
+
 	privTokenWare := privilegetoken.New(privilegetoken.Config{Log: &logger})
 
 	vehicleAddr := common.HexToAddress(settings.VehicleNFTAddress)
 
 	// vehicle command privileges
 	vPriv.Patch("/vin", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), userDeviceController.UpdateVINV2)
+	vPriv.Get("/commands", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.GetCommandHistory)
+	vPriv.Get("/commands/:requestID", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.GetCommandRequest)
+	vPriv.Get("/autopi/jobs", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), autoPiJobsController.ListVehicleAutoPiJobs)
 	vPriv.Post("/commands/doors/unlock", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.UnlockDoors)
 	vPriv.Post("/commands/doors/lock", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.LockDoors)
 	vPriv.Post("/commands/trunk/open", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.OpenTrunk)
 	vPriv.Post("/commands/frunk/open", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.OpenFrunk)
 	vPriv.Post("/commands/charge/start", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.ChargeStart)
 	vPriv.Post("/commands/charge/stop", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.ChargeStop)
+	vPriv.Post("/commands/charge/limit", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.SetChargeLimit)
+	vPriv.Post("/commands/charge/amps", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.SetChargingAmps)
+	vPriv.Post("/commands/climate/on", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.ClimateOn)
+	vPriv.Post("/commands/climate/off", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.ClimateOff)
+	vPriv.Post("/commands/horn/honk", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.HonkHorn)
+	vPriv.Post("/commands/lights/flash", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.FlashLights)
 
 	// Vehicle owner routes.
 	vPriv.Get("/error-codes", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), userDeviceController.GetUserDeviceErrorCodeQueriesByTokenID)
 	vPriv.Post("/error-codes", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), userDeviceController.QueryDeviceErrorCodesByTokenID)
 	vPriv.Post("/error-codes/clear", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), userDeviceController.ClearUserDeviceErrorCodeQueryByTokenID)
+	vPriv.Get("/error-codes/summary", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), userDeviceController.GetErrorCodesSummaryByTokenID)
 
 	// Traditional tokens
 
@@ -228,9 +258,15 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	v1Auth.Get("/documents", documentsController.GetDocuments)
 	v1Auth.Get("/documents/:id", documentsController.GetDocumentByID)
 	v1Auth.Post("/documents", documentsController.PostDocument)
+	v1Auth.Post("/documents/upload-url", documentsController.CreateDocumentUploadURL)
+	v1Auth.Post("/documents/:id/finalize", documentsController.FinalizeDocument)
+	v1Auth.Patch("/documents/:id", documentsController.UpdateDocument)
 	v1Auth.Delete("/documents/:id", documentsController.DeleteDocument)
 	v1Auth.Get("/documents/:id/download", documentsController.DownloadDocument)
 
+	transactionsController := controllers.NewTransactionsController(settings, pdb.DBS, &logger)
+	aftermarketDevicesController := controllers.NewAftermarketDevicesController(pdb.DBS, &logger, &registryClient, sigVerifier)
+
 	// Vehicle owner routes.
 	udOwnerMw := owner.UserDevice(pdb, &logger)
 	udOwner := v1Auth.Group("/user/devices/:userDeviceID", udOwnerMw)
@@ -248,6 +284,9 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	udOwner.Delete("/integrations/:integrationID", userDeviceController.DeleteUserDeviceIntegration)
 	udOwner.Post("/integrations/:integrationID", userDeviceController.RegisterDeviceIntegration)
 
+	// Aftermarket device owner routes.
+	amOwner := owner.AftermarketDevice(pdb, &logger)
+
 	{
 		addr := address.New(&logger)
 
@@ -266,9 +305,49 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		}
 
 		v1Auth.Post("/user/synthetic/device/:tokenID/commands/reauthenticate", addr, sdc.PostReauthenticate)
+
+		webhookSubscriptionsController := controllers.NewWebhookSubscriptionsController(pdb.DBS, &logger, cipher)
+
+		v1Auth.Get("/webhooks", addr, webhookSubscriptionsController.ListSubscriptions)
+		v1Auth.Post("/webhooks", addr, webhookSubscriptionsController.CreateSubscription)
+		v1Auth.Delete("/webhooks/:subscriptionID", addr, webhookSubscriptionsController.DeleteSubscription)
+		v1Auth.Get("/webhooks/:subscriptionID/deliveries", addr, webhookSubscriptionsController.ListDeliveries)
+		v1Auth.Post("/webhooks/:subscriptionID/deliveries/:deliveryID/replay", addr, webhookSubscriptionsController.ReplayDelivery)
+
+		v1Auth.Get("/transactions", addr, transactionsController.ListTransactions)
+
+		v1Auth.Get("/aftermarket/devices", addr, aftermarketDevicesController.ListAftermarketDevices)
+
+		v1Auth.Get("/aftermarket/device/:serial/commands/claim", amOwner, addr, aftermarketDevicesController.GetAftermarketDeviceClaimPayload)
+		v1Auth.Post("/aftermarket/device/:serial/commands/claim", amOwner, addr, aftermarketDevicesController.PostAftermarketDeviceClaim)
+		v1Auth.Get("/aftermarket/device/:serial/commands/pair", amOwner, addr, aftermarketDevicesController.GetAftermarketDevicePairPayload)
+		v1Auth.Post("/aftermarket/device/:serial/commands/pair", amOwner, addr, aftermarketDevicesController.PostAftermarketDevicePair)
+		v1Auth.Get("/aftermarket/device/:serial/commands/unpair", amOwner, addr, aftermarketDevicesController.GetAftermarketDeviceUnpairPayload)
+		v1Auth.Post("/aftermarket/device/:serial/commands/unpair", amOwner, addr, aftermarketDevicesController.PostAftermarketDeviceUnpair)
+
+		vehicleHandoversController := controllers.NewVehicleHandoversController(pdb.DBS, &logger)
+
+		v1Auth.Get("/vehicle-handovers", addr, vehicleHandoversController.ListVehicleHandovers)
+		v1Auth.Get("/vehicle-handovers/:handoverID", addr, vehicleHandoversController.GetVehicleHandover)
+
+		mintBatchesController := controllers.NewMintBatchesController(mintBatcher, &logger)
+
+		v1Auth.Post("/mint-batches", addr, mintBatchesController.CreateMintBatch)
+		v1Auth.Get("/mint-batches/:batchID", addr, mintBatchesController.GetMintBatch)
+		v1Auth.Post("/mint-batches/:batchID/signatures", addr, mintBatchesController.SubmitMintBatchSignatures)
 	}
 
-	syntheticController := controllers.NewSyntheticDevicesController(settings, pdb.DBS, &logger, ddSvc, wallet, registryClient, teslaOracle)
+	v1Auth.Get("/transactions/:requestID", transactionsController.GetTransaction)
+	udOwner.Get("/transactions", transactionsController.ListVehicleTransactions)
+	udOwner.Get("/autopi/jobs", autoPiJobsController.ListUserDeviceAutoPiJobs)
+
+	// Unlike the commands, which anyone may prepare for an unpaired device, details are for owners.
+	amReader := owner.AftermarketDeviceOwner(pdb, &logger)
+	v1Auth.Get("/aftermarket/device/by-serial/:serial", amReader, aftermarketDevicesController.GetAftermarketDeviceBySerial)
+	v1Auth.Get("/aftermarket/device/by-token-id/:tokenID", amReader, aftermarketDevicesController.GetAftermarketDeviceByTokenID)
+	v1Auth.Get("/aftermarket/device/by-address/:address", amReader, aftermarketDevicesController.GetAftermarketDeviceByAddress)
+
+	syntheticController := controllers.NewSyntheticDevicesController(settings, pdb.DBS, &logger, ddSvc, wallet, registryClient, teslaOracle, sigVerifier)
 
 	udOwner.Get("/integrations/:integrationID/commands/mint", syntheticController.GetSyntheticDeviceMintingPayload)
 	udOwner.Post("/integrations/:integrationID/commands/mint", syntheticController.MintSyntheticDevice)
@@ -300,16 +379,22 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	ctx := context.Background()
 
-	store, err := registry.NewProcessor(pdb.DBS, &logger, settings, teslaTaskService, ddSvc)
+	store, err := registry.NewProcessor(pdb.DBS, &logger, settings, teslaTaskService, ddSvc, &registryClient)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create registry storage client")
 	}
 
-	if err := registry.RunConsumer(ctx, kclient, &logger, store); err != nil {
+	retry := kafka.NewRetryHandler(producer, kafka.DefaultRetryPolicy, &logger)
+	if err := registry.RunConsumer(ctx, kclient, &logger, store, retry); err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create transaction listener")
 	}
 
-	go startGRPCServer(settings, pdb.DBS, hardwareTemplateService, &logger, ddSvc, userDeviceSvc, teslaTaskService, cipher, teslaFleetAPISvc, producer)
+	startTeslaTokenSweeper(ctx, &logger, settings, ddSvc, teslaTokens)
+	startCommandRequestReaper(ctx, &logger, settings, pdb.DBS, s3ServiceClient)
+	startWebhookDispatcher(ctx, &logger, settings, pdb.DBS, cipher)
+	startTemplateMigrationWorker(ctx, &logger, settings, templateMigrator)
+
+	go startGRPCServer(settings, pdb.DBS, hardwareTemplateService, &logger, ddSvc, userDeviceSvc, teslaTaskService, cipher, teslaFleetAPISvc, teslaTokens, producer, mintBatcher, templateMigrator)
 
 	c := make(chan os.Signal, 1)                    // Create channel to signify a signal being sent with length of 1
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM) // When an interrupt or termination signal is sent, notify the channel
@@ -346,7 +431,10 @@ func startGRPCServer(
 	teslaTaskSvc services.TeslaTaskService,
 	cipher cip.Cipher,
 	teslaAPI services.TeslaFleetAPIService,
+	teslaTokens services.TeslaTokenManager,
 	producer sarama.SyncProducer,
+	mintBatcher *registry.MintBatcher,
+	templateMigrator *autopi.TemplateMigrator,
 ) {
 	lis, err := net.Listen("tcp", ":"+settings.GRPCPort)
 	if err != nil {
@@ -366,9 +454,9 @@ func startGRPCServer(
 	)
 
 	pb.RegisterUserDeviceServiceServer(server, rpc.NewUserDeviceRPCService(dbs, settings, hardwareTemplateService, logger,
-		deviceDefSvc, userDeviceSvc, teslaTaskSvc))
+		deviceDefSvc, userDeviceSvc, teslaTaskSvc, mintBatcher, templateMigrator))
 	pb.RegisterAftermarketDeviceServiceServer(server, rpc.NewAftermarketDeviceService(dbs, logger))
-	pb.RegisterTeslaServiceServer(server, rpc.NewTeslaRPCService(dbs, settings, cipher, teslaAPI, logger, producer))
+	pb.RegisterTeslaServiceServer(server, rpc.NewTeslaRPCService(dbs, settings, cipher, teslaAPI, teslaTokens, logger, producer))
 
 	if err := server.Serve(lis); err != nil {
 		logger.Fatal().Err(err).Msg("gRPC server terminated unexpectedly")
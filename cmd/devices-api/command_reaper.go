@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/rs/zerolog"
+)
+
+// startCommandRequestReaper periodically marks vehicle commands that never got a status
+// event, and AutoPi jobs that never got a webhook, as timed out. It also deletes document
+// uploads that were never finalized. Leaving the interval empty disables it.
+func startCommandRequestReaper(ctx context.Context, logger *zerolog.Logger, settings *config.Settings, dbs func() *db.ReaderWriter, s3Client *s3.Client) {
+	if settings.CommandReaperInterval == "" {
+		logger.Info().Msg("Command request reaper disabled.")
+		return
+	}
+
+	interval, err := time.ParseDuration(settings.CommandReaperInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse command reaper interval.")
+	}
+
+	deadlines, err := services.NewCommandDeadlines(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse command timeout settings.")
+	}
+
+	autoPiJobTimeout, err := services.NewAutoPiJobTimeout(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse AutoPi job timeout.")
+	}
+
+	pendingDocumentTTL, err := services.NewPendingDocumentTTL(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse pending document TTL.")
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := services.ReapExpiredCommandRequests(ctx, dbs, deadlines)
+				if err != nil {
+					logger.Err(err).Msg("Failed to time out overdue command requests.")
+					continue
+				}
+				if n > 0 {
+					logger.Info().Int("timedOut", n).Msg("Timed out overdue command requests.")
+				}
+
+				n, err = services.ReapExpiredAutoPiJobs(ctx, dbs, autoPiJobTimeout)
+				if err != nil {
+					logger.Err(err).Msg("Failed to time out overdue AutoPi jobs.")
+					continue
+				}
+				if n > 0 {
+					logger.Info().Int("timedOut", n).Msg("Timed out overdue AutoPi jobs.")
+				}
+
+				n, err = services.ReapPendingDocuments(ctx, dbs, s3Client, settings.AWSDocumentsBucketName, pendingDocumentTTL)
+				if err != nil {
+					logger.Err(err).Msg("Failed to delete abandoned document uploads.")
+					continue
+				}
+				if n > 0 {
+					logger.Info().Int("deleted", n).Msg("Deleted abandoned document uploads.")
+				}
+			}
+		}
+	}()
+}
@@ -92,12 +92,15 @@ func main() {
 	// Run API
 	if len(os.Args) == 1 {
 		startMonitoringServer(logger, &settings)
-		startCredentialConsumer(logger, &settings, pdb)
+		startCredentialConsumer(logger, &settings, pdb, deps.getKafkaProducer())
 		startTaskStatusConsumer(logger, &settings, pdb)
 		startWebAPI(logger, &settings, pdb, deps.getKafkaProducer(), deps.getS3ServiceClient(ctx))
 	} else {
 		subcommands.Register(&migrateDBCmd{logger: logger, settings: settings}, "database")
 		subcommands.Register(&findOldStyleTasks{logger: logger, settings: settings, pdb: pdb}, "events")
+		subcommands.Register(&reconcileChainCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "events")
+		subcommands.Register(&replayContractEventsCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "events")
+		subcommands.Register(&redriveDeadLettersCmd{logger: logger, settings: settings, container: deps}, "events")
 
 		subcommands.Register(&setCommandCompatibilityCmd{logger: logger, settings: settings, pdb: pdb, ddSvc: deps.getDeviceDefinitionService()}, "device integrations")
 		subcommands.Register(&remakeAutoPiTopicCmd{logger: logger, settings: settings, pdb: pdb, ddSvc: deps.getDeviceDefinitionService()}, "device integrations")
@@ -112,7 +115,10 @@ func main() {
 		subcommands.Register(&stopTaskByKeyCmd{logger: logger, settings: settings, container: deps, pdb: pdb}, "tasks")
 
 		subcommands.Register(&syncDeviceTemplatesCmd{logger: logger, settings: settings, pdb: pdb}, "user devices")
+		subcommands.Register(&previewHardwareTemplateCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "user devices")
+		subcommands.Register(&templateMigrationCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "user devices")
 		subcommands.Register(&vinDecodeCompareCmd{logger: logger, settings: settings, pdb: pdb}, "user devices")
+		subcommands.Register(&syncDocumentsCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "user devices")
 
 		cipher := createKMS(&settings, &logger)
 
@@ -165,7 +171,7 @@ func changeLogLevel(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).SendString("log level set to: " + level.String())
 }
 
-func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store) {
+func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store, producer sarama.SyncProducer) {
 	clusterConfig := sarama.NewConfig()
 	clusterConfig.Version = sarama.V2_8_1_0
 	clusterConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
@@ -182,7 +188,9 @@ func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, p
 		logger.Fatal().Err(err).Msg("Could not start credential update consumer")
 	}
 	credService := services.NewCredentialListener(pdb.DBS, &logger)
-	consumer.Start(context.Background(), credService.ProcessCredentialsMessages)
+	retry := kafka.NewRetryHandler(producer, kafka.DefaultRetryPolicy, &logger)
+	consumer.Start(context.Background(), retry.Watermill(settings.TaskCredentialTopic, credService.ProcessCredentialsMessage))
+	consumer.StartRetries(context.Background(), retry.Watermill(kafka.RetryTopic(settings.TaskCredentialTopic), credService.ProcessCredentialsMessage))
 
 	logger.Info().Msg("Credential update consumer started")
 }
@@ -222,7 +230,9 @@ func startTaskStatusConsumer(logger zerolog.Logger, settings *config.Settings, p
 	}
 
 	taskStatusService := services.NewTaskStatusListener(pdb.DBS, &logger, ddSvc, kp, cioSvc, settings)
-	consumer.Start(context.Background(), taskStatusService.ProcessTaskUpdates)
+	retry := kafka.NewRetryHandler(kp, kafka.DefaultRetryPolicy, &logger)
+	consumer.Start(context.Background(), retry.Watermill(settings.TaskStatusTopic, taskStatusService.ProcessTaskUpdate))
+	consumer.StartRetries(context.Background(), retry.Watermill(kafka.RetryTopic(settings.TaskStatusTopic), taskStatusService.ProcessTaskUpdate))
 
 	logger.Info().Msg("Task status consumer started")
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/autopi"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+)
+
+type previewHardwareTemplateCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	userDeviceID string
+	history      int
+}
+
+func (*previewHardwareTemplateCmd) Name() string { return "preview-hardware-template" }
+func (*previewHardwareTemplateCmd) Synopsis() string {
+	return "show which hardware template a user device should be on, and why, without applying it"
+}
+func (*previewHardwareTemplateCmd) Usage() string {
+	return `preview-hardware-template -user-device-id <user device ID> [-history <count>]
+  `
+}
+
+func (p *previewHardwareTemplateCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.userDeviceID, "user-device-id", "", "The user device to check.")
+	f.IntVar(&p.history, "history", 10, "How many recent template changes to print for the device. 0 to skip.")
+}
+
+func (p *previewHardwareTemplateCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.userDeviceID == "" {
+		p.logger.Error().Msg("-user-device-id is required")
+		return subcommands.ExitUsageError
+	}
+
+	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS, &p.logger)
+	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, p.pdb.DBS, p.container.getDeviceDefinitionService(), &p.logger)
+
+	res, err := hardwareTemplateService.PreviewTemplate(ctx, p.userDeviceID)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to preview hardware template")
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("template %s chosen by rule %s\n", res.TemplateID, res.Rule)
+	for _, c := range res.Checks {
+		outcome := "skipped"
+		if c.Matched {
+			outcome = "matched"
+		}
+		fmt.Printf("  %-18s %-7s template=%-5q %s\n", c.Rule, outcome, c.TemplateID, c.Reason)
+	}
+
+	if p.history <= 0 {
+		return subcommands.ExitSuccess
+	}
+
+	changes, err := models.HardwareTemplateChanges(
+		models.HardwareTemplateChangeWhere.UserDeviceID.EQ(null.StringFrom(p.userDeviceID)),
+		qm.OrderBy(models.HardwareTemplateChangeColumns.CreatedAt+" DESC"),
+		qm.Limit(p.history),
+	).All(ctx, p.pdb.DBS().Reader)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to load hardware template history")
+		return subcommands.ExitFailure
+	}
+
+	fmt.Printf("recent template changes: %d\n", len(changes))
+	for _, c := range changes {
+		fmt.Printf("  %s serial=%s %s -> %s %s\n", c.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), c.Serial, c.OldTemplateID.String, c.NewTemplateID, c.Reason.String)
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type reconcileChainCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	fix      bool
+	format   string
+	pageSize int
+}
+
+func (*reconcileChainCmd) Name() string { return "reconcile-chain" }
+func (*reconcileChainCmd) Synopsis() string {
+	return "compare vehicles and devices in the database with the registry"
+}
+func (*reconcileChainCmd) Usage() string {
+	return `reconcile-chain [-fix] [-format json|csv] [-page-size n]:
+	Walks user_devices, aftermarket_devices and synthetic_devices and writes every row that
+	disagrees with the chain to stdout: owner, paired vehicle, device definition, missing token
+	and burned token. With -fix, the rows are corrected as well. Burned tokens are cleaned up the
+	way the contract events consumer does it. Re-pairing doesn't touch the ingest topics; run
+	remake-aftermarket-topic afterwards.
+`
+}
+
+func (p *reconcileChainCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&p.fix, "fix", false, "write the chain's values to the database")
+	f.StringVar(&p.format, "format", "json", "output format, json or csv")
+	f.IntVar(&p.pageSize, "page-size", 100, "rows to read at a time")
+}
+
+func (p *reconcileChainCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	write, flush, err := mismatchWriter(os.Stdout, p.format)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("Bad output format.")
+		return subcommands.ExitUsageError
+	}
+
+	ethClient, err := ethclient.Dial(p.settings.MainRPCURL)
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to connect to the chain.")
+		return subcommands.ExitFailure
+	}
+	defer ethClient.Close()
+
+	chain, err := services.NewChainReader(ethClient, &p.settings)
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to bind registry contracts.")
+		return subcommands.ExitFailure
+	}
+
+	var fixer services.ChainFixer
+	if p.fix {
+		producer := p.container.getKafkaProducer()
+		fixer = services.NewContractsEventsConsumer(p.pdb, &p.logger, &p.settings, nil, p.container.getDeviceDefinitionService(),
+			services.NewTeslaTaskService(&p.settings, producer), services.NewIngestRegistrar(producer))
+	}
+
+	rec := services.NewChainReconciler(p.pdb, chain, fixer, &p.logger)
+	rec.Fix = p.fix
+	rec.PageSize = p.pageSize
+
+	var found, fixed int
+	err = rec.Run(ctx, func(m *services.ChainMismatch) error {
+		found++
+		if m.Fixed {
+			fixed++
+		}
+		return write(m)
+	})
+	if flushErr := flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to reconcile with the chain.")
+		return subcommands.ExitFailure
+	}
+
+	p.logger.Info().Int("mismatches", found).Int("fixed", fixed).Bool("fix", p.fix).Msg("Finished reconciling with the chain.")
+	return subcommands.ExitSuccess
+}
+
+// mismatchWriter returns a function that writes one mismatch to w in the given format, and one
+// that flushes anything buffered.
+func mismatchWriter(w io.Writer, format string) (func(*services.ChainMismatch) error, func() error, error) {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		return func(m *services.ChainMismatch) error { return enc.Encode(m) }, func() error { return nil }, nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		header := false
+		write := func(m *services.ChainMismatch) error {
+			if !header {
+				if err := cw.Write([]string{"table", "id", "tokenId", "kind", "database", "chain", "fixed"}); err != nil {
+					return err
+				}
+				header = true
+			}
+			tokenID := ""
+			if m.TokenID != nil {
+				tokenID = m.TokenID.String()
+			}
+			return cw.Write([]string{m.Table, m.ID, tokenID, string(m.Kind), m.Database, m.Chain, strconv.FormatBool(m.Fixed)})
+		}
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		return write, flush, nil
+	default:
+		return nil, nil, fmt.Errorf("unrecognized format %q", format)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
+	"github.com/IBM/sarama"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+// Consumer group whose committed offsets record how far each dead-letter topic has been re-driven.
+const redriveGroupID = "devices-api-dead-letter-redrive"
+
+type redriveDeadLettersCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	container dependencyContainer
+
+	topic  string
+	limit  int
+	dryRun bool
+}
+
+func (*redriveDeadLettersCmd) Name() string { return "redrive-dead-letters" }
+func (*redriveDeadLettersCmd) Synopsis() string {
+	return "send dead-lettered messages back through the devices-api consumer they failed in"
+}
+func (*redriveDeadLettersCmd) Usage() string {
+	return `redrive-dead-letters -topic topic.task.status [-limit n] [-dry-run]:
+	Reads the dead-letter topic for the given topic, from where the last re-drive stopped up to
+	its current end, and publishes each message with the same key, value and headers to the
+	original topic's retry topic (the topic name plus "` + kafka.RetrySuffix + `"). Only
+	devices-api consumes retry topics, so other services reading the original topic don't see
+	the message again. Progress is committed under the consumer group ` + redriveGroupID + `, so
+	a message is only re-driven once. Messages that fail again are dead-lettered again.
+`
+}
+
+func (p *redriveDeadLettersCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.topic, "topic", "", "topic whose dead letters should be re-driven")
+	f.IntVar(&p.limit, "limit", 0, "stop after this many messages, 0 for no limit")
+	f.BoolVar(&p.dryRun, "dry-run", false, "list the messages without publishing or committing")
+}
+
+func (p *redriveDeadLettersCmd) Execute(_ context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.topic == "" {
+		p.logger.Error().Msg("The -topic flag is required.")
+		return subcommands.ExitUsageError
+	}
+
+	dlt := kafka.DeadLetterTopic(p.topic)
+
+	kc := sarama.NewConfig()
+	kc.Version = sarama.V3_6_0_0
+
+	client, err := sarama.NewClient(strings.Split(p.settings.KafkaBrokers, ","), kc)
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to create Kafka client.")
+		return subcommands.ExitFailure
+	}
+	defer client.Close() //nolint
+
+	cons, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to create Kafka consumer.")
+		return subcommands.ExitFailure
+	}
+	defer cons.Close() //nolint
+
+	om, err := sarama.NewOffsetManagerFromClient(redriveGroupID, client)
+	if err != nil {
+		p.logger.Err(err).Msg("Failed to create offset manager.")
+		return subcommands.ExitFailure
+	}
+	defer om.Close() //nolint
+
+	ps, err := cons.Partitions(dlt)
+	if err != nil {
+		p.logger.Err(err).Str("topic", dlt).Msg("Failed to list dead-letter partitions.")
+		return subcommands.ExitFailure
+	}
+
+	var producer sarama.SyncProducer
+	if !p.dryRun {
+		producer = p.container.getKafkaProducer()
+	}
+
+	redriven := 0
+
+	for _, part := range ps {
+		if p.limit > 0 && redriven >= p.limit {
+			break
+		}
+
+		n, err := p.redrivePartition(client, cons, om, producer, dlt, part, p.limit-redriven)
+		redriven += n
+		if err != nil {
+			p.logger.Err(err).Str("topic", dlt).Int32("partition", part).Int("redriven", redriven).Msg("Failed to re-drive partition.")
+			return subcommands.ExitFailure
+		}
+	}
+
+	p.logger.Info().Str("topic", dlt).Int("messages", redriven).Bool("dryRun", p.dryRun).Msg("Finished re-driving dead letters.")
+	return subcommands.ExitSuccess
+}
+
+// redrivePartition re-publishes the partition's messages from the committed offset up to the
+// high-water mark at the time of the call, committing as it goes. A limit of zero or less means
+// no limit.
+func (p *redriveDeadLettersCmd) redrivePartition(client sarama.Client, cons sarama.Consumer, om sarama.OffsetManager, producer sarama.SyncProducer, dlt string, part int32, limit int) (int, error) {
+	pom, err := om.ManagePartition(dlt, part)
+	if err != nil {
+		return 0, err
+	}
+	defer pom.Close() //nolint
+
+	hwm, err := client.GetOffset(dlt, part, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+
+	start, _ := pom.NextOffset()
+	if start < 0 {
+		start, err = client.GetOffset(dlt, part, sarama.OffsetOldest)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if start >= hwm {
+		return 0, nil
+	}
+
+	pc, err := cons.ConsumePartition(dlt, part, start)
+	if err != nil {
+		return 0, err
+	}
+	defer pc.Close() //nolint
+
+	count := 0
+	for m := range pc.Messages() {
+		original := deadLetterHeader(m, kafka.HeaderDeadLetterTopic)
+		if original == "" {
+			original = p.topic
+		}
+		target := kafka.RetryTopic(original)
+
+		logger := p.logger.With().Str("topic", target).Int32("partition", part).Int64("offset", m.Offset).Logger()
+
+		if p.dryRun {
+			logger.Info().Str("error", deadLetterHeader(m, kafka.HeaderDeadLetterError)).Msg("Would re-drive message.")
+		} else {
+			pm := &sarama.ProducerMessage{
+				Topic:   target,
+				Headers: kafka.StripDeadLetterHeaders(m.Headers),
+			}
+			if m.Key != nil {
+				pm.Key = sarama.ByteEncoder(m.Key)
+			}
+			if m.Value != nil {
+				pm.Value = sarama.ByteEncoder(m.Value)
+			}
+
+			if _, _, err := producer.SendMessage(pm); err != nil {
+				return count, err
+			}
+
+			pom.MarkOffset(m.Offset+1, "")
+			om.Commit()
+		}
+
+		count++
+
+		if m.Offset >= hwm-1 || (limit > 0 && count >= limit) {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+func deadLetterHeader(m *sarama.ConsumerMessage, key string) string {
+	for _, h := range m.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/genericad"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/payloads"
+	"github.com/IBM/sarama"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type replayContractEventsCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	source    string
+	fromBlock uint64
+	toBlock   uint64
+	contract  string
+	dryRun    bool
+}
+
+func (*replayContractEventsCmd) Name() string { return "replay-contract-events" }
+func (*replayContractEventsCmd) Synopsis() string {
+	return "run the contract event handlers again for a block range"
+}
+func (*replayContractEventsCmd) Usage() string {
+	return `replay-contract-events -from-block n -to-block m [-source chain|kafka] [-contract 0x...] [-dry-run]:
+	Re-runs the contract events consumer's handlers for every event in the block range, including
+	ones that were already processed. With -source chain (the default) the events are read from
+	the RPC node with eth_getLogs. With -source kafka the contract events topic is streamed from
+	the oldest retained offset up to the end at the time the command starts. The contract
+	cursors only ever move forward, so replaying old blocks leaves them alone.
+`
+}
+
+func (p *replayContractEventsCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.source, "source", "chain", "where to read events from, chain or kafka")
+	f.Uint64Var(&p.fromBlock, "from-block", 0, "first block to replay")
+	f.Uint64Var(&p.toBlock, "to-block", 0, "last block to replay, inclusive")
+	f.StringVar(&p.contract, "contract", "", "only replay events emitted by this contract")
+	f.BoolVar(&p.dryRun, "dry-run", false, "list the events without running the handlers")
+}
+
+func (p *replayContractEventsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.toBlock < p.fromBlock {
+		p.logger.Error().Msg("The -to-block must not come before -from-block.")
+		return subcommands.ExitUsageError
+	}
+
+	var contract *common.Address
+	if p.contract != "" {
+		if !common.IsHexAddress(p.contract) {
+			p.logger.Error().Msgf("Invalid contract address %q.", p.contract)
+			return subcommands.ExitUsageError
+		}
+		addr := common.HexToAddress(p.contract)
+		contract = &addr
+	}
+
+	var consumer *services.ContractsEventsConsumer
+	if !p.dryRun {
+		producer := p.container.getKafkaProducer()
+		ddSvc := p.container.getDeviceDefinitionService()
+		ingestReg := services.NewIngestRegistrar(producer)
+		integ := genericad.NewIntegration(p.pdb.DBS, ddSvc, ingestReg, &p.logger)
+		consumer = services.NewContractsEventsConsumer(p.pdb, &p.logger, &p.settings, integ, ddSvc,
+			services.NewTeslaTaskService(&p.settings, producer), ingestReg)
+	}
+
+	replayed := 0
+	handle := func(ev *services.ContractEventData) error {
+		if contract != nil && ev.Contract != *contract {
+			return nil
+		}
+
+		logger := p.logger.With().Str("event", ev.EventName).Str("transactionHash", ev.TransactionHash.Hex()).Uint("index", ev.Index).Logger()
+		if ev.Block.Number != nil {
+			logger = logger.With().Uint64("block", ev.Block.Number.Uint64()).Logger()
+		}
+
+		if p.dryRun {
+			logger.Info().Msg("Would replay event.")
+			return nil
+		}
+
+		if err := consumer.Replay(ctx, ev); err != nil {
+			return fmt.Errorf("failed to replay %s event in transaction %s: %w", ev.EventName, ev.TransactionHash.Hex(), err)
+		}
+		replayed++
+		return nil
+	}
+
+	var err error
+	switch p.source {
+	case "chain":
+		err = p.fromChain(ctx, handle)
+	case "kafka":
+		err = p.fromKafka(ctx, handle)
+	default:
+		p.logger.Error().Msgf("Unrecognized source %q.", p.source)
+		return subcommands.ExitUsageError
+	}
+	if err != nil {
+		p.logger.Err(err).Int("replayed", replayed).Msg("Failed to replay events.")
+		return subcommands.ExitFailure
+	}
+
+	p.logger.Info().Int("events", replayed).Uint64("fromBlock", p.fromBlock).Uint64("toBlock", p.toBlock).Msg("Finished replaying contract events.")
+	return subcommands.ExitSuccess
+}
+
+func (p *replayContractEventsCmd) fromChain(ctx context.Context, handle func(*services.ContractEventData) error) error {
+	ethClient, err := ethclient.Dial(p.settings.MainRPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the chain: %w", err)
+	}
+	defer ethClient.Close()
+
+	events, err := services.FetchContractEvents(ctx, ethClient, &p.settings, p.fromBlock, p.toBlock)
+	if err != nil {
+		return err
+	}
+
+	for _, ev := range events {
+		if err := handle(ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partitionReader walks one partition of the contract events topic up to the high-water mark
+// it had when the replay started. next holds the partition's next event in the block range.
+type partitionReader struct {
+	partition int32
+	pc        sarama.PartitionConsumer
+	hwm       int64
+	next      *services.ContractEventData
+	done      bool
+}
+
+// fromKafka streams the contract events topic, from the oldest retained offset up to the
+// high-water marks at the time of the call, handing the events in the block range to handle.
+// Each partition is in chain order, so only the head of each is held and the earliest head is
+// handled next.
+func (p *replayContractEventsCmd) fromKafka(ctx context.Context, handle func(*services.ContractEventData) error) error {
+	kc := sarama.NewConfig()
+	kc.Version = sarama.V3_6_0_0
+
+	brokers := strings.Split(p.settings.KafkaBrokers, ",")
+
+	client, err := sarama.NewClient(brokers, kc)
+	if err != nil {
+		return err
+	}
+	defer client.Close() //nolint
+
+	cons, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer cons.Close() //nolint
+
+	topic := p.settings.ContractsEventTopic
+
+	ps, err := cons.Partitions(topic)
+	if err != nil {
+		return err
+	}
+
+	var readers []*partitionReader
+	defer func() {
+		for _, r := range readers {
+			_ = r.pc.Close()
+		}
+	}()
+
+	for _, part := range ps {
+		hwm, err := client.GetOffset(topic, part, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		oldest, err := client.GetOffset(topic, part, sarama.OffsetOldest)
+		if err != nil {
+			return err
+		}
+		if oldest >= hwm {
+			continue
+		}
+
+		pc, err := cons.ConsumePartition(topic, part, oldest)
+		if err != nil {
+			return err
+		}
+
+		r := &partitionReader{partition: part, pc: pc, hwm: hwm}
+		readers = append(readers, r)
+
+		if err := p.advance(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	for {
+		var earliest *partitionReader
+		for _, r := range readers {
+			if r.next != nil && (earliest == nil || eventBefore(r.next, earliest.next)) {
+				earliest = r
+			}
+		}
+		if earliest == nil {
+			return nil
+		}
+
+		if err := handle(earliest.next); err != nil {
+			return err
+		}
+
+		if err := p.advance(ctx, earliest); err != nil {
+			return err
+		}
+	}
+}
+
+// advance moves the reader to its partition's next event in the block range, leaving next nil
+// once the high-water mark is reached.
+func (p *replayContractEventsCmd) advance(ctx context.Context, r *partitionReader) error {
+	source := fmt.Sprintf("chain/%d", p.settings.DIMORegistryChainID)
+
+	r.next = nil
+	for !r.done {
+		var m *sarama.ConsumerMessage
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m = <-r.pc.Messages():
+		}
+
+		if m.Offset >= r.hwm-1 {
+			r.done = true
+		}
+
+		var ce payloads.CloudEvent[json.RawMessage]
+		if err := json.Unmarshal(m.Value, &ce); err != nil {
+			p.logger.Warn().Err(err).Int32("partition", r.partition).Int64("offset", m.Offset).Msg("Skipping unparseable message.")
+			continue
+		}
+		if ce.Type != "zone.dimo.contract.event" || ce.Source != source {
+			continue
+		}
+
+		var data services.ContractEventData
+		if err := json.Unmarshal(ce.Data, &data); err != nil {
+			p.logger.Warn().Err(err).Int32("partition", r.partition).Int64("offset", m.Offset).Msg("Skipping unparseable event.")
+			continue
+		}
+
+		if n := data.Block.Number; n != nil && n.IsUint64() && n.Uint64() >= p.fromBlock && n.Uint64() <= p.toBlock {
+			r.next = &data
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// eventBefore reports whether a comes before b on chain.
+func eventBefore(a, b *services.ContractEventData) bool {
+	if c := a.Block.Number.Cmp(b.Block.Number); c != 0 {
+		return c < 0
+	}
+	return a.Index < b.Index
+}
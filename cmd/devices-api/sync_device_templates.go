@@ -70,9 +70,9 @@ func (p *syncDeviceTemplatesCmd) Execute(ctx context.Context, _ *flag.FlagSet, _
 	}
 
 	p.logger.Info().Msgf("starting syncing device templates based on device definition setting."+
-		"\n Only moving from template ID: %s. To change specify --move-from-template XX. Set to 0 for none.\n Will never move on tmpl: 115,116,128,126,127", moveFromTemplateID)
-	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS)
-	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, p.pdb.DBS, &p.logger)
+		"\n Only moving from template ID: %s. To change specify --move-from-template XX. Set to 0 for none.\n Will never move on tmpl: %v", moveFromTemplateID, autopi.ProtectedTemplateIDs())
+	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS, &p.logger)
+	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, p.pdb.DBS, services.NewDeviceDefinitionService(p.pdb.DBS, &p.logger, &p.settings), &p.logger)
 
 	targetTempl, err2 := strconv.Atoi(*p.targetTemplateID)
 	if err2 != nil {
@@ -178,7 +178,7 @@ func syncDeviceTemplates(ctx context.Context, logger *zerolog.Logger, settings *
 				continue
 			}
 			fmt.Printf("%d Update template for ud: %s from template %s to template %s", i+1, ud.UserDeviceID, ud.CurrentTemplate, templateID)
-			if ud.CurrentTemplate == "115" || ud.CurrentTemplate == "116" || ud.CurrentTemplate == "128" || ud.CurrentTemplate == "126" {
+			if current, err := strconv.Atoi(ud.CurrentTemplate); err == nil && autopi.IsProtectedTemplate(current) {
 				fmt.Printf("Skipping since %s template id in blacklist to not move\n", ud.CurrentTemplate)
 				continue
 			}
@@ -187,6 +187,7 @@ func syncDeviceTemplates(ctx context.Context, logger *zerolog.Logger, settings *
 				UserDeviceId:       ud.UserDeviceID,
 				AutoApiUnitId:      ud.AutoPiUnitID,
 				HardwareTemplateId: templateID,
+				Reason:             "sync-device-templates: definition template sync",
 			})
 			if err != nil {
 				fmt.Printf(" : failed\n")
@@ -225,7 +226,7 @@ func moveAllDevicesToTemplate(ctx context.Context, pdb db.Store, autoPiHWSvc aut
 	reader := bufio.NewReader(os.Stdin)
 	// loop over each template
 	for _, template := range templates {
-		if template.ID == targetTemplateID || template.ID == 128 || template.ID == 127 {
+		if template.ID == targetTemplateID || autopi.IsProtectedTemplate(template.ID) {
 			continue // skip if base tmpl or the tmpl we are moving to
 		}
 
@@ -286,6 +287,7 @@ func moveDevicesInTemplate(ctx context.Context, pdb db.Store, autoPiHWSvc autopi
 			UserDeviceId:       udID,
 			AutoApiUnitId:      d.UnitID,
 			HardwareTemplateId: strconv.Itoa(targetTemplateID),
+			Reason:             "sync-device-templates: moving all devices",
 		})
 		if err != nil {
 			fmt.Printf("Failed to move device %s to template %d\n", d.UnitID, targetTemplateID)
@@ -347,6 +349,7 @@ func moveDevicesFromCSV(ctx context.Context, path string, targetTemplateID int,
 				UserDeviceId:       udID,
 				AutoApiUnitId:      amd.Serial,
 				HardwareTemplateId: strconv.Itoa(targetTemplateID),
+				Reason:             "sync-device-templates: moving devices from csv",
 			})
 			if err != nil {
 				fmt.Printf("Failed to move device %s to template %d\n", row[0], targetTemplateID)
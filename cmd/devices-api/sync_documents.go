@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"path"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/controllers"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type syncDocumentsCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	dryRun bool
+}
+
+func (*syncDocumentsCmd) Name() string { return "sync-documents" }
+func (*syncDocumentsCmd) Synopsis() string {
+	return "create document rows for glovebox documents that only exist in S3"
+}
+func (*syncDocumentsCmd) Usage() string {
+	return `sync-documents [-dry-run]:
+	Walks the documents bucket and records every object that is missing from the documents table.
+`
+}
+
+func (p *syncDocumentsCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&p.dryRun, "dry-run", false, "only log the documents that would be created")
+}
+
+func (p *syncDocumentsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	s3Client := p.container.getS3ServiceClient(ctx)
+
+	var created, skipped int
+
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(p.settings.AWSDocumentsBucketName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			p.logger.Err(err).Msg("Failed to list documents.")
+			return subcommands.ExitFailure
+		}
+
+		for _, obj := range page.Contents {
+			ok, err := p.syncObject(ctx, s3Client, aws.ToString(obj.Key))
+			if err != nil {
+				p.logger.Err(err).Str("key", aws.ToString(obj.Key)).Msg("Failed to sync document.")
+				return subcommands.ExitFailure
+			}
+			if ok {
+				created++
+			} else {
+				skipped++
+			}
+		}
+	}
+
+	p.logger.Info().Int("created", created).Int("skipped", skipped).Bool("dryRun", p.dryRun).Msg("Finished syncing documents.")
+	return subcommands.ExitSuccess
+}
+
+// syncObject creates the row for the object with the given key, if it's missing. Keys look like
+// USER_ID/DOCUMENT_ID or USER_ID/USER_DEVICE_ID/DOCUMENT_ID.
+func (p *syncDocumentsCmd) syncObject(ctx context.Context, s3Client *s3.Client, key string) (bool, error) {
+	id := path.Base(key)
+	if _, err := ksuid.Parse(id); err != nil {
+		p.logger.Warn().Str("key", key).Msg("Skipping object that doesn't look like a document.")
+		return false, nil
+	}
+
+	exists, err := models.DocumentExists(ctx, p.pdb.DBS().Reader, id)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	head, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(p.settings.AWSDocumentsBucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	userID, _, _ := strings.Cut(key, "/")
+
+	doc := &models.Document{
+		ID:          id,
+		UserID:      userID,
+		Type:        head.Metadata[controllers.MetadataDocumentType],
+		Name:        head.Metadata[controllers.MetadataDocumentName],
+		FileName:    head.Metadata[controllers.MetadataDocumentFile],
+		FileExt:     head.Metadata[controllers.MetadataDocumentFileExtension],
+		ContentType: aws.ToString(head.ContentType),
+		FileSize:    aws.ToInt64(head.ContentLength),
+		S3Key:       key,
+		Tags:        []string{},
+		CreatedAt:   aws.ToTime(head.LastModified),
+	}
+
+	if udi := head.Metadata[controllers.MetadataDocumentUserDeviceID]; udi != "" {
+		ud, err := models.FindUserDevice(ctx, p.pdb.DBS().Reader, udi)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+		// The device may have been deleted since.
+		if ud != nil {
+			doc.UserDeviceID = null.StringFrom(ud.ID)
+		}
+	}
+
+	if p.dryRun {
+		p.logger.Info().Str("key", key).Str("userId", userID).Msg("Would create document.")
+		return true, nil
+	}
+
+	if err := doc.Insert(ctx, p.pdb.DBS().Writer, boil.Infer()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/autopi"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+)
+
+type templateMigrationCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	fromTemplate   int
+	csvPath        string
+	targetTemplate int
+	id             string
+	pause          bool
+	resume         bool
+	run            bool
+}
+
+func (*templateMigrationCmd) Name() string { return "template-migration" }
+func (*templateMigrationCmd) Synopsis() string {
+	return "create, pause, resume or check on a bulk move of AutoPi devices to a hardware template"
+}
+func (*templateMigrationCmd) Usage() string {
+	return `template-migration -target-template <id> (-from-template <id> | -csv <path>) [-run]
+template-migration -id <migration ID> [-pause | -resume] [-run]
+  `
+}
+
+func (p *templateMigrationCmd) SetFlags(f *flag.FlagSet) {
+	f.IntVar(&p.fromTemplate, "from-template", 0, "Move every device currently on this AutoPi template.")
+	f.StringVar(&p.csvPath, "csv", "", "Move the devices whose 0x addresses are in the first column of this csv file.")
+	f.IntVar(&p.targetTemplate, "target-template", 0, "The AutoPi template to move devices to, when creating a migration.")
+	f.StringVar(&p.id, "id", "", "An existing migration to check on, pause or resume.")
+	f.BoolVar(&p.pause, "pause", false, "Pause the migration given by -id.")
+	f.BoolVar(&p.resume, "resume", false, "Resume the migration given by -id.")
+	f.BoolVar(&p.run, "run", false, "Process batches in the foreground until the migration is done or paused.")
+}
+
+func (p *templateMigrationCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	creating := p.targetTemplate != 0 || p.fromTemplate != 0 || p.csvPath != ""
+	if creating == (p.id != "") {
+		p.logger.Error().Msg("pass either -id or -target-template with one of -from-template and -csv")
+		return subcommands.ExitUsageError
+	}
+	if creating && (p.targetTemplate <= 0 || (p.fromTemplate != 0) == (p.csvPath != "")) {
+		p.logger.Error().Msg("-target-template and exactly one of -from-template and -csv are required")
+		return subcommands.ExitUsageError
+	}
+	if p.pause && (p.resume || p.run) {
+		p.logger.Error().Msg("-pause can't be combined with -resume or -run")
+		return subcommands.ExitUsageError
+	}
+
+	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS, &p.logger)
+	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, p.pdb.DBS, p.container.getDeviceDefinitionService(), &p.logger)
+	migrator, err := autopi.NewTemplateMigrator(p.pdb.DBS, autoPiSvc, hardwareTemplateService, &p.settings, &p.logger)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to create template migrator")
+		return subcommands.ExitFailure
+	}
+
+	id := p.id
+	switch {
+	case p.fromTemplate != 0:
+		tm, err := migrator.CreateFromTemplate(ctx, p.fromTemplate, p.targetTemplate)
+		if err != nil {
+			p.logger.Error().Err(err).Msg("failed to create template migration")
+			return subcommands.ExitFailure
+		}
+		id = tm.ID
+	case p.csvPath != "":
+		addrs, err := readAddressesCSV(p.csvPath)
+		if err != nil {
+			p.logger.Error().Err(err).Msg("failed to read csv file")
+			return subcommands.ExitFailure
+		}
+		tm, err := migrator.CreateFromAddresses(ctx, addrs, p.targetTemplate)
+		if err != nil {
+			p.logger.Error().Err(err).Msg("failed to create template migration")
+			return subcommands.ExitFailure
+		}
+		id = tm.ID
+	case p.pause:
+		if _, err := migrator.Pause(ctx, id); err != nil {
+			p.logger.Error().Err(err).Msg("failed to pause template migration")
+			return subcommands.ExitFailure
+		}
+	case p.resume:
+		if _, err := migrator.Resume(ctx, id); err != nil {
+			p.logger.Error().Err(err).Msg("failed to resume template migration")
+			return subcommands.ExitFailure
+		}
+	}
+
+	if p.run {
+		for {
+			st, err := migrator.Status(ctx, id)
+			if err != nil {
+				p.logger.Error().Err(err).Msg("failed to load template migration")
+				return subcommands.ExitFailure
+			}
+			if st.Migration.Status != models.TemplateMigrationStatusRunning {
+				break
+			}
+			fmt.Printf("%s: %d pending, %d succeeded, %d failed\n", id, st.Pending, st.Succeeded, st.Failed)
+			// Batches are taken from the oldest running migration, which may not be this one.
+			n, err := migrator.ProcessDue(ctx)
+			if err != nil {
+				p.logger.Error().Err(err).Msg("failed to process template migration batch")
+				return subcommands.ExitFailure
+			}
+			if n == 0 {
+				fmt.Println("another worker holds the migration, stopping")
+				break
+			}
+		}
+	}
+
+	st, err := migrator.Status(ctx, id)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to load template migration")
+		return subcommands.ExitFailure
+	}
+
+	tm := st.Migration
+	fmt.Printf("migration %s to template %d: %s\n", tm.ID, tm.TargetTemplateID, tm.Status)
+	fmt.Printf("  %d pending, %d succeeded, %d failed\n", st.Pending, st.Succeeded, st.Failed)
+	for _, f := range st.Failures {
+		fmt.Printf("  failed serial=%s %s\n", f.Serial, f.Error.String)
+	}
+
+	return subcommands.ExitSuccess
+}
+
+// readAddressesCSV reads 0x device addresses from the first column of a csv file.
+func readAddressesCSV(path string) ([]common.Address, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint
+
+	var addrs []common.Address
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	for line := 1; ; line++ {
+		row, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(row) == 0 {
+			continue
+		}
+		hex := strings.TrimSpace(row[0])
+		if !common.IsHexAddress(hex) {
+			return nil, fmt.Errorf("line %d: %q is not an address", line, hex)
+		}
+		addrs = append(addrs, common.HexToAddress(hex))
+	}
+
+	return addrs, nil
+}
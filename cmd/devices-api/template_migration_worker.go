@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services/autopi"
+	"github.com/rs/zerolog"
+)
+
+// startTemplateMigrationWorker periodically moves a batch of devices for running AutoPi template
+// migrations. Leaving the interval empty disables it.
+func startTemplateMigrationWorker(ctx context.Context, logger *zerolog.Logger, settings *config.Settings, migrator *autopi.TemplateMigrator) {
+	if settings.TemplateMigrationInterval == "" {
+		logger.Info().Msg("Template migration worker disabled.")
+		return
+	}
+
+	interval, err := time.ParseDuration(settings.TemplateMigrationInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse template migration interval.")
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := migrator.ProcessDue(ctx)
+				if err != nil {
+					logger.Err(err).Msg("Failed to process template migration batch.")
+					continue
+				}
+				if n > 0 {
+					logger.Debug().Int("attempted", n).Msg("Processed template migration batch.")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// startTeslaTokenSweeper periodically renews Tesla Fleet API tokens that are close to expiring,
+// so that integrations without a polling task don't go stale. Leaving the interval empty
+// disables it.
+func startTeslaTokenSweeper(ctx context.Context, logger *zerolog.Logger, settings *config.Settings, ddSvc services.DeviceDefinitionService, tokens services.TeslaTokenManager) {
+	if settings.TeslaTokenRefreshInterval == "" {
+		logger.Info().Msg("Tesla token sweeper disabled.")
+		return
+	}
+
+	interval, err := time.ParseDuration(settings.TeslaTokenRefreshInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse Tesla token refresh interval.")
+	}
+
+	window := time.Hour
+	if settings.TeslaTokenRefreshWindow != "" {
+		window, err = time.ParseDuration(settings.TeslaTokenRefreshWindow)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Couldn't parse Tesla token refresh window.")
+		}
+	}
+
+	integ, err := ddSvc.GetIntegrationByVendor(ctx, constants.TeslaVendor)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't retrieve Tesla integration for token sweeper.")
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := tokens.RefreshExpiring(ctx, integ.Id, window)
+				if err != nil {
+					logger.Err(err).Msg("Tesla token sweep failed.")
+					continue
+				}
+				if n > 0 {
+					logger.Info().Int("refreshed", n).Msg("Refreshed expiring Tesla tokens.")
+				}
+			}
+		}
+	}()
+}
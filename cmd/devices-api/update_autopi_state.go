@@ -40,7 +40,7 @@ func (p *updateStateCmd) SetFlags(f *flag.FlagSet) {
 
 func (p *updateStateCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
 
-	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS)
+	autoPiSvc := services.NewAutoPiAPIService(&p.settings, p.pdb.DBS, &p.logger)
 	ddSvc := services.NewDeviceDefinitionService(p.pdb.DBS, &p.logger, &p.settings)
 	err := updateState(ctx, p.pdb, &p.logger, autoPiSvc, ddSvc)
 	if err != nil {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/rs/zerolog"
+)
+
+// startWebhookDispatcher periodically sends pending webhook deliveries to subscribers. Leaving
+// the interval empty disables it.
+func startWebhookDispatcher(ctx context.Context, logger *zerolog.Logger, settings *config.Settings, dbs func() *db.ReaderWriter, cipher cipher.Cipher) {
+	if settings.WebhookDispatchInterval == "" {
+		logger.Info().Msg("Webhook dispatcher disabled.")
+		return
+	}
+
+	interval, err := time.ParseDuration(settings.WebhookDispatchInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse webhook dispatch interval.")
+	}
+
+	dispatcher, err := services.NewWebhookDispatcher(dbs, cipher, settings, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse webhook retry settings.")
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := dispatcher.DeliverDue(ctx)
+				if err != nil {
+					logger.Err(err).Msg("Failed to dispatch webhook deliveries.")
+					continue
+				}
+				if n > 0 {
+					logger.Debug().Int("attempted", n).Msg("Dispatched webhook deliveries.")
+				}
+			}
+		}
+	}()
+}
@@ -15,39 +15,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/countries": {
+        "/aftermarket/device/by-address/{address}": {
             "get": {
-                "description": "Returns all the supported countries",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "countries"
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_constants.CountryInfo"
-                        }
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/countries/{countryCode}": {
-            "get": {
-                "description": "Returns a specific country by 3-letter ISO country code",
+                ],
+                "description": "Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "countries"
+                    "aftermarket-devices"
                 ],
+                "summary": "Get an aftermarket device by address",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "3-letter country code",
-                        "name": "countryCode",
+                        "description": "Device ethereum address",
+                        "name": "address",
                         "in": "path",
                         "required": true
                     }
@@ -56,124 +43,115 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_constants.CountryInfo"
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceResponse"
                         }
                     },
                     "400": {
-                        "description": "invalid country code"
+                        "description": "Invalid address."
                     },
                     "404": {
-                        "description": "country not found with that country code"
+                        "description": "No such device, or the caller owns neither it nor its vehicle."
                     }
                 }
             }
         },
-        "/documents": {
+        "/aftermarket/device/by-serial/{serial}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "gets all documents associated with current user - pulled from token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "aftermarket-devices"
+                ],
+                "summary": "Get an aftermarket device by serial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device serial",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/internal_controllers.DocumentResponse"
-                            }
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceResponse"
                         }
+                    },
+                    "404": {
+                        "description": "No such device, or the caller owns neither it nor its vehicle."
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/aftermarket/device/by-token-id/{tokenID}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "post document by id associated with current user - pulled from token",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "aftermarket-devices"
                 ],
+                "summary": "Get an aftermarket device by token id",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "The file to upload. file is required",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The document name. name is required",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The document type. type is required",
-                        "name": "type",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Device token id",
+                        "name": "tokenID",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The user device ID, optional",
-                        "name": "userDeviceID",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid token id."
+                    },
+                    "404": {
+                        "description": "No such device, or the caller owns neither it nor its vehicle."
                     }
                 }
             }
         },
-        "/documents/{id}": {
+        "/aftermarket/device/{serial}/commands/claim": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get document by id associated with current user - pulled from token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the EIP-712 payload that both the caller and the device sign to claim it for the caller.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "aftermarket-devices"
                 ],
+                "summary": "Get the payload for claiming an aftermarket device",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Document ID",
-                        "name": "id",
+                        "description": "Device serial",
+                        "name": "serial",
                         "in": "path",
                         "required": true
                     }
@@ -182,18 +160,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
+                            "$ref": "#/definitions/apitypes.TypedData"
                         }
+                    },
+                    "404": {
+                        "description": "No such device."
+                    },
+                    "409": {
+                        "description": "The device is already claimed, or claiming is in progress."
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete document associated with current user - pulled from token",
+                "description": "Submits the caller's and the device's signatures of the claim payload, and sends the claiming meta-transaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,67 +185,105 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "aftermarket-devices"
                 ],
+                "summary": "Claim an aftermarket device",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Document ID",
-                        "name": "id",
+                        "description": "Device serial",
+                        "name": "serial",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Signatures",
+                        "name": "claimRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceClaimRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid signature."
+                    },
+                    "404": {
+                        "description": "No such device."
+                    },
+                    "409": {
+                        "description": "The device is already claimed, or claiming is in progress."
                     }
                 }
             }
         },
-        "/documents/{id}/download": {
+        "/aftermarket/device/{serial}/commands/pair": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "download document associated with current user - pulled from token",
+                "description": "Returns the EIP-712 payload for pairing the device with a vehicle. If the device and vehicle have different owners, the device and the vehicle's owner both sign it. The caller must own one of them.",
                 "produces": [
-                    "application/octet-stream",
-                    "image/png",
-                    "image/jpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "aftermarket-devices"
                 ],
+                "summary": "Get the payload for pairing an aftermarket device",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Document ID",
-                        "name": "id",
+                        "description": "Device serial",
+                        "name": "serial",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "vehicle_token_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid vehicle token id."
+                    },
+                    "403": {
+                        "description": "The caller owns neither the device nor the vehicle."
+                    },
+                    "404": {
+                        "description": "No such device or vehicle."
+                    },
+                    "409": {
+                        "description": "The device isn't claimed, either one is already paired, or pairing is in progress."
                     }
                 }
-            }
-        },
-        "/integration/{tokenID}/credentials": {
+            },
             "post": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Complete Tesla auth and get devices for authenticated user",
+                "description": "Submits signatures of the pairing payload and sends the pairing meta-transaction. If one address owns both the device and the vehicle, send signature; otherwise send aftermarketDeviceSignature and vehicleOwnerSignature.",
                 "consumes": [
                     "application/json"
                 ],
@@ -269,23 +291,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-devices"
+                    "aftermarket-devices"
                 ],
+                "summary": "Pair an aftermarket device with a vehicle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "token id for integration",
-                        "name": "tokenID",
+                        "description": "Device serial",
+                        "name": "serial",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "all fields are required",
-                        "name": "user_device",
+                        "description": "Vehicle and signatures",
+                        "name": "pairRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CompleteOAuthExchangeRequest"
+                            "$ref": "#/definitions/internal_controllers.AftermarketDevicePairRequest"
                         }
                     }
                 ],
@@ -293,23 +316,73 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CompleteOAuthExchangeResponseWrapper"
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Missing or invalid signature."
+                    },
+                    "403": {
+                        "description": "The caller owns neither the device nor the vehicle."
+                    },
+                    "404": {
+                        "description": "No such device or vehicle."
+                    },
+                    "409": {
+                        "description": "The device isn't claimed, either one is already paired, or pairing is in progress."
                     }
                 }
             }
         },
-        "/user/devices": {
-            "post": {
+        "/aftermarket/device/{serial}/commands/unpair": {
+            "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the EIP-712 payload for unpairing the device from its vehicle. The caller must own the device or the vehicle.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aftermarket-devices"
+                ],
+                "summary": "Get the payload for unpairing an aftermarket device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device serial",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller owns neither the device nor the vehicle."
+                    },
+                    "404": {
+                        "description": "No such device."
                     },
+                    "409": {
+                        "description": "The device isn't paired, or unpairing is in progress."
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "adds a device to a user. can add with only device_definition_id or with MMY, which will create a device_definition on the fly",
+                "description": "Submits the caller's signature of the unpairing payload and sends the unpairing meta-transaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -317,106 +390,204 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-devices"
+                    "aftermarket-devices"
                 ],
+                "summary": "Unpair an aftermarket device",
                 "parameters": [
                     {
-                        "description": "add device to user. either MMY or id are required",
-                        "name": "user_device",
+                        "type": "string",
+                        "description": "Device serial",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signature",
+                        "name": "unpairRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.RegisterUserDevice"
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceUnpairRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.RegisterUserDeviceResponse"
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid signature."
+                    },
+                    "403": {
+                        "description": "The caller owns neither the device nor the vehicle."
+                    },
+                    "404": {
+                        "description": "No such device."
+                    },
+                    "409": {
+                        "description": "The device isn't paired, or unpairing is in progress."
                     }
                 }
             }
         },
-        "/user/devices/me": {
+        "/aftermarket/devices": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "gets all devices associated with current user - pulled from token",
+                "description": "Lists the aftermarket devices owned by the caller's address, with pairing status, attributes and beneficiary.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-devices"
+                    "aftermarket-devices"
                 ],
+                "summary": "List the caller's aftermarket devices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers.AftermarketDeviceResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/countries": {
+            "get": {
+                "description": "Returns all the supported countries",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "delete the user device record (hard delete)",
                 "tags": [
-                    "user-devices"
+                    "countries"
                 ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "device id",
-                        "name": "userDeviceID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_constants.CountryInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/{countryCode}": {
+            "get": {
+                "description": "Returns a specific country by 3-letter ISO country code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "3-letter country code",
+                        "name": "countryCode",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_constants.CountryInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid country code"
+                    },
+                    "404": {
+                        "description": "country not found with that country code"
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/commands/mint": {
+        "/documents": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the data the user must sign in order to mint this device.",
+                "description": "gets documents associated with current user - pulled from token, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user-devices"
+                    "documents"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user device ID",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
+                        "description": "Only documents for this user device",
+                        "name": "user_device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only documents for this vehicle",
+                        "name": "token_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only documents of this type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only documents with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only documents that expire in the next this many days",
+                        "name": "expiring_within_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Page size, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last document of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apitypes.TypedData"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers.DocumentResponse"
+                            }
                         }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor."
                     }
                 }
             },
@@ -426,82 +597,133 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Sends a mint device request to the blockchain",
+                "description": "post document by id associated with current user - pulled from token",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user-devices"
+                    "documents"
                 ],
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The file to upload. file is required",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "user device ID",
-                        "name": "userDeviceID",
-                        "in": "path",
+                        "description": "The document name. name is required",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "Signature and NFT data",
-                        "name": "mintRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.VehicleMintRequest"
-                        }
+                        "type": "string",
+                        "description": "The document type. type is required",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The user device ID, optional",
+                        "name": "userDeviceID",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tags, optional",
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expiry date, as YYYY-MM-DD or RFC 3339, optional",
+                        "name": "expiresAt",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/commands/opt-in": {
+        "/documents/upload-url": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Opts the device into data-sharing, and hence rewards.",
+                "description": "creates a pending document associated with current user - pulled from token, and returns a presigned S3 URL to PUT the file to. Call finalize once the upload is done.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-devices"
+                    "documents"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user device id",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
+                        "description": "Document details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.DocumentUploadURLRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.DocumentUploadURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request."
+                    },
+                    "404": {
+                        "description": "Device not found."
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/error-codes": {
+        "/documents/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes` + "`" + ` instead",
+                "description": "get document by id associated with current user - pulled from token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "error-codes"
+                    "documents"
                 ],
-                "summary": "List all error code queries made for this vehicle.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user device id",
-                        "name": "userDeviceID",
+                        "description": "Document ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -510,43 +732,73 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.GetUserDeviceErrorCodeQueriesResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Vehicle not found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes` + "`" + ` instead",
+                "description": "delete document associated with current user - pulled from token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "error-codes"
+                    "documents"
                 ],
-                "summary": "Obtain, store, and return descriptions for a list of error codes from this vehicle.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user device id",
-                        "name": "userDeviceID",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update the name, tags or expiry of a document associated with current user - pulled from token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "error codes",
-                        "name": "queryDeviceErrorCodes",
+                        "description": "Fields to change",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesReq"
+                            "$ref": "#/definitions/internal_controllers.UpdateDocumentRequest"
                         }
                     }
                 ],
@@ -554,137 +806,211 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesResponse"
+                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request."
+                    },
                     "404": {
-                        "description": "Vehicle not found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
-                        }
+                        "description": "Document not found."
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/error-codes/clear": {
-            "post": {
+        "/documents/{id}/download": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes/clear` + "`" + ` instead",
+                "description": "download document associated with current user - pulled from token. With redirect=true, responds with a redirect to a short-lived presigned S3 URL instead of the file.",
+                "produces": [
+                    "application/octet-stream",
+                    "image/png",
+                    "image/jpeg"
+                ],
                 "tags": [
-                    "error-codes"
+                    "documents"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Redirect to a presigned URL",
+                        "name": "redirect",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Mark the most recent set of error codes as having been cleared.",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Vehicle not found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
-                        }
+                        "description": "OK"
                     },
-                    "429": {
-                        "description": "Last query already cleared",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
-                        }
+                    "307": {
+                        "description": "Temporary Redirect"
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}": {
-            "get": {
+        "/documents/{id}/finalize": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Receive status updates about a connection",
+                "description": "checks that the file for a pending document was uploaded and makes the document available",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "integrations"
+                    "documents"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.GetUserDeviceIntegrationResponse"
+                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
                         }
+                    },
+                    "400": {
+                        "description": "The file is missing or doesn't match what was declared."
+                    },
+                    "404": {
+                        "description": "No pending document with this ID."
                     }
                 }
-            },
+            }
+        },
+        "/integration/{tokenID}/credentials": {
             "post": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Submit credentials for registering a device with a given integration. This must be called for any new pairing.",
+                "description": "Complete Tesla auth and get devices for authenticated user",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "integrations"
+                    "user-devices"
                 ],
                 "parameters": [
                     {
-                        "description": "Integration credentials",
-                        "name": "userDeviceIntegrationRegistration",
+                        "type": "string",
+                        "description": "token id for integration",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "all fields are required",
+                        "name": "user_device",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.RegisterDeviceIntegrationRequest"
+                            "$ref": "#/definitions/internal_controllers.CompleteOAuthExchangeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CompleteOAuthExchangeResponseWrapper"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/mint-batches": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an integration from a device.",
+                "description": "Registers a list of vehicles for the caller and returns the EIP-712 payload to sign for each. Every vehicle is checked first; if any fails, none are created and the reasons are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "integrations"
+                    "mint-batches"
+                ],
+                "summary": "Start a batch mint",
+                "parameters": [
+                    {
+                        "description": "Vehicles",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateMintBatchRequest"
+                        }
+                    }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchValidationErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/burn": {
+        "/mint-batches/{batchID}": {
             "get": {
-                "description": "Produces the payload that the user signs and submits to burn a synthetic device.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the mint status of each vehicle in the batch, with the payload to sign for those still waiting on a signature.",
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "mint-batches"
+                ],
+                "summary": "Get a batch mint",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user device KSUID, must be minted",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "integration KSUD, must be software-based and active",
-                        "name": "integrationID",
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "batchID",
                         "in": "path",
                         "required": true
                     }
@@ -693,41 +1019,48 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/apitypes.TypedData"
-                            }
+                            "$ref": "#/definitions/internal_controllers.MintBatchResponse"
                         }
+                    },
+                    "404": {
+                        "description": "No such batch, or it isn't the caller's."
                     }
                 }
-            },
+            }
+        },
+        "/mint-batches/{batchID}/signatures": {
             "post": {
-                "description": "Submit the signature required for the synthetic device burning meta-transaction.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks each signature against its vehicle's payload and sends the mints whose signatures are good. A bad signature doesn't hold up the others; its error is reported and the vehicle can be signed again.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "mint-batches"
+                ],
+                "summary": "Sign vehicles in a batch mint",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user device KSUID, must be minted",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "integration KSUD, must be software-based and active",
-                        "name": "integrationID",
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "batchID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "only field is the signed EIP-712",
-                        "name": "signed",
+                        "description": "Signatures",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.BurnSyntheticDeviceRequest"
+                            "$ref": "#/definitions/internal_controllers.SubmitMintBatchSignaturesRequest"
                         }
                     }
                 ],
@@ -737,257 +1070,269 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/apitypes.TypedData"
+                                "$ref": "#/definitions/internal_controllers.MintBatchSignatureResponse"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Couldn't parse the request."
+                    },
+                    "404": {
+                        "description": "No such batch, or it isn't the caller's."
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/mint": {
+        "/transactions": {
             "get": {
-                "description": "Produces the payload that the user signs and submits to mint a synthetic device for\nthe given vehicle and integration.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists meta-transaction requests made for the caller's address, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "transactions"
                 ],
+                "summary": "List the caller's transactions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user device KSUID",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by operation: Mint, Burn, Claim, Pair, Unpair, SyntheticMint, or SyntheticBurn",
+                        "name": "operation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status: Unsubmitted, Submitted, Mined, Confirmed, or Failed",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "integration KSUD, must be software-based",
-                        "name": "integrationID",
-                        "in": "path",
-                        "required": true
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/apitypes.TypedData"
-                            }
+                            "$ref": "#/definitions/internal_controllers.TransactionsResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid filter, limit or cursor."
                     }
                 }
-            },
-            "post": {
-                "description": "Submit a metadata",
+            }
+        },
+        "/transactions/{requestID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets the status, timeline, hash and failure reason of a meta-transaction request, and any resubmission of it. The caller must own the address or vehicle it was made for.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "transactions"
                 ],
+                "summary": "Get a transaction",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user device KSUID, must be minted",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "integration KSUD, must be software-based",
-                        "name": "integrationID",
+                        "type": "string",
+                        "description": "Meta-transaction request ID",
+                        "name": "requestID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "only field is the signed EIP-712",
-                        "name": "signed",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.MintSyntheticDeviceRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TransactionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No such request, or it isn't the caller's."
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/telemetry/subscribe": {
+        "/user/devices": {
             "post": {
-                "description": "Subscribe vehicle for Telemetry Data. Currently, this only works for Teslas connected through Tesla.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "device",
-                    "integration",
-                    "command"
-                ],
-                "summary": "Subscribe vehicle for Telemetry Data",
-                "operationId": "telemetry-subscribe",
-                "parameters": [
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     },
                     {
-                        "type": "string",
-                        "description": "Integration ID",
-                        "name": "integrationID",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {}
-            }
-        },
-        "/user/synthetic/device/{tokenID}/commands/reauthenticate": {
-            "post": {
-                "description": "Restarts a synthetic device polling job with a new set of credentials.",
+                "description": "adds a device to a user. can add with only device_definition_id or with MMY, which will create a device_definition on the fly",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "user-devices"
+                ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Synthetic device token id",
-                        "name": "tokenID",
-                        "in": "path",
-                        "required": true
+                        "description": "add device to user. either MMY or id are required",
+                        "name": "user_device",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.RegisterUserDevice"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_user_sd.Message"
+                            "$ref": "#/definitions/internal_controllers.RegisterUserDeviceResponse"
                         }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/charge/start": {
-            "post": {
-                "description": "Start the vehicle charging.",
+        "/user/devices/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "gets all devices associated with current user - pulled from token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
-                ],
-                "summary": "Start the vehicle charging.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "user-devices"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
                         }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/charge/stop": {
-            "post": {
-                "description": "Stop the vehicle charging.",
-                "produces": [
-                    "application/json"
+        "/user/devices/{userDeviceID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "delete the user device record (hard delete)",
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "user-devices"
                 ],
-                "summary": "Stop the vehicle charging.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
+                        "description": "device id",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/doors/lock": {
-            "post": {
-                "description": "Lock the device's doors.",
+        "/user/devices/{userDeviceID}/autopi/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the commands sent to the vehicle's AutoPi since the current owner took it, newest first, with their state and results.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "user-devices"
                 ],
-                "summary": "Lock the device's doors",
+                "summary": "List the AutoPi jobs for a vehicle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
+                        "description": "User device ID",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.AutoPiJobsResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor."
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/doors/unlock": {
-            "post": {
-                "description": "Unlock the device's doors.",
-                "produces": [
-                    "application/json"
+        "/user/devices/{userDeviceID}/commands/mint": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the data the user must sign in order to mint this device.",
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "user-devices"
                 ],
-                "summary": "Unlock the device's doors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
+                        "description": "user device ID",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     }
@@ -996,90 +1341,93 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/apitypes.TypedData"
                         }
                     }
                 }
-            }
-        },
-        "/vehicle/{tokenID}/commands/frunk/open": {
+            },
             "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
-                "produces": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Sends a mint device request to the blockchain",
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "user-devices"
                 ],
-                "summary": "Open the device's front trunk",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
+                        "description": "user device ID",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Signature and NFT data",
+                        "name": "mintRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.VehicleMintRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/trunk/open": {
+        "/user/devices/{userDeviceID}/commands/opt-in": {
             "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opts the device into data-sharing, and hence rewards.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "user-devices"
                 ],
-                "summary": "Open the device's rear trunk",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Token ID",
-                        "name": "tokenID",
+                        "description": "user device id",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/error-codes": {
+        "/user/devices/{userDeviceID}/error-codes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes` + "`" + ` instead",
                 "tags": [
                     "error-codes"
                 ],
                 "summary": "List all error code queries made for this vehicle.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "vehicle token id",
-                        "name": "tokenID",
+                        "type": "string",
+                        "description": "user device id",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     }
@@ -1105,15 +1453,16 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes` + "`" + ` instead",
                 "tags": [
                     "error-codes"
                 ],
                 "summary": "Obtain, store, and return descriptions for a list of error codes from this vehicle.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "vehicle token id",
-                        "name": "tokenID",
+                        "type": "string",
+                        "description": "user device id",
+                        "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     },
@@ -1139,30 +1488,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
                         }
+                    },
+                    "429": {
+                        "description": "Too many error code lookups",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/error-codes/clear": {
+        "/user/devices/{userDeviceID}/error-codes/clear": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Deprecated. Use ` + "`" + `/user/vehicle/{tokenID}/error-codes/clear` + "`" + ` instead",
                 "tags": [
                     "error-codes"
                 ],
                 "summary": "Mark the most recent set of error codes as having been cleared.",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "vehicle token id",
-                        "name": "tokenID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1185,44 +1532,1384 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/{tokenId}/vin": {
-            "patch": {
+        "/user/devices/{userDeviceID}/integrations/{integrationID}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "updates the VIN on the user device record. Can optionally also update the protocol and the country code.",
-                "consumes": [
-                    "application/json"
+                "description": "Receive status updates about a connection",
+                "tags": [
+                    "integrations"
                 ],
-                "produces": [
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.GetUserDeviceIntegrationResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit credentials for registering a device with a given integration. This must be called for any new pairing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "description": "Integration credentials",
+                        "name": "userDeviceIntegrationRegistration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.RegisterDeviceIntegrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an integration from a device.",
+                "tags": [
+                    "integrations"
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/burn": {
+            "get": {
+                "description": "Produces the payload that the user signs and submits to burn a synthetic device.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user device KSUID, must be minted",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based and active",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apitypes.TypedData"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submit the signature required for the synthetic device burning meta-transaction.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user device KSUID, must be minted",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based and active",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "only field is the signed EIP-712",
+                        "name": "signed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.BurnSyntheticDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apitypes.TypedData"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/mint": {
+            "get": {
+                "description": "Produces the payload that the user signs and submits to mint a synthetic device for\nthe given vehicle and integration.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user device KSUID",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apitypes.TypedData"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submit a metadata",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user device KSUID, must be minted",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "only field is the signed EIP-712",
+                        "name": "signed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintSyntheticDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/telemetry/subscribe": {
+            "post": {
+                "description": "Subscribe vehicle for Telemetry Data. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Subscribe vehicle for Telemetry Data",
+                "operationId": "telemetry-subscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Integration ID",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/user/devices/{userDeviceID}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists meta-transaction requests made for the vehicle since it passed to its current owner, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List a vehicle's transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User device ID",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by operation: Mint, Burn, Claim, Pair, Unpair, SyntheticMint, or SyntheticBurn",
+                        "name": "operation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status: Unsubmitted, Submitted, Mined, Confirmed, or Failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.TransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, limit or cursor."
+                    }
+                }
+            }
+        },
+        "/user/synthetic/device/{tokenID}/commands/reauthenticate": {
+            "post": {
+                "description": "Restarts a synthetic device polling job with a new set of credentials.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Synthetic device token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_user_sd.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle-handovers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists transfers of vehicles to or from the caller's address, newest first, with what was done to each vehicle's data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle-handovers"
+                ],
+                "summary": "List the caller's vehicle handovers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only handovers of this vehicle",
+                        "name": "token_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers.VehicleHandoverResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or limit."
+                    }
+                }
+            }
+        },
+        "/vehicle-handovers/{handoverID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gets a vehicle transfer and its audit trail. The caller must be the previous or the new owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle-handovers"
+                ],
+                "summary": "Get a vehicle handover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Handover ID",
+                        "name": "handoverID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.VehicleHandoverResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No such handover, or the caller wasn't part of it."
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/autopi/jobs": {
+            "get": {
+                "description": "Lists the commands sent to the vehicle's AutoPi since the current owner took it, newest first, with their state and results.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "List the AutoPi jobs for a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AutoPiJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor."
+                    },
+                    "404": {
+                        "description": "Vehicle not found."
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands": {
+            "get": {
+                "description": "Lists the commands sent to the vehicle, newest first, along with their status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "List commands sent to the vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor."
+                    },
+                    "404": {
+                        "description": "Vehicle not found."
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/charge/amps": {
+            "post": {
+                "description": "Set the current, in amps, that the vehicle draws while charging. Currently, this only works for Teslas connected through Tesla.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Set the vehicle's charging current",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Charging current",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SetChargingAmpsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/charge/limit": {
+            "post": {
+                "description": "Set the state of charge at which the vehicle stops charging. Currently, this only works for Teslas connected through Tesla.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Set the vehicle's charge limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Charge limit",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.SetChargeLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/charge/start": {
+            "post": {
+                "description": "Start the vehicle charging.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Start the vehicle charging.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/charge/stop": {
+            "post": {
+                "description": "Stop the vehicle charging.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Stop the vehicle charging.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/climate/off": {
+            "post": {
+                "description": "Turn off climate control. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Turn off the vehicle's climate control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/climate/on": {
+            "post": {
+                "description": "Turn on climate control, optionally setting the target temperature. Currently, this only works for Teslas connected through Tesla.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Turn on the vehicle's climate control",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target temperature",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.ClimateOnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/doors/lock": {
+            "post": {
+                "description": "Lock the device's doors.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Lock the device's doors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/doors/unlock": {
+            "post": {
+                "description": "Unlock the device's doors.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Unlock the device's doors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/frunk/open": {
+            "post": {
+                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Open the device's front trunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/horn/honk": {
+            "post": {
+                "description": "Honk the vehicle's horn. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Honk the vehicle's horn",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/lights/flash": {
+            "post": {
+                "description": "Flash the vehicle's headlights. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Flash the vehicle's lights",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/trunk/open": {
+            "post": {
+                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Open the device's rear trunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands/{requestID}": {
+            "get": {
+                "description": "Gets a single command sent to the vehicle. Poll this after enqueueing a command to learn whether it succeeded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Get the status of a command",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandRequestResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle or command not found."
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/error-codes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "error-codes"
+                ],
+                "summary": "List all error code queries made for this vehicle.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vehicle token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.GetUserDeviceErrorCodeQueriesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "error-codes"
+                ],
+                "summary": "Obtain, store, and return descriptions for a list of error codes from this vehicle.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vehicle token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "error codes",
+                        "name": "queryDeviceErrorCodes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many error code lookups",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/error-codes/clear": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "error-codes"
+                ],
+                "summary": "Mark the most recent set of error codes as having been cleared.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vehicle token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.QueryDeviceErrorCodesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Last query already cleared",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/error-codes/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Groups codes across all of the vehicle's error code queries, showing when each was first and last seen, how often it was reported, and whether it was cleared.",
+                "tags": [
+                    "error-codes"
+                ],
+                "summary": "Summarize the error codes reported by this vehicle.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vehicle token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.ErrorCodesSummaryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_controllers_helpers.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/vin": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "updates the VIN on the user device record. Can optionally also update the protocol and the country code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "VIN",
+                        "name": "vin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.UpdateVINReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the webhooks registered by the caller.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers.WebhookSubscriptionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a URL to receive signed CloudEvents for a vehicle, or for every vehicle owned by an address. Each request carries an X-DIMO-Signature header with the hex-encoded HMAC-SHA256 of the body, keyed with the secret in the response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CreateWebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request."
+                    },
+                    "403": {
+                        "description": "Caller doesn't own the vehicle or address."
+                    },
+                    "404": {
+                        "description": "Vehicle not found."
+                    }
+                }
+            }
+        },
+        "/webhooks/{subscriptionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a webhook along with its pending and dead-lettered deliveries.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Subscription not found."
+                    }
+                }
+            }
+        },
+        "/webhooks/{subscriptionID}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the deliveries for a webhook, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status: Pending, Delivered, or DeadLettered",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or limit."
+                    },
+                    "404": {
+                        "description": "Subscription not found."
+                    }
+                }
+            }
+        },
+        "/webhooks/{subscriptionID}/deliveries/{deliveryID}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a delivery to be sent again with a fresh set of retries. Use this to recover dead-lettered deliveries once the endpoint is fixed.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-devices"
+                    "webhooks"
                 ],
+                "summary": "Replay a webhook delivery",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "token id",
-                        "name": "tokenId",
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subscriptionID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "VIN",
-                        "name": "vin",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.UpdateVINReq"
-                        }
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.WebhookDeliveryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription or delivery not found."
                     }
                 }
             }
@@ -1502,6 +3189,14 @@ const docTemplate = `{
         "github_com_DIMO-Network_devices-api_internal_services.ErrorCodesResponse": {
             "type": "object",
             "properties": {
+                "classification": {
+                    "description": "Classification is parsed from the code. It is missing for codes that aren't in the\nstandard five-character format.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services_dtc.Classification"
+                        }
+                    ]
+                },
                 "code": {
                     "type": "string",
                     "example": "P0148"
@@ -1509,57 +3204,300 @@ const docTemplate = `{
                 "description": {
                     "type": "string",
                     "example": "Fuel delivery error"
+                },
+                "source": {
+                    "description": "Source is \"catalog\" if the description came from the built-in code tables and \"ai\" if it\nwas generated. Empty for queries made before this was recorded.",
+                    "type": "string",
+                    "example": "catalog"
+                }
+            }
+        },
+        "github_com_DIMO-Network_devices-api_internal_services.PowertrainType": {
+            "type": "string",
+            "enum": [
+                "ICE",
+                "HEV",
+                "PHEV",
+                "BEV",
+                "FCEV"
+            ],
+            "x-enum-varnames": [
+                "ICE",
+                "HEV",
+                "PHEV",
+                "BEV",
+                "FCEV"
+            ]
+        },
+        "github_com_DIMO-Network_devices-api_internal_services.UserDeviceMetadata": {
+            "type": "object",
+            "properties": {
+                "canProtocol": {
+                    "description": "CANProtocol is the protocol that was detected by edge-network from the autopi.",
+                    "type": "string"
+                },
+                "elasticDefinitionSynced": {
+                    "type": "boolean"
+                },
+                "elasticRegionSynced": {
+                    "type": "boolean"
+                },
+                "geoDecodedCountry": {
+                    "type": "string"
+                },
+                "geoDecodedStateProv": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "powertrainType": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.PowertrainType"
+                }
+            }
+        },
+        "github_com_DIMO-Network_devices-api_internal_services_dtc.Classification": {
+            "type": "object",
+            "properties": {
+                "manufacturerSpecific": {
+                    "description": "ManufacturerSpecific is true for codes whose meaning is set by the manufacturer rather\nthan by SAE J2012.",
+                    "type": "boolean"
+                },
+                "severity": {
+                    "description": "Severity is one of \"critical\", \"high\", \"medium\", or \"low\".",
+                    "type": "string",
+                    "example": "high"
+                },
+                "system": {
+                    "description": "System is one of \"powertrain\", \"body\", \"chassis\", or \"network\".",
+                    "type": "string",
+                    "example": "powertrain"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDeviceClaimRequest": {
+            "type": "object",
+            "properties": {
+                "aftermarketDeviceSignature": {
+                    "description": "AftermarketDeviceSignature is the device's own signature.",
+                    "type": "string"
+                },
+                "ownerSignature": {
+                    "description": "OwnerSignature is the caller's signature.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDevicePairRequest": {
+            "type": "object",
+            "properties": {
+                "aftermarketDeviceSignature": {
+                    "description": "AftermarketDeviceSignature is the device's own signature, when the owners differ.",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Signature is the signature of the owner of both the device and the vehicle.",
+                    "type": "string"
+                },
+                "vehicleOwnerSignature": {
+                    "description": "VehicleOwnerSignature is the vehicle owner's signature, when the owners differ.",
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes are the device's on-chain attributes other than the serial, such as its\nhardware revision.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "beneficiaryAddress": {
+                    "description": "BeneficiaryAddress receives the device's rewards. It's the owner unless another address\nwas set.",
+                    "type": "string"
+                },
+                "claim": {
+                    "description": "Claim contains the status of the on-chain claiming meta-transaction.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.TransactionStatus"
+                        }
+                    ]
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "ethereumAddress": {
+                    "type": "string"
+                },
+                "manufacturerTokenId": {
+                    "type": "number"
+                },
+                "ownerAddress": {
+                    "type": "string"
+                },
+                "pair": {
+                    "description": "Pair contains the status of the on-chain pairing meta-transaction.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.TransactionStatus"
+                        }
+                    ]
+                },
+                "queriedVin": {
+                    "description": "QueriedVIN is the VIN the device last read from the vehicle, if it has been asked to.\nIt may differ from the VIN the owner entered for the vehicle.",
+                    "type": "string"
+                },
+                "serial": {
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "number"
+                },
+                "unpair": {
+                    "description": "Unpair contains the status of the on-chain unpairing meta-transaction.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.TransactionStatus"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "description": "VehicleTokenID is the vehicle the device is paired with, if any.",
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDeviceUnpairRequest": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.AutoPiJobResponse": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUpdated": {
+                    "description": "LastUpdated is when AutoPi last reported on the job, if ever.",
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/internal_controllers.AutoPiJobResult"
+                },
+                "state": {
+                    "description": "State is one of \"Sent\", \"COMMAND_EXECUTED\", \"COMMAND_FAILED\", or \"COMMAND_TIMEOUT\".",
+                    "type": "string"
+                },
+                "vin": {
+                    "description": "VIN is the VIN read by a successful VIN query.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.AutoPiJobResult": {
+            "type": "object",
+            "properties": {
+                "tag": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.AutoPiJobsResponse": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.AutoPiJobResponse"
+                    }
+                },
+                "nextCursor": {
+                    "description": "NextCursor should be passed as the cursor parameter to retrieve the next page. It is\nomitted on the last page.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.BurnSyntheticDeviceRequest": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.ClimateOnRequest": {
+            "type": "object",
+            "properties": {
+                "targetTemperature": {
+                    "description": "TargetTemperature is in degrees Celsius, between 15 and 28. If omitted, the vehicle keeps\nits current setting.",
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers.CommandHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "commands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.CommandRequestResponse"
+                    }
+                },
+                "nextCursor": {
+                    "description": "NextCursor should be passed as the cursor parameter to retrieve the next page. It is\nomitted on the last page.",
+                    "type": "string"
                 }
             }
         },
-        "github_com_DIMO-Network_devices-api_internal_services.PowertrainType": {
-            "type": "string",
-            "enum": [
-                "ICE",
-                "HEV",
-                "PHEV",
-                "BEV",
-                "FCEV"
-            ],
-            "x-enum-varnames": [
-                "ICE",
-                "HEV",
-                "PHEV",
-                "BEV",
-                "FCEV"
-            ]
-        },
-        "github_com_DIMO-Network_devices-api_internal_services.UserDeviceMetadata": {
+        "internal_controllers.CommandRequestResponse": {
             "type": "object",
             "properties": {
-                "canProtocol": {
-                    "description": "CANProtocol is the protocol that was detected by edge-network from the autopi.",
+                "command": {
+                    "description": "Command is the command path, e.g., \"doors/unlock\".",
                     "type": "string"
                 },
-                "elasticDefinitionSynced": {
-                    "type": "boolean"
-                },
-                "elasticRegionSynced": {
-                    "type": "boolean"
+                "createdAt": {
+                    "type": "string"
                 },
-                "geoDecodedCountry": {
+                "integrationId": {
+                    "description": "IntegrationID is the integration through which the command was sent.",
                     "type": "string"
                 },
-                "geoDecodedStateProv": {
+                "requestId": {
+                    "description": "RequestID is the id returned when the command was enqueued.",
                     "type": "string"
                 },
-                "postal_code": {
+                "status": {
+                    "description": "Status is one of \"Pending\", \"Complete\", \"Failed\", or \"TimedOut\".",
                     "type": "string"
                 },
-                "powertrainType": {
-                    "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.PowertrainType"
-                }
-            }
-        },
-        "internal_controllers.BurnSyntheticDeviceRequest": {
-            "type": "object",
-            "properties": {
-                "signature": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
@@ -1608,6 +3546,71 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.CreateMintBatchRequest": {
+            "type": "object",
+            "properties": {
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchVehicleRequest"
+                    }
+                }
+            }
+        },
+        "internal_controllers.CreateWebhookSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "eventTypes": {
+                    "description": "EventTypes is the set of events to deliver, e.g., \"zone.dimo.vehicle.transferred\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ownerAddress": {
+                    "description": "OwnerAddress subscribes to events for every vehicle owned by the address, which must be\nthe caller's.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL receives the events. It must use https and point to a public host.",
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "description": "VehicleTokenID subscribes to events for a single vehicle, which the caller must own.",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers.CreateWebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ownerAddress": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is the key for the HMAC-SHA256 signature sent in the X-DIMO-Signature header.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_controllers.DeviceDefinition": {
             "type": "object",
             "properties": {
@@ -1631,15 +3634,27 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "expiresAt": {
+                    "type": "string"
+                },
                 "ext": {
                     "type": "string"
                 },
+                "fileSize": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "type": {
                     "$ref": "#/definitions/internal_controllers.DocumentTypeEnum"
                 },
@@ -1648,6 +3663,9 @@ const docTemplate = `{
                 },
                 "userDeviceId": {
                     "type": "string"
+                },
+                "vehicleTokenId": {
+                    "type": "integer"
                 }
             }
         },
@@ -1672,59 +3690,294 @@ const docTemplate = `{
                 "VehicleCustomImage"
             ]
         },
-        "internal_controllers.GetUserDeviceErrorCodeQueriesResponse": {
+        "internal_controllers.DocumentUploadURLRequest": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "description": "ContentType is one of image/jpeg, image/png or application/pdf.",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size is the exact size of the file in bytes.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/internal_controllers.DocumentTypeEnum"
+                },
+                "userDeviceId": {
+                    "description": "UserDeviceID optionally attaches the document to a vehicle.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.DocumentUploadURLResponse": {
+            "type": "object",
+            "properties": {
+                "document": {
+                    "description": "Document is the pending document. It doesn't show up in listings until it is finalized.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.DocumentResponse"
+                        }
+                    ]
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the URL stops working.",
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers must be sent with the PUT, exactly as given.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "description": "URL accepts a PUT of the file.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.ErrorCodeSummaryItem": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services_dtc.Classification"
+                },
+                "cleared": {
+                    "description": "Cleared is true if the most recent query that reported the code has been cleared.",
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "P0300"
+                },
+                "description": {
+                    "description": "Description is from the most recent query that reported the code.",
+                    "type": "string",
+                    "example": "Random/multiple cylinder misfire detected"
+                },
+                "firstSeen": {
+                    "type": "string",
+                    "example": "2023-05-23T12:56:36Z"
+                },
+                "lastSeen": {
+                    "type": "string",
+                    "example": "2023-06-02T08:10:12Z"
+                },
+                "timesReported": {
+                    "description": "TimesReported is the number of queries that included the code.",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "internal_controllers.ErrorCodesSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.ErrorCodeSummaryItem"
+                    }
+                }
+            }
+        },
+        "internal_controllers.GetUserDeviceErrorCodeQueriesResponse": {
+            "type": "object",
+            "properties": {
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.GetUserDeviceErrorCodeQueriesResponseItem"
+                    }
+                }
+            }
+        },
+        "internal_controllers.GetUserDeviceErrorCodeQueriesResponseItem": {
+            "type": "object",
+            "properties": {
+                "clearedAt": {
+                    "description": "ClearedAt is the time at which the user cleared the codes from this query.\nMay be null.",
+                    "type": "string",
+                    "example": "2023-05-23T12:57:05Z"
+                },
+                "errorCodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.ErrorCodesResponse"
+                    }
+                },
+                "requestedAt": {
+                    "type": "string",
+                    "example": "2023-05-23T12:56:36Z"
+                }
+            }
+        },
+        "internal_controllers.GetUserDeviceIntegrationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is the creation time of this integration for this device.",
+                    "type": "string"
+                },
+                "externalId": {
+                    "description": "ExternalID is the identifier used by the third party for the device. It may be absent if we\nhaven't authorized yet.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of \"Pending\", \"PendingFirstData\", \"Active\", \"Failed\", \"DuplicateIntegration\".",
+                    "type": "string"
+                },
+                "tesla": {
+                    "description": "Contains further details about tesla integration status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.TeslaIntegrationInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_controllers.MintBatchResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ownerAddress": {
+                    "type": "string"
+                },
+                "vehicles": {
+                    "description": "Vehicles is sorted by VIN.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchVehicleResponse"
+                    }
+                }
+            }
+        },
+        "internal_controllers.MintBatchSignatureRequest": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "description": "Signature is the hex encoding of the EIP-712 signature result.",
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchSignatureResponse": {
             "type": "object",
             "properties": {
-                "queries": {
+                "error": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "vehicles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_controllers.GetUserDeviceErrorCodeQueriesResponseItem"
+                        "$ref": "#/definitions/internal_controllers.MintBatchVehicleErrorResponse"
                     }
                 }
             }
         },
-        "internal_controllers.GetUserDeviceErrorCodeQueriesResponseItem": {
+        "internal_controllers.MintBatchVehicleErrorResponse": {
             "type": "object",
             "properties": {
-                "clearedAt": {
-                    "description": "ClearedAt is the time at which the user cleared the codes from this query.\nMay be null.",
+                "index": {
+                    "description": "Index is the vehicle's position in the request, starting from 0.",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchVehicleRequest": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
                     "type": "string",
-                    "example": "2023-05-23T12:57:05Z"
+                    "example": "USA"
                 },
-                "errorCodes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.ErrorCodesResponse"
-                    }
+                "definitionId": {
+                    "description": "DefinitionID is the device definition slug, e.g., \"honda_accord_2003\".",
+                    "type": "string",
+                    "example": "honda_accord_2003"
                 },
-                "requestedAt": {
+                "vin": {
                     "type": "string",
-                    "example": "2023-05-23T12:56:36Z"
+                    "example": "1HGCM82633A004352"
                 }
             }
         },
-        "internal_controllers.GetUserDeviceIntegrationResponse": {
+        "internal_controllers.MintBatchVehicleResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "description": "CreatedAt is the creation time of this integration for this device.",
+                "definitionId": {
                     "type": "string"
                 },
-                "externalId": {
-                    "description": "ExternalID is the identifier used by the third party for the device. It may be absent if we\nhaven't authorized yet.",
+                "failureReason": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "RequestID is the vehicle's mint request. See /v1/transactions/{requestID} for its\ntimeline.",
                     "type": "string"
                 },
                 "status": {
-                    "description": "Status is one of \"Pending\", \"PendingFirstData\", \"Active\", \"Failed\", \"DuplicateIntegration\".",
+                    "description": "Status is one of \"Unsubmitted\", \"Submitted\", \"Mined\", \"Confirmed\", or \"Failed\".",
                     "type": "string"
                 },
-                "tesla": {
-                    "description": "Contains further details about tesla integration status",
+                "typedData": {
+                    "description": "TypedData is the EIP-712 payload for the owner to sign. It's only present while the\nvehicle is waiting for its signature.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/internal_controllers.TeslaIntegrationInfo"
+                            "$ref": "#/definitions/apitypes.TypedData"
                         }
                     ]
+                },
+                "userDeviceId": {
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string"
                 }
             }
         },
@@ -1898,6 +4151,35 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.SetChargeLimitRequest": {
+            "type": "object",
+            "properties": {
+                "percent": {
+                    "description": "Percent is the target state of charge, between 50 and 100.",
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers.SetChargingAmpsRequest": {
+            "type": "object",
+            "properties": {
+                "amps": {
+                    "description": "Amps is the charging current, between 1 and 80.",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers.SubmitMintBatchSignaturesRequest": {
+            "type": "object",
+            "properties": {
+                "signatures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchSignatureRequest"
+                    }
+                }
+            }
+        },
         "internal_controllers.SyntheticDeviceStatus": {
             "type": "object",
             "properties": {
@@ -1975,6 +4257,69 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.TransactionResponse": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "Attempt counts the submissions of this call, starting from 1.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "explorerUrl": {
+                    "description": "ExplorerURL links to the transaction on a block explorer.",
+                    "type": "string"
+                },
+                "failureKind": {
+                    "description": "FailureKind is set for failed transactions. \"Permanent\" means sending the same call again\nwon't help: the contract rejected it, or a nonce signed into it was already used. \"Transient\"\nmeans the transaction reverted without data, as when it runs out of gas or is dropped; these\nare resubmitted as a new request, listed in retriedBy, until the attempts run out.",
+                    "type": "string"
+                },
+                "failureReason": {
+                    "description": "FailureReason is the decoded revert reason for failed transactions.",
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash is the transaction hash, once the transaction has been submitted.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "Operation is one of \"Mint\", \"Burn\", \"Claim\", \"Pair\", \"Unpair\", \"SyntheticMint\", or\n\"SyntheticBurn\". It's empty for some old requests.",
+                    "type": "string"
+                },
+                "ownerAddress": {
+                    "type": "string"
+                },
+                "retriedBy": {
+                    "description": "RetriedBy is the request that resubmitted this one after it failed.",
+                    "type": "string"
+                },
+                "retryOf": {
+                    "description": "RetryOf is the failed request that this one resubmits.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of \"Unsubmitted\", \"Submitted\", \"Mined\", \"Confirmed\", or \"Failed\".",
+                    "type": "string"
+                },
+                "timeline": {
+                    "description": "Timeline lists the statuses the request has been in, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.TransactionStatusChange"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controllers.TransactionStatus": {
             "type": "object",
             "properties": {
@@ -2011,6 +4356,56 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.TransactionStatusChange": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "Status is one of \"Unsubmitted\", \"Submitted\", \"Mined\", \"Confirmed\", or \"Failed\".",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.TransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "description": "NextCursor should be passed as the cursor parameter to retrieve the next page. It is\nomitted on the last page.",
+                    "type": "string"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "internal_controllers.UpdateDocumentRequest": {
+            "type": "object",
+            "properties": {
+                "clearExpiresAt": {
+                    "description": "ClearExpiresAt removes the expiry date.",
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt sets the expiry date, in RFC 3339 format.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags replaces all of the document's tags.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "internal_controllers.UpdateVINReq": {
             "type": "object",
             "required": [
@@ -2116,6 +4511,58 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.VehicleHandoverResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "newOwner": {
+                    "type": "string"
+                },
+                "previousOwner": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role is \"PreviousOwner\" or \"NewOwner\", depending on which one the caller is.",
+                    "type": "string"
+                },
+                "steps": {
+                    "description": "Steps lists what was done to the vehicle's data, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.VehicleHandoverStep"
+                    }
+                },
+                "transactionHash": {
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers.VehicleHandoverStep": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is one of \"PrivilegesCleared\", \"IntegrationRevoked\", \"WebhooksDeleted\",\n\"DocumentsDetached\", \"ErrorCodesHidden\", or \"OwnerChanged\".",
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controllers.VehicleMintRequest": {
             "type": "object",
             "required": [
@@ -2194,6 +4641,68 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastResponseCode": {
+                    "type": "integer"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of \"Pending\", \"Delivered\", or \"DeadLettered\".",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ownerAddress": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_controllers_user_sd.Message": {
             "type": "object",
             "properties": {
@@ -11,7 +11,30 @@ var (
 		Help: "Total successful Drivly used",
 	})
 
+	TeslaTokenRefreshCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devices_api_tesla_token_refresh_total",
+		Help: "Total number of Tesla Fleet API token refresh attempts",
+	}, []string{"status"})
+
+	CommandRequestTimedOutCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devices_api_command_requests_timed_out_total",
+		Help: "Total number of vehicle commands that never received a status",
+	}, []string{"command"})
+
+	WebhookDeliveryCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devices_api_webhook_deliveries_total",
+		Help: "Total number of outbound webhook delivery attempts",
+	}, []string{"event_type", "status"})
+
 	// Chat GPT Metrics
+	ErrorCodesCatalogHitsOps = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "devices_api_error_codes_catalog_hits_total",
+		Help: "Total number of error codes described from the built-in catalog instead of Open AI",
+	})
+	LLMCacheHitsOps = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "devices_api_error_codes_llm_cache_hits_total",
+		Help: "Total number of error codes described from cached LLM responses",
+	})
 	OpenAITotalCallsOps = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "devices_api_error_codes_openai_requests_total",
 		Help: "Total number of calls to Open AI ChatGPT",
@@ -63,6 +63,8 @@ type Settings struct {
 	TeslaTelemetryHostName      string `yaml:"TESLA_TELEMETRY_HOST_NAME"`
 	TeslaTelemetryPort          int    `yaml:"TESLA_TELEMETRY_PORT"`
 	TeslaTelemetryCACertificate string `yaml:"TESLA_TELEMETRY_CA_CERTIFICATE"`
+	TeslaTokenRefreshInterval   string `yaml:"TESLA_TOKEN_REFRESH_INTERVAL"`
+	TeslaTokenRefreshWindow     string `yaml:"TESLA_TOKEN_REFRESH_WINDOW"`
 
 	IPFSURL string `yaml:"IPFS_URL"`
 
@@ -92,6 +94,67 @@ type Settings struct {
 	BlockMinting bool `yaml:"BLOCK_MINTING"`
 
 	NewNFTHost string `yaml:"NEW_NFT_HOST"`
+
+	// CommandTimeout is how long a vehicle command may stay pending before it is marked as timed
+	// out. CommandTimeoutOverrides is a comma-separated list of command=duration pairs, e.g.,
+	// "climate/on=5m,doors/unlock=1m".
+	CommandTimeout          string `yaml:"COMMAND_TIMEOUT"`
+	CommandTimeoutOverrides string `yaml:"COMMAND_TIMEOUT_OVERRIDES"`
+	CommandReaperInterval   string `yaml:"COMMAND_REAPER_INTERVAL"`
+	// AutoPiJobTimeout is how long an AutoPi job may wait for its webhook before the command
+	// reaper marks it as timed out. Defaults to 10m.
+	AutoPiJobTimeout string `yaml:"AUTOPI_JOB_TIMEOUT"`
+
+	// WebhookDispatchInterval is how often pending webhook deliveries are sent. Leaving it empty
+	// disables delivery. Failed deliveries are retried with exponential backoff starting at
+	// WebhookRetryBackoff, and dead-lettered after WebhookMaxAttempts.
+	WebhookDispatchInterval string `yaml:"WEBHOOK_DISPATCH_INTERVAL"`
+	WebhookRetryBackoff     string `yaml:"WEBHOOK_RETRY_BACKOFF"`
+	WebhookMaxAttempts      int    `yaml:"WEBHOOK_MAX_ATTEMPTS"`
+
+	// TemplateMigrationInterval is how often a batch of devices is moved for a running template
+	// migration. Leaving it empty disables the worker. Each batch holds up to
+	// TemplateMigrationBatchSize devices, defaulting to 50, with TemplateMigrationDeviceDelay
+	// between AutoPi calls, defaulting to 400ms. Only one replica works a migration at a time and
+	// migrations run one after another, so the delay holds across the deployment.
+	TemplateMigrationInterval    string `yaml:"TEMPLATE_MIGRATION_INTERVAL"`
+	TemplateMigrationBatchSize   int    `yaml:"TEMPLATE_MIGRATION_BATCH_SIZE"`
+	TemplateMigrationDeviceDelay string `yaml:"TEMPLATE_MIGRATION_DEVICE_DELAY"`
+
+	// LLMProvider explains error codes, and is either "openai" (the default) or "ollama". For
+	// "openai", LLMURL falls back to ChatGPTURL. LLMTimeout and LLMCacheTTL are durations;
+	// setting the cache TTL to 0s turns off caching.
+	LLMProvider    string  `yaml:"LLM_PROVIDER"`
+	LLMURL         string  `yaml:"LLM_URL"`
+	LLMModel       string  `yaml:"LLM_MODEL"`
+	LLMTemperature float64 `yaml:"LLM_TEMPERATURE"`
+	LLMTimeout     string  `yaml:"LLM_TIMEOUT"`
+	LLMCacheTTL    string  `yaml:"LLM_CACHE_TTL"`
+
+	// ErrorCodesRateLimit caps how many error code lookups that reach the LLM a single user can
+	// make per ErrorCodesRateLimitWindow. Zero means no limit.
+	ErrorCodesRateLimit       int    `yaml:"ERROR_CODES_RATE_LIMIT"`
+	ErrorCodesRateLimitWindow string `yaml:"ERROR_CODES_RATE_LIMIT_WINDOW"`
+
+	// DocumentsPresignedURLTTL is how long presigned document upload and download URLs stay
+	// valid, as a duration. Defaults to 15 minutes.
+	DocumentsPresignedURLTTL string `yaml:"DOCUMENTS_PRESIGNED_URL_TTL"`
+	// PendingDocumentTTL is how long a presigned upload may go unfinalized before the command
+	// reaper deletes it. Defaults to 24h.
+	PendingDocumentTTL string `yaml:"PENDING_DOCUMENT_TTL"`
+
+	// BlockExplorerURL is the base URL for transaction links, e.g., "https://polygonscan.com".
+	// Links are left out if it's empty.
+	BlockExplorerURL string `yaml:"BLOCK_EXPLORER_URL"`
+
+	// MetaTransactionMaxAttempts is how many times a meta-transaction that failed for a reason
+	// unrelated to its contents, such as running out of gas, is sent before giving up. Defaults
+	// to 3. Set it to 1 to turn off resubmission.
+	MetaTransactionMaxAttempts int `yaml:"META_TRANSACTION_MAX_ATTEMPTS"`
+
+	// SignatureCacheTTL is how long the answers of smart contract wallets to signature checks are
+	// remembered, as a duration. Defaults to 10 minutes; 0s turns off caching.
+	SignatureCacheTTL string `yaml:"SIGNATURE_CACHE_TTL"`
 }
 
 func (s *Settings) IsProduction() bool {
@@ -56,6 +56,11 @@ func (r AutoPiSubStatusEnum) String() string {
 
 const (
 	ChargeLimit        string = "charge/limit"
+	ChargeAmps         string = "charge/amps"
+	ClimateOn          string = "climate/on"
+	ClimateOff         string = "climate/off"
+	HornHonk           string = "horn/honk"
+	LightsFlash        string = "lights/flash"
 	FrunkOpen          string = "frunk/open"
 	TrunkOpen          string = "trunk/open"
 	DoorsLock          string = "doors/lock"
@@ -0,0 +1,713 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// AftermarketDevicesController serves what we know about aftermarket devices, so that clients
+// don't have to ask the chain, and sends their claiming and pairing meta-transactions.
+type AftermarketDevicesController struct {
+	dbs            func() *db.ReaderWriter
+	log            *zerolog.Logger
+	registryClient *registry.Client
+	sigVerifier    services.SignatureVerifier
+}
+
+func NewAftermarketDevicesController(dbs func() *db.ReaderWriter, log *zerolog.Logger, registryClient *registry.Client, sigVerifier services.SignatureVerifier) *AftermarketDevicesController {
+	return &AftermarketDevicesController{
+		dbs:            dbs,
+		log:            log,
+		registryClient: registryClient,
+		sigVerifier:    sigVerifier,
+	}
+}
+
+// AftermarketDeviceResponse describes an aftermarket device and its on-chain state.
+type AftermarketDeviceResponse struct {
+	Serial              string          `json:"serial"`
+	TokenID             *big.Int        `json:"tokenId" swaggertype:"number"`
+	ManufacturerTokenID *big.Int        `json:"manufacturerTokenId" swaggertype:"number"`
+	EthereumAddress     common.Address  `json:"ethereumAddress" swaggertype:"string"`
+	OwnerAddress        *common.Address `json:"ownerAddress,omitempty" swaggertype:"string"`
+	// BeneficiaryAddress receives the device's rewards. It's the owner unless another address
+	// was set.
+	BeneficiaryAddress *common.Address `json:"beneficiaryAddress,omitempty" swaggertype:"string"`
+	// VehicleTokenID is the vehicle the device is paired with, if any.
+	VehicleTokenID *big.Int `json:"vehicleTokenId,omitempty" swaggertype:"number"`
+
+	// Claim contains the status of the on-chain claiming meta-transaction.
+	Claim *TransactionStatus `json:"claim,omitempty"`
+	// Pair contains the status of the on-chain pairing meta-transaction.
+	Pair *TransactionStatus `json:"pair,omitempty"`
+	// Unpair contains the status of the on-chain unpairing meta-transaction.
+	Unpair *TransactionStatus `json:"unpair,omitempty"`
+
+	// Attributes are the device's on-chain attributes other than the serial, such as its
+	// hardware revision.
+	Attributes map[string]string `json:"attributes"`
+	// QueriedVIN is the VIN the device last read from the vehicle, if it has been asked to.
+	// It may differ from the VIN the owner entered for the vehicle.
+	QueriedVIN string `json:"queriedVin,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+var loadAftermarketDeviceRequests = []qm.QueryMod{
+	qm.Load(models.AftermarketDeviceRels.ClaimMetaTransactionRequest),
+	qm.Load(models.AftermarketDeviceRels.PairRequest),
+	qm.Load(models.AftermarketDeviceRels.UnpairRequest),
+}
+
+func transactionStatus(mtr *models.MetaTransactionRequest) *TransactionStatus {
+	if mtr == nil {
+		return nil
+	}
+
+	var maybeHash *string
+	if mtr.Hash.Valid {
+		hash := common.BytesToHash(mtr.Hash.Bytes).Hex()
+		maybeHash = &hash
+	}
+
+	return &TransactionStatus{
+		Status:        mtr.Status,
+		Hash:          maybeHash,
+		CreatedAt:     mtr.CreatedAt,
+		UpdatedAt:     mtr.UpdatedAt,
+		FailureReason: mtr.FailureReason.Ptr(),
+	}
+}
+
+func (ac *AftermarketDevicesController) aftermarketDeviceToAPI(unit *models.AftermarketDevice) AftermarketDeviceResponse {
+	out := AftermarketDeviceResponse{
+		Serial:              unit.Serial,
+		TokenID:             unit.TokenID.Int(nil),
+		ManufacturerTokenID: unit.DeviceManufacturerTokenID.Int(nil),
+		EthereumAddress:     common.BytesToAddress(unit.EthereumAddress),
+		Attributes:          map[string]string{},
+		CreatedAt:           unit.CreatedAt,
+		UpdatedAt:           unit.UpdatedAt,
+	}
+
+	if unit.OwnerAddress.Valid {
+		owner := common.BytesToAddress(unit.OwnerAddress.Bytes)
+		out.OwnerAddress = &owner
+		out.BeneficiaryAddress = &owner
+
+		if unit.Beneficiary.Valid {
+			beneficiary := common.BytesToAddress(unit.Beneficiary.Bytes)
+			out.BeneficiaryAddress = &beneficiary
+		}
+	}
+
+	if !unit.VehicleTokenID.IsZero() {
+		out.VehicleTokenID = unit.VehicleTokenID.Int(nil)
+	}
+
+	if unit.R != nil {
+		out.Claim = transactionStatus(unit.R.ClaimMetaTransactionRequest)
+		out.Pair = transactionStatus(unit.R.PairRequest)
+		out.Unpair = transactionStatus(unit.R.UnpairRequest)
+	}
+
+	if unit.Metadata.Valid {
+		md := new(services.AftermarketDeviceMetadata)
+		if err := unit.Metadata.Unmarshal(md); err != nil {
+			ac.log.Err(err).Str("serial", unit.Serial).Msg("Couldn't parse aftermarket device metadata.")
+		} else {
+			if md.Attributes != nil {
+				out.Attributes = md.Attributes
+			}
+			out.QueriedVIN = md.QueriedVIN
+		}
+	}
+
+	return out
+}
+
+// ListAftermarketDevices godoc
+// @Summary     List the caller's aftermarket devices
+// @Description Lists the aftermarket devices owned by the caller's address, with pairing status, attributes and beneficiary.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Success     200 {array} controllers.AftermarketDeviceResponse
+// @Security    BearerAuth
+// @Router      /aftermarket/devices [get]
+func (ac *AftermarketDevicesController) ListAftermarketDevices(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	units, err := models.AftermarketDevices(
+		append([]qm.QueryMod{
+			models.AftermarketDeviceWhere.OwnerAddress.EQ(null.BytesFrom(userAddr.Bytes())),
+			qm.OrderBy(models.AftermarketDeviceColumns.TokenID),
+		}, loadAftermarketDeviceRequests...)...,
+	).All(c.Context(), ac.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	out := make([]AftermarketDeviceResponse, len(units))
+	for i, unit := range units {
+		out[i] = ac.aftermarketDeviceToAPI(unit)
+	}
+
+	return c.JSON(out)
+}
+
+// GetAftermarketDeviceBySerial godoc
+// @Summary     Get an aftermarket device by serial
+// @Description Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Success     200 {object} controllers.AftermarketDeviceResponse
+// @Failure     404 "No such device, or the caller owns neither it nor its vehicle."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial} [get]
+func (ac *AftermarketDevicesController) GetAftermarketDeviceBySerial(c *fiber.Ctx) error {
+	return ac.getAftermarketDevice(c)
+}
+
+// GetAftermarketDeviceByTokenID godoc
+// @Summary     Get an aftermarket device by token id
+// @Description Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       tokenID path int true "Device token id"
+// @Success     200 {object} controllers.AftermarketDeviceResponse
+// @Failure     400 "Invalid token id."
+// @Failure     404 "No such device, or the caller owns neither it nor its vehicle."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-token-id/{tokenID} [get]
+func (ac *AftermarketDevicesController) GetAftermarketDeviceByTokenID(c *fiber.Ctx) error {
+	return ac.getAftermarketDevice(c)
+}
+
+// GetAftermarketDeviceByAddress godoc
+// @Summary     Get an aftermarket device by address
+// @Description Gets an aftermarket device with its paired vehicle, claim and pairing status, attributes and beneficiary. Only the device's owner and the owner of the vehicle it's paired to can see it.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       address path string true "Device ethereum address"
+// @Success     200 {object} controllers.AftermarketDeviceResponse
+// @Failure     400 "Invalid address."
+// @Failure     404 "No such device, or the caller owns neither it nor its vehicle."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-address/{address} [get]
+func (ac *AftermarketDevicesController) GetAftermarketDeviceByAddress(c *fiber.Ctx) error {
+	return ac.getAftermarketDevice(c)
+}
+
+// getAftermarketDevice serves the device found by the owner.AftermarketDevice middleware.
+func (ac *AftermarketDevicesController) getAftermarketDevice(c *fiber.Ctx) error {
+	serial := c.Locals("serial").(string)
+
+	unit, err := models.AftermarketDevices(
+		append([]qm.QueryMod{models.AftermarketDeviceWhere.Serial.EQ(serial)}, loadAftermarketDeviceRequests...)...,
+	).One(c.Context(), ac.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(ac.aftermarketDeviceToAPI(unit))
+}
+
+// AftermarketDeviceClaimRequest carries the signatures for claiming a device. Both sign the
+// payload from the GET endpoint.
+type AftermarketDeviceClaimRequest struct {
+	// OwnerSignature is the caller's signature.
+	OwnerSignature hexutil.Bytes `json:"ownerSignature" swaggertype:"string"`
+	// AftermarketDeviceSignature is the device's own signature.
+	AftermarketDeviceSignature hexutil.Bytes `json:"aftermarketDeviceSignature" swaggertype:"string"`
+}
+
+// AftermarketDevicePairRequest carries the signatures for pairing a device with a vehicle. If
+// one address owns both, only Signature is needed. Otherwise the device and the vehicle's owner
+// sign.
+type AftermarketDevicePairRequest struct {
+	VehicleTokenID *big.Int `json:"vehicleTokenId" swaggertype:"number"`
+	// Signature is the signature of the owner of both the device and the vehicle.
+	Signature hexutil.Bytes `json:"signature,omitempty" swaggertype:"string"`
+	// AftermarketDeviceSignature is the device's own signature, when the owners differ.
+	AftermarketDeviceSignature hexutil.Bytes `json:"aftermarketDeviceSignature,omitempty" swaggertype:"string"`
+	// VehicleOwnerSignature is the vehicle owner's signature, when the owners differ.
+	VehicleOwnerSignature hexutil.Bytes `json:"vehicleOwnerSignature,omitempty" swaggertype:"string"`
+}
+
+// AftermarketDeviceUnpairRequest carries the caller's signature for unpairing a device.
+type AftermarketDeviceUnpairRequest struct {
+	Signature hexutil.Bytes `json:"signature" swaggertype:"string"`
+}
+
+// loadCommandDevice loads the device found by the owner.AftermarketDevice middleware, with the
+// requests that might block a new command.
+func (ac *AftermarketDevicesController) loadCommandDevice(c *fiber.Ctx, exec boil.ContextExecutor) (*models.AftermarketDevice, error) {
+	serial := c.Locals("serial").(string)
+
+	return models.AftermarketDevices(
+		append([]qm.QueryMod{models.AftermarketDeviceWhere.Serial.EQ(serial)}, loadAftermarketDeviceRequests...)...,
+	).One(c.Context(), exec)
+}
+
+// inProgress reports whether the request may still succeed.
+func inProgress(mtr *models.MetaTransactionRequest) bool {
+	return mtr != nil && mtr.Status != models.MetaTransactionRequestStatusFailed
+}
+
+func checkClaim(unit *models.AftermarketDevice) error {
+	if unit.OwnerAddress.Valid {
+		return fiber.NewError(fiber.StatusConflict, "Device already claimed.")
+	}
+	if inProgress(unit.R.ClaimMetaTransactionRequest) {
+		return fiber.NewError(fiber.StatusConflict, "Claiming already in progress.")
+	}
+	return nil
+}
+
+// GetAftermarketDeviceClaimPayload godoc
+// @Summary     Get the payload for claiming an aftermarket device
+// @Description Returns the EIP-712 payload that both the caller and the device sign to claim it for the caller.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Success     200 {object} signer.TypedData
+// @Failure     404 "No such device."
+// @Failure     409 "The device is already claimed, or claiming is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/claim [get]
+func (ac *AftermarketDevicesController) GetAftermarketDeviceClaimPayload(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	unit, err := ac.loadCommandDevice(c, ac.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	if err := checkClaim(unit); err != nil {
+		return err
+	}
+
+	return c.JSON(ac.registryClient.GetPayload(&registry.ClaimAftermarketDeviceSign{
+		AftermarketDeviceNode: unit.TokenID.Int(nil),
+		Owner:                 userAddr,
+	}))
+}
+
+// PostAftermarketDeviceClaim godoc
+// @Summary     Claim an aftermarket device
+// @Description Submits the caller's and the device's signatures of the claim payload, and sends the claiming meta-transaction.
+// @Tags        aftermarket-devices
+// @Accept      json
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Param       claimRequest body controllers.AftermarketDeviceClaimRequest true "Signatures"
+// @Success     200 {object} controllers.CommandResponse
+// @Failure     400 "Invalid signature."
+// @Failure     404 "No such device."
+// @Failure     409 "The device is already claimed, or claiming is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/claim [post]
+func (ac *AftermarketDevicesController) PostAftermarketDeviceClaim(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+	userID := c.Locals("userID").(string)
+
+	var req AftermarketDeviceClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := ac.dbs().Writer.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	unit, err := ac.loadCommandDevice(c, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := checkClaim(unit); err != nil {
+		return err
+	}
+
+	adNode := unit.TokenID.Int(nil)
+
+	hash, err := ac.registryClient.Hash(&registry.ClaimAftermarketDeviceSign{
+		AftermarketDeviceNode: adNode,
+		Owner:                 userAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := ac.sigVerifier.VerifySignature(c.Context(), userAddr, hash, req.OwnerSignature); err != nil {
+		return signatureError(err)
+	}
+
+	if err := ac.sigVerifier.VerifySignature(c.Context(), common.BytesToAddress(unit.EthereumAddress), hash, req.AftermarketDeviceSignature); err != nil {
+		return signatureError(err)
+	}
+
+	requestID := ksuid.New().String()
+
+	if _, err := services.NewMetaTransactionRequest(c.Context(), tx, requestID, models.MetaTransactionRequestOperationClaim, "", userAddr); err != nil {
+		return err
+	}
+
+	cols := models.AftermarketDeviceColumns
+
+	unit.ClaimMetaTransactionRequestID = null.StringFrom(requestID)
+	unit.UserID = null.StringFrom(userID)
+	if _, err := unit.Update(c.Context(), tx, boil.Whitelist(cols.ClaimMetaTransactionRequestID, cols.UserID, cols.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := ac.registryClient.ClaimAftermarketDeviceSign(requestID, adNode, userAddr, req.OwnerSignature, req.AftermarketDeviceSignature); err != nil {
+		return err
+	}
+
+	return c.JSON(CommandResponse{RequestID: requestID})
+}
+
+// checkPair returns the vehicle to pair with, if the device can be paired with it.
+func (ac *AftermarketDevicesController) checkPair(c *fiber.Ctx, exec boil.ContextExecutor, unit *models.AftermarketDevice, vehicleNode *big.Int) (*models.UserDevice, error) {
+	if !unit.OwnerAddress.Valid {
+		return nil, fiber.NewError(fiber.StatusConflict, "Device must be claimed before it's paired.")
+	}
+	if !unit.VehicleTokenID.IsZero() {
+		return nil, fiber.NewError(fiber.StatusConflict, "Device already paired.")
+	}
+	if inProgress(unit.R.PairRequest) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Pairing already in progress.")
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(utils.NullableBigToDecimal(vehicleNode)),
+	).One(c.Context(), exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "No vehicle with that token id found.")
+		}
+		return nil, err
+	}
+
+	userAddr := address.Get(c)
+	if common.BytesToAddress(unit.OwnerAddress.Bytes) != userAddr && common.BytesToAddress(ud.OwnerAddress.Bytes) != userAddr {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Caller owns neither the device nor the vehicle.")
+	}
+
+	taken, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.VehicleTokenID.EQ(utils.NullableBigToDecimal(vehicleNode)),
+	).Exists(c.Context(), exec)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fiber.NewError(fiber.StatusConflict, "Vehicle already paired with another device.")
+	}
+
+	return ud, nil
+}
+
+// GetAftermarketDevicePairPayload godoc
+// @Summary     Get the payload for pairing an aftermarket device
+// @Description Returns the EIP-712 payload for pairing the device with a vehicle. If the device and vehicle have different owners, the device and the vehicle's owner both sign it. The caller must own one of them.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Param       vehicle_token_id query int true "Vehicle token id"
+// @Success     200 {object} signer.TypedData
+// @Failure     400 "Missing or invalid vehicle token id."
+// @Failure     403 "The caller owns neither the device nor the vehicle."
+// @Failure     404 "No such device or vehicle."
+// @Failure     409 "The device isn't claimed, either one is already paired, or pairing is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/pair [get]
+func (ac *AftermarketDevicesController) GetAftermarketDevicePairPayload(c *fiber.Ctx) error {
+	vns := c.Query("vehicle_token_id")
+	vehicleNode, ok := new(big.Int).SetString(vns, 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse vehicle token id %q.", vns))
+	}
+
+	unit, err := ac.loadCommandDevice(c, ac.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ac.checkPair(c, ac.dbs().Reader, unit, vehicleNode); err != nil {
+		return err
+	}
+
+	return c.JSON(ac.registryClient.GetPayload(&registry.PairAftermarketDeviceSign{
+		AftermarketDeviceNode: unit.TokenID.Int(nil),
+		VehicleNode:           vehicleNode,
+	}))
+}
+
+// PostAftermarketDevicePair godoc
+// @Summary     Pair an aftermarket device with a vehicle
+// @Description Submits signatures of the pairing payload and sends the pairing meta-transaction. If one address owns both the device and the vehicle, send signature; otherwise send aftermarketDeviceSignature and vehicleOwnerSignature.
+// @Tags        aftermarket-devices
+// @Accept      json
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Param       pairRequest body controllers.AftermarketDevicePairRequest true "Vehicle and signatures"
+// @Success     200 {object} controllers.CommandResponse
+// @Failure     400 "Missing or invalid signature."
+// @Failure     403 "The caller owns neither the device nor the vehicle."
+// @Failure     404 "No such device or vehicle."
+// @Failure     409 "The device isn't claimed, either one is already paired, or pairing is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/pair [post]
+func (ac *AftermarketDevicesController) PostAftermarketDevicePair(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	var req AftermarketDevicePairRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	if req.VehicleTokenID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Vehicle token id is required.")
+	}
+
+	tx, err := ac.dbs().Writer.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	unit, err := ac.loadCommandDevice(c, tx)
+	if err != nil {
+		return err
+	}
+
+	ud, err := ac.checkPair(c, tx, unit, req.VehicleTokenID)
+	if err != nil {
+		return err
+	}
+
+	adNode := unit.TokenID.Int(nil)
+	adOwner := common.BytesToAddress(unit.OwnerAddress.Bytes)
+	vehicleOwner := common.BytesToAddress(ud.OwnerAddress.Bytes)
+
+	hash, err := ac.registryClient.Hash(&registry.PairAftermarketDeviceSign{
+		AftermarketDeviceNode: adNode,
+		VehicleNode:           req.VehicleTokenID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if adOwner == vehicleOwner {
+		if len(req.Signature) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Signature is required when one address owns both the device and the vehicle.")
+		}
+		if err := ac.sigVerifier.VerifySignature(c.Context(), adOwner, hash, req.Signature); err != nil {
+			return signatureError(err)
+		}
+	} else {
+		if len(req.AftermarketDeviceSignature) == 0 || len(req.VehicleOwnerSignature) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "The device and the vehicle have different owners, so the device and the vehicle's owner must both sign.")
+		}
+		// The registry checks this one against the device's own address, as with claims.
+		if err := ac.sigVerifier.VerifySignature(c.Context(), common.BytesToAddress(unit.EthereumAddress), hash, req.AftermarketDeviceSignature); err != nil {
+			return signatureError(err)
+		}
+		if err := ac.sigVerifier.VerifySignature(c.Context(), vehicleOwner, hash, req.VehicleOwnerSignature); err != nil {
+			return signatureError(err)
+		}
+	}
+
+	requestID := ksuid.New().String()
+
+	if _, err := services.NewMetaTransactionRequest(c.Context(), tx, requestID, models.MetaTransactionRequestOperationPair, ud.ID, userAddr); err != nil {
+		return err
+	}
+
+	cols := models.AftermarketDeviceColumns
+
+	// A finished unpairing from an earlier pairing shouldn't block the next one.
+	unit.PairRequestID = null.StringFrom(requestID)
+	unit.UnpairRequestID = null.String{}
+	if _, err := unit.Update(c.Context(), tx, boil.Whitelist(cols.PairRequestID, cols.UnpairRequestID, cols.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if adOwner == vehicleOwner {
+		err = ac.registryClient.PairAftermarketDeviceSignSameOwner(requestID, adNode, req.VehicleTokenID, req.Signature)
+	} else {
+		err = ac.registryClient.PairAftermarketDeviceSignTwoOwners(requestID, adNode, req.VehicleTokenID, req.AftermarketDeviceSignature, req.VehicleOwnerSignature)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(CommandResponse{RequestID: requestID})
+}
+
+// checkUnpair returns the paired vehicle, if we know about it, when the caller may unpair the
+// device.
+func (ac *AftermarketDevicesController) checkUnpair(c *fiber.Ctx, exec boil.ContextExecutor, unit *models.AftermarketDevice) (*models.UserDevice, error) {
+	if unit.VehicleTokenID.IsZero() {
+		return nil, fiber.NewError(fiber.StatusConflict, "Device isn't paired.")
+	}
+	if inProgress(unit.R.UnpairRequest) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Unpairing already in progress.")
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(unit.VehicleTokenID),
+	).One(c.Context(), exec)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	userAddr := address.Get(c)
+	if common.BytesToAddress(unit.OwnerAddress.Bytes) != userAddr && (ud == nil || common.BytesToAddress(ud.OwnerAddress.Bytes) != userAddr) {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Caller owns neither the device nor the vehicle.")
+	}
+
+	return ud, nil
+}
+
+// GetAftermarketDeviceUnpairPayload godoc
+// @Summary     Get the payload for unpairing an aftermarket device
+// @Description Returns the EIP-712 payload for unpairing the device from its vehicle. The caller must own the device or the vehicle.
+// @Tags        aftermarket-devices
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Success     200 {object} signer.TypedData
+// @Failure     403 "The caller owns neither the device nor the vehicle."
+// @Failure     404 "No such device."
+// @Failure     409 "The device isn't paired, or unpairing is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/unpair [get]
+func (ac *AftermarketDevicesController) GetAftermarketDeviceUnpairPayload(c *fiber.Ctx) error {
+	unit, err := ac.loadCommandDevice(c, ac.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ac.checkUnpair(c, ac.dbs().Reader, unit); err != nil {
+		return err
+	}
+
+	return c.JSON(ac.registryClient.GetPayload(&registry.UnPairAftermarketDeviceSign{
+		AftermarketDeviceNode: unit.TokenID.Int(nil),
+		VehicleNode:           unit.VehicleTokenID.Int(nil),
+	}))
+}
+
+// PostAftermarketDeviceUnpair godoc
+// @Summary     Unpair an aftermarket device
+// @Description Submits the caller's signature of the unpairing payload and sends the unpairing meta-transaction.
+// @Tags        aftermarket-devices
+// @Accept      json
+// @Produce     json
+// @Param       serial path string true "Device serial"
+// @Param       unpairRequest body controllers.AftermarketDeviceUnpairRequest true "Signature"
+// @Success     200 {object} controllers.CommandResponse
+// @Failure     400 "Invalid signature."
+// @Failure     403 "The caller owns neither the device nor the vehicle."
+// @Failure     404 "No such device."
+// @Failure     409 "The device isn't paired, or unpairing is in progress."
+// @Security    BearerAuth
+// @Router      /aftermarket/device/{serial}/commands/unpair [post]
+func (ac *AftermarketDevicesController) PostAftermarketDeviceUnpair(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	var req AftermarketDeviceUnpairRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := ac.dbs().Writer.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	unit, err := ac.loadCommandDevice(c, tx)
+	if err != nil {
+		return err
+	}
+
+	ud, err := ac.checkUnpair(c, tx, unit)
+	if err != nil {
+		return err
+	}
+
+	adNode := unit.TokenID.Int(nil)
+	vehicleNode := unit.VehicleTokenID.Int(nil)
+
+	hash, err := ac.registryClient.Hash(&registry.UnPairAftermarketDeviceSign{
+		AftermarketDeviceNode: adNode,
+		VehicleNode:           vehicleNode,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := ac.sigVerifier.VerifySignature(c.Context(), userAddr, hash, req.Signature); err != nil {
+		return signatureError(err)
+	}
+
+	var userDeviceID string
+	if ud != nil {
+		userDeviceID = ud.ID
+	}
+
+	requestID := ksuid.New().String()
+
+	if _, err := services.NewMetaTransactionRequest(c.Context(), tx, requestID, models.MetaTransactionRequestOperationUnpair, userDeviceID, userAddr); err != nil {
+		return err
+	}
+
+	cols := models.AftermarketDeviceColumns
+
+	unit.UnpairRequestID = null.StringFrom(requestID)
+	if _, err := unit.Update(c.Context(), tx, boil.Whitelist(cols.UnpairRequestID, cols.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := ac.registryClient.UnPairAftermarketDeviceSign(requestID, adNode, vehicleNode, req.Signature); err != nil {
+		return err
+	}
+
+	return c.JSON(CommandResponse{RequestID: requestID})
+}
@@ -0,0 +1,349 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/middleware/owner"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/IBM/sarama/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestAftermarketDevices(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+	ac := NewAftermarketDevicesController(pdb.DBS, logger, nil, nil)
+
+	installer := common.HexToAddress("0x1")
+	stranger := common.HexToAddress("0x2")
+	beneficiary := common.HexToAddress("0x3")
+	deviceAddr := common.HexToAddress("0xd1")
+
+	ud := test.SetupCreateUserDevice(t, "installer", ksuid.New().String(), nil, "", pdb)
+	ud.TokenID = utils.NullableBigToDecimal(big.NewInt(7))
+	ud.OwnerAddress = null.BytesFrom(installer.Bytes())
+	_, err := ud.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	pair, err := services.NewMetaTransactionRequest(ctx, pdb.DBS().Writer, ksuid.New().String(), models.MetaTransactionRequestOperationPair, ud.ID, installer)
+	require.NoError(t, err)
+
+	md := null.JSON{}
+	require.NoError(t, md.Marshal(services.AftermarketDeviceMetadata{Attributes: map[string]string{"HardwareRevision": "7.2"}}))
+
+	unit := models.AftermarketDevice{
+		Serial:                    "macaron1",
+		EthereumAddress:           deviceAddr.Bytes(),
+		TokenID:                   utils.BigToDecimal(big.NewInt(42)),
+		DeviceManufacturerTokenID: utils.BigToDecimal(big.NewInt(142)),
+		OwnerAddress:              null.BytesFrom(installer.Bytes()),
+		Beneficiary:               null.BytesFrom(beneficiary.Bytes()),
+		VehicleTokenID:            ud.TokenID,
+		PairRequestID:             null.StringFrom(pair.ID),
+		Metadata:                  md,
+	}
+	require.NoError(t, unit.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	setup := func(addr common.Address) func(path string) *http.Response {
+		app := test.SetupAppFiber(*logger)
+		app.Use(test.AuthInjectorTestHandler("user", &addr))
+		amReader := owner.AftermarketDeviceOwner(pdb, logger)
+		app.Get("/aftermarket/devices", address.New(logger), ac.ListAftermarketDevices)
+		app.Get("/aftermarket/device/by-serial/:serial", amReader, ac.GetAftermarketDeviceBySerial)
+		app.Get("/aftermarket/device/by-token-id/:tokenID", amReader, ac.GetAftermarketDeviceByTokenID)
+		app.Get("/aftermarket/device/by-address/:address", amReader, ac.GetAftermarketDeviceByAddress)
+
+		return func(path string) *http.Response {
+			resp, err := app.Test(test.BuildRequest("GET", path, ""))
+			require.NoError(t, err)
+			return resp
+		}
+	}
+
+	get := setup(installer)
+
+	for _, path := range []string{
+		"/aftermarket/device/by-serial/macaron1",
+		"/aftermarket/device/by-token-id/42",
+		"/aftermarket/device/by-address/" + deviceAddr.Hex(),
+	} {
+		resp := get(path)
+		require.Equal(t, http.StatusOK, resp.StatusCode, path)
+
+		var out AftermarketDeviceResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+
+		assert.Equal(t, "macaron1", out.Serial)
+		assert.Equal(t, big.NewInt(42), out.TokenID)
+		assert.Equal(t, big.NewInt(142), out.ManufacturerTokenID)
+		assert.Equal(t, deviceAddr, out.EthereumAddress)
+		assert.Equal(t, &installer, out.OwnerAddress)
+		assert.Equal(t, &beneficiary, out.BeneficiaryAddress)
+		assert.Equal(t, big.NewInt(7), out.VehicleTokenID)
+		require.NotNil(t, out.Pair)
+		assert.Equal(t, models.MetaTransactionRequestStatusUnsubmitted, out.Pair.Status)
+		assert.Nil(t, out.Claim)
+		assert.Nil(t, out.Unpair)
+		assert.Equal(t, map[string]string{"HardwareRevision": "7.2"}, out.Attributes)
+	}
+
+	resp := get("/aftermarket/device/by-token-id/44")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = get("/aftermarket/device/by-address/xdd")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Others can't see it, paired or not.
+	resp = setup(stranger)("/aftermarket/device/by-serial/macaron1")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	unpaired := models.AftermarketDevice{
+		Serial:                    "macaron2",
+		EthereumAddress:           common.HexToAddress("0xd2").Bytes(),
+		TokenID:                   utils.BigToDecimal(big.NewInt(43)),
+		DeviceManufacturerTokenID: utils.BigToDecimal(big.NewInt(142)),
+		OwnerAddress:              null.BytesFrom(installer.Bytes()),
+	}
+	require.NoError(t, unpaired.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	resp = get("/aftermarket/device/by-serial/macaron2")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = setup(stranger)("/aftermarket/device/by-serial/macaron2")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	hidden, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// Someone else's device looks just like one that doesn't exist.
+	resp = setup(stranger)("/aftermarket/device/by-serial/macaron3")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	missing, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, string(missing), string(hidden))
+
+	resp = get("/aftermarket/devices")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list []AftermarketDeviceResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "macaron1", list[0].Serial)
+
+	resp = setup(stranger)("/aftermarket/devices")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Empty(t, list)
+}
+
+func TestAftermarketDeviceCommands(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+
+	producer := mocks.NewSyncProducer(t, nil)
+	client := &registry.Client{
+		Producer:     producer,
+		RequestTopic: "topic.transaction.request.send",
+		DB:           pdb.DBS,
+		Contract: registry.Contract{
+			ChainID: big.NewInt(137),
+			Address: common.HexToAddress("0x5"),
+			Name:    "DIMO",
+			Version: "1",
+		},
+	}
+
+	sigVerifier, err := services.NewSignatureVerifier(&config.Settings{}, nil, logger)
+	require.NoError(t, err)
+
+	ac := NewAftermarketDevicesController(pdb.DBS, logger, client, sigVerifier)
+
+	installerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	vehicleOwnerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deviceKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	installer := crypto.PubkeyToAddress(installerKey.PublicKey)
+	vehicleOwner := crypto.PubkeyToAddress(vehicleOwnerKey.PublicKey)
+	stranger := common.HexToAddress("0x2")
+
+	ud := test.SetupCreateUserDevice(t, "vehicleOwner", ksuid.New().String(), nil, "", pdb)
+	ud.TokenID = utils.NullableBigToDecimal(big.NewInt(7))
+	ud.OwnerAddress = null.BytesFrom(vehicleOwner.Bytes())
+	_, err = ud.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	unit := models.AftermarketDevice{
+		Serial:                    "macaron2",
+		EthereumAddress:           crypto.PubkeyToAddress(deviceKey.PublicKey).Bytes(),
+		TokenID:                   utils.BigToDecimal(big.NewInt(42)),
+		DeviceManufacturerTokenID: utils.BigToDecimal(big.NewInt(142)),
+	}
+	require.NoError(t, unit.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	setup := func(addr common.Address) func(method, path string, body any) *http.Response {
+		app := test.SetupAppFiber(*logger)
+		app.Use(test.AuthInjectorTestHandler("user", &addr))
+		amOwner := owner.AftermarketDevice(pdb, logger)
+		addrMw := address.New(logger)
+		app.Get("/aftermarket/device/:serial/commands/claim", amOwner, addrMw, ac.GetAftermarketDeviceClaimPayload)
+		app.Post("/aftermarket/device/:serial/commands/claim", amOwner, addrMw, ac.PostAftermarketDeviceClaim)
+		app.Get("/aftermarket/device/:serial/commands/pair", amOwner, addrMw, ac.GetAftermarketDevicePairPayload)
+		app.Post("/aftermarket/device/:serial/commands/pair", amOwner, addrMw, ac.PostAftermarketDevicePair)
+		app.Get("/aftermarket/device/:serial/commands/unpair", amOwner, addrMw, ac.GetAftermarketDeviceUnpairPayload)
+		app.Post("/aftermarket/device/:serial/commands/unpair", amOwner, addrMw, ac.PostAftermarketDeviceUnpair)
+
+		return func(method, path string, body any) *http.Response {
+			b := ""
+			if body != nil {
+				j, err := json.Marshal(body)
+				require.NoError(t, err)
+				b = string(j)
+			}
+			resp, err := app.Test(test.BuildRequest(method, path, b))
+			require.NoError(t, err)
+			return resp
+		}
+	}
+
+	payload := func(resp *http.Response) []byte {
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var td signer.TypedData
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&td))
+		hash, _, err := signer.TypedDataAndHash(td)
+		require.NoError(t, err)
+		return hash
+	}
+
+	sign := func(hash []byte, key *ecdsa.PrivateKey) hexutil.Bytes {
+		sig, err := crypto.Sign(hash, key)
+		require.NoError(t, err)
+		sig[64] += 27
+		return sig
+	}
+
+	requestID := func(resp *http.Response) string {
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var out CommandResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out.RequestID
+	}
+
+	asInstaller := setup(installer)
+	asVehicleOwner := setup(vehicleOwner)
+
+	// Claiming takes both the caller's and the device's signatures.
+	hash := payload(asInstaller("GET", "/aftermarket/device/macaron2/commands/claim", nil))
+
+	resp := asInstaller("POST", "/aftermarket/device/macaron2/commands/claim", AftermarketDeviceClaimRequest{
+		OwnerSignature: sign(hash, installerKey),
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	producer.ExpectSendMessageAndSucceed()
+	claimID := requestID(asInstaller("POST", "/aftermarket/device/macaron2/commands/claim", AftermarketDeviceClaimRequest{
+		OwnerSignature:             sign(hash, installerKey),
+		AftermarketDeviceSignature: sign(hash, deviceKey),
+	}))
+
+	require.NoError(t, unit.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, null.StringFrom(claimID), unit.ClaimMetaTransactionRequestID)
+	assert.Equal(t, null.StringFrom("user"), unit.UserID)
+
+	resp = asInstaller("GET", "/aftermarket/device/macaron2/commands/claim", nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// The claim lands.
+	unit.OwnerAddress = null.BytesFrom(installer.Bytes())
+	_, err = unit.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	resp = asInstaller("GET", "/aftermarket/device/macaron2/commands/pair?vehicle_token_id=8", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = setup(stranger)("GET", "/aftermarket/device/macaron2/commands/pair?vehicle_token_id=7", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// The device and vehicle have different owners, so the device and the vehicle's owner sign.
+	hash = payload(asInstaller("GET", "/aftermarket/device/macaron2/commands/pair?vehicle_token_id=7", nil))
+
+	resp = asInstaller("POST", "/aftermarket/device/macaron2/commands/pair", AftermarketDevicePairRequest{
+		VehicleTokenID: big.NewInt(7),
+		Signature:      sign(hash, installerKey),
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// The device owner's signature doesn't stand in for the device's.
+	resp = asInstaller("POST", "/aftermarket/device/macaron2/commands/pair", AftermarketDevicePairRequest{
+		VehicleTokenID:             big.NewInt(7),
+		AftermarketDeviceSignature: sign(hash, installerKey),
+		VehicleOwnerSignature:      sign(hash, vehicleOwnerKey),
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	producer.ExpectSendMessageAndSucceed()
+	pairID := requestID(asInstaller("POST", "/aftermarket/device/macaron2/commands/pair", AftermarketDevicePairRequest{
+		VehicleTokenID:             big.NewInt(7),
+		AftermarketDeviceSignature: sign(hash, deviceKey),
+		VehicleOwnerSignature:      sign(hash, vehicleOwnerKey),
+	}))
+
+	require.NoError(t, unit.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, null.StringFrom(pairID), unit.PairRequestID)
+
+	resp = asInstaller("GET", "/aftermarket/device/macaron2/commands/pair?vehicle_token_id=7", nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// The pairing lands.
+	unit.VehicleTokenID = ud.TokenID
+	_, err = unit.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	resp = setup(stranger)("GET", "/aftermarket/device/macaron2/commands/unpair", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Either owner can unpair.
+	hash = payload(asVehicleOwner("GET", "/aftermarket/device/macaron2/commands/unpair", nil))
+
+	producer.ExpectSendMessageAndSucceed()
+	unpairID := requestID(asVehicleOwner("POST", "/aftermarket/device/macaron2/commands/unpair", AftermarketDeviceUnpairRequest{
+		Signature: sign(hash, vehicleOwnerKey),
+	}))
+
+	require.NoError(t, unit.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, null.StringFrom(unpairID), unit.UnpairRequestID)
+
+	mtr, err := models.FindMetaTransactionRequest(ctx, pdb.DBS().Reader, unpairID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom(models.MetaTransactionRequestOperationUnpair), mtr.Operation)
+	assert.Equal(t, null.StringFrom(ud.ID), mtr.UserDeviceID)
+}
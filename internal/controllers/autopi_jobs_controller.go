@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ericlagergren/decimal"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// AutoPiJobsController shows the commands sent to a vehicle's AutoPi and what came back.
+type AutoPiJobsController struct {
+	dbs func() *db.ReaderWriter
+	log *zerolog.Logger
+}
+
+func NewAutoPiJobsController(dbs func() *db.ReaderWriter, log *zerolog.Logger) *AutoPiJobsController {
+	return &AutoPiJobsController{
+		dbs: dbs,
+		log: log,
+	}
+}
+
+// AutoPiJobResult is the raw result AutoPi reported for a job.
+type AutoPiJobResult struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Tag   string `json:"tag"`
+}
+
+// AutoPiJobResponse describes a command sent to an AutoPi.
+type AutoPiJobResponse struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	// State is one of "Sent", "COMMAND_EXECUTED", "COMMAND_FAILED", or "COMMAND_TIMEOUT".
+	State  string           `json:"state"`
+	Result *AutoPiJobResult `json:"result,omitempty"`
+	// VIN is the VIN read by a successful VIN query.
+	VIN string `json:"vin,omitempty"`
+	// LastUpdated is when AutoPi last reported on the job, if ever.
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// AutoPiJobsResponse is a page of AutoPi jobs, newest first.
+type AutoPiJobsResponse struct {
+	Jobs []AutoPiJobResponse `json:"jobs"`
+	// NextCursor should be passed as the cursor parameter to retrieve the next page. It is
+	// omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func (ac *AutoPiJobsController) autoPiJobToAPI(j *models.AutopiJob) AutoPiJobResponse {
+	out := AutoPiJobResponse{
+		ID:          j.ID,
+		Command:     j.Command,
+		State:       j.State,
+		LastUpdated: j.CommandLastUpdated.Ptr(),
+		CreatedAt:   j.CreatedAt,
+	}
+
+	if j.CommandResult.Valid {
+		res := new(services.AutoPiCommandResult)
+		if err := j.CommandResult.Unmarshal(res); err != nil {
+			ac.log.Err(err).Str("jobId", j.ID).Msg("Couldn't parse AutoPi job result.")
+			return out
+		}
+		out.Result = &AutoPiJobResult{Type: res.Type, Value: res.Value, Tag: res.Tag}
+
+		if j.State == services.AutoPiJobStateExecuted {
+			if parsed, err := services.ParseAutoPiCommandResult(j.Command, res); err == nil && parsed != nil {
+				out.VIN = parsed.VIN
+			}
+		}
+	}
+
+	return out
+}
+
+// ListUserDeviceAutoPiJobs godoc
+// @Summary     List the AutoPi jobs for a vehicle
+// @Description Lists the commands sent to the vehicle's AutoPi since the current owner took it, newest first, with their state and results.
+// @Tags        user-devices
+// @Produce     json
+// @Param       userDeviceID path string true "User device ID"
+// @Param       limit        query int false "Page size, at most 100" default(20)
+// @Param       cursor       query string false "Cursor from the previous page"
+// @Success     200 {object} controllers.AutoPiJobsResponse
+// @Failure     400 "Invalid limit or cursor."
+// @Security    BearerAuth
+// @Router      /user/devices/{userDeviceID}/autopi/jobs [get]
+func (ac *AutoPiJobsController) ListUserDeviceAutoPiJobs(c *fiber.Ctx) error {
+	return ac.listJobs(c, c.Params("userDeviceID"))
+}
+
+// ListVehicleAutoPiJobs godoc
+// @Summary     List the AutoPi jobs for a vehicle
+// @Description Lists the commands sent to the vehicle's AutoPi since the current owner took it, newest first, with their state and results.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Param       limit   query int false "Page size, at most 100" default(20)
+// @Param       cursor  query string false "Cursor from the previous page"
+// @Success     200 {object} controllers.AutoPiJobsResponse
+// @Failure     400 "Invalid limit or cursor."
+// @Failure     404 "Vehicle not found."
+// @Router      /vehicle/{tokenID}/autopi/jobs [get]
+func (ac *AutoPiJobsController) ListVehicleAutoPiJobs(c *fiber.Ctx) error {
+	tokenIDRaw := c.Params("tokenID")
+	tokenID, ok := new(decimal.Big).SetString(tokenIDRaw)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tokenIDRaw))
+	}
+
+	nft, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(tokenID)),
+	).One(c.Context(), ac.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Vehicle NFT not found.")
+		}
+		return err
+	}
+
+	return ac.listJobs(c, nft.ID)
+}
+
+func (ac *AutoPiJobsController) listJobs(c *fiber.Ctx, userDeviceID string) error {
+	limit := c.QueryInt("limit", services.DefaultCommandHistoryLimit)
+	if limit <= 0 || limit > services.MaxCommandHistoryLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", services.MaxCommandHistoryLimit))
+	}
+
+	// Jobs sent for a previous owner aren't shown.
+	since, err := services.OwnedSince(c.Context(), ac.dbs().Reader, userDeviceID)
+	if err != nil {
+		return err
+	}
+
+	jobs, next, err := services.ListAutoPiJobs(c.Context(), ac.dbs().Reader, userDeviceID, since, c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCommandCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid cursor.")
+		}
+		return err
+	}
+
+	out := AutoPiJobsResponse{
+		Jobs:       make([]AutoPiJobResponse, len(jobs)),
+		NextCursor: next,
+	}
+	for i, j := range jobs {
+		out.Jobs[i] = ac.autoPiJobToAPI(j)
+	}
+
+	return c.JSON(out)
+}
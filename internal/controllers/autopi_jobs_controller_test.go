@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestAutoPiJobs(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+	ac := NewAutoPiJobsController(pdb.DBS, logger)
+
+	ud := test.SetupCreateUserDevice(t, "user1", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud, big.NewInt(7), null.BytesFrom(test.MkAddr(1).Bytes()), pdb)
+
+	now := time.Now()
+	insert := func(command, state, result string, createdAt time.Time) *models.AutopiJob {
+		job := &models.AutopiJob{
+			ID:             ksuid.New().String(),
+			AutopiDeviceID: "device123",
+			UserDeviceID:   null.StringFrom(ud.ID),
+			Command:        command,
+			State:          state,
+			CreatedAt:      createdAt,
+		}
+		if result != "" {
+			require.NoError(t, job.CommandResult.UnmarshalJSON([]byte(result)))
+		}
+		require.NoError(t, job.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return job
+	}
+
+	vinJob := insert(services.AutoPiQueryVINCommand, services.AutoPiJobStateExecuted, `{"value": "1HGCM82633A004352", "type": "vin", "tag": "salt/job/1"}`, now.Add(-time.Minute))
+	syncJob := insert(services.AutoPiSyncCommand, services.AutoPiJobStateSent, "", now)
+
+	app := test.SetupAppFiber(*logger)
+	app.Get("/user/devices/:userDeviceID/autopi/jobs", ac.ListUserDeviceAutoPiJobs)
+	app.Get("/vehicle/:tokenID/autopi/jobs", ac.ListVehicleAutoPiJobs)
+
+	get := func(path string) *http.Response {
+		resp, err := app.Test(test.BuildRequest("GET", path, ""))
+		require.NoError(t, err)
+		return resp
+	}
+
+	for _, path := range []string{"/user/devices/" + ud.ID + "/autopi/jobs", "/vehicle/7/autopi/jobs"} {
+		resp := get(path)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var out AutoPiJobsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+
+		require.Len(t, out.Jobs, 2)
+		assert.Empty(t, out.NextCursor)
+
+		assert.Equal(t, syncJob.ID, out.Jobs[0].ID)
+		assert.Equal(t, services.AutoPiJobStateSent, out.Jobs[0].State)
+		assert.Nil(t, out.Jobs[0].Result)
+		assert.Empty(t, out.Jobs[0].VIN)
+
+		assert.Equal(t, vinJob.ID, out.Jobs[1].ID)
+		assert.Equal(t, services.AutoPiJobStateExecuted, out.Jobs[1].State)
+		require.NotNil(t, out.Jobs[1].Result)
+		assert.Equal(t, "vin", out.Jobs[1].Result.Type)
+		assert.Equal(t, "salt/job/1", out.Jobs[1].Result.Tag)
+		assert.Equal(t, "1HGCM82633A004352", out.Jobs[1].VIN)
+	}
+
+	resp := get("/vehicle/7/autopi/jobs?limit=1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var page AutoPiJobsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, syncJob.ID, page.NextCursor)
+
+	resp = get("/vehicle/7/autopi/jobs?limit=1&cursor=" + page.NextCursor)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, vinJob.ID, page.Jobs[0].ID)
+
+	assert.Equal(t, http.StatusBadRequest, get("/vehicle/7/autopi/jobs?limit=0").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("/vehicle/7/autopi/jobs?cursor=nope").StatusCode)
+	assert.Equal(t, http.StatusNotFound, get("/vehicle/8/autopi/jobs").StatusCode)
+
+	// A new owner doesn't see the jobs sent before the vehicle was theirs.
+	_, err := services.NewVehicleHandover(ctx, pdb.DBS().Writer, ud.ID, big.NewInt(7), test.MkAddr(1), test.MkAddr(2), common.Hash{})
+	require.NoError(t, err)
+
+	newJob := insert(services.AutoPiSyncCommand, services.AutoPiJobStateSent, "", time.Now().Add(time.Minute))
+
+	resp = get("/vehicle/7/autopi/jobs")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var owned AutoPiJobsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&owned))
+	require.Len(t, owned.Jobs, 1)
+	assert.Equal(t, newJob.ID, owned.Jobs[0].ID)
+	assert.Empty(t, owned.NextCursor)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ericlagergren/decimal"
@@ -14,12 +16,14 @@ import (
 	"github.com/DIMO-Network/shared/pkg/grpcfiber"
 
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/dtc"
 	"github.com/segmentio/ksuid"
 
 	"github.com/DIMO-Network/devices-api/internal/appmetrics"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -57,6 +61,7 @@ var errorCodeRegex = regexp.MustCompile(`^.{5,8}$`)
 // @Param       queryDeviceErrorCodes body controllers.QueryDeviceErrorCodesReq true "error codes"
 // @Success     200 {object} controllers.QueryDeviceErrorCodesResponse
 // @Failure     404 {object} helpers.ErrorRes "Vehicle not found"
+// @Failure     429 {object} helpers.ErrorRes "Too many error code lookups"
 // @Security    BearerAuth
 // @Router      /user/devices/{userDeviceID}/error-codes [post]
 // Deprecated
@@ -109,10 +114,8 @@ func (udc *UserDevicesController) QueryDeviceErrorCodes(c *fiber.Ctx) error {
 		})
 	}
 
-	appmetrics.OpenAITotalCallsOps.Inc() // record new total call to chatgpt
-	chtResp, err := udc.openAI.GetErrorCodesDescription(dd.Make.Name, dd.Model, errorCodesCleaned)
+	chtResp, err := udc.describeErrorCodes(c, dd.Make.Name, dd.Model, errorCodesCleaned)
 	if err != nil {
-		appmetrics.OpenAITotalFailedCallsOps.Inc()
 		logger.Err(err).Interface("requestBody", req).Msg("Error occurred fetching description for error codes")
 		return err
 	}
@@ -153,7 +156,7 @@ func (udc *UserDevicesController) GetUserDeviceErrorCodeQueries(c *fiber.Ctx) er
 
 	userDevice, err := models.UserDevices(
 		models.UserDeviceWhere.ID.EQ(userDeviceID),
-		qm.Load(models.UserDeviceRels.ErrorCodeQueries, qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC")),
+		qm.Load(models.UserDeviceRels.ErrorCodeQueries, models.ErrorCodeQueryWhere.HiddenAt.IsNull(), qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC")),
 	).One(c.Context(), udc.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -200,6 +203,7 @@ func (udc *UserDevicesController) ClearUserDeviceErrorCodeQuery(c *fiber.Ctx) er
 
 	errCodeQuery, err := models.ErrorCodeQueries(
 		models.ErrorCodeQueryWhere.UserDeviceID.EQ(udi),
+		models.ErrorCodeQueryWhere.HiddenAt.IsNull(),
 		qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC"),
 		qm.Limit(1),
 	).One(c.Context(), udc.DBS().Reader)
@@ -247,7 +251,7 @@ func (udc *UserDevicesController) GetUserDeviceErrorCodeQueriesByTokenID(c *fibe
 
 	userDevice, err := models.UserDevices(
 		models.UserDeviceWhere.TokenID.EQ(tid),
-		qm.Load(models.UserDeviceRels.ErrorCodeQueries, qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC")),
+		qm.Load(models.UserDeviceRels.ErrorCodeQueries, models.ErrorCodeQueryWhere.HiddenAt.IsNull(), qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC")),
 	).One(c.Context(), udc.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -284,6 +288,7 @@ func (udc *UserDevicesController) GetUserDeviceErrorCodeQueriesByTokenID(c *fibe
 // @Param       queryDeviceErrorCodes body controllers.QueryDeviceErrorCodesReq true "error codes"
 // @Success     200 {object} controllers.QueryDeviceErrorCodesResponse
 // @Failure     404 {object} helpers.ErrorRes "Vehicle not found"
+// @Failure     429 {object} helpers.ErrorRes "Too many error code lookups"
 // @Security    BearerAuth
 // @Router      /vehicle/{tokenID}/error-codes [post]
 func (udc *UserDevicesController) QueryDeviceErrorCodesByTokenID(c *fiber.Ctx) error {
@@ -340,10 +345,8 @@ func (udc *UserDevicesController) QueryDeviceErrorCodesByTokenID(c *fiber.Ctx) e
 		})
 	}
 
-	appmetrics.OpenAITotalCallsOps.Inc() // record new total call to chatgpt
-	chtResp, err := udc.openAI.GetErrorCodesDescription(dd.Make.Name, dd.Model, errorCodesCleaned)
+	chtResp, err := udc.describeErrorCodes(c, dd.Make.Name, dd.Model, errorCodesCleaned)
 	if err != nil {
-		appmetrics.OpenAITotalFailedCallsOps.Inc()
 		logger.Err(err).Interface("requestBody", req).Msg("Error occurred fetching description for error codes")
 		return err
 	}
@@ -388,6 +391,7 @@ func (udc *UserDevicesController) ClearUserDeviceErrorCodeQueryByTokenID(c *fibe
 
 	errCodeQuery, err := models.ErrorCodeQueries(
 		models.ErrorCodeQueryWhere.VehicleTokenID.EQ(tid),
+		models.ErrorCodeQueryWhere.HiddenAt.IsNull(),
 		qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt+" DESC"),
 		qm.Limit(1),
 	).One(c.Context(), udc.DBS().Reader)
@@ -418,3 +422,158 @@ func (udc *UserDevicesController) ClearUserDeviceErrorCodeQueryByTokenID(c *fibe
 		ClearedAt:  &errCodeQuery.ClearedAt.Time,
 	})
 }
+
+// ErrorCodesSummaryResponse groups the codes a vehicle has reported, most recently seen first.
+type ErrorCodesSummaryResponse struct {
+	Codes []ErrorCodeSummaryItem `json:"codes"`
+}
+
+// ErrorCodeSummaryItem is one code in a vehicle's error code summary, with when and how often it was
+// reported.
+type ErrorCodeSummaryItem struct {
+	Code string `json:"code" example:"P0300"`
+	// Description is from the most recent query that reported the code.
+	Description    string              `json:"description" example:"Random/multiple cylinder misfire detected"`
+	Classification *dtc.Classification `json:"classification,omitempty"`
+	FirstSeen      time.Time           `json:"firstSeen" example:"2023-05-23T12:56:36Z"`
+	LastSeen       time.Time           `json:"lastSeen" example:"2023-06-02T08:10:12Z"`
+	// TimesReported is the number of queries that included the code.
+	TimesReported int `json:"timesReported" example:"3"`
+	// Cleared is true if the most recent query that reported the code has been cleared.
+	Cleared bool `json:"cleared"`
+}
+
+// GetErrorCodesSummaryByTokenID godoc
+// @Summary     Summarize the error codes reported by this vehicle.
+// @Description Groups codes across all of the vehicle's error code queries, showing when each was first and last seen, how often it was reported, and whether it was cleared.
+// @Tags        error-codes
+// @Param       tokenID path int true "vehicle token id"
+// @Success     200 {object} controllers.ErrorCodesSummaryResponse
+// @Failure     404 {object} helpers.ErrorRes "Vehicle not found"
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/error-codes/summary [get]
+func (udc *UserDevicesController) GetErrorCodesSummaryByTokenID(c *fiber.Ctx) error {
+	tis := c.Params("tokenID")
+	ti, ok := new(big.Int).SetString(tis, 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tis))
+	}
+	tid := types.NewNullDecimal(new(decimal.Big).SetBigMantScale(ti, 0))
+	logger := helpers.GetLogger(c, udc.log)
+
+	userDevice, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(tid),
+		qm.Load(models.UserDeviceRels.ErrorCodeQueries, models.ErrorCodeQueryWhere.HiddenAt.IsNull(), qm.OrderBy(models.ErrorCodeQueryColumns.CreatedAt)),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Could not find user device")
+		}
+		logger.Err(err).Msg("error occurred when fetching error codes for device")
+		return fiber.NewError(fiber.StatusInternalServerError, "error occurred fetching device error queries")
+	}
+
+	summary, err := summarizeErrorCodeQueries(userDevice.R.ErrorCodeQueries)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(ErrorCodesSummaryResponse{Codes: summary})
+}
+
+// summarizeErrorCodeQueries groups codes across queries, which must be sorted oldest first.
+func summarizeErrorCodeQueries(queries models.ErrorCodeQuerySlice) ([]ErrorCodeSummaryItem, error) {
+	byCode := make(map[string]*ErrorCodeSummaryItem)
+
+	for _, q := range queries {
+		var codes []services.ErrorCodesResponse
+		if err := q.CodesQueryResponse.Unmarshal(&codes); err != nil {
+			return nil, err
+		}
+
+		for _, ec := range codes {
+			code := strings.ToUpper(ec.Code)
+
+			item, ok := byCode[code]
+			if !ok {
+				item = &ErrorCodeSummaryItem{Code: code, FirstSeen: q.CreatedAt}
+				// Older queries didn't store this.
+				item.Classification, _ = dtc.Classify(code)
+				byCode[code] = item
+			}
+
+			item.Description = ec.Description
+			item.LastSeen = q.CreatedAt
+			item.TimesReported++
+			item.Cleared = q.ClearedAt.Valid
+		}
+	}
+
+	out := make([]ErrorCodeSummaryItem, 0, len(byCode))
+	for _, item := range byCode {
+		out = append(out, *item)
+	}
+
+	slices.SortFunc(out, func(a, b ErrorCodeSummaryItem) int {
+		if c := b.LastSeen.Compare(a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return out, nil
+}
+
+// describeErrorCodes looks codes up in the DTC catalog and only asks the LLM about the ones it
+// doesn't know. Catalog descriptions come first, in request order. Only lookups that miss both
+// the catalog and the LLM cache count against the caller's rate limit.
+func (udc *UserDevicesController) describeErrorCodes(c *fiber.Ctx, vMake, model string, codes []string) ([]services.ErrorCodesResponse, error) {
+	out := make([]services.ErrorCodesResponse, 0, len(codes))
+	var unknown []string
+
+	for _, code := range codes {
+		if desc, ok := udc.dtcCatalog.Lookup(vMake, code); ok {
+			cl, _ := dtc.Classify(code)
+			out = append(out, services.ErrorCodesResponse{
+				Code:           code,
+				Description:    desc,
+				Source:         services.ErrorCodeSourceCatalog,
+				Classification: cl,
+			})
+		} else {
+			unknown = append(unknown, code)
+		}
+	}
+
+	appmetrics.ErrorCodesCatalogHitsOps.Add(float64(len(out)))
+
+	if len(unknown) == 0 {
+		return out, nil
+	}
+
+	aiResp, err := udc.llm.GetErrorCodesDescription(services.WithLLMCaller(c.Context(), rateLimitKey(c)), vMake, model, unknown)
+	if err != nil {
+		if errors.Is(err, services.ErrLLMRateLimited) {
+			return nil, fiber.NewError(fiber.StatusTooManyRequests, "Too many error code lookups, please try again later.")
+		}
+		return nil, err
+	}
+
+	for _, r := range aiResp {
+		r.Source = services.ErrorCodeSourceAI
+		r.Classification, _ = dtc.Classify(r.Code)
+		out = append(out, r)
+	}
+
+	return out, nil
+}
+
+// rateLimitKey identifies the caller by JWT subject, falling back to the client IP.
+func rateLimitKey(c *fiber.Ctx) string {
+	if token, ok := c.Locals("user").(*jwt.Token); ok {
+		if sub, err := token.Claims.GetSubject(); err == nil && sub != "" {
+			return sub
+		}
+	}
+	return c.IP()
+}
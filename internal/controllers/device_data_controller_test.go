@@ -14,6 +14,7 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/dtc"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
 	"github.com/DIMO-Network/devices-api/internal/test"
 	"github.com/DIMO-Network/devices-api/models"
@@ -21,6 +22,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -34,7 +36,7 @@ type deps struct {
 	deviceDefSvc     *mock_services.MockDeviceDefinitionService
 	teslaTaskService *mock_services.MockTeslaTaskService
 	autoPiIngest     *mock_services.MockIngestRegistrar
-	openAISvc        *mock_services.MockOpenAI
+	llmSvc           *mock_services.MockLLMProvider
 	logger           zerolog.Logger
 	mockCtrl         *gomock.Controller
 	credentialSvc    *mock_services.MockVCService
@@ -48,7 +50,7 @@ func createMockDependencies(t *testing.T) deps {
 	deviceDefSvc := mock_services.NewMockDeviceDefinitionService(mockCtrl)
 	teslaTaskService := mock_services.NewMockTeslaTaskService(mockCtrl)
 	autoPiIngest := mock_services.NewMockIngestRegistrar(mockCtrl)
-	openAISvc := mock_services.NewMockOpenAI(mockCtrl)
+	llmSvc := mock_services.NewMockLLMProvider(mockCtrl)
 	credentialSvc := mock_services.NewMockVCService(mockCtrl)
 
 	logger := zerolog.New(os.Stdout).With().
@@ -61,7 +63,7 @@ func createMockDependencies(t *testing.T) deps {
 		deviceDefSvc:     deviceDefSvc,
 		teslaTaskService: teslaTaskService,
 		autoPiIngest:     autoPiIngest,
-		openAISvc:        openAISvc,
+		llmSvc:           llmSvc,
 		logger:           logger,
 		mockCtrl:         mockCtrl,
 		credentialSvc:    credentialSvc,
@@ -84,13 +86,13 @@ func TestUserDevicesController_QueryDeviceErrorCodes(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodes)
 
 	t.Run("POST - get description for query codes", func(t *testing.T) {
 		req := QueryDeviceErrorCodesReq{
-			ErrorCodes: []string{"P0017", "P0016"},
+			ErrorCodes: []string{"P0017", "P1A16"},
 		}
 
 		autoPiInteg := test.BuildIntegrationGRPC(ksuid.New().String(), constants.AutoPiVendor, 10, 0)
@@ -117,11 +119,10 @@ func TestUserDevicesController_QueryDeviceErrorCodes(t *testing.T) {
 			},
 		}
 
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
-			Return(openAIResp, nil).
-			AnyTimes()
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq([]string{"P1A16"})).
+			Return(openAIResp, nil)
 
 		j, _ := json.Marshal(req)
 
@@ -130,7 +131,26 @@ func TestUserDevicesController_QueryDeviceErrorCodes(t *testing.T) {
 		body, _ := io.ReadAll(response.Body)
 
 		chatGptResp := QueryDeviceErrorCodesResponse{
-			ErrorCodes: openAIResp,
+			ErrorCodes: []services.ErrorCodesResponse{
+				{
+					Code:        "P0017",
+					Description: "Crankshaft position - camshaft position correlation (bank 1 sensor B)",
+					Source:      services.ErrorCodeSourceCatalog,
+					Classification: &dtc.Classification{
+						System:   dtc.SystemPowertrain,
+						Severity: dtc.SeverityMedium,
+					},
+				},
+				{
+					Code:        openAIResp[0].Code,
+					Description: openAIResp[0].Description,
+					Source:      services.ErrorCodeSourceAI,
+					Classification: &dtc.Classification{
+						System:   dtc.SystemPowertrain,
+						Severity: dtc.SeverityMedium,
+					},
+				},
+			},
 		}
 		chtJSON, err := json.Marshal(chatGptResp)
 		assert.NoError(t, err)
@@ -159,7 +179,7 @@ func TestUserDevicesController_ShouldErrorOnTooManyErrorCodes(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodes)
 
@@ -196,9 +216,9 @@ func TestUserDevicesController_ShouldErrorOnTooManyErrorCodes(t *testing.T) {
 				Description: "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat.",
 			},
 		}
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
 			Return(chatGptResp, nil).
 			AnyTimes()
 
@@ -233,7 +253,7 @@ func TestUserDevicesController_ShouldErrorInvalidErrorCodes(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodes)
 
@@ -266,9 +286,9 @@ func TestUserDevicesController_ShouldErrorInvalidErrorCodes(t *testing.T) {
 				Description: "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat.",
 			},
 		}
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
 			Return(chatGptResp, nil).
 			AnyTimes()
 
@@ -303,7 +323,7 @@ func TestUserDevicesController_ShouldErrorOnEmptyErrorCodes(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodes)
 
@@ -336,9 +356,9 @@ func TestUserDevicesController_ShouldErrorOnEmptyErrorCodes(t *testing.T) {
 				Description: "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat.",
 			},
 		}
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
 			Return(chatGptResp, nil).
 			AnyTimes()
 
@@ -373,12 +393,12 @@ func TestUserDevicesController_ShouldStoreErrorCodeResponse(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodes)
 
 	t.Run("POST - get description for query codes", func(t *testing.T) {
-		erCodeReq := []string{"P0017", "P0016"}
+		erCodeReq := []string{"P1A17", "P1A16"}
 		req := QueryDeviceErrorCodesReq{
 			ErrorCodes: erCodeReq,
 		}
@@ -406,9 +426,9 @@ func TestUserDevicesController_ShouldStoreErrorCodeResponse(t *testing.T) {
 				Description: "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat.",
 			},
 		}
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
 			Return(openAIResp, nil).
 			AnyTimes()
 
@@ -419,7 +439,17 @@ func TestUserDevicesController_ShouldStoreErrorCodeResponse(t *testing.T) {
 		body, _ := io.ReadAll(response.Body)
 
 		chatGptResp := QueryDeviceErrorCodesResponse{
-			ErrorCodes: openAIResp,
+			ErrorCodes: []services.ErrorCodesResponse{
+				{
+					Code:        openAIResp[0].Code,
+					Description: openAIResp[0].Description,
+					Source:      services.ErrorCodeSourceAI,
+					Classification: &dtc.Classification{
+						System:   dtc.SystemPowertrain,
+						Severity: dtc.SeverityMedium,
+					},
+				},
+			},
 		}
 		chtJSON, err := json.Marshal(chatGptResp)
 		assert.NoError(t, err)
@@ -436,7 +466,7 @@ func TestUserDevicesController_ShouldStoreErrorCodeResponse(t *testing.T) {
 		assert.NoError(t, err)
 
 		ddd := null.JSONFrom([]byte(
-			`[{"code": "P0113", "description": "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat."}]`,
+			`[{"code": "P0113", "source": "ai", "description": "Engine Coolant Temperature Circuit Malfunction: This code indicates that the engine coolant temperature sensor is sending a signal that is outside of the expected range, which may cause the engine to run poorly or overheat.", "classification": {"system": "powertrain", "severity": "medium", "manufacturerSpecific": false}}]`,
 		))
 
 		assert.Equal(t, errCodeResp.CodesQueryResponse, ddd)
@@ -459,7 +489,7 @@ func TestUserDevicesController_GetUserDevicesErrorCodeQueries(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Get("/user/devices/:userDeviceID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.GetUserDeviceErrorCodeQueries)
 
@@ -535,7 +565,7 @@ func TestUserDevicesController_ClearUserDeviceErrorCodeQuery(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes/clear", test.AuthInjectorTestHandler(testUserID, nil), c.ClearUserDeviceErrorCodeQuery)
 
@@ -622,7 +652,7 @@ func TestUserDevicesController_ErrorOnAllErrorCodesCleared(t *testing.T) {
 	}()
 
 	testUserID := "123123"
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/user/devices/:userDeviceID/error-codes/clear", test.AuthInjectorTestHandler(testUserID, nil), c.ClearUserDeviceErrorCodeQuery)
 
@@ -690,13 +720,13 @@ func TestUserDevicesController_QueryDeviceErrorCodesByTokenID(t *testing.T) {
 	testUserID := "123123"
 	testTokenID := "321321"
 	ti, _ := new(big.Int).SetString(testTokenID, 10)
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/vehicle/:tokenID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.QueryDeviceErrorCodesByTokenID)
 
 	t.Run("POST - get description for query codes by tokenID", func(t *testing.T) {
 		req := QueryDeviceErrorCodesReq{
-			ErrorCodes: []string{"P0017", "P0016"},
+			ErrorCodes: []string{"P1A17", "P1A16"},
 		}
 
 		autoPiInteg := test.BuildIntegrationGRPC(autoPiIntegrationID, constants.AutoPiVendor, 10, 0)
@@ -723,9 +753,9 @@ func TestUserDevicesController_QueryDeviceErrorCodesByTokenID(t *testing.T) {
 			},
 		}
 
-		mockDeps.openAISvc.
+		mockDeps.llmSvc.
 			EXPECT().
-			GetErrorCodesDescription(gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
+			GetErrorCodesDescription(gomock.Any(), gomock.Eq("Toyota"), gomock.Eq("Camry"), gomock.Eq(req.ErrorCodes)).
 			Return(openAIResp, nil).
 			AnyTimes()
 
@@ -736,7 +766,17 @@ func TestUserDevicesController_QueryDeviceErrorCodesByTokenID(t *testing.T) {
 		body, _ := io.ReadAll(response.Body)
 
 		chatGptResp := QueryDeviceErrorCodesResponse{
-			ErrorCodes: openAIResp,
+			ErrorCodes: []services.ErrorCodesResponse{
+				{
+					Code:        openAIResp[0].Code,
+					Description: openAIResp[0].Description,
+					Source:      services.ErrorCodeSourceAI,
+					Classification: &dtc.Classification{
+						System:   dtc.SystemPowertrain,
+						Severity: dtc.SeverityMedium,
+					},
+				},
+			},
 		}
 		chtJSON, err := json.Marshal(chatGptResp)
 		assert.NoError(t, err)
@@ -791,7 +831,7 @@ func TestUserDevicesController_GetUserDevicesErrorCodeQueriesByTokenID(t *testin
 	testUserID := "123123"
 	testTokenID := "321321"
 	ti, _ := new(big.Int).SetString(testTokenID, 10)
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Get("/vehicle/:tokenID/error-codes", test.AuthInjectorTestHandler(testUserID, nil), c.GetUserDeviceErrorCodeQueriesByTokenID)
 
@@ -881,7 +921,7 @@ func TestUserDevicesController_ErrorOnAllErrorCodesClearedByTokenID(t *testing.T
 	testUserID := "123123"
 	testTokenID := "321321"
 	ti, _ := new(big.Int).SetString(testTokenID, 10)
-	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.openAISvc, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000"}, pdb.DBS, &mockDeps.logger, mockDeps.deviceDefSvc, mockDeps.deviceDefIntSvc, mockDeps.teslaTaskService, nil, nil, nil, mockDeps.autoPiIngest, nil, nil, mockDeps.llmSvc, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	app.Post("/vehicle/:tokenID/error-codes/clear", test.AuthInjectorTestHandler(testUserID, nil), c.ClearUserDeviceErrorCodeQueryByTokenID)
 
@@ -952,3 +992,57 @@ func TestUserDevicesController_ErrorOnAllErrorCodesClearedByTokenID(t *testing.T
 		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
 	})
 }
+
+func TestSummarizeErrorCodeQueries(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2023, 5, d, 12, 0, 0, 0, time.UTC)
+	}
+	query := func(createdAt time.Time, cleared bool, codes ...string) *models.ErrorCodeQuery {
+		resp := make([]services.ErrorCodesResponse, len(codes))
+		for i, c := range codes {
+			resp[i] = services.ErrorCodesResponse{Code: c, Description: fmt.Sprintf("%s on %d", c, createdAt.Day())}
+		}
+		b, _ := json.Marshal(resp)
+		q := &models.ErrorCodeQuery{CreatedAt: createdAt, CodesQueryResponse: null.JSONFrom(b)}
+		if cleared {
+			q.ClearedAt = null.TimeFrom(createdAt.Add(time.Hour))
+		}
+		return q
+	}
+
+	summary, err := summarizeErrorCodeQueries(models.ErrorCodeQuerySlice{
+		query(day(1), true, "P0300", "B0001"),
+		query(day(3), false, "p0300", "U1234"),
+		query(day(5), true, "P0300"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ErrorCodeSummaryItem{
+		{
+			Code:           "P0300",
+			Description:    "P0300 on 5",
+			Classification: &dtc.Classification{System: dtc.SystemPowertrain, Severity: dtc.SeverityHigh},
+			FirstSeen:      day(1),
+			LastSeen:       day(5),
+			TimesReported:  3,
+			Cleared:        true,
+		},
+		{
+			Code:           "U1234",
+			Description:    "U1234 on 3",
+			Classification: &dtc.Classification{System: dtc.SystemNetwork, ManufacturerSpecific: true, Severity: dtc.SeverityMedium},
+			FirstSeen:      day(3),
+			LastSeen:       day(3),
+			TimesReported:  1,
+		},
+		{
+			Code:           "B0001",
+			Description:    "B0001 on 1",
+			Classification: &dtc.Classification{System: dtc.SystemBody, Severity: dtc.SeverityCritical},
+			FirstSeen:      day(1),
+			LastSeen:       day(1),
+			TimesReported:  1,
+			Cleared:        true,
+		},
+	}, summary)
+}
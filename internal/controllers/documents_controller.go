@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"database/sql"
 	"fmt"
 	"io"
+	"math/big"
+	"mime"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,75 +14,118 @@ import (
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
 	"github.com/pkg/errors"
 	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 type DocumentsController struct {
-	settings *config.Settings
-	s3Client *s3.Client
-	DBS      func() *db.ReaderWriter
-	logger   *zerolog.Logger
+	settings      *config.Settings
+	s3Client      *s3.Client
+	presignClient *s3.PresignClient
+	presignTTL    time.Duration
+	DBS           func() *db.ReaderWriter
+	logger        *zerolog.Logger
 }
 
+const defaultDocumentsPresignedURLTTL = 15 * time.Minute
+
 // NewDocumentsController constructor
 func NewDocumentsController(settings *config.Settings, z *zerolog.Logger, s3Client *s3.Client, dbs func() *db.ReaderWriter) DocumentsController {
-	return DocumentsController{settings: settings, s3Client: s3Client, DBS: dbs, logger: z}
+	presignTTL := defaultDocumentsPresignedURLTTL
+	if settings.DocumentsPresignedURLTTL != "" {
+		var err error
+		presignTTL, err = time.ParseDuration(settings.DocumentsPresignedURLTTL)
+		if err != nil {
+			z.Fatal().Err(err).Msg("Couldn't parse presigned document URL TTL.")
+		}
+	}
+
+	return DocumentsController{
+		settings:      settings,
+		s3Client:      s3Client,
+		presignClient: s3.NewPresignClient(s3Client),
+		presignTTL:    presignTTL,
+		DBS:           dbs,
+		logger:        z,
+	}
 }
 
 // GetDocuments godoc
-// @Description gets all documents associated with current user - pulled from token
+// @Description gets documents associated with current user - pulled from token, newest first
 // @Tags        documents
 // @Produce     json
 // @Accept      json
-// @Success     200 {object} []controllers.DocumentResponse
+// @Param       user_device_id       query    string false "Only documents for this user device"
+// @Param       token_id             query    int    false "Only documents for this vehicle"
+// @Param       type                 query    string false "Only documents of this type"
+// @Param       tag                  query    string false "Only documents with this tag"
+// @Param       expiring_within_days query    int    false "Only documents that expire in the next this many days"
+// @Param       limit                query    int    false "Page size, at most 500" default(100)
+// @Param       cursor               query    string false "ID of the last document of the previous page"
+// @Success     200                  {object} []controllers.DocumentResponse
+// @Failure     400                  "Invalid filter or cursor."
 // @Security    BearerAuth
 // @Router      /documents [get]
 func (udc *DocumentsController) GetDocuments(c *fiber.Ctx) error {
-	userID := helpers.GetUserID(c)
-	udi := c.Query("user_device_id")
+	filter := services.DocumentFilter{
+		UserID:       helpers.GetUserID(c),
+		UserDeviceID: c.Query("user_device_id"),
+		Type:         c.Query("type"),
+		Tag:          c.Query("tag"),
+		Limit:        c.QueryInt("limit", services.DefaultDocumentsLimit),
+	}
 
-	folder := userID
-	if len(udi) > 0 {
-		folder = fmt.Sprintf("%s/%s", userID, udi)
+	if filter.Limit <= 0 || filter.Limit > services.MaxDocumentsLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", services.MaxDocumentsLimit))
 	}
 
-	response, err := udc.s3Client.ListObjectsV2(c.Context(), &s3.ListObjectsV2Input{
-		Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
-		Prefix: aws.String(folder),
-	})
-	if err != nil {
-		return fiber.NewError(fiber.StatusNotFound, "the bucket does not exist")
+	if filter.Type != "" {
+		if err := DocumentTypeEnum(filter.Type).IsValid(); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid document type.")
+		}
 	}
 
-	documents := []DocumentResponse{}
+	if tis := c.Query("token_id"); tis != "" {
+		ti, ok := new(big.Int).SetString(tis, 10)
+		if !ok {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tis))
+		}
+		filter.VehicleTokenID = ti
+	}
 
-	for _, item := range response.Contents {
-		responseItem, err := udc.s3Client.HeadObject(c.Context(),
-			&s3.HeadObjectInput{
-				Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
-				Key:    aws.String(*item.Key),
-			})
-		if err != nil {
-			return err
+	if days := c.QueryInt("expiring_within_days"); days != 0 {
+		if days < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "expiring_within_days must be positive.")
 		}
+		filter.ExpiringWithin = time.Duration(days) * 24 * time.Hour
+	}
 
-		documents = append(documents, DocumentResponse{
-			ID:           responseItem.Metadata[MetadataDocumentID],
-			Name:         responseItem.Metadata[MetadataDocumentName],
-			Ext:          responseItem.Metadata[MetadataDocumentFileExtension],
-			UserDeviceID: responseItem.Metadata[MetadataDocumentUserDeviceID],
-			CreatedAt:    *responseItem.LastModified,
-			URL:          fmt.Sprintf("%s/v1/documents/%s/download", udc.settings.DeploymentBaseURL, responseItem.Metadata[MetadataDocumentID]),
-			Type:         DocumentTypeEnum(responseItem.Metadata[MetadataDocumentType]),
-		})
+	if cursor := c.Query("cursor"); cursor != "" {
+		filter.Cursor = documentRowID(cursor)
+	}
 
+	docs, err := services.ListDocuments(c.Context(), udc.DBS().Reader, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDocumentCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid cursor.")
+		}
+		return err
+	}
+
+	documents := make([]DocumentResponse, len(docs))
+	for i, d := range docs {
+		documents[i] = udc.documentToAPI(d)
 	}
 
 	return c.JSON(documents)
@@ -95,30 +141,12 @@ func (udc *DocumentsController) GetDocuments(c *fiber.Ctx) error {
 // @Security    BearerAuth
 // @Router      /documents/{id} [get]
 func (udc *DocumentsController) GetDocumentByID(c *fiber.Ctx) error {
-	userID := helpers.GetUserID(c)
-	fileID := c.Params("id")
-	folder := resolveFolderKey(userID, fileID)
-
-	response, err := udc.s3Client.HeadObject(c.Context(),
-		&s3.HeadObjectInput{
-			Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
-			Key:    aws.String(folder),
-		})
+	doc, err := udc.getDocument(c, models.DocumentStatusReady)
 	if err != nil {
-		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("no document with id %s found", fileID))
-	}
-
-	document := DocumentResponse{
-		ID:           response.Metadata[MetadataDocumentID],
-		Name:         response.Metadata[MetadataDocumentName],
-		Ext:          response.Metadata[MetadataDocumentFileExtension],
-		UserDeviceID: response.Metadata[MetadataDocumentUserDeviceID],
-		CreatedAt:    *response.LastModified,
-		URL:          fmt.Sprintf("%s/v1/documents/%s/download", udc.settings.DeploymentBaseURL, response.Metadata[MetadataDocumentID]),
-		Type:         DocumentTypeEnum(response.Metadata[MetadataDocumentType]),
+		return err
 	}
 
-	return c.JSON(document)
+	return c.JSON(udc.documentToAPI(doc))
 }
 
 // PostDocument godoc
@@ -130,6 +158,8 @@ func (udc *DocumentsController) GetDocumentByID(c *fiber.Ctx) error {
 // @Param       name         formData string true  "The document name. name is required"
 // @Param       type         formData string true  "The document type. type is required"
 // @Param       userDeviceID formData string false "The user device ID, optional"
+// @Param       tags         formData string false "Comma-separated tags, optional"
+// @Param       expiresAt    formData string false "Expiry date, as YYYY-MM-DD or RFC 3339, optional"
 // @Success     201          {object} controllers.DocumentResponse
 // @Security    BearerAuth
 // @Router      /documents [post]
@@ -145,24 +175,39 @@ func (udc *DocumentsController) PostDocument(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid file.")
 	}
 
+	var ud *models.UserDevice
 	if len(udi) > 0 {
 		// Validate if user devices exists
-		exists, err := models.UserDevices(
+		ud, err = models.UserDevices(
 			models.UserDeviceWhere.UserID.EQ(userID),
 			models.UserDeviceWhere.ID.EQ(udi),
-		).Exists(c.Context(), udc.DBS().Writer)
+		).One(c.Context(), udc.DBS().Writer)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fiber.NewError(fiber.StatusNotFound, "Device not found.")
+			}
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
-
-		if !exists {
-			return fiber.NewError(fiber.StatusNotFound, "Device not found.")
-		}
 	}
 
 	if err := DocumentTypeEnum(documentType).IsValid(); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid document type.")
 	}
+
+	tags, err := parseDocumentTags(strings.Split(c.FormValue("tags"), ","))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var expiresAt null.Time
+	if raw := c.FormValue("expiresAt"); raw != "" {
+		t, err := parseDocumentExpiry(raw)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid expiry date.")
+		}
+		expiresAt = null.TimeFrom(t)
+	}
+
 	// Get Buffer from file
 	fileObj, err := file.Open()
 	if err != nil {
@@ -179,18 +224,26 @@ func (udc *DocumentsController) PostDocument(c *fiber.Ctx) error {
 
 	// Unique ID
 	id := ksuid.New().String()
-	documentID := buildUniqueID(id, udi)
-
-	metadata := map[string]string{}
-	metadata[MetadataDocumentID] = documentID
-	metadata[MetadataDocumentName] = documentName
-	metadata[MetadataDocumentFile] = file.Filename
-	metadata[MetadataDocumentFileExtension] = filepath.Ext(file.Filename)
-	metadata[MetadataDocumentType] = documentType
-	metadata[MetadataDocumentUserDeviceID] = udi
-
-	fileID := buildFileID(udi, id)
-	awsPathKey := getAwsFilePath(userID, fileID)
+	awsPathKey := getAwsFilePath(userID, buildFileID(udi, id))
+
+	doc := &models.Document{
+		ID:          id,
+		UserID:      userID,
+		Type:        documentType,
+		Name:        documentName,
+		FileName:    file.Filename,
+		FileExt:     filepath.Ext(file.Filename),
+		ContentType: filetype,
+		FileSize:    file.Size,
+		S3Key:       awsPathKey,
+		Tags:        tags,
+		ExpiresAt:   expiresAt,
+	}
+	if ud != nil {
+		doc.UserDeviceID = null.StringFrom(ud.ID)
+		doc.R = doc.R.NewStruct()
+		doc.R.UserDevice = ud
+	}
 
 	// Upload the file to S3.
 	_, err = udc.s3Client.PutObject(c.Context(), &s3.PutObjectInput{
@@ -199,27 +252,93 @@ func (udc *DocumentsController) PostDocument(c *fiber.Ctx) error {
 		Body:               fileObj,
 		ContentDisposition: aws.String("attachment"),
 		ContentType:        aws.String(filetype),
-		Metadata:           metadata,
+		Metadata:           documentMetadata(doc),
 	})
 	if err != nil {
 		udc.logger.Err(err).Msg("failed to upload glovebox document")
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	url := fmt.Sprintf("%s/v1/documents/%s/download", udc.settings.DeploymentBaseURL, id)
-	if len(udi) > 0 {
-		url = fmt.Sprintf("%s/v1/documents/%s-%s/download", udc.settings.DeploymentBaseURL, udi, id)
+	if err := doc.Insert(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
+		// Don't leave behind an object that no listing will ever show.
+		if _, derr := udc.s3Client.DeleteObject(c.Context(), &s3.DeleteObjectInput{
+			Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
+			Key:    aws.String(awsPathKey),
+		}); derr != nil {
+			udc.logger.Err(derr).Str("key", awsPathKey).Msg("Failed to clean up glovebox document after database error.")
+		}
+		return err
 	}
+
 	udc.logger.Info().Msgf("succesfully uploaded glovebox document %s", documentName)
-	return c.JSON(DocumentResponse{
-		ID:           id,
-		Name:         documentName,
-		Ext:          filepath.Ext(file.Filename),
-		CreatedAt:    time.Now().UTC(),
-		UserDeviceID: udi,
-		URL:          url,
-		Type:         DocumentTypeEnum(documentType),
-	})
+	resp := udc.documentToAPI(doc)
+	// Keep returning the bare ID here, as this endpoint always has.
+	resp.ID = id
+	return c.JSON(resp)
+}
+
+// UpdateDocumentRequest changes a document's details. Omitted fields are left alone.
+type UpdateDocumentRequest struct {
+	Name *string `json:"name"`
+	// Tags replaces all of the document's tags.
+	Tags []string `json:"tags"`
+	// ExpiresAt sets the expiry date, in RFC 3339 format.
+	ExpiresAt *time.Time `json:"expiresAt"`
+	// ClearExpiresAt removes the expiry date.
+	ClearExpiresAt bool `json:"clearExpiresAt"`
+}
+
+func (r *UpdateDocumentRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.NilOrNotEmpty),
+		validation.Field(&r.ExpiresAt, validation.When(r.ClearExpiresAt, validation.Nil.Error("can't be set together with clearExpiresAt"))),
+	)
+}
+
+// UpdateDocument godoc
+// @Description update the name, tags or expiry of a document associated with current user - pulled from token
+// @Tags        documents
+// @Produce     json
+// @Accept      json
+// @Param       id   path     string                            true "Document ID"
+// @Param       body body     controllers.UpdateDocumentRequest true "Fields to change"
+// @Success     200  {object} controllers.DocumentResponse
+// @Failure     400  "Invalid request."
+// @Failure     404  "Document not found."
+// @Security    BearerAuth
+// @Router      /documents/{id} [patch]
+func (udc *DocumentsController) UpdateDocument(c *fiber.Ctx) error {
+	var req UpdateDocumentRequest
+	if err := parseDocumentBody(c, &req); err != nil {
+		return err
+	}
+
+	doc, err := udc.getDocument(c, models.DocumentStatusReady)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		doc.Name = *req.Name
+	}
+	if req.Tags != nil {
+		tags, err := parseDocumentTags(req.Tags)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		doc.Tags = tags
+	}
+	if req.ExpiresAt != nil {
+		doc.ExpiresAt = null.TimeFrom(*req.ExpiresAt)
+	} else if req.ClearExpiresAt {
+		doc.ExpiresAt = null.Time{}
+	}
+
+	if _, err := doc.Update(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
+		return err
+	}
+
+	return c.JSON(udc.documentToAPI(doc))
 }
 
 // DeleteDocument godoc
@@ -232,43 +351,63 @@ func (udc *DocumentsController) PostDocument(c *fiber.Ctx) error {
 // @Security    BearerAuth
 // @Router      /documents/{id} [delete]
 func (udc *DocumentsController) DeleteDocument(c *fiber.Ctx) error {
-	userID := helpers.GetUserID(c)
-	fileID := c.Params("id")
-	folder := resolveFolderKey(userID, fileID)
+	// Pending uploads can be abandoned too.
+	doc, err := udc.getDocument(c, "")
+	if err != nil {
+		return err
+	}
 
-	_, err := udc.s3Client.DeleteObject(c.Context(), &s3.DeleteObjectInput{
+	_, err = udc.s3Client.DeleteObject(c.Context(), &s3.DeleteObjectInput{
 		Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
-		Key:    aws.String(folder),
+		Key:    aws.String(doc.S3Key),
 	})
-
 	if err != nil {
 		return helpers.ErrorResponseHandler(c, err, fiber.StatusInternalServerError)
 	}
 
+	if _, err := doc.Delete(c.Context(), udc.DBS().Writer); err != nil {
+		return err
+	}
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
 // DownloadDocument godoc
-// @Description download document associated with current user - pulled from token
+// @Description download document associated with current user - pulled from token. With redirect=true, responds with a redirect to a short-lived presigned S3 URL instead of the file.
 // @Tags        documents
 // @Produce     octet-stream
 // @Produce     png
 // @Produce     jpeg
-// @Param       id path string true "Document ID"
+// @Param       id       path  string true  "Document ID"
+// @Param       redirect query bool   false "Redirect to a presigned URL"
 // @Success     200
+// @Success     307
 // @Security    BearerAuth
 // @Router      /documents/{id}/download [get]
 func (udc *DocumentsController) DownloadDocument(c *fiber.Ctx) error {
-	userID := helpers.GetUserID(c)
-	fileID := c.Params("id")
-	folder := resolveFolderKey(userID, fileID)
+	doc, err := udc.getDocument(c, models.DocumentStatusReady)
+	if err != nil {
+		return err
+	}
+
+	if c.QueryBool("redirect") {
+		req, err := udc.presignClient.PresignGetObject(c.Context(), &s3.GetObjectInput{
+			Bucket:                     aws.String(udc.settings.AWSDocumentsBucketName),
+			Key:                        aws.String(doc.S3Key),
+			ResponseContentDisposition: aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName})),
+		}, s3.WithPresignExpires(udc.presignTTL))
+		if err != nil {
+			return err
+		}
+		return c.Redirect(req.URL, fiber.StatusTemporaryRedirect)
+	}
 
 	obj, err := udc.s3Client.GetObject(c.Context(), &s3.GetObjectInput{
 		Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
-		Key:    aws.String(folder),
+		Key:    aws.String(doc.S3Key),
 	})
 	if err != nil {
-		var nsk types.NoSuchKey
+		var nsk *types.NoSuchKey
 		if errors.As(err, &nsk) {
 			return fiber.NewError(fiber.StatusNotFound, "Document not found.")
 		}
@@ -284,6 +423,310 @@ func (udc *DocumentsController) DownloadDocument(c *fiber.Ctx) error {
 	return c.Send(bs)
 }
 
+// DocumentUploadURLRequest describes a document that the client will upload straight to S3.
+type DocumentUploadURLRequest struct {
+	Name string           `json:"name"`
+	Type DocumentTypeEnum `json:"type"`
+	// UserDeviceID optionally attaches the document to a vehicle.
+	UserDeviceID string `json:"userDeviceId"`
+	FileName     string `json:"fileName"`
+	// ContentType is one of image/jpeg, image/png or application/pdf.
+	ContentType string `json:"contentType"`
+	// Size is the exact size of the file in bytes.
+	Size      int64      `json:"size"`
+	Tags      []string   `json:"tags"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func (r *DocumentUploadURLRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required),
+		validation.Field(&r.Type, validation.Required, validation.By(func(any) error { return r.Type.IsValid() })),
+		validation.Field(&r.FileName, validation.Required),
+		validation.Field(&r.ContentType, validation.Required, validation.By(func(any) error { return FileTypeAllowedEnum(r.ContentType).IsValid() })),
+		validation.Field(&r.Size, validation.Required, validation.Min(int64(1)), validation.Max(FileTypeAllowedEnum(r.ContentType).MaxSize())),
+	)
+}
+
+// DocumentUploadURLResponse tells the client where and how to upload the file.
+type DocumentUploadURLResponse struct {
+	// Document is the pending document. It doesn't show up in listings until it is finalized.
+	Document DocumentResponse `json:"document"`
+	// URL accepts a PUT of the file.
+	URL string `json:"url"`
+	// Headers must be sent with the PUT, exactly as given.
+	Headers map[string]string `json:"headers"`
+	// ExpiresAt is when the URL stops working.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateDocumentUploadURL godoc
+// @Description creates a pending document associated with current user - pulled from token, and returns a presigned S3 URL to PUT the file to. Call finalize once the upload is done.
+// @Tags        documents
+// @Produce     json
+// @Accept      json
+// @Param       body body     controllers.DocumentUploadURLRequest true "Document details"
+// @Success     201  {object} controllers.DocumentUploadURLResponse
+// @Failure     400  "Invalid request."
+// @Failure     404  "Device not found."
+// @Security    BearerAuth
+// @Router      /documents/upload-url [post]
+func (udc *DocumentsController) CreateDocumentUploadURL(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+
+	var req DocumentUploadURLRequest
+	if err := parseDocumentBody(c, &req); err != nil {
+		return err
+	}
+
+	tags, err := parseDocumentTags(req.Tags)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	id := ksuid.New().String()
+	doc := &models.Document{
+		ID:          id,
+		UserID:      userID,
+		Type:        string(req.Type),
+		Name:        req.Name,
+		FileName:    req.FileName,
+		FileExt:     filepath.Ext(req.FileName),
+		ContentType: req.ContentType,
+		FileSize:    req.Size,
+		S3Key:       getAwsFilePath(userID, buildFileID(req.UserDeviceID, id)),
+		Tags:        tags,
+		Status:      models.DocumentStatusPending,
+	}
+	if req.ExpiresAt != nil {
+		doc.ExpiresAt = null.TimeFrom(*req.ExpiresAt)
+	}
+
+	if req.UserDeviceID != "" {
+		ud, err := models.UserDevices(
+			models.UserDeviceWhere.UserID.EQ(userID),
+			models.UserDeviceWhere.ID.EQ(req.UserDeviceID),
+		).One(c.Context(), udc.DBS().Reader)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fiber.NewError(fiber.StatusNotFound, "Device not found.")
+			}
+			return err
+		}
+		doc.UserDeviceID = null.StringFrom(ud.ID)
+		doc.R = doc.R.NewStruct()
+		doc.R.UserDevice = ud
+	}
+
+	// Content type, length and metadata are all signed, so S3 rejects uploads that don't match.
+	presigned, err := udc.presignClient.PresignPutObject(c.Context(), &s3.PutObjectInput{
+		Bucket:             aws.String(udc.settings.AWSDocumentsBucketName),
+		Key:                aws.String(doc.S3Key),
+		ContentDisposition: aws.String("attachment"),
+		ContentType:        aws.String(doc.ContentType),
+		ContentLength:      aws.Int64(doc.FileSize),
+		Metadata:           documentMetadata(doc),
+	}, s3.WithPresignExpires(udc.presignTTL))
+	if err != nil {
+		return err
+	}
+
+	if err := doc.Insert(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
+		return err
+	}
+
+	headers := make(map[string]string, len(presigned.SignedHeader))
+	for k := range presigned.SignedHeader {
+		if strings.EqualFold(k, "Host") {
+			continue
+		}
+		headers[k] = presigned.SignedHeader.Get(k)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(DocumentUploadURLResponse{
+		Document:  udc.documentToAPI(doc),
+		URL:       presigned.URL,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(udc.presignTTL),
+	})
+}
+
+// FinalizeDocument godoc
+// @Description checks that the file for a pending document was uploaded and makes the document available
+// @Tags        documents
+// @Produce     json
+// @Param       id  path     string true "Document ID"
+// @Success     200 {object} controllers.DocumentResponse
+// @Failure     400 "The file is missing or doesn't match what was declared."
+// @Failure     404 "No pending document with this ID."
+// @Security    BearerAuth
+// @Router      /documents/{id}/finalize [post]
+func (udc *DocumentsController) FinalizeDocument(c *fiber.Ctx) error {
+	doc, err := udc.getDocument(c, models.DocumentStatusPending)
+	if err != nil {
+		return err
+	}
+
+	head, err := udc.s3Client.HeadObject(c.Context(), &s3.HeadObjectInput{
+		Bucket: aws.String(udc.settings.AWSDocumentsBucketName),
+		Key:    aws.String(doc.S3Key),
+	})
+	if err != nil {
+		var nf *types.NotFound
+		if errors.As(err, &nf) {
+			return fiber.NewError(fiber.StatusBadRequest, "The file hasn't been uploaded yet.")
+		}
+		return err
+	}
+
+	if aws.ToInt64(head.ContentLength) != doc.FileSize || aws.ToString(head.ContentType) != doc.ContentType {
+		return fiber.NewError(fiber.StatusBadRequest, "The uploaded file doesn't match the declared size and content type.")
+	}
+
+	// The pending document reaper may have removed the upload in the meantime.
+	now := time.Now()
+	n, err := models.Documents(
+		models.DocumentWhere.ID.EQ(doc.ID),
+		models.DocumentWhere.Status.EQ(models.DocumentStatusPending),
+	).UpdateAll(c.Context(), udc.DBS().Writer, models.M{
+		models.DocumentColumns.Status:    models.DocumentStatusReady,
+		models.DocumentColumns.UpdatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("no document with id %s found", c.Params("id")))
+	}
+
+	doc.Status = models.DocumentStatusReady
+	doc.UpdatedAt = now
+
+	return c.JSON(udc.documentToAPI(doc))
+}
+
+// getDocument loads the document in the id path parameter, making sure that it belongs to the
+// user in the token. If status is not empty, the document must also be in that status.
+func (udc *DocumentsController) getDocument(c *fiber.Ctx, status string) (*models.Document, error) {
+	fileID := c.Params("id")
+
+	mods := []qm.QueryMod{
+		models.DocumentWhere.ID.EQ(documentRowID(fileID)),
+		models.DocumentWhere.UserID.EQ(helpers.GetUserID(c)),
+		qm.Load(models.DocumentRels.UserDevice),
+	}
+	if status != "" {
+		mods = append(mods, models.DocumentWhere.Status.EQ(status))
+	}
+
+	doc, err := models.Documents(mods...).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("no document with id %s found", fileID))
+		}
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (udc *DocumentsController) documentToAPI(d *models.Document) DocumentResponse {
+	documentID := buildUniqueID(d.ID, d.UserDeviceID.String)
+
+	out := DocumentResponse{
+		ID:           documentID,
+		Name:         d.Name,
+		Ext:          d.FileExt,
+		UserDeviceID: d.UserDeviceID.String,
+		CreatedAt:    d.CreatedAt,
+		URL:          fmt.Sprintf("%s/v1/documents/%s/download", udc.settings.DeploymentBaseURL, documentID),
+		Type:         DocumentTypeEnum(d.Type),
+		FileSize:     d.FileSize,
+		Tags:         d.Tags,
+	}
+	if out.Tags == nil {
+		out.Tags = []string{}
+	}
+	if d.R != nil && d.R.UserDevice != nil && !d.R.UserDevice.TokenID.IsZero() {
+		out.VehicleTokenID = d.R.UserDevice.TokenID.Int(nil)
+	}
+	if d.ExpiresAt.Valid {
+		out.ExpiresAt = &d.ExpiresAt.Time
+	}
+
+	return out
+}
+
+const (
+	maxDocumentTags      = 20
+	maxDocumentTagLength = 50
+)
+
+// parseDocumentBody parses and validates the JSON body of a document request.
+func parseDocumentBody(c *fiber.Ctx, req validation.Validatable) error {
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse document request body.")
+	}
+	if err := req.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return nil
+}
+
+// parseDocumentTags trims and de-duplicates tags, dropping empty ones.
+func parseDocumentTags(raw []string) ([]string, error) {
+	tags := []string{}
+	seen := make(map[string]bool)
+
+	for _, t := range raw {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		if len(t) > maxDocumentTagLength {
+			return nil, fmt.Errorf("tags can be at most %d characters long", maxDocumentTagLength)
+		}
+		seen[t] = true
+		tags = append(tags, t)
+	}
+
+	if len(tags) > maxDocumentTags {
+		return nil, fmt.Errorf("a document can have at most %d tags", maxDocumentTags)
+	}
+
+	return tags, nil
+}
+
+// parseDocumentExpiry accepts a bare date, which is taken to be the end of that day in UTC, or
+// an RFC 3339 timestamp.
+func parseDocumentExpiry(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// documentRowID strips the user device prefix, if any, from a document ID.
+func documentRowID(fileID string) string {
+	if i := strings.LastIndexByte(fileID, '-'); i >= 0 {
+		return fileID[i+1:]
+	}
+	return fileID
+}
+
+// documentMetadata is stored on the S3 object, so that documents can be recovered from the
+// bucket alone.
+func documentMetadata(d *models.Document) map[string]string {
+	return map[string]string{
+		MetadataDocumentID:            buildUniqueID(d.ID, d.UserDeviceID.String),
+		MetadataDocumentName:          d.Name,
+		MetadataDocumentFile:          d.FileName,
+		MetadataDocumentFileExtension: d.FileExt,
+		MetadataDocumentType:          d.Type,
+		MetadataDocumentUserDeviceID:  d.UserDeviceID.String,
+	}
+}
+
 func getAwsFilePath(userID, fileID string) string {
 	return fmt.Sprintf("%s/%s", userID, fileID)
 }
@@ -304,18 +747,18 @@ func buildFileID(userDeviceID string, uniqueID string) string {
 	return uniqueID
 }
 
-func resolveFolderKey(userID string, fileID string) string {
-	return fmt.Sprintf("%s/%s", userID, strings.Replace(fileID, "-", "/", 1))
-}
-
 type DocumentResponse struct {
-	ID           string           `json:"id"`
-	Name         string           `json:"name"`
-	URL          string           `json:"url"`
-	Ext          string           `json:"ext"`
-	UserDeviceID string           `json:"userDeviceId"`
-	CreatedAt    time.Time        `json:"createdAt"`
-	Type         DocumentTypeEnum `json:"type"`
+	ID             string           `json:"id"`
+	Name           string           `json:"name"`
+	URL            string           `json:"url"`
+	Ext            string           `json:"ext"`
+	UserDeviceID   string           `json:"userDeviceId"`
+	VehicleTokenID *big.Int         `json:"vehicleTokenId,omitempty" swaggertype:"integer"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Type           DocumentTypeEnum `json:"type"`
+	FileSize       int64            `json:"fileSize"`
+	Tags           []string         `json:"tags"`
+	ExpiresAt      *time.Time       `json:"expiresAt,omitempty"`
 }
 
 type FileTypeAllowedEnum string
@@ -326,6 +769,16 @@ const (
 	pdf  FileTypeAllowedEnum = "application/pdf"
 )
 
+// MaxSize is the largest file of this type that can be uploaded with a presigned URL.
+func (r FileTypeAllowedEnum) MaxSize() int64 {
+	switch r {
+	case pdf:
+		return 50 << 20
+	default:
+		return 20 << 20
+	}
+}
+
 func (r FileTypeAllowedEnum) IsValid() error {
 	switch r {
 	case jpeg, png, pdf:
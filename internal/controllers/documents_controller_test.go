@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentsPresignedUpload(t *testing.T) {
+	ctx := context.Background()
+	const bucket = "documents"
+
+	pdb, pgContainer := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer pgContainer.Terminate(ctx) //nolint
+
+	s3Client, minioContainer := test.StartContainerMinIO(ctx, t, bucket)
+	defer minioContainer.Terminate(ctx) //nolint
+
+	const userID = "user1"
+	settings := &config.Settings{AWSDocumentsBucketName: bucket, DeploymentBaseURL: "https://devices-api.dimo.zone"}
+	dc := NewDocumentsController(settings, test.Logger(), s3Client, pdb.DBS)
+
+	app := test.SetupAppFiber(*test.Logger())
+	app.Use(test.AuthInjectorTestHandler(userID, nil))
+	app.Get("/documents", dc.GetDocuments)
+	app.Post("/documents/upload-url", dc.CreateDocumentUploadURL)
+	app.Post("/documents/:id/finalize", dc.FinalizeDocument)
+	app.Get("/documents/:id/download", dc.DownloadDocument)
+
+	file := []byte("%PDF-1.4 not really")
+
+	// Too big for a PDF.
+	req := test.BuildRequest("POST", "/documents/upload-url", `{"name": "Insurance", "type": "VehicleInsurance", "fileName": "ins.pdf", "contentType": "application/pdf", "size": 1000000000}`)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body, _ := json.Marshal(DocumentUploadURLRequest{
+		Name:        "Insurance",
+		Type:        VehicleInsurance,
+		FileName:    "ins.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(file)),
+		Tags:        []string{"car"},
+	})
+	resp, err = app.Test(test.BuildRequest("POST", "/documents/upload-url", string(body)))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var upload DocumentUploadURLResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&upload))
+
+	finalizePath := "/documents/" + upload.Document.ID + "/finalize"
+
+	// Nothing uploaded yet.
+	resp, err = app.Test(test.BuildRequest("POST", finalizePath, ""))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Pending documents aren't listed.
+	assert.Empty(t, listDocuments(t, app))
+
+	put, err := http.NewRequest(http.MethodPut, upload.URL, bytes.NewReader(file))
+	require.NoError(t, err)
+	for k, v := range upload.Headers {
+		put.Header.Set(k, v)
+	}
+	put.ContentLength = int64(len(file))
+	putResp, err := http.DefaultClient.Do(put)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, putResp.StatusCode)
+
+	resp, err = app.Test(test.BuildRequest("POST", finalizePath, ""))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	doc, err := models.FindDocument(ctx, pdb.DBS().Reader, upload.Document.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DocumentStatusReady, doc.Status)
+
+	docs := listDocuments(t, app)
+	require.Len(t, docs, 1)
+	assert.Equal(t, []string{"car"}, docs[0].Tags)
+	assert.EqualValues(t, len(file), docs[0].FileSize)
+
+	resp, err = app.Test(test.BuildRequest("GET", "/documents/"+upload.Document.ID+"/download?redirect=true", ""))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+
+	getResp, err := http.Get(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	got, _ := io.ReadAll(getResp.Body)
+	assert.Equal(t, file, got)
+}
+
+func listDocuments(t *testing.T, app *fiber.App) []DocumentResponse {
+	resp, err := app.Test(test.BuildRequest("GET", "/documents", ""))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var docs []DocumentResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&docs))
+	return docs
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/DIMO-Network/device-definitions-api/pkg/grpc"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
@@ -175,35 +174,6 @@ func GetActualDeviceDefinitionMetadataValues(dd *grpc.GetDeviceDefinitionItemRes
 
 var zeroAddr common.Address
 
-const sigLen = 65
-
-// Ecrecover mimics the ecrecover opcode, returning the address that signed
-// hash with signature. sig must have length 65 and the last byte, the recovery
-// byte usually denoted v, must be 27 or 28.
-func Ecrecover(hash, sig []byte) (common.Address, error) {
-	if len(sig) != sigLen {
-		return zeroAddr, fmt.Errorf("signature has invalid length %d", len(sig))
-	}
-
-	// Defensive copy: the caller shouldn't have to worry about us modifying
-	// the signature. We adjust because crypto.Ecrecover demands 0 <= v <= 4.
-	fixedSig := make([]byte, sigLen)
-	copy(fixedSig, sig)
-	fixedSig[64] -= 27
-
-	rawPk, err := crypto.Ecrecover(hash, fixedSig)
-	if err != nil {
-		return zeroAddr, err
-	}
-
-	pk, err := crypto.UnmarshalPubkey(rawPk)
-	if err != nil {
-		return zeroAddr, err
-	}
-
-	return crypto.PubkeyToAddress(*pk), nil
-}
-
 // TODO(elffjs): This is becoming a dumping ground.
 
 func APIError(code int, message string, args ...any) error {
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+// MintBatchesController lets fleet operators register and mint many vehicles at once.
+type MintBatchesController struct {
+	batcher *registry.MintBatcher
+	log     *zerolog.Logger
+}
+
+func NewMintBatchesController(batcher *registry.MintBatcher, log *zerolog.Logger) *MintBatchesController {
+	return &MintBatchesController{
+		batcher: batcher,
+		log:     log,
+	}
+}
+
+// CreateMintBatchRequest lists the vehicles to register and mint.
+type CreateMintBatchRequest struct {
+	Vehicles []MintBatchVehicleRequest `json:"vehicles"`
+}
+
+type MintBatchVehicleRequest struct {
+	VIN string `json:"vin" example:"1HGCM82633A004352"`
+	// DefinitionID is the device definition slug, e.g., "honda_accord_2003".
+	DefinitionID string `json:"definitionId" example:"honda_accord_2003"`
+	CountryCode  string `json:"countryCode" example:"USA"`
+}
+
+// MintBatchVehicleErrorResponse says why a vehicle was turned down.
+type MintBatchVehicleErrorResponse struct {
+	// Index is the vehicle's position in the request, starting from 0.
+	Index  int    `json:"index"`
+	VIN    string `json:"vin"`
+	Reason string `json:"reason"`
+}
+
+// MintBatchValidationErrorResponse is returned when any vehicle in a new batch is invalid. No
+// vehicles are created in that case.
+type MintBatchValidationErrorResponse struct {
+	Message  string                          `json:"message"`
+	Vehicles []MintBatchVehicleErrorResponse `json:"vehicles"`
+}
+
+// MintBatchResponse describes a batch and each of its vehicles.
+type MintBatchResponse struct {
+	ID           string         `json:"id"`
+	OwnerAddress common.Address `json:"ownerAddress" swaggertype:"string"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	// Vehicles is sorted by VIN.
+	Vehicles []MintBatchVehicleResponse `json:"vehicles"`
+}
+
+// MintBatchVehicleResponse is one vehicle in a batch.
+type MintBatchVehicleResponse struct {
+	UserDeviceID string `json:"userDeviceId"`
+	VIN          string `json:"vin"`
+	DefinitionID string `json:"definitionId"`
+	// RequestID is the vehicle's mint request. See /v1/transactions/{requestID} for its
+	// timeline.
+	RequestID string `json:"requestId"`
+	// Status is one of "Unsubmitted", "Submitted", "Mined", "Confirmed", or "Failed".
+	Status        string `json:"status"`
+	FailureReason string `json:"failureReason,omitempty"`
+	// TypedData is the EIP-712 payload for the owner to sign. It's only present while the
+	// vehicle is waiting for its signature.
+	TypedData *signer.TypedData `json:"typedData,omitempty"`
+}
+
+// SubmitMintBatchSignaturesRequest carries signatures for any number of the batch's vehicles.
+type SubmitMintBatchSignaturesRequest struct {
+	Signatures []MintBatchSignatureRequest `json:"signatures"`
+}
+
+type MintBatchSignatureRequest struct {
+	UserDeviceID string `json:"userDeviceId"`
+	// Signature is the hex encoding of the EIP-712 signature result.
+	Signature hexutil.Bytes `json:"signature" swaggertype:"string"`
+}
+
+// MintBatchSignatureResponse says whether a signature was accepted. RequestID is set if the
+// mint was sent, and Error otherwise.
+type MintBatchSignatureResponse struct {
+	UserDeviceID string `json:"userDeviceId"`
+	RequestID    string `json:"requestId,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func mintBatchToAPI(b *registry.MintBatch) MintBatchResponse {
+	out := MintBatchResponse{
+		ID:           b.ID,
+		OwnerAddress: b.Owner,
+		CreatedAt:    b.CreatedAt,
+		Vehicles:     make([]MintBatchVehicleResponse, len(b.Vehicles)),
+	}
+
+	for i, v := range b.Vehicles {
+		out.Vehicles[i] = MintBatchVehicleResponse{
+			UserDeviceID:  v.UserDevice.ID,
+			VIN:           v.UserDevice.VinIdentifier.String,
+			DefinitionID:  v.UserDevice.DefinitionID,
+			RequestID:     v.Request.ID,
+			Status:        v.Request.Status,
+			FailureReason: v.Request.FailureReason.String,
+			TypedData:     v.TypedData,
+		}
+	}
+
+	return out
+}
+
+// CreateMintBatch godoc
+// @Summary     Start a batch mint
+// @Description Registers a list of vehicles for the caller and returns the EIP-712 payload to sign for each. Every vehicle is checked first; if any fails, none are created and the reasons are returned.
+// @Tags        mint-batches
+// @Accept      json
+// @Produce     json
+// @Param       body body controllers.CreateMintBatchRequest true "Vehicles"
+// @Success     201 {object} controllers.MintBatchResponse
+// @Failure     400 {object} controllers.MintBatchValidationErrorResponse
+// @Security    BearerAuth
+// @Router      /mint-batches [post]
+func (mc *MintBatchesController) CreateMintBatch(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	var req CreateMintBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	vehicles := make([]registry.MintBatchVehicle, len(req.Vehicles))
+	for i, v := range req.Vehicles {
+		vehicles[i] = registry.MintBatchVehicle{VIN: v.VIN, DefinitionID: v.DefinitionID, CountryCode: v.CountryCode}
+	}
+
+	batch, err := mc.batcher.CreateBatch(c.Context(), userAddr, helpers.GetUserID(c), vehicles)
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchSize) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		var verr *registry.MintBatchValidationError
+		if errors.As(err, &verr) {
+			out := MintBatchValidationErrorResponse{
+				Message:  "Some vehicles are invalid. No vehicles were created.",
+				Vehicles: make([]MintBatchVehicleErrorResponse, len(verr.Vehicles)),
+			}
+			for i, v := range verr.Vehicles {
+				out.Vehicles[i] = MintBatchVehicleErrorResponse{Index: v.Index, VIN: v.VIN, Reason: v.Reason}
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(out)
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(mintBatchToAPI(batch))
+}
+
+// GetMintBatch godoc
+// @Summary     Get a batch mint
+// @Description Gets the mint status of each vehicle in the batch, with the payload to sign for those still waiting on a signature.
+// @Tags        mint-batches
+// @Produce     json
+// @Param       batchID path string true "Batch ID"
+// @Success     200 {object} controllers.MintBatchResponse
+// @Failure     404 "No such batch, or it isn't the caller's."
+// @Security    BearerAuth
+// @Router      /mint-batches/{batchID} [get]
+func (mc *MintBatchesController) GetMintBatch(c *fiber.Ctx) error {
+	batch, err := mc.batcher.GetBatch(c.Context(), c.Params("batchID"), address.Get(c))
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "No batch with that id found.")
+		}
+		return err
+	}
+
+	return c.JSON(mintBatchToAPI(batch))
+}
+
+// SubmitMintBatchSignatures godoc
+// @Summary     Sign vehicles in a batch mint
+// @Description Checks each signature against its vehicle's payload and sends the mints whose signatures are good. A bad signature doesn't hold up the others; its error is reported and the vehicle can be signed again.
+// @Tags        mint-batches
+// @Accept      json
+// @Produce     json
+// @Param       batchID path string true "Batch ID"
+// @Param       body body controllers.SubmitMintBatchSignaturesRequest true "Signatures"
+// @Success     200 {array} controllers.MintBatchSignatureResponse
+// @Failure     400 "Couldn't parse the request."
+// @Failure     404 "No such batch, or it isn't the caller's."
+// @Security    BearerAuth
+// @Router      /mint-batches/{batchID}/signatures [post]
+func (mc *MintBatchesController) SubmitMintBatchSignatures(c *fiber.Ctx) error {
+	var req SubmitMintBatchSignaturesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if len(req.Signatures) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "No signatures provided.")
+	}
+
+	sigs := make([]registry.MintBatchSignature, len(req.Signatures))
+	for i, s := range req.Signatures {
+		sigs[i] = registry.MintBatchSignature{UserDeviceID: s.UserDeviceID, Signature: s.Signature}
+	}
+
+	results, err := mc.batcher.SubmitSignatures(c.Context(), c.Params("batchID"), address.Get(c), sigs)
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "No batch with that id found.")
+		}
+		return err
+	}
+
+	out := make([]MintBatchSignatureResponse, len(results))
+	for i, r := range results {
+		out[i] = MintBatchSignatureResponse{UserDeviceID: r.UserDeviceID, RequestID: r.RequestID, Error: r.Error}
+	}
+
+	return c.JSON(out)
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/IBM/sarama/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMintBatches(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+	mockCtrl := gomock.NewController(t)
+
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "Escape", 2020, nil)[0]
+	dd.Make.TokenId = 42
+
+	ddSvc := mock_services.NewMockDeviceDefinitionService(mockCtrl)
+	ddSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), dd.Id).Return(dd, nil).AnyTimes()
+	ddSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), "tesla_cybertruck_1999").Return(nil, assert.AnError).AnyTimes()
+
+	producer := mocks.NewSyncProducer(t, nil)
+	client := &registry.Client{
+		Producer:     producer,
+		RequestTopic: "topic.transaction.request.send",
+		DB:           pdb.DBS,
+		Contract: registry.Contract{
+			ChainID: big.NewInt(137),
+			Address: common.HexToAddress("0x5"),
+			Name:    "DIMO",
+			Version: "1",
+		},
+	}
+
+	sigVerifier, err := services.NewSignatureVerifier(&config.Settings{}, nil, logger)
+	require.NoError(t, err)
+
+	batcher := registry.NewMintBatcher(pdb.DBS, ddSvc, client, &config.Settings{}, logger, sigVerifier)
+	mc := NewMintBatchesController(batcher, logger)
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	app := test.SetupAppFiber(*logger)
+	app.Use(test.AuthInjectorTestHandler("fleet1", &owner))
+	app.Post("/mint-batches", address.New(logger), mc.CreateMintBatch)
+	app.Get("/mint-batches/:batchID", address.New(logger), mc.GetMintBatch)
+	app.Post("/mint-batches/:batchID/signatures", address.New(logger), mc.SubmitMintBatchSignatures)
+
+	do := func(method, path string, body any) *http.Response {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		resp, err := app.Test(test.BuildRequest(method, path, string(b)))
+		require.NoError(t, err)
+		return resp
+	}
+
+	// One bad vehicle spoils the batch.
+	resp := do("POST", "/mint-batches", CreateMintBatchRequest{Vehicles: []MintBatchVehicleRequest{
+		{VIN: "1FMCU0F70LUA00001", DefinitionID: dd.Id, CountryCode: "USA"},
+		{VIN: "1FMCU0F70LUA00001", DefinitionID: dd.Id, CountryCode: "USA"},
+		{VIN: "1FMCU0F70LUA00003", DefinitionID: "tesla_cybertruck_1999", CountryCode: "USA"},
+	}})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var verr MintBatchValidationErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&verr))
+	require.Len(t, verr.Vehicles, 2)
+	assert.Equal(t, 1, verr.Vehicles[0].Index)
+	assert.Equal(t, 2, verr.Vehicles[1].Index)
+
+	n, err := models.UserDevices().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	resp = do("POST", "/mint-batches", CreateMintBatchRequest{Vehicles: []MintBatchVehicleRequest{
+		{VIN: "1FMCU0F70LUA00002", DefinitionID: dd.Id, CountryCode: "USA"},
+		{VIN: "1fmcu0f70lua00001", DefinitionID: dd.Id, CountryCode: "USA"},
+	}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var batch MintBatchResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&batch))
+	require.Len(t, batch.Vehicles, 2)
+	assert.Equal(t, owner, batch.OwnerAddress)
+
+	first, second := batch.Vehicles[0], batch.Vehicles[1]
+	assert.Equal(t, "1FMCU0F70LUA00001", first.VIN)
+	assert.Equal(t, models.MetaTransactionRequestStatusUnsubmitted, first.Status)
+	require.NotNil(t, first.TypedData)
+
+	sign := func(v MintBatchVehicleResponse) hexutil.Bytes {
+		hash, _, err := signer.TypedDataAndHash(*v.TypedData)
+		require.NoError(t, err)
+		sig, err := crypto.Sign(hash, privKey)
+		require.NoError(t, err)
+		sig[64] += 27
+		return sig
+	}
+
+	var sent registry.RequestData
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(val []byte) error {
+		var event struct {
+			Data registry.RequestData `json:"data"`
+		}
+		if err := json.Unmarshal(val, &event); err != nil {
+			return err
+		}
+		sent = event.Data
+		return nil
+	})
+
+	// The second vehicle isn't in the batch.
+	resp = do("POST", "/mint-batches/"+batch.ID+"/signatures", SubmitMintBatchSignaturesRequest{Signatures: []MintBatchSignatureRequest{
+		{UserDeviceID: first.UserDeviceID, Signature: sign(first)},
+		{UserDeviceID: "2OQjmqUt9dguwwwIZ1WfNOw8ZQD", Signature: sign(first)},
+	}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results []MintBatchSignatureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	require.Len(t, results, 2)
+	assert.Equal(t, first.RequestID, results[0].RequestID)
+	assert.Empty(t, results[0].Error)
+	assert.NotEmpty(t, results[1].Error)
+
+	assert.Equal(t, first.RequestID, sent.ID)
+
+	resp = do("GET", "/mint-batches/"+batch.ID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var after MintBatchResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&after))
+	assert.Nil(t, after.Vehicles[0].TypedData, "signed vehicles no longer need a payload")
+	assert.Equal(t, second.UserDeviceID, after.Vehicles[1].UserDeviceID)
+	assert.NotNil(t, after.Vehicles[1].TypedData)
+
+	// Signing twice is refused.
+	resp = do("POST", "/mint-batches/"+batch.ID+"/signatures", SubmitMintBatchSignaturesRequest{Signatures: []MintBatchSignatureRequest{
+		{UserDeviceID: first.UserDeviceID, Signature: sign(first)},
+	}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var again []MintBatchSignatureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&again))
+	assert.NotEmpty(t, again[0].Error)
+
+	// Of two signatures for the same vehicle that both get past the status check, only the
+	// first claims the request and is sent.
+	producer.ExpectSendMessageAndSucceed()
+
+	resp = do("POST", "/mint-batches/"+batch.ID+"/signatures", SubmitMintBatchSignaturesRequest{Signatures: []MintBatchSignatureRequest{
+		{UserDeviceID: second.UserDeviceID, Signature: sign(second)},
+		{UserDeviceID: second.UserDeviceID, Signature: sign(second)},
+	}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var twice []MintBatchSignatureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&twice))
+	require.Len(t, twice, 2)
+	assert.Equal(t, second.RequestID, twice[0].RequestID)
+	assert.Empty(t, twice[0].Error)
+	assert.Empty(t, twice[1].RequestID)
+	assert.Equal(t, "Vehicle's mint has already been submitted.", twice[1].Error)
+
+	// A vehicle that fails partway through doesn't lose the ones already sent.
+	resp = do("POST", "/mint-batches", CreateMintBatchRequest{Vehicles: []MintBatchVehicleRequest{
+		{VIN: "1FMCU0F70LUA00004", DefinitionID: dd.Id, CountryCode: "USA"},
+		{VIN: "1FMCU0F70LUA00005", DefinitionID: dd.Id, CountryCode: "USA"},
+	}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var batch2 MintBatchResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&batch2))
+	require.Len(t, batch2.Vehicles, 2)
+
+	broken, err := models.FindUserDevice(ctx, pdb.DBS().Writer, batch2.Vehicles[1].UserDeviceID)
+	require.NoError(t, err)
+	broken.DefinitionID = "tesla_cybertruck_1999"
+	_, err = broken.Update(ctx, pdb.DBS().Writer, boil.Whitelist(models.UserDeviceColumns.DefinitionID))
+	require.NoError(t, err)
+
+	producer.ExpectSendMessageAndSucceed()
+
+	resp = do("POST", "/mint-batches/"+batch2.ID+"/signatures", SubmitMintBatchSignaturesRequest{Signatures: []MintBatchSignatureRequest{
+		{UserDeviceID: batch2.Vehicles[0].UserDeviceID, Signature: sign(batch2.Vehicles[0])},
+		{UserDeviceID: batch2.Vehicles[1].UserDeviceID, Signature: sign(batch2.Vehicles[1])},
+	}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var partial []MintBatchSignatureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&partial))
+	require.Len(t, partial, 2)
+	assert.Equal(t, batch2.Vehicles[0].RequestID, partial[0].RequestID)
+	assert.Empty(t, partial[0].Error)
+	assert.Empty(t, partial[1].RequestID)
+	assert.Equal(t, "Couldn't submit the mint. Try again.", partial[1].Error)
+}
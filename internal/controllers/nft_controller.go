@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
@@ -20,6 +21,7 @@ import (
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -36,6 +38,8 @@ type NFTController struct {
 	integSvc         services.DeviceDefinitionIntegrationService
 	teslaTaskService services.TeslaTaskService
 	oracleClient     pb_oracle.TeslaOracleClient
+	commandDeadlines *services.CommandDeadlines
+	sigVerifier      services.SignatureVerifier
 }
 
 // NewNFTController constructor
@@ -44,7 +48,13 @@ func NewNFTController(settings *config.Settings, dbs func() *db.ReaderWriter, lo
 	teslaTaskService services.TeslaTaskService,
 	integSvc services.DeviceDefinitionIntegrationService,
 	oracleClient pb_oracle.TeslaOracleClient,
+	sigVerifier services.SignatureVerifier,
 ) NFTController {
+	commandDeadlines, err := services.NewCommandDeadlines(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse command timeout settings.")
+	}
+
 	return NFTController{
 		Settings:         settings,
 		DBS:              dbs,
@@ -53,6 +63,8 @@ func NewNFTController(settings *config.Settings, dbs func() *db.ReaderWriter, lo
 		teslaTaskService: teslaTaskService,
 		integSvc:         integSvc,
 		oracleClient:     oracleClient,
+		commandDeadlines: commandDeadlines,
+		sigVerifier:      sigVerifier,
 	}
 }
 
@@ -117,26 +129,36 @@ func (udc *UserDevicesController) UpdateVINV2(c *fiber.Ctx) error {
 	if req.Signature != "" {
 		vinByte := []byte(req.VIN)
 		sig := common.FromHex(req.Signature)
-		if len(sig) != 65 {
-			logger.Error().Str("rawSignature", req.Signature).Msg("Signature was not 65 bytes.")
-			return fiber.NewError(fiber.StatusBadRequest, "Signature is not 65 bytes long.")
-		}
 
 		hash := crypto.Keccak256(vinByte)
 
-		recAddr, err := helpers.Ecrecover(hash, sig)
-		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "Couldn't recover signer address.")
+		// Any known aftermarket device may attest with its own key.
+		found := false
+		if recAddr, err := utils.Ecrecover(hash, sig); err == nil {
+			found, err = models.AftermarketDevices(
+				models.AftermarketDeviceWhere.EthereumAddress.EQ(recAddr.Bytes()),
+			).Exists(c.Context(), tx)
+			if err != nil {
+				return err
+			}
 		}
 
-		found, err := models.AftermarketDevices(
-			models.AftermarketDeviceWhere.EthereumAddress.EQ(recAddr.Bytes()),
-		).Exists(c.Context(), tx)
-		if err != nil {
-			return err
-		}
+		// Otherwise the device paired to this vehicle has to vouch for it, which also covers
+		// devices whose address is a contract account.
 		if !found {
-			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("VIN signature author %s does not match any known aftermarket device.", recAddr))
+			ad, err := models.AftermarketDevices(
+				models.AftermarketDeviceWhere.VehicleTokenID.EQ(utils.NullableBigToDecimal(tokenID)),
+			).One(c.Context(), tx)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fiber.NewError(fiber.StatusBadRequest, "VIN signature author does not match any known aftermarket device, and no device is paired to this vehicle.")
+				}
+				return err
+			}
+
+			if err := udc.sigVerifier.VerifySignature(c.Context(), common.BytesToAddress(ad.EthereumAddress), hash, sig); err != nil {
+				return signatureError(err)
+			}
 		}
 	}
 
@@ -207,7 +229,7 @@ func (udc *UserDevicesController) UpdateVINV2(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/doors/unlock [post]
 func (nc *NFTController) UnlockDoors(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.DoorsUnlock)
+	return nc.handleEnqueueCommand(c, constants.DoorsUnlock, nil)
 }
 
 // LockDoors godoc
@@ -219,7 +241,7 @@ func (nc *NFTController) UnlockDoors(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/doors/lock [post]
 func (nc *NFTController) LockDoors(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.DoorsLock)
+	return nc.handleEnqueueCommand(c, constants.DoorsLock, nil)
 }
 
 // StopCharging godoc
@@ -231,7 +253,7 @@ func (nc *NFTController) LockDoors(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/charge/stop [post]
 func (nc *NFTController) ChargeStop(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.ChargeStop)
+	return nc.handleEnqueueCommand(c, constants.ChargeStop, nil)
 }
 
 // StartCharging godoc
@@ -243,7 +265,7 @@ func (nc *NFTController) ChargeStop(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/charge/start [post]
 func (nc *NFTController) ChargeStart(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.ChargeStart)
+	return nc.handleEnqueueCommand(c, constants.ChargeStart, nil)
 }
 
 // OpenTrunk godoc
@@ -255,7 +277,7 @@ func (nc *NFTController) ChargeStart(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/trunk/open [post]
 func (nc *NFTController) OpenTrunk(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.TrunkOpen)
+	return nc.handleEnqueueCommand(c, constants.TrunkOpen, nil)
 }
 
 // OpenFrunk godoc
@@ -267,14 +289,162 @@ func (nc *NFTController) OpenTrunk(c *fiber.Ctx) error {
 // @Param       tokenID  path string true "Token ID"
 // @Router      /vehicle/{tokenID}/commands/frunk/open [post]
 func (nc *NFTController) OpenFrunk(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.FrunkOpen)
+	return nc.handleEnqueueCommand(c, constants.FrunkOpen, nil)
+}
+
+// SetChargeLimitRequest is the body for setting the charge limit.
+type SetChargeLimitRequest struct {
+	// Percent is the target state of charge, between 50 and 100.
+	Percent float64 `json:"percent"`
+}
+
+func (r *SetChargeLimitRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Percent, validation.Required, validation.Min(50.0), validation.Max(100.0)),
+	)
+}
+
+// SetChargingAmpsRequest is the body for setting the charging current.
+type SetChargingAmpsRequest struct {
+	// Amps is the charging current, between 1 and 80.
+	Amps int `json:"amps"`
+}
+
+func (r *SetChargingAmpsRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Amps, validation.Required, validation.Min(1), validation.Max(80)),
+	)
+}
+
+// ClimateOnRequest is the body for turning on climate control.
+type ClimateOnRequest struct {
+	// TargetTemperature is in degrees Celsius, between 15 and 28. If omitted, the vehicle keeps
+	// its current setting.
+	TargetTemperature *float64 `json:"targetTemperature"`
+}
+
+func (r *ClimateOnRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TargetTemperature, validation.Min(15.0), validation.Max(28.0)),
+	)
+}
+
+// SetChargeLimit godoc
+// @Summary     Set the vehicle's charge limit
+// @Description Set the state of charge at which the vehicle stops charging. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Accept      json
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Param       body    body controllers.SetChargeLimitRequest true "Charge limit"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/charge/limit [post]
+func (nc *NFTController) SetChargeLimit(c *fiber.Ctx) error {
+	var req SetChargeLimitRequest
+	if err := parseCommandBody(c, &req); err != nil {
+		return err
+	}
+	return nc.handleEnqueueCommand(c, constants.ChargeLimit, &commandArgs{chargeLimit: req.Percent})
+}
+
+// SetChargingAmps godoc
+// @Summary     Set the vehicle's charging current
+// @Description Set the current, in amps, that the vehicle draws while charging. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Accept      json
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Param       body    body controllers.SetChargingAmpsRequest true "Charging current"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/charge/amps [post]
+func (nc *NFTController) SetChargingAmps(c *fiber.Ctx) error {
+	var req SetChargingAmpsRequest
+	if err := parseCommandBody(c, &req); err != nil {
+		return err
+	}
+	return nc.handleEnqueueCommand(c, constants.ChargeAmps, &commandArgs{chargingAmps: req.Amps})
+}
+
+// ClimateOn godoc
+// @Summary     Turn on the vehicle's climate control
+// @Description Turn on climate control, optionally setting the target temperature. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Accept      json
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Param       body    body controllers.ClimateOnRequest false "Target temperature"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/climate/on [post]
+func (nc *NFTController) ClimateOn(c *fiber.Ctx) error {
+	var req ClimateOnRequest
+	if len(c.Body()) != 0 {
+		if err := parseCommandBody(c, &req); err != nil {
+			return err
+		}
+	}
+	return nc.handleEnqueueCommand(c, constants.ClimateOn, &commandArgs{targetTemperature: req.TargetTemperature})
+}
+
+// ClimateOff godoc
+// @Summary     Turn off the vehicle's climate control
+// @Description Turn off climate control. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/climate/off [post]
+func (nc *NFTController) ClimateOff(c *fiber.Ctx) error {
+	return nc.handleEnqueueCommand(c, constants.ClimateOff, nil)
+}
+
+// HonkHorn godoc
+// @Summary     Honk the vehicle's horn
+// @Description Honk the vehicle's horn. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/horn/honk [post]
+func (nc *NFTController) HonkHorn(c *fiber.Ctx) error {
+	return nc.handleEnqueueCommand(c, constants.HornHonk, nil)
+}
+
+// FlashLights godoc
+// @Summary     Flash the vehicle's lights
+// @Description Flash the vehicle's headlights. Currently, this only works for Teslas connected through Tesla.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Success     200 {object} controllers.CommandResponse
+// @Router      /vehicle/{tokenID}/commands/lights/flash [post]
+func (nc *NFTController) FlashLights(c *fiber.Ctx) error {
+	return nc.handleEnqueueCommand(c, constants.LightsFlash, nil)
+}
+
+func parseCommandBody(c *fiber.Ctx, req validation.Validatable) error {
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if err := req.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return nil
+}
+
+// commandArgs carries the parameters of parameterized commands. Only the fields relevant to
+// the command are read.
+type commandArgs struct {
+	chargeLimit       float64
+	chargingAmps      int
+	targetTemperature *float64
 }
 
 // handleEnqueueCommand enqueues the command specified by commandPath with the
-// appropriate task service.
+// appropriate task service. Parameterized commands pass their validated
+// arguments in args; others pass nil.
 //
 // Grabs token ID and privileges from Ctx.
-func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string) error {
+func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string, args *commandArgs) error {
 	tokenIDRaw := c.Params("tokenID")
 
 	logger := nc.log.With().
@@ -326,6 +496,10 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		return opaqueInternalError
 	}
 
+	if args == nil {
+		args = new(commandArgs)
+	}
+
 	// TODO(elffjs): This map is ugly. Surely we interface our way out of this?
 	commandMap := map[string]map[string]func(udai *models.UserDeviceAPIIntegration) (string, error){
 		constants.TeslaVendor: {
@@ -335,6 +509,18 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 			"frunk/open":   nc.teslaTaskService.OpenFrunk,
 			"charge/start": nc.teslaTaskService.ChargeStart,
 			"charge/stop":  nc.teslaTaskService.ChargeStop,
+			"climate/off":  nc.teslaTaskService.ClimateOff,
+			"horn/honk":    nc.teslaTaskService.HonkHorn,
+			"lights/flash": nc.teslaTaskService.FlashLights,
+			"charge/limit": func(udai *models.UserDeviceAPIIntegration) (string, error) {
+				return nc.teslaTaskService.SetChargeLimit(udai, args.chargeLimit)
+			},
+			"charge/amps": func(udai *models.UserDeviceAPIIntegration) (string, error) {
+				return nc.teslaTaskService.SetChargingAmps(udai, args.chargingAmps)
+			},
+			"climate/on": func(udai *models.UserDeviceAPIIntegration) (string, error) {
+				return nc.teslaTaskService.ClimateOn(udai, args.targetTemperature)
+			},
 		},
 	}
 
@@ -348,9 +534,13 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
 	}
 
-	if (md.Commands == nil || !slices.Contains(md.Commands.Enabled, commandPath)) &&
-		(integration.Vendor != constants.TeslaVendor || !slices.Contains([]string{"charge/start", "charge/stop"}, commandPath)) { // Ugly hack for Tesla charge being tacked on for a pilot.
+	capable := md.Commands != nil && slices.Contains(md.Commands.Enabled, commandPath)
+	if integration.Vendor == constants.TeslaVendor {
+		capable = services.TeslaCommandEnabled(md.Commands, commandPath) ||
+			slices.Contains([]string{"charge/start", "charge/stop"}, commandPath) // Ugly hack for Tesla charge being tacked on for a pilot.
+	}
 
+	if !capable {
 		return fiber.NewError(fiber.StatusBadRequest, "Integration is not capable of this command.")
 	}
 
@@ -391,6 +581,7 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		IntegrationID: udai.IntegrationID,
 		Command:       commandPath,
 		Status:        models.DeviceCommandRequestStatusPending,
+		ExpiresAt:     null.TimeFrom(nc.commandDeadlines.ExpiresAt(commandPath, time.Now())),
 	}
 
 	if err := comRow.Insert(c.Context(), nc.DBS().Writer, boil.Infer()); err != nil {
@@ -403,6 +594,132 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 	return c.JSON(CommandResponse{RequestID: subTaskID})
 }
 
+// CommandRequestResponse describes a command sent to a vehicle and its current status.
+type CommandRequestResponse struct {
+	// RequestID is the id returned when the command was enqueued.
+	RequestID string `json:"requestId"`
+	// Command is the command path, e.g., "doors/unlock".
+	Command string `json:"command"`
+	// IntegrationID is the integration through which the command was sent.
+	IntegrationID string `json:"integrationId"`
+	// Status is one of "Pending", "Complete", "Failed", or "TimedOut".
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CommandHistoryResponse is a page of command requests, newest first.
+type CommandHistoryResponse struct {
+	Commands []CommandRequestResponse `json:"commands"`
+	// NextCursor should be passed as the cursor parameter to retrieve the next page. It is
+	// omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func commandRequestToAPI(r *models.DeviceCommandRequest) CommandRequestResponse {
+	return CommandRequestResponse{
+		RequestID:     r.ID,
+		Command:       r.Command,
+		IntegrationID: r.IntegrationID,
+		Status:        r.Status,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// GetCommandHistory godoc
+// @Summary     List commands sent to the vehicle
+// @Description Lists the commands sent to the vehicle, newest first, along with their status.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID path string true "Token ID"
+// @Param       limit   query int false "Page size, at most 100" default(20)
+// @Param       cursor  query string false "Cursor from the previous page"
+// @Success     200 {object} controllers.CommandHistoryResponse
+// @Failure     400 "Invalid limit or cursor."
+// @Failure     404 "Vehicle not found."
+// @Router      /vehicle/{tokenID}/commands [get]
+func (nc *NFTController) GetCommandHistory(c *fiber.Ctx) error {
+	nft, err := nc.commandVehicle(c)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", services.DefaultCommandHistoryLimit)
+	if limit <= 0 || limit > services.MaxCommandHistoryLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", services.MaxCommandHistoryLimit))
+	}
+
+	reqs, next, err := services.ListDeviceCommandRequests(c.Context(), nc.DBS().Reader, nft.ID, c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCommandCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid cursor.")
+		}
+		return err
+	}
+
+	out := CommandHistoryResponse{
+		Commands:   make([]CommandRequestResponse, len(reqs)),
+		NextCursor: next,
+	}
+	for i, r := range reqs {
+		out.Commands[i] = commandRequestToAPI(r)
+	}
+
+	return c.JSON(out)
+}
+
+// GetCommandRequest godoc
+// @Summary     Get the status of a command
+// @Description Gets a single command sent to the vehicle. Poll this after enqueueing a command to learn whether it succeeded.
+// @Tags        device,integration,command
+// @Produce     json
+// @Param       tokenID   path string true "Token ID"
+// @Param       requestID path string true "Command request ID"
+// @Success     200 {object} controllers.CommandRequestResponse
+// @Failure     404 "Vehicle or command not found."
+// @Router      /vehicle/{tokenID}/commands/{requestID} [get]
+func (nc *NFTController) GetCommandRequest(c *fiber.Ctx) error {
+	nft, err := nc.commandVehicle(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.ID.EQ(c.Params("requestID")),
+		models.DeviceCommandRequestWhere.UserDeviceID.EQ(nft.ID),
+	).One(c.Context(), nc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Command request not found.")
+		}
+		return err
+	}
+
+	return c.JSON(commandRequestToAPI(req))
+}
+
+// commandVehicle loads the vehicle named by the tokenID path parameter.
+func (nc *NFTController) commandVehicle(c *fiber.Ctx) (*models.UserDevice, error) {
+	tokenIDRaw := c.Params("tokenID")
+	tokenID, ok := new(decimal.Big).SetString(tokenIDRaw)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tokenIDRaw))
+	}
+
+	nft, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(tokenID)),
+	).One(c.Context(), nc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Vehicle NFT not found.")
+		}
+		return nil, err
+	}
+
+	return nft, nil
+}
+
 // BurnRequest contains the user's signature for the burn request.
 type BurnRequest struct {
 	// Signature is the hex encoding of the EIP-712 signature result.
@@ -5,12 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"testing"
+	"time"
 
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
 	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	pb_oracle "github.com/DIMO-Network/tesla-oracle/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 )
 
 func (s *UserDevicesControllerTestSuite) TestUpdateVINV2_setCountryAndProtocol() {
@@ -141,3 +152,172 @@ func (s *UserDevicesControllerTestSuite) TestUpdateVINV2_invalid() {
 	s.Require().NoError(err)
 	s.Equal(400, response.StatusCode)
 }
+
+func (s *UserDevicesControllerTestSuite) TestGetCommandHistory() {
+	nc := NewNFTController(s.controller.Settings, s.pdb.DBS, test.Logger(), s.deviceDefSvc, nil, s.deviceDefIntSvc, nil, nil)
+	app := test.SetupAppFiber(*test.Logger())
+	app.Get("/vehicle/:tokenID/commands", nc.GetCommandHistory)
+	app.Get("/vehicle/:tokenID/commands/:requestID", nc.GetCommandRequest)
+
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, ksuid.New().String(), nil, "", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(5), null.BytesFrom(common.BigToAddress(big.NewInt(5)).Bytes()), s.pdb)
+
+	start := time.Now().Add(-time.Hour)
+	var ids []string
+	for i := range 3 {
+		cr := models.DeviceCommandRequest{
+			ID:            ksuid.New().String(),
+			UserDeviceID:  ud.ID,
+			IntegrationID: "26A5Dk3vvvQutjSyF0Jka2DP5lg",
+			Command:       "doors/unlock",
+			Status:        models.DeviceCommandRequestStatusComplete,
+			CreatedAt:     start.Add(time.Duration(i) * time.Minute),
+		}
+		s.Require().NoError(cr.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+		ids = append(ids, cr.ID)
+	}
+
+	response, err := app.Test(test.BuildRequest("GET", "/vehicle/5/commands?limit=2", ""))
+	s.Require().NoError(err)
+	s.Require().Equal(200, response.StatusCode)
+
+	var page CommandHistoryResponse
+	s.Require().NoError(json.NewDecoder(response.Body).Decode(&page))
+	s.Require().Len(page.Commands, 2)
+	s.Equal(ids[2], page.Commands[0].RequestID)
+	s.Equal(ids[1], page.Commands[1].RequestID)
+	s.Equal(ids[1], page.NextCursor)
+
+	response, err = app.Test(test.BuildRequest("GET", "/vehicle/5/commands?limit=2&cursor="+page.NextCursor, ""))
+	s.Require().NoError(err)
+	s.Require().Equal(200, response.StatusCode)
+
+	page = CommandHistoryResponse{}
+	s.Require().NoError(json.NewDecoder(response.Body).Decode(&page))
+	s.Require().Len(page.Commands, 1)
+	s.Equal(ids[0], page.Commands[0].RequestID)
+	s.Empty(page.NextCursor)
+
+	response, err = app.Test(test.BuildRequest("GET", "/vehicle/5/commands/"+ids[0], ""))
+	s.Require().NoError(err)
+	s.Require().Equal(200, response.StatusCode)
+
+	var single CommandRequestResponse
+	s.Require().NoError(json.NewDecoder(response.Body).Decode(&single))
+	s.Equal("doors/unlock", single.Command)
+	s.Equal(models.DeviceCommandRequestStatusComplete, single.Status)
+
+	response, err = app.Test(test.BuildRequest("GET", "/vehicle/5/commands/"+ksuid.New().String(), ""))
+	s.Require().NoError(err)
+	s.Equal(404, response.StatusCode)
+}
+
+// fakeTeslaOracle reports one synthetic device with the given subscription status for any VIN.
+type fakeTeslaOracle struct {
+	pb_oracle.TeslaOracleClient
+	status string
+}
+
+func (f *fakeTeslaOracle) GetSyntheticDevicesByVIN(_ context.Context, _ *pb_oracle.GetSyntheticDevicesByVINRequest, _ ...grpc.CallOption) (*pb_oracle.GetSyntheticDevicesByVINResponse, error) {
+	return &pb_oracle.GetSyntheticDevicesByVINResponse{
+		SyntheticDevices: []*pb_oracle.SyntheticDevice{{SubscriptionStatus: f.status}},
+	}, nil
+}
+
+func (s *UserDevicesControllerTestSuite) TestEnqueueParameterizedCommands() {
+	teslaTaskSvc := mock_services.NewMockTeslaTaskService(s.mockCtrl)
+	oracle := &fakeTeslaOracle{status: "active"}
+	nc := NewNFTController(s.controller.Settings, s.pdb.DBS, test.Logger(), s.deviceDefSvc, teslaTaskSvc, s.deviceDefIntSvc, oracle, nil)
+	app := test.SetupAppFiber(*test.Logger())
+	app.Post("/vehicle/:tokenID/commands/charge/amps", nc.SetChargingAmps)
+	app.Post("/vehicle/:tokenID/commands/climate/on", nc.ClimateOn)
+	app.Post("/vehicle/:tokenID/commands/horn/honk", nc.HonkHorn)
+
+	const vin = "5YJ3E1EA1JF000001"
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, ksuid.New().String(), nil, vin, s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(9), null.BytesFrom(common.BigToAddress(big.NewInt(9)).Bytes()), s.pdb)
+
+	integration := test.BuildIntegrationGRPC(ksuid.New().String(), constants.TeslaVendor, 0, 0)
+
+	// Authorized before the newer commands existed, so only the original ones are listed.
+	md, err := json.Marshal(services.UserDeviceAPIIntegrationsMetadata{
+		Commands: &services.UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.DoorsLock, constants.ChargeLimit}},
+	})
+	s.Require().NoError(err)
+	udai := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: integration.Id,
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+		Metadata:      null.JSONFrom(md),
+	}
+	s.Require().NoError(udai.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	s.deviceDefIntSvc.EXPECT().GetAutoPiIntegration(gomock.Any()).Return(test.BuildIntegrationGRPC(ksuid.New().String(), constants.AutoPiVendor, 10, 0), nil).AnyTimes()
+	s.deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), integration.Id).Return(integration, nil).AnyTimes()
+
+	temp := 21.5
+	teslaTaskSvc.EXPECT().ClimateOn(gomock.Any(), &temp).Return(ksuid.New().String(), nil)
+	teslaTaskSvc.EXPECT().SetChargingAmps(gomock.Any(), 16).Return(ksuid.New().String(), nil)
+	teslaTaskSvc.EXPECT().HonkHorn(gomock.Any()).Return(ksuid.New().String(), nil)
+
+	for _, tc := range []struct {
+		path, body string
+		status     int
+	}{
+		{"/vehicle/9/commands/climate/on", `{"targetTemperature": 21.5}`, 200},
+		{"/vehicle/9/commands/charge/amps", `{"amps": 16}`, 200},
+		{"/vehicle/9/commands/horn/honk", "", 200},
+		{"/vehicle/9/commands/charge/amps", `{"amps": 100}`, 400},
+	} {
+		response, err := app.Test(test.BuildRequest("POST", tc.path, tc.body))
+		s.Require().NoError(err)
+		s.Equal(tc.status, response.StatusCode, tc.path)
+	}
+
+	n, err := models.DeviceCommandRequests(models.DeviceCommandRequestWhere.UserDeviceID.EQ(ud.ID)).Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(3, n)
+}
+
+func TestTeslaCommandEnabled(t *testing.T) {
+	old := &services.UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.DoorsLock, constants.ChargeLimit}}
+	chargingOnly := &services.UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.ChargeLimit}, Disabled: []string{constants.DoorsLock}}
+
+	assert.True(t, services.TeslaCommandEnabled(old, constants.ClimateOn))
+	assert.True(t, services.TeslaCommandEnabled(old, constants.ChargeAmps))
+	assert.True(t, services.TeslaCommandEnabled(chargingOnly, constants.ChargeAmps))
+	assert.False(t, services.TeslaCommandEnabled(chargingOnly, constants.HornHonk))
+	assert.False(t, services.TeslaCommandEnabled(nil, constants.DoorsLock))
+}
+
+func TestCommandBodyValidation(t *testing.T) {
+	temp := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name    string
+		req     interface{ Validate() error }
+		wantErr bool
+	}{
+		{"charge limit ok", &SetChargeLimitRequest{Percent: 80}, false},
+		{"charge limit too low", &SetChargeLimitRequest{Percent: 20}, true},
+		{"charge limit too high", &SetChargeLimitRequest{Percent: 101}, true},
+		{"charge limit missing", &SetChargeLimitRequest{}, true},
+		{"amps ok", &SetChargingAmpsRequest{Amps: 32}, false},
+		{"amps too high", &SetChargingAmpsRequest{Amps: 100}, true},
+		{"amps missing", &SetChargingAmpsRequest{}, true},
+		{"climate no temperature", &ClimateOnRequest{}, false},
+		{"climate ok", &ClimateOnRequest{TargetTemperature: temp(21.5)}, false},
+		{"climate too hot", &ClimateOnRequest{TargetTemperature: temp(35)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
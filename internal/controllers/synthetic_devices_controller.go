@@ -9,7 +9,6 @@ import (
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/contracts"
-	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
@@ -21,7 +20,6 @@ import (
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/ethclient"
 	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
@@ -42,6 +40,7 @@ type SyntheticDevicesController struct {
 	walletSvc      services.SyntheticWalletInstanceService
 	registryClient registry.Client
 	teslaOracle    TeslaOracleClient
+	sigVerifier    services.SignatureVerifier
 }
 
 type TeslaOracleClient interface {
@@ -64,6 +63,7 @@ func NewSyntheticDevicesController(
 	walletSvc services.SyntheticWalletInstanceService,
 	registryClient registry.Client,
 	teslaOracle TeslaOracleClient,
+	sigVerifier services.SignatureVerifier,
 ) SyntheticDevicesController {
 	return SyntheticDevicesController{
 		Settings:       settings,
@@ -73,6 +73,7 @@ func NewSyntheticDevicesController(
 		walletSvc:      walletSvc,
 		registryClient: registryClient,
 		teslaOracle:    teslaOracle,
+		sigVerifier:    sigVerifier,
 	}
 }
 
@@ -312,26 +313,8 @@ func (sdc *SyntheticDevicesController) MintSyntheticDevice(c *fiber.Ctx) error {
 
 	ownerSignature := common.FromHex(req.Signature)
 
-	recAddr, origErr := helpers.Ecrecover(tdHash, ownerSignature)
-	if origErr != nil || recAddr != userAddr {
-		ethClient, err := ethclient.Dial(sdc.Settings.MainRPCURL)
-		if err != nil {
-			return err
-		}
-
-		sigCon, err := sig2.NewErc1271(userAddr, ethClient)
-		if err != nil {
-			return err
-		}
-
-		ret, err := sigCon.IsValidSignature(nil, common.BytesToHash(tdHash), ownerSignature)
-		if err != nil {
-			return err
-		}
-
-		if ret != erc1271magicValue {
-			return fiber.NewError(fiber.StatusBadRequest, "Could not verify ERC-1271 signature.")
-		}
+	if err := sdc.sigVerifier.VerifySignature(c.Context(), userAddr, tdHash, ownerSignature); err != nil {
+		return signatureError(err)
 	}
 
 	if newIntegIDs.Name != "Tesla" {
@@ -369,12 +352,7 @@ func (sdc *SyntheticDevicesController) MintSyntheticDevice(c *fiber.Ctx) error {
 		return err
 	}
 
-	metaReq := &models.MetaTransactionRequest{
-		ID:     requestID,
-		Status: models.MetaTransactionRequestStatusUnsubmitted,
-	}
-
-	if err = metaReq.Insert(c.Context(), tx, boil.Infer()); err != nil {
+	if _, err = services.NewMetaTransactionRequest(c.Context(), tx, requestID, models.MetaTransactionRequestOperationSyntheticMint, ud.ID, userAddr); err != nil {
 		sdc.log.Err(err).Msg("error occurred creating meta transaction request")
 		return fiber.NewError(fiber.StatusInternalServerError, "synthetic device minting request failed")
 	}
@@ -551,36 +529,13 @@ func (sdc *SyntheticDevicesController) BurnSyntheticDevice(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't verify signature.")
 	}
 
-	recAddr, origErr := helpers.Ecrecover(hash, ownerSignature)
-	if origErr != nil || recAddr != ownerAddr {
-		ethClient, err := ethclient.Dial(sdc.Settings.MainRPCURL)
-		if err != nil {
-			return err
-		}
-
-		sigCon, err := sig2.NewErc1271(ownerAddr, ethClient)
-		if err != nil {
-			return err
-		}
-
-		ret, err := sigCon.IsValidSignature(nil, common.BytesToHash(hash), ownerSignature)
-		if err != nil {
-			return err
-		}
-
-		if ret != erc1271magicValue {
-			return fiber.NewError(fiber.StatusBadRequest, "Could not verify ERC-1271 signature.")
-		}
+	if err := sdc.sigVerifier.VerifySignature(c.Context(), ownerAddr, hash, ownerSignature); err != nil {
+		return signatureError(err)
 	}
 
 	reqID := ksuid.New().String()
 
-	mtr := models.MetaTransactionRequest{
-		ID:     reqID,
-		Status: models.MetaTransactionRequestStatusUnsubmitted,
-	}
-
-	if err := mtr.Insert(c.Context(), tx, boil.Infer()); err != nil {
+	if _, err := services.NewMetaTransactionRequest(c.Context(), tx, reqID, models.MetaTransactionRequestOperationSyntheticBurn, ud.ID, ownerAddr); err != nil {
 		return err
 	}
 
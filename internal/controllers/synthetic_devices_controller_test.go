@@ -12,6 +12,7 @@ import (
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/services"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/internal/test"
@@ -105,7 +106,10 @@ func (s *SyntheticDevicesControllerTestSuite) SetupTest() {
 
 	logger := test.Logger()
 
-	c := NewSyntheticDevicesController(mockSettings, s.pdb.DBS, logger, s.deviceDefSvc, s.syntheticDeviceSigSvc, client, s.mockOracle)
+	sigVerifier, err := services.NewSignatureVerifier(mockSettings, nil, logger)
+	s.Require().NoError(err)
+
+	c := NewSyntheticDevicesController(mockSettings, s.pdb.DBS, logger, s.deviceDefSvc, s.syntheticDeviceSigSvc, client, s.mockOracle, sigVerifier)
 	s.sdc = c
 
 	app := test.SetupAppFiber(*logger)
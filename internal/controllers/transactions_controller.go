@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// TransactionsController reports on the meta-transactions devices-api sends to the registry on
+// users' behalf: mints, burns, claims and pairings.
+type TransactionsController struct {
+	settings *config.Settings
+	dbs      func() *db.ReaderWriter
+	log      *zerolog.Logger
+}
+
+func NewTransactionsController(settings *config.Settings, dbs func() *db.ReaderWriter, log *zerolog.Logger) *TransactionsController {
+	return &TransactionsController{
+		settings: settings,
+		dbs:      dbs,
+		log:      log,
+	}
+}
+
+// TransactionStatusChange is one step in a transaction's progress.
+type TransactionStatusChange struct {
+	// Status is one of "Unsubmitted", "Submitted", "Mined", "Confirmed", or "Failed".
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+}
+
+// TransactionResponse describes a meta-transaction request.
+type TransactionResponse struct {
+	ID string `json:"id"`
+	// Operation is one of "Mint", "Burn", "Claim", "Pair", "Unpair", "SyntheticMint", or
+	// "SyntheticBurn". It's empty for some old requests.
+	Operation string `json:"operation,omitempty"`
+	// Status is one of "Unsubmitted", "Submitted", "Mined", "Confirmed", or "Failed".
+	Status       string          `json:"status"`
+	UserDeviceID string          `json:"userDeviceId,omitempty"`
+	OwnerAddress *common.Address `json:"ownerAddress,omitempty" swaggertype:"string"`
+	// Hash is the transaction hash, once the transaction has been submitted.
+	Hash *common.Hash `json:"hash,omitempty" swaggertype:"string"`
+	// ExplorerURL links to the transaction on a block explorer.
+	ExplorerURL string `json:"explorerUrl,omitempty"`
+	// FailureReason is the decoded revert reason for failed transactions.
+	FailureReason string `json:"failureReason,omitempty"`
+	// FailureKind is set for failed transactions. "Permanent" means sending the same call again
+	// won't help: the contract rejected it, or a nonce signed into it was already used. "Transient"
+	// means the transaction reverted without data, as when it runs out of gas or is dropped; these
+	// are resubmitted as a new request, listed in retriedBy, until the attempts run out.
+	FailureKind string `json:"failureKind,omitempty"`
+	// Attempt counts the submissions of this call, starting from 1.
+	Attempt int `json:"attempt"`
+	// RetryOf is the failed request that this one resubmits.
+	RetryOf string `json:"retryOf,omitempty"`
+	// RetriedBy is the request that resubmitted this one after it failed.
+	RetriedBy string `json:"retriedBy,omitempty"`
+	// Timeline lists the statuses the request has been in, oldest first.
+	Timeline  []TransactionStatusChange `json:"timeline"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	UpdatedAt time.Time                 `json:"updatedAt"`
+}
+
+// TransactionsResponse is a page of meta-transaction requests.
+type TransactionsResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	// NextCursor should be passed as the cursor parameter to retrieve the next page. It is
+	// omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func (tc *TransactionsController) transactionToAPI(mtr *models.MetaTransactionRequest) TransactionResponse {
+	out := TransactionResponse{
+		ID:            mtr.ID,
+		Operation:     mtr.Operation.String,
+		Status:        mtr.Status,
+		UserDeviceID:  mtr.UserDeviceID.String,
+		FailureReason: mtr.FailureReason.String,
+		Attempt:       mtr.Attempt,
+		RetryOf:       mtr.RetryOf.String,
+		CreatedAt:     mtr.CreatedAt,
+		UpdatedAt:     mtr.UpdatedAt,
+	}
+
+	if mtr.OwnerAddress.Valid {
+		addr := common.BytesToAddress(mtr.OwnerAddress.Bytes)
+		out.OwnerAddress = &addr
+	}
+
+	if mtr.Hash.Valid {
+		hash := common.BytesToHash(mtr.Hash.Bytes)
+		out.Hash = &hash
+		if tc.settings.BlockExplorerURL != "" {
+			if u, err := url.JoinPath(tc.settings.BlockExplorerURL, "tx", hash.Hex()); err == nil {
+				out.ExplorerURL = u
+			}
+		}
+	}
+
+	if mtr.Status == models.MetaTransactionRequestStatusFailed && mtr.FailureRetryable.Valid {
+		if mtr.FailureRetryable.Bool {
+			out.FailureKind = "Transient"
+		} else {
+			out.FailureKind = "Permanent"
+		}
+	}
+
+	if mtr.R != nil && len(mtr.R.RetryOfMetaTransactionRequests) != 0 {
+		out.RetriedBy = mtr.R.RetryOfMetaTransactionRequests[0].ID
+	}
+
+	if mtr.R != nil && len(mtr.R.MetaTransactionRequestStatusChanges) != 0 {
+		for _, sc := range mtr.R.MetaTransactionRequestStatusChanges {
+			out.Timeline = append(out.Timeline, TransactionStatusChange{Status: sc.Status, Time: sc.CreatedAt})
+		}
+	} else {
+		// Requests from before we kept a timeline.
+		out.Timeline = []TransactionStatusChange{{Status: models.MetaTransactionRequestStatusUnsubmitted, Time: mtr.CreatedAt}}
+		if mtr.Status != models.MetaTransactionRequestStatusUnsubmitted {
+			out.Timeline = append(out.Timeline, TransactionStatusChange{Status: mtr.Status, Time: mtr.UpdatedAt})
+		}
+	}
+
+	return out
+}
+
+var loadTransactionTimeline = qm.Load(
+	models.MetaTransactionRequestRels.MetaTransactionRequestStatusChanges,
+	qm.OrderBy(models.MetaTransactionRequestStatusChangeColumns.CreatedAt),
+)
+
+var loadTransactionRetry = qm.Load(models.MetaTransactionRequestRels.RetryOfMetaTransactionRequests)
+
+// GetTransaction godoc
+// @Summary     Get a transaction
+// @Description Gets the status, timeline, hash and failure reason of a meta-transaction request, and any resubmission of it. The caller must own the address or vehicle it was made for.
+// @Tags        transactions
+// @Produce     json
+// @Param       requestID path string true "Meta-transaction request ID"
+// @Success     200 {object} controllers.TransactionResponse
+// @Failure     404 "No such request, or it isn't the caller's."
+// @Security    BearerAuth
+// @Router      /transactions/{requestID} [get]
+func (tc *TransactionsController) GetTransaction(c *fiber.Ctx) error {
+	requestID := c.Params("requestID")
+
+	mtr, err := models.MetaTransactionRequests(
+		models.MetaTransactionRequestWhere.ID.EQ(requestID),
+		loadTransactionTimeline,
+		loadTransactionRetry,
+	).One(c.Context(), tc.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No transaction with that id found.")
+		}
+		return err
+	}
+
+	ok, err := tc.canView(c, mtr)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "No transaction with that id found.")
+	}
+
+	return c.JSON(tc.transactionToAPI(mtr))
+}
+
+// canView reports whether the caller made the request, or owns the vehicle it was for and did
+// so when the request was made.
+func (tc *TransactionsController) canView(c *fiber.Ctx, mtr *models.MetaTransactionRequest) (bool, error) {
+	if addr, err := helpers.GetJWTEthAddr(c); err == nil && mtr.OwnerAddress.Valid && common.BytesToAddress(mtr.OwnerAddress.Bytes) == addr {
+		return true, nil
+	}
+
+	if !mtr.UserDeviceID.Valid {
+		return false, nil
+	}
+
+	owns, err := models.UserDevices(
+		models.UserDeviceWhere.ID.EQ(mtr.UserDeviceID.String),
+		models.UserDeviceWhere.UserID.EQ(helpers.GetUserID(c)),
+	).Exists(c.Context(), tc.dbs().Reader)
+	if err != nil || !owns {
+		return false, err
+	}
+
+	since, err := services.OwnedSince(c.Context(), tc.dbs().Reader, mtr.UserDeviceID.String)
+	if err != nil {
+		return false, err
+	}
+
+	return !mtr.CreatedAt.Before(since), nil
+}
+
+// ListTransactions godoc
+// @Summary     List the caller's transactions
+// @Description Lists meta-transaction requests made for the caller's address, newest first.
+// @Tags        transactions
+// @Produce     json
+// @Param       operation query string false "Filter by operation: Mint, Burn, Claim, Pair, Unpair, SyntheticMint, or SyntheticBurn"
+// @Param       status    query string false "Filter by status: Unsubmitted, Submitted, Mined, Confirmed, or Failed"
+// @Param       limit     query int false "Page size, at most 100" default(20)
+// @Param       cursor    query string false "Cursor from the previous page"
+// @Success     200 {object} controllers.TransactionsResponse
+// @Failure     400 "Invalid filter, limit or cursor."
+// @Security    BearerAuth
+// @Router      /transactions [get]
+func (tc *TransactionsController) ListTransactions(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+	return tc.listTransactions(c, models.MetaTransactionRequestWhere.OwnerAddress.EQ(null.BytesFrom(userAddr.Bytes())))
+}
+
+// ListVehicleTransactions godoc
+// @Summary     List a vehicle's transactions
+// @Description Lists meta-transaction requests made for the vehicle since it passed to its current owner, newest first.
+// @Tags        transactions
+// @Produce     json
+// @Param       userDeviceID path string true "User device ID"
+// @Param       operation query string false "Filter by operation: Mint, Burn, Claim, Pair, Unpair, SyntheticMint, or SyntheticBurn"
+// @Param       status    query string false "Filter by status: Unsubmitted, Submitted, Mined, Confirmed, or Failed"
+// @Param       limit     query int false "Page size, at most 100" default(20)
+// @Param       cursor    query string false "Cursor from the previous page"
+// @Success     200 {object} controllers.TransactionsResponse
+// @Failure     400 "Invalid filter, limit or cursor."
+// @Security    BearerAuth
+// @Router      /user/devices/{userDeviceID}/transactions [get]
+func (tc *TransactionsController) ListVehicleTransactions(c *fiber.Ctx) error {
+	userDeviceID := c.Params("userDeviceID")
+
+	// A previous owner's transactions stay with them.
+	since, err := services.OwnedSince(c.Context(), tc.dbs().Reader, userDeviceID)
+	if err != nil {
+		return err
+	}
+
+	return tc.listTransactions(c,
+		models.MetaTransactionRequestWhere.UserDeviceID.EQ(null.StringFrom(userDeviceID)),
+		models.MetaTransactionRequestWhere.CreatedAt.GTE(since),
+	)
+}
+
+func (tc *TransactionsController) listTransactions(c *fiber.Ctx, scope ...qm.QueryMod) error {
+	limit := c.QueryInt("limit", services.DefaultTransactionsLimit)
+	if limit <= 0 || limit > services.MaxTransactionsLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", services.MaxTransactionsLimit))
+	}
+
+	if op := c.Query("operation"); op != "" {
+		if !slices.Contains(models.AllMetaTransactionRequestOperation(), op) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized operation %q.", op))
+		}
+		scope = append(scope, models.MetaTransactionRequestWhere.Operation.EQ(null.StringFrom(op)))
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !slices.Contains(models.AllMetaTransactionRequestStatus(), status) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized status %q.", status))
+		}
+		scope = append(scope, models.MetaTransactionRequestWhere.Status.EQ(status))
+	}
+
+	mtrs, next, err := services.ListMetaTransactionRequests(c.Context(), tc.dbs().Reader, c.Query("cursor"), limit, scope...)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTransactionCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid cursor.")
+		}
+		return err
+	}
+
+	out := TransactionsResponse{
+		Transactions: make([]TransactionResponse, len(mtrs)),
+		NextCursor:   next,
+	}
+	for i, mtr := range mtrs {
+		out.Transactions[i] = tc.transactionToAPI(mtr)
+	}
+
+	return c.JSON(out)
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+	settings := &config.Settings{BlockExplorerURL: "https://polygonscan.com"}
+	tc := NewTransactionsController(settings, pdb.DBS, logger)
+
+	owner := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+
+	ud := test.SetupCreateUserDevice(t, "user1", ksuid.New().String(), nil, "", pdb)
+
+	mint, err := services.NewMetaTransactionRequest(ctx, pdb.DBS().Writer, ksuid.New().String(), models.MetaTransactionRequestOperationMint, ud.ID, owner)
+	require.NoError(t, err)
+
+	hash := common.HexToHash("0xabc")
+	mint.Status = models.MetaTransactionRequestStatusFailed
+	mint.Hash = null.BytesFrom(hash.Bytes())
+	mint.FailureReason = null.StringFrom("Vehicle already minted.")
+	mint.FailureRetryable = null.BoolFrom(false)
+	_, err = mint.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+	require.NoError(t, services.RecordMetaTransactionStatus(ctx, pdb.DBS().Writer, mint))
+
+	_, err = services.NewMetaTransactionRequest(ctx, pdb.DBS().Writer, ksuid.New().String(), models.MetaTransactionRequestOperationClaim, "", other)
+	require.NoError(t, err)
+
+	setup := func(userID string, addr common.Address) func(path string) *http.Response {
+		app := test.SetupAppFiber(*logger)
+		app.Use(test.AuthInjectorTestHandler(userID, &addr))
+		app.Get("/transactions", address.New(logger), tc.ListTransactions)
+		app.Get("/transactions/:requestID", tc.GetTransaction)
+		app.Get("/user/devices/:userDeviceID/transactions", tc.ListVehicleTransactions)
+
+		return func(path string) *http.Response {
+			resp, err := app.Test(test.BuildRequest("GET", path, ""))
+			require.NoError(t, err)
+			return resp
+		}
+	}
+
+	get := setup("user1", owner)
+
+	resp := get("/transactions/" + mint.ID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var tr TransactionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tr))
+
+	assert.Equal(t, models.MetaTransactionRequestOperationMint, tr.Operation)
+	assert.Equal(t, models.MetaTransactionRequestStatusFailed, tr.Status)
+	assert.Equal(t, ud.ID, tr.UserDeviceID)
+	assert.Equal(t, &hash, tr.Hash)
+	assert.Equal(t, "https://polygonscan.com/tx/"+hash.Hex(), tr.ExplorerURL)
+	assert.Equal(t, "Vehicle already minted.", tr.FailureReason)
+	assert.Equal(t, "Permanent", tr.FailureKind)
+	assert.Equal(t, 1, tr.Attempt)
+	assert.Empty(t, tr.RetriedBy)
+	require.Len(t, tr.Timeline, 2)
+	assert.Equal(t, models.MetaTransactionRequestStatusUnsubmitted, tr.Timeline[0].Status)
+	assert.Equal(t, models.MetaTransactionRequestStatusFailed, tr.Timeline[1].Status)
+
+	var list TransactionsResponse
+
+	resp = get("/transactions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Transactions, 1)
+	assert.Equal(t, mint.ID, list.Transactions[0].ID)
+	assert.Empty(t, list.NextCursor)
+
+	resp = get("/user/devices/" + ud.ID + "/transactions?operation=Mint&status=Failed")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Transactions, 1)
+
+	pair, err := services.NewMetaTransactionRequest(ctx, pdb.DBS().Writer, ksuid.New().String(), models.MetaTransactionRequestOperationPair, ud.ID, owner)
+	require.NoError(t, err)
+
+	resp = get("/user/devices/" + ud.ID + "/transactions?limit=1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Transactions, 1)
+	assert.Equal(t, pair.ID, list.Transactions[0].ID)
+	assert.Equal(t, pair.ID, list.NextCursor)
+
+	resp = get("/user/devices/" + ud.ID + "/transactions?limit=1&cursor=" + list.NextCursor)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Transactions, 1)
+	assert.Equal(t, mint.ID, list.Transactions[0].ID)
+	assert.Empty(t, list.NextCursor)
+
+	resp = get("/user/devices/" + ud.ID + "/transactions?cursor=" + ksuid.New().String())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = get("/transactions?operation=Transfer")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Someone else can't see it.
+	resp = setup("user2", other)("/transactions/" + mint.ID)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Nor can a later owner of the vehicle.
+	_, err = services.NewVehicleHandover(ctx, pdb.DBS().Writer, ud.ID, big.NewInt(1), owner, other, common.Hash{})
+	require.NoError(t, err)
+	ud.UserID = "user2"
+	_, err = ud.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	getNew := setup("user2", other)
+
+	resp = getNew("/transactions/" + mint.ID)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = getNew("/user/devices/" + ud.ID + "/transactions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Empty(t, list.Transactions)
+
+	// The previous owner still sees the ones they made.
+	resp = get("/transactions/" + mint.ID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -19,9 +19,9 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/contracts"
-	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/dtc"
 	"github.com/DIMO-Network/devices-api/internal/services/ipfs"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/internal/utils"
@@ -35,7 +35,6 @@ import (
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/ethclient"
 	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
@@ -74,14 +73,17 @@ type UserDevicesController struct {
 	autoPiIngestRegistrar services.IngestRegistrar
 	producer              sarama.SyncProducer
 	redisCache            redis.CacheService
-	openAI                services.OpenAI
+	llm                   services.LLMProvider
+	dtcCatalog            *dtc.Catalog
 	NATSSvc               *services.NATSService
 	wallet                services.SyntheticWalletInstanceService
 	userDeviceSvc         services.UserDeviceService
 	teslaFleetAPISvc      services.TeslaFleetAPIService
+	teslaTokens           services.TeslaTokenManager
 	ipfsSvc               *ipfs.IPFS
 	clickHouseConn        clickhouse.Conn
 	oracleClient          pb_oracle.TeslaOracleClient
+	sigVerifier           services.SignatureVerifier
 }
 
 // PrivilegedDevices contains all devices for which a privilege has been shared
@@ -124,13 +126,15 @@ func NewUserDevicesController(settings *config.Settings,
 	autoPiIngestRegistrar services.IngestRegistrar,
 	producer sarama.SyncProducer,
 	cache redis.CacheService,
-	openAI services.OpenAI,
+	llm services.LLMProvider,
 	natsSvc *services.NATSService,
 	wallet services.SyntheticWalletInstanceService,
 	userDeviceSvc services.UserDeviceService,
 	teslaFleetAPISvc services.TeslaFleetAPIService,
 	ipfsSvc *ipfs.IPFS,
 	chConn clickhouse.Conn,
+	sigVerifier services.SignatureVerifier,
+	teslaTokens services.TeslaTokenManager,
 ) UserDevicesController {
 	oracleConn, err := grpc.NewClient(settings.TeslaOracleGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -151,14 +155,17 @@ func NewUserDevicesController(settings *config.Settings,
 		autoPiIngestRegistrar: autoPiIngestRegistrar,
 		producer:              producer,
 		redisCache:            cache,
-		openAI:                openAI,
+		llm:                   llm,
+		dtcCatalog:            dtc.Default,
 		NATSSvc:               natsSvc,
 		wallet:                wallet,
 		userDeviceSvc:         userDeviceSvc,
 		teslaFleetAPISvc:      teslaFleetAPISvc,
+		teslaTokens:           teslaTokens,
 		ipfsSvc:               ipfsSvc,
 		clickHouseConn:        chConn,
 		oracleClient:          oracleClient,
+		sigVerifier:           sigVerifier,
 	}
 }
 
@@ -917,6 +924,7 @@ func (udc *UserDevicesController) GetMintDevice(c *fiber.Ctx) error {
 	client := registry.Client{
 		Producer:     udc.producer,
 		RequestTopic: "topic.transaction.request.send",
+		DB:           udc.DBS,
 		Contract: registry.Contract{
 			ChainID: big.NewInt(udc.Settings.DIMORegistryChainID),
 			Address: common.HexToAddress(udc.Settings.DIMORegistryAddr),
@@ -936,7 +944,15 @@ func (udc *UserDevicesController) GetMintDevice(c *fiber.Ctx) error {
 	return c.JSON(client.GetPayload(&mvdds))
 }
 
-var erc1271magicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+// signatureError turns a rejected signature into a 400 that says why. Other errors, such as
+// trouble reaching the chain, are passed through.
+func signatureError(err error) error {
+	var sigErr *services.SignatureError
+	if errors.As(err, &sigErr) {
+		return fiber.NewError(fiber.StatusBadRequest, sigErr.Reason)
+	}
+	return err
+}
 
 // PostMintDevice godoc
 // @Description Sends a mint device request to the blockchain
@@ -987,6 +1003,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 	client := registry.Client{
 		Producer:     udc.producer,
 		RequestTopic: "topic.transaction.request.send",
+		DB:           udc.DBS,
 		Contract: registry.Contract{
 			ChainID: big.NewInt(udc.Settings.DIMORegistryChainID),
 			Address: common.HexToAddress(udc.Settings.DIMORegistryAddr),
@@ -1016,35 +1033,13 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 	sigBytes := common.FromHex(mr.Signature)
 
-	recAddr, origErr := helpers.Ecrecover(hash, sigBytes)
-	if origErr != nil || recAddr != mvs.Owner {
-		ethClient, err := ethclient.Dial(udc.Settings.MainRPCURL)
-		if err != nil {
-			return err
-		}
-
-		sigCon, err := sig2.NewErc1271(mvs.Owner, ethClient)
-		if err != nil {
-			return err
-		}
-
-		ret, err := sigCon.IsValidSignature(nil, common.BytesToHash(hash), sigBytes)
-		if err != nil {
-			return err
-		}
-
-		if ret != erc1271magicValue {
-			return fiber.NewError(fiber.StatusBadRequest, "Could not verify ERC-1271 signature.")
-		}
+	if err := udc.sigVerifier.VerifySignature(c.Context(), mvs.Owner, hash, sigBytes); err != nil {
+		return signatureError(err)
 	}
 
 	requestID := ksuid.New().String()
 
-	mtr := models.MetaTransactionRequest{
-		ID:     requestID,
-		Status: models.MetaTransactionRequestStatusUnsubmitted,
-	}
-	if err = mtr.Insert(c.Context(), tx, boil.Infer()); err != nil {
+	if _, err := services.NewMetaTransactionRequest(c.Context(), tx, requestID, models.MetaTransactionRequestOperationMint, userDevice.ID, mvs.Owner); err != nil {
 		return err
 	}
 
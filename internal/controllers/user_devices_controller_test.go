@@ -82,8 +82,11 @@ func (s *UserDevicesControllerTestSuite) SetupSuite() {
 	s.testUserID = "123123"
 	testUserID2 := "3232451"
 	s.testUserEthAddr = common.HexToAddress("0x1231231231231231231231231231231231231231")
+	sigVerifier, err := services.NewSignatureVerifier(&config.Settings{}, nil, logger)
+	s.Require().NoError(err)
+
 	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: "prod"}, s.pdb.DBS, logger, s.deviceDefSvc, s.deviceDefIntSvc, teslaTaskService, nil, new(cip.ROT13Cipher), s.autoPiSvc,
-		autoPiIngest, nil, s.redisClient, nil, s.natsService, nil, s.userDeviceSvc, nil, nil, nil)
+		autoPiIngest, nil, s.redisClient, nil, s.natsService, nil, s.userDeviceSvc, nil, nil, nil, sigVerifier, nil)
 	app := test.SetupAppFiber(*logger)
 	app.Post("/user/devices", test.AuthInjectorTestHandler(s.testUserID, nil), c.RegisterDeviceForUser)
 	app.Post("/user/devices/second", test.AuthInjectorTestHandler(testUserID2, nil), c.RegisterDeviceForUser) // for different test user
@@ -109,13 +109,13 @@ func (udc *UserDevicesController) GetUserDeviceIntegration(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, "Missing device or integration details.")
 	}
 
-	if apiIntegration.AccessExpiresAt.Valid && apiIntegration.AccessExpiresAt.Time.Before(time.Now()) {
-		return c.JSON(resp)
-	}
-
-	accessToken, err := udc.cipher.Decrypt(apiIntegration.AccessToken.String)
+	accessToken, err := udc.teslaTokens.GetAccessToken(c.Context(), apiIntegration)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt access token: %w", err)
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrNoRefreshToken) {
+			// The user will have to log in again.
+			return c.JSON(resp)
+		}
+		return fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	var claims partialTeslaClaims
@@ -406,9 +406,12 @@ func (udc *UserDevicesController) TelemetrySubscribe(c *fiber.Ctx) error {
 
 	switch integration.Vendor {
 	case constants.TeslaVendor:
-		accessToken, err := udc.cipher.Decrypt(udai.AccessToken.String)
+		accessToken, err := udc.teslaTokens.GetAccessToken(c.Context(), udai)
 		if err != nil {
-			return fmt.Errorf("failed to decrypt access token: %w", err)
+			if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrNoRefreshToken) {
+				return fiber.NewError(fiber.StatusUnauthorized, "Tesla credentials have expired or been revoked. Please reconnect the vehicle.")
+			}
+			return fmt.Errorf("failed to get access token: %w", err)
 		}
 		if err := udc.teslaFleetAPISvc.SubscribeForTelemetryData(c.Context(),
 			accessToken,
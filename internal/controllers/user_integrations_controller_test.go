@@ -100,7 +100,7 @@ func (s *UserIntegrationsControllerTestSuite) SetupSuite() {
 	logger := test.Logger()
 	c := NewUserDevicesController(&config.Settings{Port: "3000"}, s.pdb.DBS, logger, s.deviceDefSvc, s.deviceDefIntSvc, s.teslaTaskService, nil, s.cipher, s.autopiAPISvc,
 		s.autoPiIngest, nil, s.redisClient, nil, s.natsSvc, nil, s.userDeviceSvc,
-		s.teslaFleetAPISvc, nil, nil)
+		s.teslaFleetAPISvc, nil, nil, nil, services.NewTeslaTokenManager(s.pdb.DBS, s.cipher, s.teslaFleetAPISvc, s.teslaTaskService, logger))
 
 	app := test.SetupAppFiber(*logger)
 
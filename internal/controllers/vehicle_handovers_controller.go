@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	defaultHandoversLimit = 20
+	maxHandoversLimit     = 100
+)
+
+// VehicleHandoversController shows previous and new owners what happened to a vehicle's data
+// when it changed hands.
+type VehicleHandoversController struct {
+	dbs func() *db.ReaderWriter
+	log *zerolog.Logger
+}
+
+func NewVehicleHandoversController(dbs func() *db.ReaderWriter, log *zerolog.Logger) *VehicleHandoversController {
+	return &VehicleHandoversController{
+		dbs: dbs,
+		log: log,
+	}
+}
+
+// VehicleHandoverStep is one entry in a handover's audit trail.
+type VehicleHandoverStep struct {
+	// Action is one of "PrivilegesCleared", "IntegrationRevoked", "WebhooksDeleted",
+	// "DocumentsDetached", "ErrorCodesHidden", or "OwnerChanged".
+	Action string    `json:"action"`
+	Detail string    `json:"detail,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// VehicleHandoverResponse describes a vehicle moving from one owner to another.
+type VehicleHandoverResponse struct {
+	ID             string         `json:"id"`
+	VehicleTokenID *big.Int       `json:"vehicleTokenId" swaggertype:"number"`
+	UserDeviceID   string         `json:"userDeviceId"`
+	PreviousOwner  common.Address `json:"previousOwner" swaggertype:"string"`
+	NewOwner       common.Address `json:"newOwner" swaggertype:"string"`
+	// Role is "PreviousOwner" or "NewOwner", depending on which one the caller is.
+	Role            string       `json:"role"`
+	TransactionHash *common.Hash `json:"transactionHash,omitempty" swaggertype:"string"`
+	// Steps lists what was done to the vehicle's data, oldest first.
+	Steps     []VehicleHandoverStep `json:"steps"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+var loadHandoverSteps = qm.Load(
+	models.VehicleHandoverRels.VehicleHandoverEvents,
+	qm.OrderBy(models.VehicleHandoverEventColumns.CreatedAt),
+)
+
+func handoverToAPI(vh *models.VehicleHandover, caller common.Address) VehicleHandoverResponse {
+	out := VehicleHandoverResponse{
+		ID:             vh.ID,
+		VehicleTokenID: vh.VehicleTokenID.Int(nil),
+		UserDeviceID:   vh.UserDeviceID,
+		PreviousOwner:  common.BytesToAddress(vh.PreviousOwner),
+		NewOwner:       common.BytesToAddress(vh.NewOwner),
+		Role:           "NewOwner",
+		Steps:          []VehicleHandoverStep{},
+		CreatedAt:      vh.CreatedAt,
+	}
+
+	if out.PreviousOwner == caller {
+		out.Role = "PreviousOwner"
+	}
+
+	if vh.TransactionHash.Valid {
+		hash := common.BytesToHash(vh.TransactionHash.Bytes)
+		out.TransactionHash = &hash
+	}
+
+	if vh.R != nil {
+		for _, ev := range vh.R.VehicleHandoverEvents {
+			out.Steps = append(out.Steps, VehicleHandoverStep{Action: ev.Action, Detail: ev.Detail.String, Time: ev.CreatedAt})
+		}
+	}
+
+	return out
+}
+
+// ListVehicleHandovers godoc
+// @Summary     List the caller's vehicle handovers
+// @Description Lists transfers of vehicles to or from the caller's address, newest first, with what was done to each vehicle's data.
+// @Tags        vehicle-handovers
+// @Produce     json
+// @Param       token_id query int false "Only handovers of this vehicle"
+// @Param       limit    query int false "Page size, at most 100" default(20)
+// @Success     200 {array} controllers.VehicleHandoverResponse
+// @Failure     400 "Invalid filter or limit."
+// @Security    BearerAuth
+// @Router      /vehicle-handovers [get]
+func (vc *VehicleHandoversController) ListVehicleHandovers(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	limit := c.QueryInt("limit", defaultHandoversLimit)
+	if limit <= 0 || limit > maxHandoversLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", maxHandoversLimit))
+	}
+
+	mods := []qm.QueryMod{
+		qm.Expr(
+			models.VehicleHandoverWhere.PreviousOwner.EQ(userAddr.Bytes()),
+			qm.Or2(models.VehicleHandoverWhere.NewOwner.EQ(userAddr.Bytes())),
+		),
+		loadHandoverSteps,
+		qm.OrderBy(models.VehicleHandoverColumns.CreatedAt + " DESC"),
+		qm.Limit(limit),
+	}
+
+	if tis := c.Query("token_id"); tis != "" {
+		ti, ok := new(big.Int).SetString(tis, 10)
+		if !ok {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tis))
+		}
+		mods = append(mods, models.VehicleHandoverWhere.VehicleTokenID.EQ(utils.BigToDecimal(ti)))
+	}
+
+	vhs, err := models.VehicleHandovers(mods...).All(c.Context(), vc.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	out := make([]VehicleHandoverResponse, len(vhs))
+	for i, vh := range vhs {
+		out[i] = handoverToAPI(vh, userAddr)
+	}
+
+	return c.JSON(out)
+}
+
+// GetVehicleHandover godoc
+// @Summary     Get a vehicle handover
+// @Description Gets a vehicle transfer and its audit trail. The caller must be the previous or the new owner.
+// @Tags        vehicle-handovers
+// @Produce     json
+// @Param       handoverID path string true "Handover ID"
+// @Success     200 {object} controllers.VehicleHandoverResponse
+// @Failure     404 "No such handover, or the caller wasn't part of it."
+// @Security    BearerAuth
+// @Router      /vehicle-handovers/{handoverID} [get]
+func (vc *VehicleHandoversController) GetVehicleHandover(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	vh, err := models.VehicleHandovers(
+		models.VehicleHandoverWhere.ID.EQ(c.Params("handoverID")),
+		loadHandoverSteps,
+	).One(c.Context(), vc.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No handover with that id found.")
+		}
+		return err
+	}
+
+	if common.BytesToAddress(vh.PreviousOwner) != userAddr && common.BytesToAddress(vh.NewOwner) != userAddr {
+		return fiber.NewError(fiber.StatusNotFound, "No handover with that id found.")
+	}
+
+	return c.JSON(handoverToAPI(vh, userAddr))
+}
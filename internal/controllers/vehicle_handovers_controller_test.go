@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleHandovers(t *testing.T) {
+	ctx := context.Background()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+	vc := NewVehicleHandoversController(pdb.DBS, logger)
+
+	seller := common.HexToAddress("0x1")
+	buyer := common.HexToAddress("0x2")
+	stranger := common.HexToAddress("0x3")
+
+	udID := ksuid.New().String()
+	hash := common.HexToHash("0xabc")
+
+	vh, err := services.NewVehicleHandover(ctx, pdb.DBS().Writer, udID, big.NewInt(5), seller, buyer, hash)
+	require.NoError(t, err)
+	require.NoError(t, services.RecordHandoverStep(ctx, pdb.DBS().Writer, vh, services.HandoverIntegrationRevoked, "Revoked the previous owner's credentials for integration tesla."))
+	require.NoError(t, services.RecordHandoverStep(ctx, pdb.DBS().Writer, vh, services.HandoverOwnerChanged, ""))
+
+	other, err := services.NewVehicleHandover(ctx, pdb.DBS().Writer, ksuid.New().String(), big.NewInt(6), stranger, seller, hash)
+	require.NoError(t, err)
+
+	setup := func(addr common.Address) func(path string) *http.Response {
+		app := test.SetupAppFiber(*logger)
+		app.Use(test.AuthInjectorTestHandler("user", &addr))
+		app.Get("/vehicle-handovers", address.New(logger), vc.ListVehicleHandovers)
+		app.Get("/vehicle-handovers/:handoverID", address.New(logger), vc.GetVehicleHandover)
+
+		return func(path string) *http.Response {
+			resp, err := app.Test(test.BuildRequest("GET", path, ""))
+			require.NoError(t, err)
+			return resp
+		}
+	}
+
+	// Both parties see the same trail, each in their own role.
+	for addr, role := range map[common.Address]string{seller: "PreviousOwner", buyer: "NewOwner"} {
+		resp := setup(addr)("/vehicle-handovers/" + vh.ID)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var out VehicleHandoverResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+
+		assert.Equal(t, role, out.Role)
+		assert.Equal(t, big.NewInt(5), out.VehicleTokenID)
+		assert.Equal(t, udID, out.UserDeviceID)
+		assert.Equal(t, seller, out.PreviousOwner)
+		assert.Equal(t, buyer, out.NewOwner)
+		assert.Equal(t, &hash, out.TransactionHash)
+		require.Len(t, out.Steps, 2)
+		assert.Equal(t, services.HandoverIntegrationRevoked, out.Steps[0].Action)
+		assert.Equal(t, "Revoked the previous owner's credentials for integration tesla.", out.Steps[0].Detail)
+		assert.Equal(t, services.HandoverOwnerChanged, out.Steps[1].Action)
+	}
+
+	resp := setup(stranger)("/vehicle-handovers/" + vh.ID)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = setup(seller)("/vehicle-handovers")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list []VehicleHandoverResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list, 2)
+	assert.Equal(t, other.ID, list[0].ID)
+	assert.Equal(t, "NewOwner", list[0].Role)
+	assert.Equal(t, vh.ID, list[1].ID)
+	assert.Equal(t, "PreviousOwner", list[1].Role)
+
+	resp = setup(seller)("/vehicle-handovers?token_id=5")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list, 1)
+	assert.Equal(t, vh.ID, list[0].ID)
+
+	resp = setup(buyer)("/vehicle-handovers?limit=500")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,384 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/ethereum/go-ethereum/common"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 20
+	maxWebhookDeliveriesLimit     = 100
+)
+
+// WebhookSubscriptionsController lets developers manage outbound webhooks for vehicle
+// lifecycle events.
+type WebhookSubscriptionsController struct {
+	dbs    func() *db.ReaderWriter
+	log    *zerolog.Logger
+	cipher cipher.Cipher
+}
+
+func NewWebhookSubscriptionsController(dbs func() *db.ReaderWriter, log *zerolog.Logger, cipher cipher.Cipher) *WebhookSubscriptionsController {
+	return &WebhookSubscriptionsController{
+		dbs:    dbs,
+		log:    log,
+		cipher: cipher,
+	}
+}
+
+// CreateWebhookSubscriptionRequest is the body for registering a webhook. Exactly one of
+// vehicleTokenId and ownerAddress must be set.
+type CreateWebhookSubscriptionRequest struct {
+	// URL receives the events. It must use https and point to a public host.
+	URL string `json:"url"`
+	// EventTypes is the set of events to deliver, e.g., "zone.dimo.vehicle.transferred".
+	EventTypes []string `json:"eventTypes"`
+	// VehicleTokenID subscribes to events for a single vehicle, which the caller must own.
+	VehicleTokenID *big.Int `json:"vehicleTokenId" swaggertype:"integer"`
+	// OwnerAddress subscribes to events for every vehicle owned by the address, which must be
+	// the caller's.
+	OwnerAddress *common.Address `json:"ownerAddress" swaggertype:"string"`
+}
+
+func (r *CreateWebhookSubscriptionRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.URL, validation.Required, validation.By(validateWebhookURL)),
+		validation.Field(&r.EventTypes, validation.Required, validation.Each(validation.In(toAny(services.WebhookEventTypes)...))),
+		validation.Field(&r.VehicleTokenID, validation.When(r.OwnerAddress == nil, validation.Required).Else(validation.Nil)),
+	)
+}
+
+func validateWebhookURL(value any) error {
+	u, err := url.ParseRequestURI(value.(string))
+	if err != nil || u.Host == "" {
+		return errors.New("must be a valid URL")
+	}
+	if u.Scheme != "https" {
+		return errors.New("must use https")
+	}
+	if err := services.CheckWebhookHost(u.Hostname()); err != nil {
+		return errors.New("must point to a public host")
+	}
+	return nil
+}
+
+func toAny(ss []string) []any {
+	out := make([]any, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out
+}
+
+// WebhookSubscriptionResponse describes a webhook subscription.
+type WebhookSubscriptionResponse struct {
+	ID             string          `json:"id"`
+	URL            string          `json:"url"`
+	EventTypes     []string        `json:"eventTypes"`
+	VehicleTokenID *big.Int        `json:"vehicleTokenId,omitempty" swaggertype:"integer"`
+	OwnerAddress   *common.Address `json:"ownerAddress,omitempty" swaggertype:"string"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// CreateWebhookSubscriptionResponse includes the signing secret, which is only ever returned
+// here.
+type CreateWebhookSubscriptionResponse struct {
+	WebhookSubscriptionResponse
+	// Secret is the key for the HMAC-SHA256 signature sent in the X-DIMO-Signature header.
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse describes an attempt to deliver an event to a subscription.
+type WebhookDeliveryResponse struct {
+	ID        string `json:"id"`
+	EventID   string `json:"eventId"`
+	EventType string `json:"eventType"`
+	// Status is one of "Pending", "Delivered", or "DeadLettered".
+	Status           string     `json:"status"`
+	Attempts         int        `json:"attempts"`
+	NextAttemptAt    *time.Time `json:"nextAttemptAt,omitempty"`
+	LastError        string     `json:"lastError,omitempty"`
+	LastResponseCode int        `json:"lastResponseCode,omitempty"`
+	DeliveredAt      *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+func webhookSubscriptionToAPI(s *models.WebhookSubscription) WebhookSubscriptionResponse {
+	out := WebhookSubscriptionResponse{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		CreatedAt:  s.CreatedAt,
+	}
+	if !s.VehicleTokenID.IsZero() {
+		out.VehicleTokenID = s.VehicleTokenID.Int(nil)
+	}
+	if s.OwnerAddress.Valid {
+		addr := common.BytesToAddress(s.OwnerAddress.Bytes)
+		out.OwnerAddress = &addr
+	}
+	return out
+}
+
+func webhookDeliveryToAPI(d *models.WebhookDelivery) WebhookDeliveryResponse {
+	out := WebhookDeliveryResponse{
+		ID:               d.ID,
+		EventID:          d.EventID,
+		EventType:        d.EventType,
+		Status:           d.Status,
+		Attempts:         d.Attempts,
+		LastError:        d.LastError.String,
+		LastResponseCode: d.LastResponseCode.Int,
+		CreatedAt:        d.CreatedAt,
+	}
+	if d.Status == models.WebhookDeliveryStatusPending {
+		out.NextAttemptAt = &d.NextAttemptAt
+	}
+	if d.DeliveredAt.Valid {
+		out.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return out
+}
+
+// CreateSubscription godoc
+// @Summary     Register a webhook
+// @Description Registers a URL to receive signed CloudEvents for a vehicle, or for every vehicle owned by an address. Each request carries an X-DIMO-Signature header with the hex-encoded HMAC-SHA256 of the body, keyed with the secret in the response.
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       body body controllers.CreateWebhookSubscriptionRequest true "Subscription"
+// @Success     201 {object} controllers.CreateWebhookSubscriptionResponse
+// @Failure     400 "Invalid request."
+// @Failure     403 "Caller doesn't own the vehicle or address."
+// @Failure     404 "Vehicle not found."
+// @Security    BearerAuth
+// @Router      /webhooks [post]
+func (wc *WebhookSubscriptionsController) CreateSubscription(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	var req CreateWebhookSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if err := req.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	sub := models.WebhookSubscription{
+		ID:               ksuid.New().String(),
+		DeveloperAddress: userAddr.Bytes(),
+		URL:              req.URL,
+		EventTypes:       slices.Compact(slices.Sorted(slices.Values(req.EventTypes))),
+	}
+
+	if req.VehicleTokenID != nil {
+		ud, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(req.VehicleTokenID)),
+		).One(c.Context(), wc.dbs().Reader)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No vehicle with token id %d.", req.VehicleTokenID))
+			}
+			return err
+		}
+		if !ud.OwnerAddress.Valid || common.BytesToAddress(ud.OwnerAddress.Bytes) != userAddr {
+			return fiber.NewError(fiber.StatusForbidden, "You don't own this vehicle.")
+		}
+		sub.VehicleTokenID = dbtypes.NullIntToDecimal(req.VehicleTokenID)
+	} else {
+		if *req.OwnerAddress != userAddr {
+			return fiber.NewError(fiber.StatusForbidden, "You may only subscribe to your own address.")
+		}
+		sub.OwnerAddress = null.BytesFrom(req.OwnerAddress.Bytes())
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return err
+	}
+	secret := hex.EncodeToString(secretBytes)
+
+	encSecret, err := wc.cipher.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+	sub.Secret = encSecret
+
+	if err := sub.Insert(c.Context(), wc.dbs().Writer, boil.Infer()); err != nil {
+		return err
+	}
+
+	wc.log.Info().Str("subscriptionId", sub.ID).Str("developer", userAddr.Hex()).Msg("Created webhook subscription.")
+
+	return c.Status(fiber.StatusCreated).JSON(CreateWebhookSubscriptionResponse{
+		WebhookSubscriptionResponse: webhookSubscriptionToAPI(&sub),
+		Secret:                      secret,
+	})
+}
+
+// ListSubscriptions godoc
+// @Summary     List webhooks
+// @Description Lists the webhooks registered by the caller.
+// @Tags        webhooks
+// @Produce     json
+// @Success     200 {array} controllers.WebhookSubscriptionResponse
+// @Security    BearerAuth
+// @Router      /webhooks [get]
+func (wc *WebhookSubscriptionsController) ListSubscriptions(c *fiber.Ctx) error {
+	userAddr := address.Get(c)
+
+	subs, err := models.WebhookSubscriptions(
+		models.WebhookSubscriptionWhere.DeveloperAddress.EQ(userAddr.Bytes()),
+		qm.OrderBy(models.WebhookSubscriptionColumns.CreatedAt+" DESC"),
+	).All(c.Context(), wc.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	out := make([]WebhookSubscriptionResponse, len(subs))
+	for i, s := range subs {
+		out[i] = webhookSubscriptionToAPI(s)
+	}
+
+	return c.JSON(out)
+}
+
+// DeleteSubscription godoc
+// @Summary     Delete a webhook
+// @Description Deletes a webhook along with its pending and dead-lettered deliveries.
+// @Tags        webhooks
+// @Param       subscriptionID path string true "Subscription ID"
+// @Success     204
+// @Failure     404 "Subscription not found."
+// @Security    BearerAuth
+// @Router      /webhooks/{subscriptionID} [delete]
+func (wc *WebhookSubscriptionsController) DeleteSubscription(c *fiber.Ctx) error {
+	sub, err := wc.developerSubscription(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sub.Delete(c.Context(), wc.dbs().Writer); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary     List webhook deliveries
+// @Description Lists the deliveries for a webhook, newest first.
+// @Tags        webhooks
+// @Produce     json
+// @Param       subscriptionID path string true "Subscription ID"
+// @Param       status query string false "Filter by status: Pending, Delivered, or DeadLettered"
+// @Param       limit  query int false "Page size, at most 100" default(20)
+// @Success     200 {array} controllers.WebhookDeliveryResponse
+// @Failure     400 "Invalid status or limit."
+// @Failure     404 "Subscription not found."
+// @Security    BearerAuth
+// @Router      /webhooks/{subscriptionID}/deliveries [get]
+func (wc *WebhookSubscriptionsController) ListDeliveries(c *fiber.Ctx) error {
+	sub, err := wc.developerSubscription(c)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", defaultWebhookDeliveriesLimit)
+	if limit <= 0 || limit > maxWebhookDeliveriesLimit {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", maxWebhookDeliveriesLimit))
+	}
+
+	mods := []qm.QueryMod{
+		models.WebhookDeliveryWhere.SubscriptionID.EQ(sub.ID),
+		qm.OrderBy(models.WebhookDeliveryColumns.CreatedAt + " DESC"),
+		qm.Limit(limit),
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !slices.Contains(models.AllWebhookDeliveryStatus(), status) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized status %q.", status))
+		}
+		mods = append(mods, models.WebhookDeliveryWhere.Status.EQ(status))
+	}
+
+	dls, err := models.WebhookDeliveries(mods...).All(c.Context(), wc.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	out := make([]WebhookDeliveryResponse, len(dls))
+	for i, d := range dls {
+		out[i] = webhookDeliveryToAPI(d)
+	}
+
+	return c.JSON(out)
+}
+
+// ReplayDelivery godoc
+// @Summary     Replay a webhook delivery
+// @Description Queues a delivery to be sent again with a fresh set of retries. Use this to recover dead-lettered deliveries once the endpoint is fixed.
+// @Tags        webhooks
+// @Produce     json
+// @Param       subscriptionID path string true "Subscription ID"
+// @Param       deliveryID     path string true "Delivery ID"
+// @Success     200 {object} controllers.WebhookDeliveryResponse
+// @Failure     404 "Subscription or delivery not found."
+// @Security    BearerAuth
+// @Router      /webhooks/{subscriptionID}/deliveries/{deliveryID}/replay [post]
+func (wc *WebhookSubscriptionsController) ReplayDelivery(c *fiber.Ctx) error {
+	sub, err := wc.developerSubscription(c)
+	if err != nil {
+		return err
+	}
+
+	dl, err := services.ReplayWebhookDelivery(c.Context(), wc.dbs, sub.ID, c.Params("deliveryID"))
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookDeliveryNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Delivery not found.")
+		}
+		return err
+	}
+
+	return c.JSON(webhookDeliveryToAPI(dl))
+}
+
+// developerSubscription loads the subscription in the path, making sure it belongs to the caller.
+func (wc *WebhookSubscriptionsController) developerSubscription(c *fiber.Ctx) (*models.WebhookSubscription, error) {
+	userAddr := address.Get(c)
+
+	sub, err := models.WebhookSubscriptions(
+		models.WebhookSubscriptionWhere.ID.EQ(c.Params("subscriptionID")),
+		models.WebhookSubscriptionWhere.DeveloperAddress.EQ(userAddr.Bytes()),
+	).One(c.Context(), wc.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Subscription not found.")
+		}
+		return nil, err
+	}
+
+	return sub, nil
+}
@@ -4,7 +4,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"strings"
+	"errors"
 
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/models"
@@ -75,11 +75,15 @@ func (wc *WebhooksController) ProcessCommand(c *fiber.Ctx) error {
 
 	autopiJob, err := wc.autoPiSvc.UpdateJob(c.Context(), apwJID.String(), apwState.String(), cmdResult)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidAutoPiJobState) {
+			logger.Warn().Err(err).Msg("ignoring autopi webhook with invalid job state")
+			return c.SendStatus(fiber.StatusNoContent)
+		}
 		logger.Err(err).Msg("error updating autopi job")
 		return c.SendStatus(fiber.StatusNoContent)
 	}
 	// if we can link the autopi job to a device, it could be a job related to an integration registration sync command
-	if !autopiJob.UserDeviceID.IsZero() && autopiJob.Command == "state.sls pending" && strings.EqualFold(apwState.String(), "COMMAND_EXECUTED") {
+	if !autopiJob.UserDeviceID.IsZero() && autopiJob.Command == services.AutoPiSyncCommand && autopiJob.State == services.AutoPiJobStateExecuted {
 		autoPiInteg, err := wc.deviceDefIntSvc.GetAutoPiIntegration(c.Context())
 		if err != nil {
 			logger.Err(err).Msg("could not create or get autopi integration record")
@@ -2,6 +2,9 @@ package controllers
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -238,3 +241,33 @@ func (s *WebHooksControllerTestSuite) TestPostWebhookRawCommand() {
 	assert.Equal(s.T(), "123", cmdResult.Value)
 	assert.Equal(s.T(), "vin", cmdResult.Type)
 }
+
+func (s *WebHooksControllerTestSuite) TestPostWebhookInvalidState() {
+	// arrange
+	ddDefIntSvc := mock_services.NewMockDeviceDefinitionIntegrationService(s.mockCtrl)
+	autoAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
+
+	token := "BobbyHarry"
+	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc)
+	app := fiber.New()
+	app.Post(constants.AutoPiWebhookPath, c.ProcessCommand)
+
+	autoPiJobID := "AD111"
+	autoPiDeviceID := "123123"
+
+	// the job has already executed, so a late failure must not be applied, and nothing else should be called
+	autoAPISvc.EXPECT().UpdateJob(gomock.Any(), autoPiJobID, "COMMAND_FAILED", gomock.Any()).
+		Return(nil, fmt.Errorf("%w: can't go from COMMAND_EXECUTED to COMMAND_FAILED", services.ErrInvalidAutoPiJobState))
+
+	// act
+	webhookJSON := fmt.Sprintf(`{"jid": "%s","state": "COMMAND_FAILED","success": false,"device_id": "%s"}`, autoPiJobID, autoPiDeviceID)
+
+	request := test.BuildRequest("POST", constants.AutoPiWebhookPath, webhookJSON)
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(webhookJSON))
+	request.Header.Set("X-Request-Signature", hex.EncodeToString(mac.Sum(nil)))
+	response, _ := app.Test(request)
+
+	// assert
+	assert.Equal(s.T(), 204, response.StatusCode)
+}
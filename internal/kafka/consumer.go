@@ -55,3 +55,14 @@ func (c *Consumer) Start(ctx context.Context, process func(messages <-chan *mess
 	}
 	go process(messages)
 }
+
+// StartRetries is like Start, but reads the topic's retry topic, where dead letters are
+// re-driven to. It should be given the same handler.
+func (c *Consumer) StartRetries(ctx context.Context, process func(messages <-chan *message.Message)) {
+	topic := RetryTopic(c.topic)
+	messages, err := c.subscriber.Subscribe(ctx, topic)
+	if err != nil {
+		c.logger.Fatal().Err(err).Msgf("could not subscribe to topic: %s", topic)
+	}
+	go process(messages)
+}
@@ -0,0 +1,348 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	wm_kafka "github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+// DeadLetterSuffix is appended to a topic's name to get the topic its failed messages go to.
+const DeadLetterSuffix = ".dlq"
+
+// RetrySuffix is appended to a topic's name to get the topic that dead letters are re-driven
+// to. Only devices-api reads it, so other consumers of the original topic don't see the
+// messages a second time.
+const RetrySuffix = ".devices-api.retry"
+
+// Headers added to dead-lettered messages. The original headers are kept as well.
+const (
+	HeaderDeadLetterError     = "dlq-error"
+	HeaderDeadLetterTopic     = "dlq-topic"
+	HeaderDeadLetterPartition = "dlq-partition"
+	HeaderDeadLetterOffset    = "dlq-offset"
+	HeaderDeadLetterAttempts  = "dlq-attempts"
+	HeaderDeadLetterTime      = "dlq-time"
+)
+
+var (
+	retryCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devices_api",
+			Subsystem: "kafka_consumer",
+			Name:      "retries_total",
+			Help:      "Messages whose handler failed and were tried again.",
+		},
+		[]string{"topic"},
+	)
+	deadLetterCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devices_api",
+			Subsystem: "kafka_consumer",
+			Name:      "dead_lettered_total",
+			Help:      "Messages given up on and published to a dead-letter topic.",
+		},
+		[]string{"topic"},
+	)
+)
+
+// DeadLetterTopic returns the name of the dead-letter topic for the given topic.
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// RetryTopic returns the name of the topic that the given topic's dead letters are re-driven to.
+func RetryTopic(topic string) string {
+	return topic + RetrySuffix
+}
+
+// OriginalTopic returns the topic that a retry topic stands in for. Other topics are returned
+// as they are.
+func OriginalTopic(topic string) string {
+	return strings.TrimSuffix(topic, RetrySuffix)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error as one that retrying won't fix, like a payload that doesn't parse.
+// The message goes straight to the dead-letter topic.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the error, or anything it wraps, was marked with Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// RetryPolicy controls how a failing message is retried before it's dead-lettered.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times the handler is run, including the first.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles each time after that.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy gives a message about a minute to go through.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    6,
+	InitialBackoff: 2 * time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// Message is the part of a Kafka record that the retry handler needs, whichever client
+// delivered it.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []sarama.RecordHeader
+}
+
+// RetryHandler runs message handlers with retries and publishes the messages that still fail
+// to the topic's dead-letter topic, so that consumers can commit and move on without losing them.
+type RetryHandler struct {
+	producer sarama.SyncProducer
+	policy   RetryPolicy
+	logger   *zerolog.Logger
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryHandler creates a RetryHandler that dead-letters with the given producer.
+func NewRetryHandler(producer sarama.SyncProducer, policy RetryPolicy, logger *zerolog.Logger) *RetryHandler {
+	return &RetryHandler{producer: producer, policy: policy, logger: logger, sleep: sleepContext}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Handle runs handle until it succeeds, returns a Permanent error or runs out of attempts. In
+// the last two cases the message is dead-lettered. A non-nil return means the message was
+// neither handled nor dead-lettered, because the context ended or the publish failed, and must
+// not be committed.
+func (r *RetryHandler) Handle(ctx context.Context, msg *Message, handle func(ctx context.Context) error) error {
+	logger := r.logger.With().Str("topic", msg.Topic).Int32("partition", msg.Partition).Int64("offset", msg.Offset).Logger()
+
+	var err error
+	attempt := 1
+	for {
+		err = handle(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if IsPermanent(err) || attempt >= r.policy.MaxAttempts {
+			break
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		wait := r.policy.backoff(attempt)
+		logger.Warn().Err(err).Int("attempt", attempt).Dur("backoff", wait).Msg("Failed to handle message, retrying.")
+		retryCount.WithLabelValues(msg.Topic).Inc()
+
+		if err := r.sleep(ctx, wait); err != nil {
+			return err
+		}
+		attempt++
+	}
+
+	logger.Err(err).Int("attempts", attempt).Msg("Giving up on message, sending it to the dead-letter topic.")
+
+	if dlErr := r.deadLetter(msg, err, attempt); dlErr != nil {
+		return fmt.Errorf("failed to dead-letter message after %w: %w", err, dlErr)
+	}
+
+	return nil
+}
+
+// deadLetter publishes the message to the dead-letter topic of the topic it originally came
+// from, so that a message that fails again after a re-drive ends up back in the same place.
+func (r *RetryHandler) deadLetter(msg *Message, cause error, attempts int) error {
+	topic := OriginalTopic(msg.Topic)
+
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+6)
+	for _, h := range msg.Headers {
+		if !isDeadLetterHeader(string(h.Key)) {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterError), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterTopic), Value: []byte(topic)},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterAttempts), Value: []byte(strconv.Itoa(attempts))},
+		sarama.RecordHeader{Key: []byte(HeaderDeadLetterTime), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	pm := &sarama.ProducerMessage{
+		Topic:   DeadLetterTopic(topic),
+		Headers: headers,
+	}
+	// Keep nil keys and values nil, so that tombstones stay tombstones.
+	if msg.Key != nil {
+		pm.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		pm.Value = sarama.ByteEncoder(msg.Value)
+	}
+
+	if _, _, err := r.producer.SendMessage(pm); err != nil {
+		return err
+	}
+
+	deadLetterCount.WithLabelValues(topic).Inc()
+	return nil
+}
+
+func isDeadLetterHeader(key string) bool {
+	switch key {
+	case HeaderDeadLetterError, HeaderDeadLetterTopic, HeaderDeadLetterPartition, HeaderDeadLetterOffset, HeaderDeadLetterAttempts, HeaderDeadLetterTime:
+		return true
+	default:
+		return false
+	}
+}
+
+// StripDeadLetterHeaders returns the headers without the ones added when dead-lettering.
+func StripDeadLetterHeaders(headers []*sarama.RecordHeader) []sarama.RecordHeader {
+	out := make([]sarama.RecordHeader, 0, len(headers))
+	for _, h := range headers {
+		if !isDeadLetterHeader(string(h.Key)) {
+			out = append(out, *h)
+		}
+	}
+	return out
+}
+
+// ConsumerGroupHandler wraps handle in a sarama.ConsumerGroupHandler. Messages are marked only
+// once handled or dead-lettered; if neither is possible, the session ends and the message is
+// delivered again.
+func (r *RetryHandler) ConsumerGroupHandler(handle func(ctx context.Context, msg *sarama.ConsumerMessage) error) sarama.ConsumerGroupHandler {
+	return &retryGroupHandler{retry: r, handle: handle}
+}
+
+type retryGroupHandler struct {
+	retry  *RetryHandler
+	handle func(ctx context.Context, msg *sarama.ConsumerMessage) error
+}
+
+func (h *retryGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *retryGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *retryGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case cm, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			msg := &Message{
+				Topic:     cm.Topic,
+				Partition: cm.Partition,
+				Offset:    cm.Offset,
+				Key:       cm.Key,
+				Value:     cm.Value,
+				Headers:   StripDeadLetterHeaders(cm.Headers),
+			}
+
+			err := h.retry.Handle(session.Context(), msg, func(ctx context.Context) error {
+				return h.handle(ctx, cm)
+			})
+			if err != nil {
+				return err
+			}
+
+			session.MarkMessage(cm, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Watermill wraps handle for use with Consumer.Start. Messages are acked once handled or
+// dead-lettered, and nacked, so that they are delivered again, otherwise.
+func (r *RetryHandler) Watermill(topic string, handle func(msg *message.Message) error) func(messages <-chan *message.Message) {
+	return func(messages <-chan *message.Message) {
+		for wm := range messages {
+			ctx := wm.Context()
+
+			msg := &Message{
+				Topic:   topic,
+				Value:   wm.Payload,
+				Headers: []sarama.RecordHeader{{Key: []byte(wm_kafka.UUIDHeaderKey), Value: []byte(wm.UUID)}},
+			}
+			if p, ok := wm_kafka.MessagePartitionFromCtx(ctx); ok {
+				msg.Partition = p
+			}
+			if o, ok := wm_kafka.MessagePartitionOffsetFromCtx(ctx); ok {
+				msg.Offset = o
+			}
+			if k, ok := wm_kafka.MessageKeyFromCtx(ctx); ok {
+				msg.Key = k
+			}
+			for k, v := range wm.Metadata {
+				msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+			}
+
+			if err := r.Handle(ctx, msg, func(context.Context) error { return handle(wm) }); err != nil {
+				r.logger.Err(err).Str("topic", topic).Msg("Failed to handle or dead-letter message, will redeliver.")
+				wm.Nack()
+				continue
+			}
+
+			wm.Ack()
+		}
+	}
+}
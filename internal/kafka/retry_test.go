@@ -0,0 +1,162 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryHandler(t *testing.T, producer sarama.SyncProducer) (*RetryHandler, *[]time.Duration) {
+	logger := zerolog.Nop()
+	r := NewRetryHandler(producer, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: 90 * time.Second}, &logger)
+
+	var waits []time.Duration
+	r.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { _ = producer.Close() })
+
+	return r, &waits
+}
+
+func TestRetryHandlerSucceedsAfterRetry(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	r, waits := newTestRetryHandler(t, producer)
+
+	calls := 0
+	err := r.Handle(context.Background(), &Message{Topic: "topic.task.status"}, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database down")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{time.Second}, *waits)
+}
+
+func TestRetryHandlerDeadLetters(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+
+	var sent *sarama.ProducerMessage
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(pm *sarama.ProducerMessage) error {
+		sent = pm
+		return nil
+	})
+
+	r, waits := newTestRetryHandler(t, producer)
+
+	msg := &Message{
+		Topic:     "topic.task.status",
+		Partition: 2,
+		Offset:    40,
+		Key:       []byte("key"),
+		Value:     []byte(`{"id": "x"}`),
+		Headers:   []sarama.RecordHeader{{Key: []byte("trace"), Value: []byte("abc")}},
+	}
+
+	calls := 0
+	err := r.Handle(context.Background(), msg, func(context.Context) error {
+		calls++
+		return errors.New("database down")
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *waits)
+
+	require.NotNil(t, sent)
+	assert.Equal(t, "topic.task.status.dlq", sent.Topic)
+
+	key, _ := sent.Key.Encode()
+	value, _ := sent.Value.Encode()
+	assert.Equal(t, msg.Key, key)
+	assert.Equal(t, msg.Value, value)
+
+	headers := make(map[string]string)
+	for _, h := range sent.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	assert.Equal(t, "abc", headers["trace"])
+	assert.Equal(t, "database down", headers[HeaderDeadLetterError])
+	assert.Equal(t, "topic.task.status", headers[HeaderDeadLetterTopic])
+	assert.Equal(t, "2", headers[HeaderDeadLetterPartition])
+	assert.Equal(t, "40", headers[HeaderDeadLetterOffset])
+	assert.Equal(t, "3", headers[HeaderDeadLetterAttempts])
+}
+
+func TestRetryHandlerPermanentSkipsRetries(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndSucceed()
+
+	r, waits := newTestRetryHandler(t, producer)
+
+	calls := 0
+	err := r.Handle(context.Background(), &Message{Topic: "table.task.credential"}, func(context.Context) error {
+		calls++
+		return Permanent(errors.New("bad payload"))
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *waits)
+}
+
+func TestRetryHandlerDeadLetterFailure(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+	r, _ := newTestRetryHandler(t, producer)
+
+	err := r.Handle(context.Background(), &Message{Topic: "topic.task.status"}, func(context.Context) error {
+		return Permanent(errors.New("bad payload"))
+	})
+	require.ErrorIs(t, err, sarama.ErrOutOfBrokers)
+}
+
+func TestRetryHandlerRetryTopicDeadLettersToOriginal(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+
+	var sent *sarama.ProducerMessage
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(pm *sarama.ProducerMessage) error {
+		sent = pm
+		return nil
+	})
+
+	r, _ := newTestRetryHandler(t, producer)
+
+	assert.Equal(t, "topic.task.status.devices-api.retry", RetryTopic("topic.task.status"))
+
+	err := r.Handle(context.Background(), &Message{Topic: RetryTopic("topic.task.status")}, func(context.Context) error {
+		return Permanent(errors.New("still broken"))
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, sent)
+	assert.Equal(t, "topic.task.status.dlq", sent.Topic)
+	for _, h := range sent.Headers {
+		if string(h.Key) == HeaderDeadLetterTopic {
+			assert.Equal(t, "topic.task.status", string(h.Value))
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 4*time.Second, p.backoff(3))
+	assert.Equal(t, 5*time.Second, p.backoff(4))
+	assert.Equal(t, 5*time.Second, p.backoff(10))
+}
@@ -3,15 +3,18 @@ package owner
 import (
 	"database/sql"
 	"errors"
+	"math/big"
 	"strings"
 
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/utils"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
@@ -67,62 +70,139 @@ func UserDevice(dbs db.Store, logger *zerolog.Logger) fiber.Handler {
 // For the middleware to allow the request to proceed:
 //
 //   - The request must have a valid JWT, identifying a user.
-//   - There must be a serial path parameter, and that autopi must exist (serial = unitID).
+//   - There must be a serial, tokenID or address path parameter, and that autopi must exist
+//     (serial = unitID, address = the device's ethereum address).
 //   - Either the device has not been paired on chain (anyone can access the endpoint) or
 //     the user has an address on file that is either the owner of the AftermarketDevice or the owner
 //     of the paired vehicle.
+//
+// The device's serial is stored in the "serial" local, whichever parameter found it.
 func AftermarketDevice(dbs db.Store, logger *zerolog.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userID := helpers.GetUserID(c)
-
-		serial := c.Params("serial")
-		serial = strings.TrimSpace(serial)
-		if len(serial) == 36 {
-			// The lowercasing here is really just for AutoPi's UUIDs.
-			serial = strings.ToLower(serial)
-		}
-
-		logger := logger.With().Str("userId", userID).Str("serial", serial).Logger()
-		c.Locals("userID", userID)
-		c.Locals("serial", serial)
-		c.Locals("logger", &logger)
-
-		aftermarketDevice, err := models.AftermarketDevices(models.AftermarketDeviceWhere.Serial.EQ(serial)).One(c.Context(), dbs.DBS().Reader)
+		aftermarketDevice, err := loadAftermarketDevice(c, dbs, logger)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return fiber.NewError(fiber.StatusNotFound, "AftermarketDevice not minted, or serial is invalid.")
-			}
 			return err
 		}
 
 		// If token_id is null, device is not paired.
-		// Also short-circuit the address checks if user is the "web2 owner".
-		if aftermarketDevice.VehicleTokenID.IsZero() || aftermarketDevice.UserID.Valid && aftermarketDevice.UserID.String == userID {
+		if aftermarketDevice.VehicleTokenID.IsZero() {
 			return c.Next()
 		}
 
-		userAddr, err := helpers.GetJWTEthAddr(c)
+		owns, err := ownsAftermarketDevice(c, dbs, aftermarketDevice)
 		if err != nil {
 			return err
 		}
+		if !owns {
+			return fiber.NewError(fiber.StatusForbidden, "user is not owner of paired vehicle or AftermarketDevice")
+		}
 
-		apOwner := common.BytesToAddress(aftermarketDevice.OwnerAddress.Bytes)
+		return c.Next()
+	}
+}
 
-		if userAddr == apOwner {
-			return c.Next()
+// AftermarketDeviceOwner is like AftermarketDevice, but lets the request through only if the
+// user owns the device or the vehicle it's paired to, paired or not. Use it for routes that
+// show the device's details. Everyone else gets a 404, as if the device didn't exist.
+func AftermarketDeviceOwner(dbs db.Store, logger *zerolog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// A missing device and someone else's look the same, so that the response doesn't give
+		// away which devices exist.
+		notFound := fiber.NewError(fiber.StatusNotFound, "No AftermarketDevice found.")
+
+		aftermarketDevice, err := loadAftermarketDevice(c, dbs, logger)
+		if err != nil {
+			var ferr *fiber.Error
+			if errors.As(err, &ferr) && ferr.Code == fiber.StatusNotFound {
+				return notFound
+			}
+			return err
 		}
 
-		ownsVehicle, err := models.UserDevices(
-			models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(aftermarketDevice.VehicleTokenID.Big)),
-			models.UserDeviceWhere.OwnerAddress.EQ(null.BytesFrom(userAddr.Bytes())),
-		).Exists(c.Context(), dbs.DBS().Reader)
+		owns, err := ownsAftermarketDevice(c, dbs, aftermarketDevice)
 		if err != nil {
 			return err
 		}
-		if !ownsVehicle {
-			return fiber.NewError(fiber.StatusForbidden, "user is not owner of paired vehicle or AftermarketDevice")
+		if !owns {
+			return notFound
 		}
 
 		return c.Next()
 	}
 }
+
+// loadAftermarketDevice finds the device named by the serial, tokenID or address path parameter
+// and sets the userID, serial and logger locals.
+func loadAftermarketDevice(c *fiber.Ctx, dbs db.Store, logger *zerolog.Logger) (*models.AftermarketDevice, error) {
+	userID := helpers.GetUserID(c)
+
+	var where qm.QueryMod
+	notFound := fiber.NewError(fiber.StatusNotFound, "AftermarketDevice not minted, or serial is invalid.")
+
+	if tis := c.Params("tokenID"); tis != "" {
+		ti, ok := new(big.Int).SetString(tis, 10)
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse token id.")
+		}
+		where = models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(ti))
+		notFound = fiber.NewError(fiber.StatusNotFound, "No AftermarketDevice with that token id.")
+	} else if as := c.Params("address"); as != "" {
+		if !common.IsHexAddress(as) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse ethereum address.")
+		}
+		where = models.AftermarketDeviceWhere.EthereumAddress.EQ(common.HexToAddress(as).Bytes())
+		notFound = fiber.NewError(fiber.StatusNotFound, "No AftermarketDevice with that address.")
+	} else {
+		serial := c.Params("serial")
+		serial = strings.TrimSpace(serial)
+		if len(serial) == 36 {
+			// The lowercasing here is really just for AutoPi's UUIDs.
+			serial = strings.ToLower(serial)
+		}
+		where = models.AftermarketDeviceWhere.Serial.EQ(serial)
+	}
+
+	aftermarketDevice, err := models.AftermarketDevices(where).One(c.Context(), dbs.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+
+	serial := aftermarketDevice.Serial
+
+	devLogger := logger.With().Str("userId", userID).Str("serial", serial).Logger()
+	c.Locals("userID", userID)
+	c.Locals("serial", serial)
+	c.Locals("logger", &devLogger)
+
+	return aftermarketDevice, nil
+}
+
+// ownsAftermarketDevice reports whether the user is the device's "web2 owner", or has an
+// address that owns either the device or the vehicle it's paired to.
+func ownsAftermarketDevice(c *fiber.Ctx, dbs db.Store, aftermarketDevice *models.AftermarketDevice) (bool, error) {
+	// Short-circuit the address checks if user is the "web2 owner".
+	if aftermarketDevice.UserID.Valid && aftermarketDevice.UserID.String == helpers.GetUserID(c) {
+		return true, nil
+	}
+
+	userAddr, err := helpers.GetJWTEthAddr(c)
+	if err != nil {
+		return false, err
+	}
+
+	if aftermarketDevice.OwnerAddress.Valid && common.BytesToAddress(aftermarketDevice.OwnerAddress.Bytes) == userAddr {
+		return true, nil
+	}
+
+	if aftermarketDevice.VehicleTokenID.IsZero() {
+		return false, nil
+	}
+
+	return models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(aftermarketDevice.VehicleTokenID.Big)),
+		models.UserDeviceWhere.OwnerAddress.EQ(null.BytesFrom(userAddr.Bytes())),
+	).Exists(c.Context(), dbs.DBS().Reader)
+}
@@ -2,12 +2,19 @@ package rpc
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
+	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/models"
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -22,9 +29,16 @@ type aftermarketDeviceService struct {
 	logger *zerolog.Logger
 }
 
+// loadAftermarketDeviceRequests loads the meta-transactions whose status goes in the response.
+var loadAftermarketDeviceRequests = []qm.QueryMod{
+	qm.Load(models.AftermarketDeviceRels.ClaimMetaTransactionRequest),
+	qm.Load(models.AftermarketDeviceRels.PairRequest),
+	qm.Load(models.AftermarketDeviceRels.UnpairRequest),
+}
+
 func (s *aftermarketDeviceService) ListAftermarketDevicesForUser(ctx context.Context, req *pb.ListAftermarketDevicesForUserRequest) (*pb.ListAftermarketDevicesForUserResponse, error) {
 	units, err := models.AftermarketDevices(
-		models.AftermarketDeviceWhere.UserID.EQ(null.StringFrom(req.UserId)),
+		append([]qm.QueryMod{models.AftermarketDeviceWhere.UserID.EQ(null.StringFrom(req.UserId))}, loadAftermarketDeviceRequests...)...,
 	).All(ctx, s.dbs().Reader)
 	if err != nil {
 		s.logger.Err(err).Str("userId", req.UserId).Str("method", "ListAftermarketDevicesForUser").Msg("Database failure.")
@@ -34,23 +48,123 @@ func (s *aftermarketDeviceService) ListAftermarketDevicesForUser(ctx context.Con
 	out := make([]*pb.AftermarketDevice, len(units))
 
 	for i, unit := range units {
-		out[i] = &pb.AftermarketDevice{
-			Serial:              unit.Serial,
-			UserId:              &req.UserId,
-			TokenId:             decimalToUint(unit.TokenID),
-			ManufacturerTokenId: decimalToUint(unit.DeviceManufacturerTokenID),
+		out[i] = s.aftermarketDeviceToAPI(unit)
+	}
+
+	return &pb.ListAftermarketDevicesForUserResponse{AftermarketDevices: out}, nil
+}
+
+func (s *aftermarketDeviceService) ListAftermarketDevicesForOwner(ctx context.Context, req *pb.ListAftermarketDevicesForOwnerRequest) (*pb.ListAftermarketDevicesForOwnerResponse, error) {
+	if len(req.OwnerAddress) != common.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "Owner address must be 20 bytes.")
+	}
+
+	units, err := models.AftermarketDevices(
+		append([]qm.QueryMod{
+			models.AftermarketDeviceWhere.OwnerAddress.EQ(null.BytesFrom(req.OwnerAddress)),
+			qm.OrderBy(models.AftermarketDeviceColumns.TokenID),
+		}, loadAftermarketDeviceRequests...)...,
+	).All(ctx, s.dbs().Reader)
+	if err != nil {
+		s.logger.Err(err).Str("owner", common.BytesToAddress(req.OwnerAddress).Hex()).Str("method", "ListAftermarketDevicesForOwner").Msg("Database failure.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	out := make([]*pb.AftermarketDevice, len(units))
+
+	for i, unit := range units {
+		out[i] = s.aftermarketDeviceToAPI(unit)
+	}
+
+	return &pb.ListAftermarketDevicesForOwnerResponse{AftermarketDevices: out}, nil
+}
+
+func (s *aftermarketDeviceService) GetAftermarketDevice(ctx context.Context, req *pb.GetAftermarketDeviceRequest) (*pb.AftermarketDevice, error) {
+	var where qm.QueryMod
+
+	switch lookup := req.Lookup.(type) {
+	case *pb.GetAftermarketDeviceRequest_Serial:
+		where = models.AftermarketDeviceWhere.Serial.EQ(lookup.Serial)
+	case *pb.GetAftermarketDeviceRequest_TokenId:
+		where = models.AftermarketDeviceWhere.TokenID.EQ(types.NewDecimal(new(decimal.Big).SetUint64(lookup.TokenId)))
+	case *pb.GetAftermarketDeviceRequest_EthereumAddress:
+		if len(lookup.EthereumAddress) != common.AddressLength {
+			return nil, status.Error(codes.InvalidArgument, "Ethereum address must be 20 bytes.")
+		}
+		where = models.AftermarketDeviceWhere.EthereumAddress.EQ(lookup.EthereumAddress)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "One of serial, token id or ethereum address is required.")
+	}
+
+	unit, err := models.AftermarketDevices(append([]qm.QueryMod{where}, loadAftermarketDeviceRequests...)...).One(ctx, s.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No aftermarket device found.")
 		}
+		s.logger.Err(err).Str("method", "GetAftermarketDevice").Msg("Database failure.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
 
-		if unit.OwnerAddress.Valid {
-			out[i].OwnerAddress = unit.OwnerAddress.Bytes
+	return s.aftermarketDeviceToAPI(unit), nil
+}
+
+func (s *aftermarketDeviceService) aftermarketDeviceToAPI(unit *models.AftermarketDevice) *pb.AftermarketDevice {
+	out := &pb.AftermarketDevice{
+		Serial:              unit.Serial,
+		UserId:              unit.UserID.Ptr(),
+		TokenId:             decimalToUint(unit.TokenID),
+		ManufacturerTokenId: decimalToUint(unit.DeviceManufacturerTokenID),
+		EthereumAddress:     unit.EthereumAddress,
+	}
 
-			if unit.Beneficiary.Valid {
-				out[i].Beneficiary = unit.Beneficiary.Bytes
-			} else {
-				out[i].Beneficiary = unit.OwnerAddress.Bytes
-			}
+	if unit.OwnerAddress.Valid {
+		out.OwnerAddress = unit.OwnerAddress.Bytes
+
+		if unit.Beneficiary.Valid {
+			out.Beneficiary = unit.Beneficiary.Bytes
+		} else {
+			out.Beneficiary = unit.OwnerAddress.Bytes
 		}
 	}
 
-	return &pb.ListAftermarketDevicesForUserResponse{AftermarketDevices: out}, nil
+	if !unit.VehicleTokenID.IsZero() {
+		if vtID, ok := unit.VehicleTokenID.Uint64(); ok {
+			out.VehicleTokenId = &vtID
+		}
+	}
+
+	if unit.R != nil {
+		out.ClaimRequest = aftermarketTransactionToAPI(unit.R.ClaimMetaTransactionRequest)
+		out.PairRequest = aftermarketTransactionToAPI(unit.R.PairRequest)
+		out.UnpairRequest = aftermarketTransactionToAPI(unit.R.UnpairRequest)
+	}
+
+	if unit.Metadata.Valid {
+		md := new(services.AftermarketDeviceMetadata)
+		if err := unit.Metadata.Unmarshal(md); err != nil {
+			s.logger.Err(err).Str("serial", unit.Serial).Msg("Couldn't parse aftermarket device metadata.")
+		} else {
+			out.Attributes = md.Attributes
+		}
+	}
+
+	return out
+}
+
+func aftermarketTransactionToAPI(mtr *models.MetaTransactionRequest) *pb.AftermarketDeviceTransaction {
+	if mtr == nil {
+		return nil
+	}
+
+	out := &pb.AftermarketDeviceTransaction{
+		Id:            mtr.ID,
+		Status:        mtr.Status,
+		FailureReason: mtr.FailureReason.Ptr(),
+	}
+
+	if mtr.Hash.Valid {
+		out.Hash = mtr.Hash.Bytes
+	}
+
+	return out
 }
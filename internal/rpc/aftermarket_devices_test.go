@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	pb_devices "github.com/DIMO-Network/devices-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAftermarketDevice(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	s := NewAftermarketDeviceService(pdb.DBS, &logger)
+
+	owner := common.HexToAddress("0x1")
+	deviceAddr := common.HexToAddress("0xd1")
+
+	claim := models.MetaTransactionRequest{
+		ID:            "claim1",
+		Status:        models.MetaTransactionRequestStatusFailed,
+		Hash:          null.BytesFrom(common.HexToHash("0xabc").Bytes()),
+		FailureReason: null.StringFrom("Device already claimed."),
+	}
+	require.NoError(t, claim.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	md := null.JSON{}
+	require.NoError(t, md.Marshal(services.AftermarketDeviceMetadata{Attributes: map[string]string{"HardwareRevision": "7.2"}}))
+
+	unit := models.AftermarketDevice{
+		Serial:                        "macaron1",
+		EthereumAddress:               deviceAddr.Bytes(),
+		TokenID:                       utils.BigToDecimal(big.NewInt(42)),
+		DeviceManufacturerTokenID:     utils.BigToDecimal(big.NewInt(142)),
+		OwnerAddress:                  null.BytesFrom(owner.Bytes()),
+		ClaimMetaTransactionRequestID: null.StringFrom(claim.ID),
+		Metadata:                      md,
+	}
+	require.NoError(t, unit.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	for _, req := range []*pb_devices.GetAftermarketDeviceRequest{
+		{Lookup: &pb_devices.GetAftermarketDeviceRequest_Serial{Serial: "macaron1"}},
+		{Lookup: &pb_devices.GetAftermarketDeviceRequest_TokenId{TokenId: 42}},
+		{Lookup: &pb_devices.GetAftermarketDeviceRequest_EthereumAddress{EthereumAddress: deviceAddr.Bytes()}},
+	} {
+		ad, err := s.GetAftermarketDevice(ctx, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "macaron1", ad.Serial)
+		assert.EqualValues(t, 42, ad.TokenId)
+		assert.EqualValues(t, 142, ad.ManufacturerTokenId)
+		assert.Equal(t, deviceAddr.Bytes(), ad.EthereumAddress)
+		assert.Equal(t, owner.Bytes(), ad.OwnerAddress)
+		// With no beneficiary set, rewards go to the owner.
+		assert.Equal(t, owner.Bytes(), ad.Beneficiary)
+		assert.Nil(t, ad.VehicleTokenId)
+		require.NotNil(t, ad.ClaimRequest)
+		assert.Equal(t, models.MetaTransactionRequestStatusFailed, ad.ClaimRequest.Status)
+		assert.Equal(t, "Device already claimed.", ad.ClaimRequest.GetFailureReason())
+		assert.Nil(t, ad.PairRequest)
+		assert.Equal(t, map[string]string{"HardwareRevision": "7.2"}, ad.Attributes)
+	}
+
+	_, err := s.GetAftermarketDevice(ctx, &pb_devices.GetAftermarketDeviceRequest{Lookup: &pb_devices.GetAftermarketDeviceRequest_TokenId{TokenId: 43}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.GetAftermarketDevice(ctx, &pb_devices.GetAftermarketDeviceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := s.ListAftermarketDevicesForOwner(ctx, &pb_devices.ListAftermarketDevicesForOwnerRequest{OwnerAddress: owner.Bytes()})
+	require.NoError(t, err)
+	require.Len(t, list.AftermarketDevices, 1)
+	assert.Equal(t, "macaron1", list.AftermarketDevices[0].Serial)
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *userDeviceRPCServer) CreateMintBatch(ctx context.Context, req *pb.CreateMintBatchRequest) (*pb.MintBatch, error) {
+	if len(req.OwnerAddress) != common.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "Owner address must be 20 bytes.")
+	}
+
+	vehicles := make([]registry.MintBatchVehicle, len(req.Vehicles))
+	for i, v := range req.Vehicles {
+		vehicles[i] = registry.MintBatchVehicle{VIN: v.Vin, DefinitionID: v.DefinitionId, CountryCode: v.CountryCode}
+	}
+
+	batch, err := s.mintBatcher.CreateBatch(ctx, common.BytesToAddress(req.OwnerAddress), "", vehicles)
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		var verr *registry.MintBatchValidationError
+		if errors.As(err, &verr) {
+			reasons := make([]string, len(verr.Vehicles))
+			for i, v := range verr.Vehicles {
+				reasons[i] = fmt.Sprintf("vehicle %d (%s): %s", v.Index, v.VIN, v.Reason)
+			}
+			return nil, status.Errorf(codes.InvalidArgument, "No vehicles were created. %s", strings.Join(reasons, "; "))
+		}
+
+		return nil, err
+	}
+
+	return mintBatchToAPI(batch)
+}
+
+func (s *userDeviceRPCServer) GetMintBatch(ctx context.Context, req *pb.GetMintBatchRequest) (*pb.MintBatch, error) {
+	batch, err := s.mintBatcher.GetBatch(ctx, req.Id, common.BytesToAddress(req.OwnerAddress))
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchNotFound) {
+			return nil, status.Error(codes.NotFound, "No batch with that id for this owner.")
+		}
+		return nil, err
+	}
+
+	return mintBatchToAPI(batch)
+}
+
+func (s *userDeviceRPCServer) SubmitMintBatchSignatures(ctx context.Context, req *pb.SubmitMintBatchSignaturesRequest) (*pb.SubmitMintBatchSignaturesResponse, error) {
+	sigs := make([]registry.MintBatchSignature, len(req.Signatures))
+	for i, sig := range req.Signatures {
+		sigs[i] = registry.MintBatchSignature{UserDeviceID: sig.UserDeviceId, Signature: sig.Signature}
+	}
+
+	results, err := s.mintBatcher.SubmitSignatures(ctx, req.Id, common.BytesToAddress(req.OwnerAddress), sigs)
+	if err != nil {
+		if errors.Is(err, registry.ErrMintBatchNotFound) {
+			return nil, status.Error(codes.NotFound, "No batch with that id for this owner.")
+		}
+		return nil, err
+	}
+
+	out := &pb.SubmitMintBatchSignaturesResponse{Results: make([]*pb.MintBatchSignatureResult, len(results))}
+	for i, r := range results {
+		out.Results[i] = &pb.MintBatchSignatureResult{UserDeviceId: r.UserDeviceID, RequestId: r.RequestID, Error: r.Error}
+	}
+
+	return out, nil
+}
+
+func mintBatchToAPI(b *registry.MintBatch) (*pb.MintBatch, error) {
+	out := &pb.MintBatch{
+		Id:           b.ID,
+		OwnerAddress: b.Owner.Bytes(),
+		CreatedAt:    timestamppb.New(b.CreatedAt),
+		Vehicles:     make([]*pb.MintBatchVehicle, len(b.Vehicles)),
+	}
+
+	for i, v := range b.Vehicles {
+		pv := &pb.MintBatchVehicle{
+			UserDeviceId:  v.UserDevice.ID,
+			Vin:           v.UserDevice.VinIdentifier.String,
+			DefinitionId:  v.UserDevice.DefinitionID,
+			RequestId:     v.Request.ID,
+			Status:        v.Request.Status,
+			FailureReason: v.Request.FailureReason.String,
+		}
+
+		if v.TypedData != nil {
+			td, err := json.Marshal(v.TypedData)
+			if err != nil {
+				return nil, err
+			}
+			pv.TypedData = string(td)
+		}
+
+		out.Vehicles[i] = pv
+	}
+
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DIMO-Network/devices-api/internal/services/autopi"
+	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *userDeviceRPCServer) CreateTemplateMigration(ctx context.Context, req *pb.CreateTemplateMigrationRequest) (*pb.TemplateMigration, error) {
+	if req.TargetTemplateId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Target template id must be positive.")
+	}
+
+	if (req.SourceTemplateId > 0) == (len(req.DeviceAddresses) > 0) {
+		return nil, status.Error(codes.InvalidArgument, "Exactly one of source template id and device addresses must be given.")
+	}
+
+	var err error
+	var id string
+	if req.SourceTemplateId > 0 {
+		if req.SourceTemplateId == req.TargetTemplateId {
+			return nil, status.Error(codes.InvalidArgument, "Source and target templates are the same.")
+		}
+		tm, cerr := s.templateMigrator.CreateFromTemplate(ctx, int(req.SourceTemplateId), int(req.TargetTemplateId))
+		if cerr == nil {
+			id = tm.ID
+		}
+		err = cerr
+	} else {
+		addrs := make([]common.Address, len(req.DeviceAddresses))
+		for i, a := range req.DeviceAddresses {
+			if len(a) != common.AddressLength {
+				return nil, status.Errorf(codes.InvalidArgument, "Device address %d is not 20 bytes.", i)
+			}
+			addrs[i] = common.BytesToAddress(a)
+		}
+		tm, cerr := s.templateMigrator.CreateFromAddresses(ctx, addrs, int(req.TargetTemplateId))
+		if cerr == nil {
+			id = tm.ID
+		}
+		err = cerr
+	}
+	if err != nil {
+		if errors.Is(err, autopi.ErrTemplateMigrationNoDevices) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, autopi.ErrTemplateMigrationProtected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		var uerr *autopi.UnknownAftermarketDevicesError
+		if errors.As(err, &uerr) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return s.templateMigrationStatus(ctx, id)
+}
+
+func (s *userDeviceRPCServer) GetTemplateMigration(ctx context.Context, req *pb.GetTemplateMigrationRequest) (*pb.TemplateMigration, error) {
+	return s.templateMigrationStatus(ctx, req.Id)
+}
+
+func (s *userDeviceRPCServer) PauseTemplateMigration(ctx context.Context, req *pb.PauseTemplateMigrationRequest) (*pb.TemplateMigration, error) {
+	if _, err := s.templateMigrator.Pause(ctx, req.Id); err != nil {
+		return nil, templateMigrationError(err)
+	}
+
+	return s.templateMigrationStatus(ctx, req.Id)
+}
+
+func (s *userDeviceRPCServer) ResumeTemplateMigration(ctx context.Context, req *pb.ResumeTemplateMigrationRequest) (*pb.TemplateMigration, error) {
+	if _, err := s.templateMigrator.Resume(ctx, req.Id); err != nil {
+		return nil, templateMigrationError(err)
+	}
+
+	return s.templateMigrationStatus(ctx, req.Id)
+}
+
+func (s *userDeviceRPCServer) templateMigrationStatus(ctx context.Context, id string) (*pb.TemplateMigration, error) {
+	st, err := s.templateMigrator.Status(ctx, id)
+	if err != nil {
+		return nil, templateMigrationError(err)
+	}
+
+	tm := st.Migration
+	out := &pb.TemplateMigration{
+		Id:               tm.ID,
+		SourceTemplateId: int64(tm.SourceTemplateID.Int),
+		TargetTemplateId: int64(tm.TargetTemplateID),
+		Status:           tm.Status,
+		Pending:          int64(st.Pending),
+		Succeeded:        int64(st.Succeeded),
+		Failed:           int64(st.Failed),
+		Failures:         make([]*pb.TemplateMigrationFailure, len(st.Failures)),
+		CreatedAt:        timestamppb.New(tm.CreatedAt),
+	}
+
+	if tm.CompletedAt.Valid {
+		out.CompletedAt = timestamppb.New(tm.CompletedAt.Time)
+	}
+
+	for i, f := range st.Failures {
+		out.Failures[i] = &pb.TemplateMigrationFailure{Serial: f.Serial, Error: f.Error.String}
+	}
+
+	return out, nil
+}
+
+func templateMigrationError(err error) error {
+	switch {
+	case errors.Is(err, autopi.ErrTemplateMigrationNotFound):
+		return status.Error(codes.NotFound, "No template migration with that id.")
+	case errors.Is(err, autopi.ErrTemplateMigrationCompleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
@@ -31,16 +31,19 @@ func NewTeslaRPCService(
 	settings *config.Settings,
 	cipher cip.Cipher,
 	teslaAPI services.TeslaFleetAPIService,
+	tokens services.TeslaTokenManager,
 	logger *zerolog.Logger,
 	producer sarama.SyncProducer,
 ) pb.TeslaServiceServer {
+	taskSvc := services.NewTeslaTaskService(settings, producer)
 	return &teslaRPCServer{
 		dbs:      dbs,
 		logger:   logger,
 		settings: settings,
 		cipher:   cipher,
 		teslaAPI: teslaAPI,
-		taskSvc:  services.NewTeslaTaskService(settings, producer),
+		taskSvc:  taskSvc,
+		tokens:   tokens,
 	}
 }
 
@@ -53,6 +56,7 @@ type teslaRPCServer struct {
 	cipher     cip.Cipher
 	teslaAPI   services.TeslaFleetAPIService
 	taskSvc    services.TeslaTaskService
+	tokens     services.TeslaTokenManager
 	partnerMu  sync.Mutex
 	partnerTok *services.TeslaAuthCodeResponse
 }
@@ -64,6 +68,18 @@ func convertBoolRef(b *bool) *wrapperspb.BoolValue {
 	return wrapperspb.Bool(*b)
 }
 
+// accessToken returns a usable access token for the integration, refreshing it if needed.
+func (s *teslaRPCServer) accessToken(ctx context.Context, udai *models.UserDeviceAPIIntegration) (string, error) {
+	token, err := s.tokens.GetAccessToken(ctx, udai)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrNoRefreshToken) {
+			return "", status.Error(codes.FailedPrecondition, "Tesla credentials have expired or been revoked.")
+		}
+		return "", err
+	}
+	return token, nil
+}
+
 func (s *teslaRPCServer) GetPollingInfo(ctx context.Context, req *pb.GetPollingInfoRequest) (*pb.GetPollingInfoResponse, error) {
 	udai, err := models.UserDeviceAPIIntegrations(
 		models.UserDeviceAPIIntegrationWhere.TaskID.EQ(null.StringFrom(req.TaskId)),
@@ -116,7 +132,7 @@ func (s *teslaRPCServer) GetFleetStatus(ctx context.Context, req *pb.GetFleetSta
 	}
 	vin := metadata.TeslaVIN
 
-	token, err := s.cipher.Decrypt(ud.R.UserDeviceAPIIntegrations[0].AccessToken.String)
+	token, err := s.accessToken(ctx, ud.R.UserDeviceAPIIntegrations[0])
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +181,7 @@ func (s *teslaRPCServer) GetFleetTelemetryConfig(ctx context.Context, req *pb.Ge
 	}
 	vin := metadata.TeslaVIN
 
-	token, err := s.cipher.Decrypt(ud.R.UserDeviceAPIIntegrations[0].AccessToken.String)
+	token, err := s.accessToken(ctx, ud.R.UserDeviceAPIIntegrations[0])
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +228,7 @@ func (s *teslaRPCServer) ConfigureFleetTelemetry(ctx context.Context, req *pb.Co
 	}
 	vin := metadata.TeslaVIN
 
-	token, err := s.cipher.Decrypt(ud.R.UserDeviceAPIIntegrations[0].AccessToken.String)
+	token, err := s.accessToken(ctx, ud.R.UserDeviceAPIIntegrations[0])
 	if err != nil {
 		return nil, err
 	}
@@ -270,7 +286,7 @@ func (s *teslaRPCServer) GetScopes(ctx context.Context, req *pb.GetScopesRequest
 		return nil, status.Error(codes.FailedPrecondition, "No Tesla integration found.")
 	}
 
-	token, err := s.cipher.Decrypt(ud.R.UserDeviceAPIIntegrations[0].AccessToken.String)
+	token, err := s.accessToken(ctx, ud.R.UserDeviceAPIIntegrations[0])
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/DIMO-Network/devices-api/internal/services/autopi"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/models"
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
 )
@@ -43,6 +44,8 @@ func NewUserDeviceRPCService(
 	deviceDefSvc services.DeviceDefinitionService,
 	userDeviceService services.UserDeviceService,
 	teslaTaskService services.TeslaTaskService,
+	mintBatcher *registry.MintBatcher,
+	templateMigrator *autopi.TemplateMigrator,
 ) pb.UserDeviceServiceServer {
 	return &userDeviceRPCServer{dbs: dbs,
 		logger:                  logger,
@@ -51,6 +54,8 @@ func NewUserDeviceRPCService(
 		deviceDefSvc:            deviceDefSvc,
 		userDeviceSvc:           userDeviceService,
 		teslaTaskService:        teslaTaskService,
+		mintBatcher:             mintBatcher,
+		templateMigrator:        templateMigrator,
 	}
 }
 
@@ -64,6 +69,8 @@ type userDeviceRPCServer struct {
 	deviceDefSvc            services.DeviceDefinitionService
 	userDeviceSvc           services.UserDeviceService
 	teslaTaskService        services.TeslaTaskService
+	mintBatcher             *registry.MintBatcher
+	templateMigrator        *autopi.TemplateMigrator
 }
 
 func (s *userDeviceRPCServer) GetUserDevice(ctx context.Context, req *pb.GetUserDeviceRequest) (*pb.UserDevice, error) {
@@ -211,6 +218,33 @@ func (s *userDeviceRPCServer) ApplyHardwareTemplate(ctx context.Context, req *pb
 	return resp, err
 }
 
+func (s *userDeviceRPCServer) PreviewHardwareTemplate(ctx context.Context, req *pb.PreviewHardwareTemplateRequest) (*pb.PreviewHardwareTemplateResponse, error) {
+	res, err := s.hardwareTemplateService.PreviewTemplate(ctx, req.UserDeviceId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No device with that ID found.")
+		}
+		s.logger.Err(err).Str("user_device_id", req.UserDeviceId).Msg("failed to preview hardware template")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	out := &pb.PreviewHardwareTemplateResponse{
+		HardwareTemplateId: res.TemplateID,
+		Rule:               res.Rule,
+		Checks:             make([]*pb.HardwareTemplateRuleCheck, len(res.Checks)),
+	}
+	for i, c := range res.Checks {
+		out.Checks[i] = &pb.HardwareTemplateRuleCheck{
+			Rule:       c.Rule,
+			TemplateId: c.TemplateID,
+			Matched:    c.Matched,
+			Reason:     c.Reason,
+		}
+	}
+
+	return out, nil
+}
+
 func (s *userDeviceRPCServer) CreateTemplate(_ context.Context, req *pb.CreateTemplateRequest) (*pb.CreateTemplateResponse, error) {
 	resp, err := s.hardwareTemplateService.CreateTemplate(req)
 	if err != nil {
@@ -826,3 +860,68 @@ func (s *userDeviceRPCServer) GetVehicleByTokenIdFast(ctx context.Context, req *
 		Vin: vin,
 	}, nil
 }
+
+func (s *userDeviceRPCServer) ListVehicleCommands(ctx context.Context, req *pb.ListVehicleCommandsRequest) (*pb.ListVehicleCommandsResponse, error) {
+	// Zero is what an unset limit looks like, and gets the default.
+	if req.Limit < 0 || req.Limit > services.MaxCommandHistoryLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "Limit must be between 1 and %d, or 0 for the default of %d.", services.MaxCommandHistoryLimit, services.DefaultCommandHistoryLimit)
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(new(decimal.Big).SetUint64(req.TokenId))),
+	).One(ctx, s.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No known vehicle with that token id.")
+		}
+		return nil, fmt.Errorf("failed to find vehicle: %w", err)
+	}
+
+	reqs, next, err := services.ListDeviceCommandRequests(ctx, s.dbs().Reader, ud.ID, req.Cursor, int(req.Limit))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCommandCursor) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor.")
+		}
+		return nil, err
+	}
+
+	out := &pb.ListVehicleCommandsResponse{
+		Commands:   make([]*pb.VehicleCommand, len(reqs)),
+		NextCursor: next,
+	}
+	for i, r := range reqs {
+		out.Commands[i] = commandRequestToAPI(r)
+	}
+
+	return out, nil
+}
+
+func (s *userDeviceRPCServer) GetVehicleCommand(ctx context.Context, req *pb.GetVehicleCommandRequest) (*pb.VehicleCommand, error) {
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(new(decimal.Big).SetUint64(req.TokenId))),
+		qm.Load(models.UserDeviceRels.DeviceCommandRequests, models.DeviceCommandRequestWhere.ID.EQ(req.RequestId)),
+	).One(ctx, s.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No known vehicle with that token id.")
+		}
+		return nil, fmt.Errorf("failed to find vehicle: %w", err)
+	}
+
+	if len(ud.R.DeviceCommandRequests) == 0 {
+		return nil, status.Error(codes.NotFound, "No command request with that id for this vehicle.")
+	}
+
+	return commandRequestToAPI(ud.R.DeviceCommandRequests[0]), nil
+}
+
+func commandRequestToAPI(r *models.DeviceCommandRequest) *pb.VehicleCommand {
+	return &pb.VehicleCommand{
+		RequestId:     r.ID,
+		Command:       r.Command,
+		IntegrationId: r.IntegrationID,
+		Status:        r.Status,
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+		UpdatedAt:     timestamppb.New(r.UpdatedAt),
+	}
+}
@@ -121,7 +121,7 @@ func TestGetUserDevice_AftermarketDeviceObj_NotNil(t *testing.T) {
 
 	logger := zerolog.Logger{}
 	userDeviceSvc := services.NewUserDeviceService(nil, logger, pdb.DBS)
-	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil)
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil, nil, nil)
 
 	udResult, err := udService.GetUserDevice(ctx, &pb_devices.GetUserDeviceRequest{Id: userDeviceID})
 	assert.NoError(err)
@@ -147,7 +147,7 @@ func TestGetUserDevice_AftermarketDeviceObj_Nil(t *testing.T) {
 
 	logger := zerolog.Logger{}
 	userDeviceSvc := services.NewUserDeviceService(nil, logger, pdb.DBS)
-	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil)
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil, nil, nil)
 
 	_, err = models.AftermarketDevices(
 		models.AftermarketDeviceWhere.UserID.EQ(null.StringFrom(userDeviceID)),
@@ -174,7 +174,7 @@ func TestGetUserDevice_PopulateDeprecatedFields(t *testing.T) {
 
 	logger := zerolog.Logger{}
 	userDeviceSvc := services.NewUserDeviceService(nil, logger, pdb.DBS)
-	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil)
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil, nil, nil)
 
 	udResult, err := udService.GetUserDevice(ctx, &pb_devices.GetUserDeviceRequest{Id: userDeviceID})
 	assert.NoError(err)
@@ -200,7 +200,7 @@ func TestGetUserDevice_PopulateSyntheticDeviceFields(t *testing.T) {
 
 	logger := zerolog.Logger{}
 	userDeviceSvc := services.NewUserDeviceService(nil, logger, pdb.DBS)
-	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil)
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil, nil, nil)
 
 	udResult, err := udService.GetUserDevice(ctx, &pb_devices.GetUserDeviceRequest{Id: userDeviceID})
 	assert.NoError(err)
@@ -235,7 +235,7 @@ func TestGetUserDevice_NoSyntheticDeviceFields_WhenNoTokenID(t *testing.T) {
 
 	logger := zerolog.Logger{}
 	userDeviceSvc := services.NewUserDeviceService(nil, logger, pdb.DBS)
-	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil)
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, userDeviceSvc, nil, nil, nil)
 
 	udResult, err := udService.GetUserDevice(ctx, &pb_devices.GetUserDeviceRequest{Id: userDeviceID})
 	assert.NoError(err)
@@ -11,10 +11,12 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
 
 	"github.com/volatiletech/null/v8"
 
 	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/models"
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
@@ -26,69 +28,172 @@ type HardwareTemplateService interface {
 	GetTemplateID(ud *models.UserDevice, dd *ddgrpc.GetDeviceDefinitionItemResponse, integ *ddgrpc.Integration) (string, error)
 	ApplyHardwareTemplate(ctx context.Context, req *pb.ApplyHardwareTemplateRequest) (*pb.ApplyHardwareTemplateResponse, error)
 	CreateTemplate(req *pb.CreateTemplateRequest) (*pb.CreateTemplateResponse, error)
+	PreviewTemplate(ctx context.Context, userDeviceID string) (*TemplateResolution, error)
 }
 
 type hardwareTemplateService struct {
 	dbs    func() *db.ReaderWriter
 	ap     services.AutoPiAPIService
+	ddSvc  services.DeviceDefinitionService
 	logger *zerolog.Logger
 }
 
-func NewHardwareTemplateService(ap services.AutoPiAPIService, dbs func() *db.ReaderWriter, logger *zerolog.Logger) HardwareTemplateService {
+func NewHardwareTemplateService(ap services.AutoPiAPIService, dbs func() *db.ReaderWriter, ddSvc services.DeviceDefinitionService, logger *zerolog.Logger) HardwareTemplateService {
 	return &hardwareTemplateService{
 		ap:     ap,
 		dbs:    dbs,
+		ddSvc:  ddSvc,
 		logger: logger,
 	}
 }
 
 func (a *hardwareTemplateService) GetTemplateID(ud *models.UserDevice, dd *ddgrpc.GetDeviceDefinitionItemResponse, integ *ddgrpc.Integration) (string, error) {
+	res, err := ResolveTemplateID(ud, dd, integ)
+	if err != nil {
+		return res.TemplateID, err
+	}
+	if res.Rule == TemplateRuleFallback {
+		a.logger.Warn().Str("user_device_id", ud.ID).Str("device_definition_id", dd.DeviceDefinitionId).
+			Msgf("could not find a templateID for this user_device")
+	}
+
+	return res.TemplateID, nil
+}
+
+// PreviewTemplate works out which template the user device should be on without touching the
+// device. Returns sql.ErrNoRows, wrapped, if there is no such user device.
+func (a *hardwareTemplateService) PreviewTemplate(ctx context.Context, userDeviceID string) (*TemplateResolution, error) {
+	ud, err := models.FindUserDevice(ctx, a.dbs().Reader, userDeviceID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find user device %s", userDeviceID)
+	}
+
+	dd, err := a.ddSvc.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get device definition %s", ud.DefinitionID)
+	}
+
+	integ, err := a.ddSvc.GetIntegrationByVendor(ctx, constants.AutoPiVendor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get AutoPi integration")
+	}
+
+	return ResolveTemplateID(ud, dd, integ)
+}
+
+// Rules checked by ResolveTemplateID, in order.
+const (
+	TemplateRuleDeviceStyle        = "DeviceStyle"
+	TemplateRuleDeviceDefinition   = "DeviceDefinition"
+	TemplateRuleMake               = "Make"
+	TemplateRulePowertrain         = "Powertrain"
+	TemplateRuleIntegrationDefault = "IntegrationDefault"
+	TemplateRuleFallback           = "Fallback"
+)
+
+// TemplateRuleCheck is the outcome of checking one rule.
+type TemplateRuleCheck struct {
+	Rule string
+	// TemplateID is the template the rule gave, if any.
+	TemplateID string
+	Matched    bool
+	Reason     string
+}
+
+// TemplateResolution is the template chosen for a user device, along with every rule checked on
+// the way.
+type TemplateResolution struct {
+	TemplateID string
+	// Rule is the rule that chose the template.
+	Rule   string
+	Checks []TemplateRuleCheck
+}
+
+func (r *TemplateResolution) skip(rule, templateID, reason string, args ...any) {
+	r.Checks = append(r.Checks, TemplateRuleCheck{Rule: rule, TemplateID: templateID, Reason: fmt.Sprintf(reason, args...)})
+}
+
+func (r *TemplateResolution) match(rule, templateID, reason string, args ...any) *TemplateResolution {
+	r.Checks = append(r.Checks, TemplateRuleCheck{Rule: rule, TemplateID: templateID, Matched: true, Reason: fmt.Sprintf(reason, args...)})
+	r.TemplateID = templateID
+	r.Rule = rule
+	return r
+}
+
+// ResolveTemplateID walks the template rules for the user device, stopping at the first one that
+// gives a usable template. The result carries the reason each rule matched or was skipped.
+func ResolveTemplateID(ud *models.UserDevice, dd *ddgrpc.GetDeviceDefinitionItemResponse, integ *ddgrpc.Integration) (*TemplateResolution, error) {
 	const defaultTemplate = "10" // if for some reason get an empty or 0 template value, always return this.
+	res := &TemplateResolution{}
+
 	// get template from device style, only if UD has a DS set and the DS has a templateID set
-	if ud.DeviceStyleID.Valid {
-		if len(dd.DeviceStyles) > 0 {
-			for _, ds := range dd.DeviceStyles {
-				if ds.Id == ud.DeviceStyleID.String {
-					if isTemplateIDValid(ds.HardwareTemplateId) {
-						return ds.HardwareTemplateId, nil
-					}
-				}
+	if !ud.DeviceStyleID.Valid {
+		res.skip(TemplateRuleDeviceStyle, "", "user device has no device style")
+	} else {
+		var style *ddgrpc.DeviceStyle
+		for _, ds := range dd.DeviceStyles {
+			if ds.Id == ud.DeviceStyleID.String {
+				style = ds
+				break
 			}
 		}
+		switch {
+		case style == nil:
+			res.skip(TemplateRuleDeviceStyle, "", "device style %s is not on definition %s", ud.DeviceStyleID.String, dd.Id)
+		case !isTemplateIDValid(style.HardwareTemplateId):
+			res.skip(TemplateRuleDeviceStyle, style.HardwareTemplateId, "device style %s has no valid template", style.Id)
+		default:
+			return res.match(TemplateRuleDeviceStyle, style.HardwareTemplateId, "device style %s sets the template", style.Id), nil
+		}
 	}
 
 	// get template from Device Definition
 	if isTemplateIDValid(dd.HardwareTemplateId) { //nolint
-		return dd.HardwareTemplateId, nil //nolint
+		return res.match(TemplateRuleDeviceDefinition, dd.HardwareTemplateId, "definition %s sets the template", dd.Id), nil //nolint
 	}
+	res.skip(TemplateRuleDeviceDefinition, dd.HardwareTemplateId, "definition %s has no valid template", dd.Id) //nolint
 
 	// get template from Make
-	if isTemplateIDValid(dd.Make.HardwareTemplateId) { //nolint
-		return dd.Make.HardwareTemplateId, nil //nolint
+	if dd.Make != nil && isTemplateIDValid(dd.Make.HardwareTemplateId) { //nolint
+		return res.match(TemplateRuleMake, dd.Make.HardwareTemplateId, "make %s sets the template", dd.Make.Name), nil //nolint
+	}
+	if dd.Make == nil {
+		res.skip(TemplateRuleMake, "", "definition has no make")
+	} else {
+		res.skip(TemplateRuleMake, dd.Make.HardwareTemplateId, "make %s has no valid template", dd.Make.Name) //nolint
 	}
 
 	// get template from powertrain based on map in integration metadata
-	if integ.AutoPiPowertrainTemplate != nil {
+	if integ.AutoPiPowertrainTemplate == nil {
+		res.skip(TemplateRulePowertrain, "", "integration has no powertrain map")
+	} else {
 		udMd := services.UserDeviceMetadata{}
 		err := ud.Metadata.Unmarshal(&udMd)
 		if err != nil {
-			return defaultTemplate, err
+			res.skip(TemplateRulePowertrain, "", "could not read user device metadata: %s", err)
+			res.TemplateID = defaultTemplate
+			res.Rule = TemplateRuleFallback
+			return res, err
 		}
 
 		tIDFromPowerTrain := powertrainToTemplate(udMd.PowertrainType, integ)
+		pt := "unknown"
+		if udMd.PowertrainType != nil {
+			pt = udMd.PowertrainType.String()
+		}
 		if tIDFromPowerTrain > 0 {
-			return strconv.Itoa(int(tIDFromPowerTrain)), nil
+			return res.match(TemplateRulePowertrain, strconv.Itoa(int(tIDFromPowerTrain)), "powertrain map gives a template for %s powertrain", pt), nil
 		}
+		res.skip(TemplateRulePowertrain, "", "powertrain map has no template for %s powertrain", pt)
 	}
 
 	// get template from autopi integration default
 	if integ.AutoPiDefaultTemplateId > 0 {
-		return strconv.Itoa(int(integ.AutoPiDefaultTemplateId)), nil
+		return res.match(TemplateRuleIntegrationDefault, strconv.Itoa(int(integ.AutoPiDefaultTemplateId)), "integration %s has a default template", integ.Vendor), nil
 	}
-	a.logger.Warn().Str("user_device_id", ud.ID).Str("device_definition_id", dd.DeviceDefinitionId).
-		Msgf("could not find a templateID for this user_device")
+	res.skip(TemplateRuleIntegrationDefault, "", "integration %s has no default template", integ.Vendor)
 
-	return defaultTemplate, nil
+	return res.match(TemplateRuleFallback, defaultTemplate, "no rule gave a template"), nil
 }
 
 // isTemplateIDValid returns true if not empty and can be converted to a number, otherwise returns false
@@ -128,6 +233,7 @@ func (a *hardwareTemplateService) ApplyHardwareTemplate(ctx context.Context, req
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback() //nolint
 
 	udapi, err := models.UserDeviceAPIIntegrations(
 		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(req.UserDeviceId),
@@ -213,6 +319,21 @@ func (a *hardwareTemplateService) ApplyHardwareTemplate(ctx context.Context, req
 		}
 	}
 
+	change := models.HardwareTemplateChange{
+		ID:            ksuid.New().String(),
+		Serial:        autoPiModel.Serial,
+		UserDeviceID:  null.NewString(req.UserDeviceId, req.UserDeviceId != ""),
+		NewTemplateID: req.HardwareTemplateId,
+		Reason:        null.NewString(req.Reason, req.Reason != ""),
+	}
+	if autoPi.Template > 0 {
+		change.OldTemplateID = null.StringFrom(strconv.Itoa(autoPi.Template))
+	}
+
+	if err := change.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, errors.Wrap(err, "failed to record hardware template change")
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "failed to commit new hardware template to user device")
 	}
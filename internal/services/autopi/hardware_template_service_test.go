@@ -2,6 +2,7 @@ package autopi
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -16,6 +17,7 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/test"
 	"github.com/DIMO-Network/devices-api/models"
+	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
 	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -29,6 +31,7 @@ type HardwareTemplateServiceTestSuite struct {
 	suite.Suite
 	hardwareTemplateService HardwareTemplateService
 	ap                      *mock_services.MockAutoPiAPIService
+	ddSvc                   *mock_services.MockDeviceDefinitionService
 	pdb                     db.Store
 	container               testcontainers.Container
 	context                 context.Context
@@ -42,8 +45,9 @@ func (s *HardwareTemplateServiceTestSuite) SetupSuite() {
 	logger := test.Logger()
 
 	s.ap = mock_services.NewMockAutoPiAPIService(mockCtrl)
+	s.ddSvc = mock_services.NewMockDeviceDefinitionService(mockCtrl)
 
-	s.hardwareTemplateService = NewHardwareTemplateService(s.ap, s.pdb.DBS, logger)
+	s.hardwareTemplateService = NewHardwareTemplateService(s.ap, s.pdb.DBS, s.ddSvc, logger)
 }
 
 func (s *HardwareTemplateServiceTestSuite) TearDownTest() {
@@ -193,3 +197,83 @@ func (s *HardwareTemplateServiceTestSuite) Test_GetTemplateID() {
 		})
 	}
 }
+
+func (s *HardwareTemplateServiceTestSuite) Test_ResolveTemplateID_Trace() {
+	integration := test.BuildIntegrationDefaultGRPC(ksuid.New().String(), constants.AutoPiVendor, 10, 14, true)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "F150", 2020, integration)[0]
+	dd.Make.HardwareTemplateId = "13" //nolint
+
+	ud := &models.UserDevice{
+		ID:            ksuid.New().String(),
+		DefinitionID:  dd.Id,
+		DeviceStyleID: null.StringFrom(ksuid.New().String()),
+	}
+
+	res, err := ResolveTemplateID(ud, dd, integration)
+	s.Require().NoError(err)
+
+	s.Equal("13", res.TemplateID)
+	s.Equal(TemplateRuleMake, res.Rule)
+	s.Require().Len(res.Checks, 3)
+	s.Equal(TemplateRuleDeviceStyle, res.Checks[0].Rule)
+	s.False(res.Checks[0].Matched)
+	s.Contains(res.Checks[0].Reason, "is not on definition")
+	s.Equal(TemplateRuleDeviceDefinition, res.Checks[1].Rule)
+	s.False(res.Checks[1].Matched)
+	s.Equal(TemplateRuleMake, res.Checks[2].Rule)
+	s.True(res.Checks[2].Matched)
+	s.Equal("13", res.Checks[2].TemplateID)
+}
+
+func (s *HardwareTemplateServiceTestSuite) Test_PreviewTemplate() {
+	integration := test.BuildIntegrationDefaultGRPC(ksuid.New().String(), constants.AutoPiVendor, 10, 14, true)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "F150", 2020, integration)[0]
+	md := []byte(`{"powertrainType":"BEV"}`)
+	ud := test.SetupCreateUserDevice(s.T(), "testUserID", dd.Id, &md, "", s.pdb)
+
+	s.ddSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), dd.Id).Return(dd, nil)
+	s.ddSvc.EXPECT().GetIntegrationByVendor(gomock.Any(), constants.AutoPiVendor).Return(integration, nil)
+
+	res, err := s.hardwareTemplateService.PreviewTemplate(s.context, ud.ID)
+	s.Require().NoError(err)
+
+	s.Equal("14", res.TemplateID)
+	s.Equal(TemplateRulePowertrain, res.Rule)
+	s.Len(res.Checks, 4)
+
+	_, err = s.hardwareTemplateService.PreviewTemplate(s.context, ksuid.New().String())
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *HardwareTemplateServiceTestSuite) Test_ApplyHardwareTemplate_RecordsChange() {
+	const unitID = "431d2e89-46f1-6884-6226-5d1ad20c84d9"
+	deviceID := "device123"
+	ud := test.SetupCreateUserDevice(s.T(), "testUserID", ksuid.New().String(), nil, "", s.pdb)
+	test.SetupCreateAftermarketDevice(s.T(), "testUserID", nil, unitID, &deviceID, s.pdb)
+
+	apDevice := &services.AutoPiDongleDevice{ID: deviceID, UnitID: unitID, Template: 10}
+	apDevice.Vehicle.ID = 5
+	s.ap.EXPECT().GetDeviceByUnitID(unitID).Return(apDevice, nil)
+	s.ap.EXPECT().UnassociateDeviceTemplate(deviceID, 10).Return(nil)
+	s.ap.EXPECT().GetVehicleLoggers(5).Return(nil, nil)
+	s.ap.EXPECT().AssociateDeviceToTemplate(deviceID, 12).Return(nil)
+	s.ap.EXPECT().ApplyTemplate(deviceID, 12).Return(nil)
+	s.ap.EXPECT().CommandSyncDevice(gomock.Any(), unitID, deviceID, ud.ID).Return(nil, nil)
+
+	_, err := s.hardwareTemplateService.ApplyHardwareTemplate(s.context, &pb.ApplyHardwareTemplateRequest{
+		UserDeviceId:       ud.ID,
+		AutoApiUnitId:      unitID,
+		HardwareTemplateId: "12",
+		Reason:             "support fix",
+	})
+	s.Require().NoError(err)
+
+	changes, err := models.HardwareTemplateChanges().All(s.context, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(changes, 1)
+	s.Equal(unitID, changes[0].Serial)
+	s.Equal(null.StringFrom(ud.ID), changes[0].UserDeviceID)
+	s.Equal(null.StringFrom("10"), changes[0].OldTemplateID)
+	s.Equal("12", changes[0].NewTemplateID)
+	s.Equal(null.StringFrom("support fix"), changes[0].Reason)
+}
@@ -0,0 +1,510 @@
+package autopi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
+	"github.com/DIMO-Network/shared/pkg/db"
+)
+
+const (
+	defaultTemplateMigrationBatchSize = 50
+	// defaultTemplateMigrationDelay is the pause between devices, to stay within AutoPi's rate
+	// limits. Only one worker moves devices at a time, however many replicas there are, so this
+	// paces the whole deployment.
+	defaultTemplateMigrationDelay = 400 * time.Millisecond
+	// templateMigrationLease is how long a worker holds a migration after starting on a device.
+	// The lease is renewed for each device, so a worker that dies gives the migration up after
+	// this.
+	templateMigrationLease = 2 * time.Minute
+	// autoPiTemplatePageSize is how many devices are listed from AutoPi at a time.
+	autoPiTemplatePageSize = 500
+	// maxTemplateMigrationFailures caps the failed devices returned with a migration's status.
+	maxTemplateMigrationFailures = 100
+)
+
+var (
+	ErrTemplateMigrationNotFound  = errors.New("no template migration with that id found")
+	ErrTemplateMigrationCompleted = errors.New("template migration has already completed")
+	ErrTemplateMigrationNoDevices = errors.New("template migration has no devices to move")
+	ErrTemplateMigrationProtected = errors.New("devices can't be moved off a base template")
+)
+
+// protectedTemplateIDs are AutoPi's base templates. Devices on them are left where they are.
+var protectedTemplateIDs = []int{115, 116, 126, 127, 128}
+
+// ProtectedTemplateIDs returns the templates that devices are never moved off.
+func ProtectedTemplateIDs() []int {
+	return slices.Clone(protectedTemplateIDs)
+}
+
+// IsProtectedTemplate reports whether the template is one that devices are never moved off.
+func IsProtectedTemplate(id int) bool {
+	return slices.Contains(protectedTemplateIDs, id)
+}
+
+// UnknownAftermarketDevicesError is returned when creating a migration for addresses that
+// don't belong to any aftermarket device. Nothing is created in that case.
+type UnknownAftermarketDevicesError struct {
+	Addresses []common.Address
+}
+
+func (e *UnknownAftermarketDevicesError) Error() string {
+	return fmt.Sprintf("no aftermarket device with %d of the addresses, starting with %s", len(e.Addresses), e.Addresses[0].Hex())
+}
+
+// TemplateMigrationStatus is a migration along with how far it has got.
+type TemplateMigrationStatus struct {
+	Migration *models.TemplateMigration
+	Pending   int
+	Succeeded int
+	Failed    int
+	// Failures holds the most recent failed devices, up to 100.
+	Failures models.TemplateMigrationDeviceSlice
+}
+
+// TemplateMigrator moves AutoPi devices onto a new hardware template in the background. The
+// devices to move are saved when a migration is created, and each device's result is saved as
+// soon as it's known, so a migration picks up where it left off after a restart.
+type TemplateMigrator struct {
+	dbs       func() *db.ReaderWriter
+	ap        services.AutoPiAPIService
+	hwSvc     HardwareTemplateService
+	log       *zerolog.Logger
+	batchSize int
+	delay     time.Duration
+}
+
+// NewTemplateMigrator parses the template migration settings.
+func NewTemplateMigrator(dbs func() *db.ReaderWriter, ap services.AutoPiAPIService, hwSvc HardwareTemplateService, settings *config.Settings, logger *zerolog.Logger) (*TemplateMigrator, error) {
+	m := &TemplateMigrator{
+		dbs:       dbs,
+		ap:        ap,
+		hwSvc:     hwSvc,
+		log:       logger,
+		batchSize: defaultTemplateMigrationBatchSize,
+		delay:     defaultTemplateMigrationDelay,
+	}
+
+	if settings.TemplateMigrationBatchSize > 0 {
+		m.batchSize = settings.TemplateMigrationBatchSize
+	}
+
+	if settings.TemplateMigrationDeviceDelay != "" {
+		d, err := time.ParseDuration(settings.TemplateMigrationDeviceDelay)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse template migration device delay: %w", err)
+		}
+		m.delay = d
+	}
+
+	return m, nil
+}
+
+// CreateFromTemplate starts a migration of every device currently on the source template. The
+// base templates can't be the source.
+func (m *TemplateMigrator) CreateFromTemplate(ctx context.Context, sourceTemplateID, targetTemplateID int) (*models.TemplateMigration, error) {
+	if IsProtectedTemplate(sourceTemplateID) {
+		return nil, ErrTemplateMigrationProtected
+	}
+
+	var serials []string
+	for page := 1; ; page++ {
+		res, err := m.ap.GetDevicesInTemplate(sourceTemplateID, page, autoPiTemplatePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range res.Results {
+			serials = append(serials, d.UnitID)
+		}
+		if page*autoPiTemplatePageSize >= res.Count || len(res.Results) == 0 {
+			break
+		}
+	}
+
+	return m.create(ctx, null.IntFrom(sourceTemplateID), targetTemplateID, serials)
+}
+
+// CreateFromAddresses starts a migration of the aftermarket devices with the given addresses.
+func (m *TemplateMigrator) CreateFromAddresses(ctx context.Context, addrs []common.Address, targetTemplateID int) (*models.TemplateMigration, error) {
+	if len(addrs) == 0 {
+		return nil, ErrTemplateMigrationNoDevices
+	}
+
+	raw := make([]any, len(addrs))
+	for i, addr := range addrs {
+		raw[i] = addr.Bytes()
+	}
+
+	amds, err := models.AftermarketDevices(
+		qm.WhereIn(models.AftermarketDeviceColumns.EthereumAddress+" IN ?", raw...),
+	).All(ctx, m.dbs().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make(map[common.Address]string, len(amds))
+	for _, amd := range amds {
+		serials[common.BytesToAddress(amd.EthereumAddress)] = amd.Serial
+	}
+
+	var unknown []common.Address
+	out := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		serial, ok := serials[addr]
+		if !ok {
+			unknown = append(unknown, addr)
+			continue
+		}
+		out = append(out, serial)
+	}
+	if len(unknown) != 0 {
+		return nil, &UnknownAftermarketDevicesError{Addresses: unknown}
+	}
+
+	return m.create(ctx, null.Int{}, targetTemplateID, out)
+}
+
+func (m *TemplateMigrator) create(ctx context.Context, sourceTemplateID null.Int, targetTemplateID int, serials []string) (*models.TemplateMigration, error) {
+	if len(serials) == 0 {
+		return nil, ErrTemplateMigrationNoDevices
+	}
+
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	tm := models.TemplateMigration{
+		ID:               ksuid.New().String(),
+		SourceTemplateID: sourceTemplateID,
+		TargetTemplateID: targetTemplateID,
+		Status:           models.TemplateMigrationStatusRunning,
+	}
+	if err := tm.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(serials))
+	for _, serial := range serials {
+		if _, ok := seen[serial]; ok {
+			continue
+		}
+		seen[serial] = struct{}{}
+
+		d := models.TemplateMigrationDevice{
+			ID:                  ksuid.New().String(),
+			TemplateMigrationID: tm.ID,
+			Serial:              serial,
+			Status:              models.TemplateMigrationDeviceStatusPending,
+		}
+		if err := d.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tm, tx.Commit()
+}
+
+// Pause stops work on a migration after the device in flight, if any.
+func (m *TemplateMigrator) Pause(ctx context.Context, id string) (*models.TemplateMigration, error) {
+	return m.setStatus(ctx, id, models.TemplateMigrationStatusPaused)
+}
+
+// Resume puts a paused migration back in the queue.
+func (m *TemplateMigrator) Resume(ctx context.Context, id string) (*models.TemplateMigration, error) {
+	return m.setStatus(ctx, id, models.TemplateMigrationStatusRunning)
+}
+
+func (m *TemplateMigrator) setStatus(ctx context.Context, id, status string) (*models.TemplateMigration, error) {
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	tm, err := models.TemplateMigrations(
+		models.TemplateMigrationWhere.ID.EQ(id),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateMigrationNotFound
+		}
+		return nil, err
+	}
+
+	if tm.Status == models.TemplateMigrationStatusCompleted {
+		return nil, ErrTemplateMigrationCompleted
+	}
+
+	tm.Status = status
+	if _, err := tm.Update(ctx, tx, boil.Whitelist(models.TemplateMigrationColumns.Status, models.TemplateMigrationColumns.UpdatedAt)); err != nil {
+		return nil, err
+	}
+
+	return tm, tx.Commit()
+}
+
+// Status returns the migration and counts of its devices by status.
+func (m *TemplateMigrator) Status(ctx context.Context, id string) (*TemplateMigrationStatus, error) {
+	tm, err := models.FindTemplateMigration(ctx, m.dbs().Reader, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateMigrationNotFound
+		}
+		return nil, err
+	}
+
+	var counts []struct {
+		Status string `boil:"status"`
+		Count  int    `boil:"count"`
+	}
+	err = models.TemplateMigrationDevices(
+		qm.Select(models.TemplateMigrationDeviceColumns.Status, "count(*) AS count"),
+		models.TemplateMigrationDeviceWhere.TemplateMigrationID.EQ(id),
+		qm.GroupBy(models.TemplateMigrationDeviceColumns.Status),
+	).Bind(ctx, m.dbs().Reader, &counts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &TemplateMigrationStatus{Migration: tm}
+	for _, c := range counts {
+		switch c.Status {
+		case models.TemplateMigrationDeviceStatusPending:
+			out.Pending = c.Count
+		case models.TemplateMigrationDeviceStatusSucceeded:
+			out.Succeeded = c.Count
+		case models.TemplateMigrationDeviceStatusFailed:
+			out.Failed = c.Count
+		}
+	}
+
+	if out.Failed > 0 {
+		out.Failures, err = models.TemplateMigrationDevices(
+			models.TemplateMigrationDeviceWhere.TemplateMigrationID.EQ(id),
+			models.TemplateMigrationDeviceWhere.Status.EQ(models.TemplateMigrationDeviceStatusFailed),
+			qm.OrderBy(models.TemplateMigrationDeviceColumns.UpdatedAt+" DESC"),
+			qm.Limit(maxTemplateMigrationFailures),
+		).All(ctx, m.dbs().Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// ProcessDue moves one batch of devices for the oldest running migration that no other worker
+// holds. It returns how many devices were attempted.
+func (m *TemplateMigrator) ProcessDue(ctx context.Context) (int, error) {
+	tm, err := m.claim(ctx)
+	if err != nil || tm == nil {
+		return 0, err
+	}
+
+	devices, err := models.TemplateMigrationDevices(
+		models.TemplateMigrationDeviceWhere.TemplateMigrationID.EQ(tm.ID),
+		models.TemplateMigrationDeviceWhere.Status.EQ(models.TemplateMigrationDeviceStatusPending),
+		qm.OrderBy(models.TemplateMigrationDeviceColumns.ID),
+		qm.Limit(m.batchSize),
+	).All(ctx, m.dbs().Reader)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for i, d := range devices {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return n, ctx.Err()
+			case <-time.After(m.delay):
+			}
+		}
+
+		// Hold on to the migration for this device. This also picks up a pause made while the
+		// batch is running.
+		running, err := m.renew(ctx, tm.ID)
+		if err != nil {
+			return n, err
+		}
+		if !running {
+			break
+		}
+
+		if err := m.move(ctx, tm, d); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, m.release(ctx, tm.ID)
+}
+
+// claim takes the lease on the oldest running migration, unless another worker holds it.
+// Migrations run one at a time: every worker goes for the same row, so AutoPi sees one stream of
+// changes however many replicas there are.
+func (m *TemplateMigrator) claim(ctx context.Context) (*models.TemplateMigration, error) {
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	now := time.Now()
+
+	tm, err := models.TemplateMigrations(
+		models.TemplateMigrationWhere.Status.EQ(models.TemplateMigrationStatusRunning),
+		qm.OrderBy(models.TemplateMigrationColumns.CreatedAt),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if tm.LeaseExpiresAt.Valid && tm.LeaseExpiresAt.Time.After(now) {
+		return nil, nil
+	}
+
+	tm.LeaseExpiresAt = null.TimeFrom(now.Add(templateMigrationLease))
+	if _, err := tm.Update(ctx, tx, boil.Whitelist(models.TemplateMigrationColumns.LeaseExpiresAt, models.TemplateMigrationColumns.UpdatedAt)); err != nil {
+		return nil, err
+	}
+
+	return tm, tx.Commit()
+}
+
+// renew extends the lease on a migration, if it's still running.
+func (m *TemplateMigrator) renew(ctx context.Context, id string) (bool, error) {
+	rowsAff, err := models.TemplateMigrations(
+		models.TemplateMigrationWhere.ID.EQ(id),
+		models.TemplateMigrationWhere.Status.EQ(models.TemplateMigrationStatusRunning),
+	).UpdateAll(ctx, m.dbs().Writer, models.M{
+		models.TemplateMigrationColumns.LeaseExpiresAt: time.Now().Add(templateMigrationLease),
+		models.TemplateMigrationColumns.UpdatedAt:      time.Now(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAff == 1, nil
+}
+
+// release gives up the lease, marking the migration as completed if no devices are left.
+func (m *TemplateMigrator) release(ctx context.Context, id string) error {
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	tm, err := models.TemplateMigrations(
+		models.TemplateMigrationWhere.ID.EQ(id),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	pending, err := models.TemplateMigrationDevices(
+		models.TemplateMigrationDeviceWhere.TemplateMigrationID.EQ(id),
+		models.TemplateMigrationDeviceWhere.Status.EQ(models.TemplateMigrationDeviceStatusPending),
+	).Exists(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	cols := []string{models.TemplateMigrationColumns.LeaseExpiresAt, models.TemplateMigrationColumns.UpdatedAt}
+
+	tm.LeaseExpiresAt = null.Time{}
+	if !pending {
+		tm.Status = models.TemplateMigrationStatusCompleted
+		tm.CompletedAt = null.TimeFrom(time.Now())
+		cols = append(cols, models.TemplateMigrationColumns.Status, models.TemplateMigrationColumns.CompletedAt)
+		m.log.Info().Str("templateMigrationId", tm.ID).Int("targetTemplateId", tm.TargetTemplateID).Msg("Template migration completed.")
+	}
+
+	if _, err := tm.Update(ctx, tx, boil.Whitelist(cols...)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// move applies the target template to one device and saves the result. Errors from AutoPi are
+// saved on the device; only database errors are returned.
+func (m *TemplateMigrator) move(ctx context.Context, tm *models.TemplateMigration, d *models.TemplateMigrationDevice) error {
+	moveErr := m.apply(ctx, tm, d.Serial)
+
+	d.Status = models.TemplateMigrationDeviceStatusSucceeded
+	d.Error = null.String{}
+	if moveErr != nil {
+		m.log.Warn().Err(moveErr).Str("templateMigrationId", tm.ID).Str("serial", d.Serial).Msg("Failed to move device to template.")
+		d.Status = models.TemplateMigrationDeviceStatusFailed
+		d.Error = null.StringFrom(moveErr.Error())
+	}
+
+	_, err := d.Update(ctx, m.dbs().Writer, boil.Whitelist(
+		models.TemplateMigrationDeviceColumns.Status,
+		models.TemplateMigrationDeviceColumns.Error,
+		models.TemplateMigrationDeviceColumns.UpdatedAt,
+	))
+	return err
+}
+
+func (m *TemplateMigrator) apply(ctx context.Context, tm *models.TemplateMigration, serial string) error {
+	amd, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.Serial.EQ(serial),
+		qm.Load(models.AftermarketDeviceRels.VehicleToken),
+	).One(ctx, m.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no aftermarket device with serial %s", serial)
+		}
+		return err
+	}
+
+	// Devices can be added by address, so the template they're on now is only known here.
+	dev, err := m.ap.GetDeviceByUnitID(serial)
+	if err != nil {
+		return err
+	}
+	if IsProtectedTemplate(dev.Template) {
+		return fmt.Errorf("device is on base template %d", dev.Template)
+	}
+
+	udID := ""
+	if amd.R.VehicleToken != nil {
+		udID = amd.R.VehicleToken.ID
+	}
+
+	_, err = m.hwSvc.ApplyHardwareTemplate(ctx, &pb.ApplyHardwareTemplateRequest{
+		UserDeviceId:       udID,
+		AutoApiUnitId:      serial,
+		HardwareTemplateId: strconv.Itoa(tm.TargetTemplateID),
+		Reason:             "template migration " + tm.ID,
+	})
+	return err
+}
@@ -0,0 +1,394 @@
+package autopi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeAutoPi is just enough of the AutoPi API to move devices between templates.
+type fakeAutoPi struct {
+	mu sync.Mutex
+	// templates maps unit ids to the template each device is on.
+	templates map[string]int
+	// rejected holds unit ids for which AutoPi refuses template changes.
+	rejected map[string]bool
+	// onAssociate, if set, is called after each device is moved.
+	onAssociate func(unitID string)
+}
+
+var (
+	byUnitIDPath        = regexp.MustCompile(`^/dongle/devices/by_unit_id/([^/]+)/$`)
+	templateDevicesPath = regexp.MustCompile(`^/dongle/templates/(\d+)/devices/$`)
+	templatePath        = regexp.MustCompile(`^/dongle/templates/(\d+)/$`)
+	executeRawPath      = regexp.MustCompile(`^/dongle/devices/[^/]+/execute_raw/$`)
+)
+
+func (f *fakeAutoPi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && byUnitIDPath.MatchString(r.URL.Path):
+		unitID := byUnitIDPath.FindStringSubmatch(r.URL.Path)[1]
+		tmpl, ok := f.templates[unitID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(services.AutoPiDongleDevice{
+			ID:       "device-" + unitID,
+			UnitID:   unitID,
+			Template: tmpl,
+			Vehicle:  services.AutoPiDongleVehicle{ID: 1},
+		})
+	case r.Method == http.MethodGet && templateDevicesPath.MatchString(r.URL.Path):
+		tmpl, _ := strconv.Atoi(templateDevicesPath.FindStringSubmatch(r.URL.Path)[1])
+		var res services.DeviceInTemplateResponse
+		for unitID, t := range f.templates {
+			if t == tmpl {
+				res.Results = append(res.Results, services.DeviceListItem{UnitID: unitID})
+			}
+		}
+		res.Count = len(res.Results)
+		_ = json.NewEncoder(w).Encode(res)
+	case r.Method == http.MethodGet && r.URL.Path == "/obd/loggers/":
+		_, _ = w.Write([]byte("[]"))
+	case r.Method == http.MethodPatch && templatePath.MatchString(r.URL.Path):
+		tmpl, _ := strconv.Atoi(templatePath.FindStringSubmatch(r.URL.Path)[1])
+		var body struct {
+			Devices []string `json:"devices"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, d := range body.Devices {
+			unitID := d[len("device-"):]
+			if f.rejected[unitID] {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.templates[unitID] = tmpl
+			if f.onAssociate != nil {
+				f.onAssociate(unitID)
+			}
+		}
+		_, _ = w.Write([]byte("{}"))
+	case r.Method == http.MethodPost && executeRawPath.MatchString(r.URL.Path):
+		_, _ = fmt.Fprintf(w, `{"jid": %q}`, ksuid.New().String())
+	case r.Method == http.MethodPost:
+		// unassociate_devices and apply_explicit.
+		_, _ = w.Write([]byte("{}"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeAutoPi) template(unitID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.templates[unitID]
+}
+
+type TemplateMigratorTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	ap        *fakeAutoPi
+	srv       *httptest.Server
+	migrator  *TemplateMigrator
+}
+
+func (s *TemplateMigratorTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+
+	s.ap = &fakeAutoPi{}
+	s.srv = httptest.NewServer(s.ap)
+
+	mockCtrl := gomock.NewController(s.T())
+	logger := test.Logger()
+	settings := &config.Settings{
+		AutoPiAPIURL:                 s.srv.URL,
+		TemplateMigrationBatchSize:   2,
+		TemplateMigrationDeviceDelay: "0s",
+	}
+
+	apSvc := services.NewAutoPiAPIService(settings, s.pdb.DBS, logger)
+	hwSvc := NewHardwareTemplateService(apSvc, s.pdb.DBS, mock_services.NewMockDeviceDefinitionService(mockCtrl), logger)
+
+	var err error
+	s.migrator, err = NewTemplateMigrator(s.pdb.DBS, apSvc, hwSvc, settings, logger)
+	s.Require().NoError(err)
+}
+
+func (s *TemplateMigratorTestSuite) SetupTest() {
+	s.ap.mu.Lock()
+	s.ap.templates = map[string]int{}
+	s.ap.rejected = map[string]bool{}
+	s.ap.onAssociate = nil
+	s.ap.mu.Unlock()
+}
+
+func (s *TemplateMigratorTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+func (s *TemplateMigratorTestSuite) TearDownSuite() {
+	s.srv.Close()
+	fmt.Printf("shutting down postgres at with session: %s \n", s.container.SessionID())
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func TestTemplateMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplateMigratorTestSuite))
+}
+
+// setupDevice creates an aftermarket device on the given AutoPi template.
+func (s *TemplateMigratorTestSuite) setupDevice(template int) (string, common.Address) {
+	b := ksuid.New().Bytes()
+	unitID := fmt.Sprintf("%x-%x-%x-%x-%x", b[4:8], b[8:10], b[10:12], b[12:14], b[14:20])
+	addr := common.BytesToAddress(b)
+	test.SetupCreateAftermarketDevice(s.T(), ksuid.New().String(), addr.Bytes(), unitID, nil, s.pdb)
+
+	s.ap.mu.Lock()
+	s.ap.templates[unitID] = template
+	s.ap.mu.Unlock()
+
+	return unitID, addr
+}
+
+// runToCompletion processes batches until there is nothing left to do.
+func (s *TemplateMigratorTestSuite) runToCompletion() {
+	for i := 0; i < 20; i++ {
+		n, err := s.migrator.ProcessDue(s.ctx)
+		s.Require().NoError(err)
+		if n == 0 {
+			return
+		}
+	}
+	s.FailNow("migration didn't finish")
+}
+
+func (s *TemplateMigratorTestSuite) TestFromTemplate() {
+	var units []string
+	for i := 0; i < 5; i++ {
+		unitID, _ := s.setupDevice(10)
+		units = append(units, unitID)
+	}
+	other, _ := s.setupDevice(11)
+
+	s.ap.mu.Lock()
+	s.ap.rejected[units[2]] = true
+	s.ap.mu.Unlock()
+
+	tm, err := s.migrator.CreateFromTemplate(s.ctx, 10, 20)
+	s.Require().NoError(err)
+
+	st, err := s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(models.TemplateMigrationStatusRunning, st.Migration.Status)
+	s.Equal(5, st.Pending)
+
+	n, err := s.migrator.ProcessDue(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(2, n, "Should only take one batch at a time.")
+
+	s.runToCompletion()
+
+	st, err = s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(models.TemplateMigrationStatusCompleted, st.Migration.Status)
+	s.True(st.Migration.CompletedAt.Valid)
+	s.Equal(0, st.Pending)
+	s.Equal(4, st.Succeeded)
+	s.Equal(1, st.Failed)
+	s.Require().Len(st.Failures, 1)
+	s.Equal(units[2], st.Failures[0].Serial)
+	s.NotEmpty(st.Failures[0].Error.String)
+
+	for i, unitID := range units {
+		if i == 2 {
+			s.Equal(10, s.ap.template(unitID))
+		} else {
+			s.Equal(20, s.ap.template(unitID))
+		}
+	}
+	s.Equal(11, s.ap.template(other), "Devices on other templates should be left alone.")
+
+	changes, err := models.HardwareTemplateChanges().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(4, changes)
+
+	_, err = s.migrator.Pause(s.ctx, tm.ID)
+	s.ErrorIs(err, ErrTemplateMigrationCompleted)
+}
+
+func (s *TemplateMigratorTestSuite) TestFromAddresses() {
+	unit1, addr1 := s.setupDevice(10)
+	unit2, addr2 := s.setupDevice(11)
+	unit3, _ := s.setupDevice(10)
+
+	_, err := s.migrator.CreateFromAddresses(s.ctx, []common.Address{addr1, common.HexToAddress("0x1")}, 20)
+	var uerr *UnknownAftermarketDevicesError
+	s.Require().ErrorAs(err, &uerr)
+	s.Equal([]common.Address{common.HexToAddress("0x1")}, uerr.Addresses)
+
+	count, err := models.TemplateMigrations().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(count, "Nothing should be created when an address is unknown.")
+
+	_, err = s.migrator.CreateFromAddresses(s.ctx, nil, 20)
+	s.ErrorIs(err, ErrTemplateMigrationNoDevices)
+
+	tm, err := s.migrator.CreateFromAddresses(s.ctx, []common.Address{addr1, addr2, addr1}, 20)
+	s.Require().NoError(err)
+	s.False(tm.SourceTemplateID.Valid)
+
+	s.runToCompletion()
+
+	st, err := s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(models.TemplateMigrationStatusCompleted, st.Migration.Status)
+	s.Equal(2, st.Succeeded)
+
+	s.Equal(20, s.ap.template(unit1))
+	s.Equal(20, s.ap.template(unit2))
+	s.Equal(10, s.ap.template(unit3))
+}
+
+func (s *TemplateMigratorTestSuite) TestPauseAndResume() {
+	for i := 0; i < 4; i++ {
+		s.setupDevice(10)
+	}
+
+	tm, err := s.migrator.CreateFromTemplate(s.ctx, 10, 20)
+	s.Require().NoError(err)
+
+	// Pause after the first device of the batch has been moved.
+	s.ap.mu.Lock()
+	s.ap.onAssociate = func(string) {
+		_, err := s.migrator.Pause(s.ctx, tm.ID)
+		s.Require().NoError(err)
+	}
+	s.ap.mu.Unlock()
+
+	n, err := s.migrator.ProcessDue(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, n)
+
+	s.ap.mu.Lock()
+	s.ap.onAssociate = nil
+	s.ap.mu.Unlock()
+
+	n, err = s.migrator.ProcessDue(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n, "Paused migrations shouldn't be picked up.")
+
+	st, err := s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(models.TemplateMigrationStatusPaused, st.Migration.Status)
+	s.False(st.Migration.LeaseExpiresAt.Valid)
+	s.Equal(1, st.Succeeded)
+	s.Equal(3, st.Pending)
+
+	_, err = s.migrator.Resume(s.ctx, tm.ID)
+	s.Require().NoError(err)
+
+	s.runToCompletion()
+
+	st, err = s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(models.TemplateMigrationStatusCompleted, st.Migration.Status)
+	s.Equal(4, st.Succeeded)
+}
+
+func (s *TemplateMigratorTestSuite) TestOneMigrationAtATime() {
+	unit1, _ := s.setupDevice(10)
+	unit2, _ := s.setupDevice(11)
+
+	tm1, err := s.migrator.CreateFromTemplate(s.ctx, 10, 20)
+	s.Require().NoError(err)
+	tm2, err := s.migrator.CreateFromTemplate(s.ctx, 11, 20)
+	s.Require().NoError(err)
+
+	// Another worker is on the first migration.
+	tm1.LeaseExpiresAt = null.TimeFrom(time.Now().Add(time.Minute))
+	_, err = tm1.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.TemplateMigrationColumns.LeaseExpiresAt))
+	s.Require().NoError(err)
+
+	n, err := s.migrator.ProcessDue(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n, "Shouldn't start a second migration while the first is held.")
+	s.Equal(11, s.ap.template(unit2))
+
+	// The other worker went away.
+	tm1.LeaseExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	_, err = tm1.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.TemplateMigrationColumns.LeaseExpiresAt))
+	s.Require().NoError(err)
+
+	s.runToCompletion()
+
+	s.Equal(20, s.ap.template(unit1))
+	s.Equal(20, s.ap.template(unit2))
+
+	for _, id := range []string{tm1.ID, tm2.ID} {
+		st, err := s.migrator.Status(s.ctx, id)
+		s.Require().NoError(err)
+		s.Equal(models.TemplateMigrationStatusCompleted, st.Migration.Status)
+	}
+}
+
+func (s *TemplateMigratorTestSuite) TestProtectedTemplates() {
+	unitID, addr := s.setupDevice(128)
+	other, otherAddr := s.setupDevice(10)
+
+	_, err := s.migrator.CreateFromTemplate(s.ctx, 128, 20)
+	s.ErrorIs(err, ErrTemplateMigrationProtected)
+
+	// Devices added by address are checked when they're moved.
+	tm, err := s.migrator.CreateFromAddresses(s.ctx, []common.Address{addr, otherAddr}, 20)
+	s.Require().NoError(err)
+
+	s.runToCompletion()
+
+	st, err := s.migrator.Status(s.ctx, tm.ID)
+	s.Require().NoError(err)
+	s.Equal(1, st.Succeeded)
+	s.Equal(1, st.Failed)
+	s.Require().Len(st.Failures, 1)
+	s.Equal(unitID, st.Failures[0].Serial)
+
+	s.Equal(128, s.ap.template(unitID))
+	s.Equal(20, s.ap.template(other))
+}
+
+func (s *TemplateMigratorTestSuite) TestUnknownMigration() {
+	_, err := s.migrator.Status(s.ctx, ksuid.New().String())
+	s.ErrorIs(err, ErrTemplateMigrationNotFound)
+
+	_, err = s.migrator.Resume(s.ctx, ksuid.New().String())
+	s.ErrorIs(err, ErrTemplateMigrationNotFound)
+}
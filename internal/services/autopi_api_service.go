@@ -21,6 +21,7 @@ import (
 	"github.com/DIMO-Network/shared/pkg/http"
 	"github.com/DIMO-Network/shared/pkg/strings"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -56,11 +57,12 @@ type autoPiAPIService struct {
 	Settings   *config.Settings
 	httpClient http.ClientWrapper
 	dbs        func() *db.ReaderWriter
+	logger     *zerolog.Logger
 }
 
 var ErrNotFound = errors.New("not found")
 
-func NewAutoPiAPIService(settings *config.Settings, dbs func() *db.ReaderWriter) AutoPiAPIService {
+func NewAutoPiAPIService(settings *config.Settings, dbs func() *db.ReaderWriter, logger *zerolog.Logger) AutoPiAPIService {
 	h := map[string]string{"Authorization": "APIToken " + settings.AutoPiAPIToken}
 	hcw, _ := http.NewClientWrapper(settings.AutoPiAPIURL, "", 60*time.Second, h, true) // ok to ignore err since only used for tor check
 
@@ -68,6 +70,7 @@ func NewAutoPiAPIService(settings *config.Settings, dbs func() *db.ReaderWriter)
 		Settings:   settings,
 		httpClient: hcw,
 		dbs:        dbs,
+		logger:     logger,
 	}
 }
 
@@ -325,14 +328,12 @@ func (a *autoPiAPIService) ApplyTemplate(deviceID string, templateID int) error
 
 // CommandQueryVIN sends raw command to autopi to get the vin in the webhook response after. only works if device is online.
 func (a *autoPiAPIService) CommandQueryVIN(ctx context.Context, unitID, deviceID, userDeviceID string) (*AutoPiCommandResponse, error) {
-	return a.CommandRaw(ctx, unitID, deviceID,
-		"obd.query vin mode=09 pid=02 header=7DF bytes=20 formula='messages[0].data[3:].decode(\"ascii\")' baudrate=500000 protocol=auto verify=false force=true",
-		userDeviceID)
+	return a.CommandRaw(ctx, unitID, deviceID, AutoPiQueryVINCommand, userDeviceID)
 }
 
 // CommandSyncDevice sends raw command to autopi only if it is online. Invokes syncing the pending changes (eg. template change) on the device.
 func (a *autoPiAPIService) CommandSyncDevice(ctx context.Context, unitID, deviceID, userDeviceID string) (*AutoPiCommandResponse, error) {
-	return a.CommandRaw(ctx, unitID, deviceID, "state.sls pending", userDeviceID)
+	return a.CommandRaw(ctx, unitID, deviceID, AutoPiSyncCommand, userDeviceID)
 }
 
 // CommandRaw sends raw command to autopi and saves in autopi_jobs. If device is offline command will eventually timeout.
@@ -369,6 +370,7 @@ func (a *autoPiAPIService) CommandRaw(ctx context.Context, unitID, deviceID, com
 	autoPiJob := models.AutopiJob{
 		ID:             d.Jid,
 		Command:        command,
+		State:          AutoPiJobStateSent,
 		AutopiDeviceID: deviceID,
 		AutopiUnitID:   null.StringFrom(unitID),
 	}
@@ -383,14 +385,36 @@ func (a *autoPiAPIService) CommandRaw(ctx context.Context, unitID, deviceID, com
 	return d, nil
 }
 
-// UpdateJob updates the state of a autopi job on our end
+// UpdateJob moves an autopi job to the state reported by its webhook. The state must be a valid
+// next step for the job, otherwise the returned error wraps ErrInvalidAutoPiJobState and nothing
+// is saved. Results of known commands, such as the VIN query, are also saved on the aftermarket
+// device; ones that can't be read are logged and only kept on the job.
 func (a *autoPiAPIService) UpdateJob(ctx context.Context, jobID, newState string, result *AutoPiCommandResult) (*models.AutopiJob, error) {
-	autopiJob, err := models.AutopiJobs(models.AutopiJobWhere.ID.EQ(jobID)).One(ctx, a.dbs().Reader)
+	state, ok := ParseAutoPiJobState(newState)
+	if !ok {
+		return nil, fmt.Errorf("%w: unrecognized state %q", ErrInvalidAutoPiJobState, newState)
+	}
+
+	tx, err := a.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	autopiJob, err := models.AutopiJobs(
+		models.AutopiJobWhere.ID.EQ(jobID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error finding autopi job")
 	}
+
+	if err := CheckAutoPiJobTransition(autopiJob.State, state); err != nil {
+		return nil, err
+	}
+
 	// update the job state
-	autopiJob.State = newState
+	autopiJob.State = state
 	autopiJob.CommandLastUpdated = null.TimeFrom(time.Now().UTC())
 	if result != nil {
 		err = autopiJob.CommandResult.Marshal(result)
@@ -399,13 +423,60 @@ func (a *autoPiAPIService) UpdateJob(ctx context.Context, jobID, newState string
 		}
 	}
 
-	_, err = autopiJob.Update(ctx, a.dbs().Writer, boil.Infer())
+	_, err = autopiJob.Update(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, errors.Wrapf(err, "error updating autopi job")
 	}
+
+	if state == AutoPiJobStateExecuted && autopiJob.AutopiUnitID.Valid {
+		// A result we can't read is still kept on the job, so it isn't an error here.
+		parsed, err := ParseAutoPiCommandResult(autopiJob.Command, result)
+		if err != nil {
+			a.logger.Warn().Err(err).Str("jobId", jobID).Str("command", autopiJob.Command).Msg("Couldn't read AutoPi command result.")
+		} else if parsed != nil {
+			if err := saveAutoPiParsedResult(ctx, tx, autopiJob.AutopiUnitID.String, parsed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return autopiJob, nil
 }
 
+// saveAutoPiParsedResult stores the typed values from a command result in the aftermarket
+// device's metadata.
+func saveAutoPiParsedResult(ctx context.Context, tx boil.ContextExecutor, serial string, parsed *AutoPiParsedResult) error {
+	amd, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.Serial.EQ(serial),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return errors.Wrapf(err, "error finding aftermarket device %s", serial)
+	}
+
+	md := new(AftermarketDeviceMetadata)
+	if amd.Metadata.Valid {
+		if err := amd.Metadata.Unmarshal(md); err != nil {
+			return errors.Wrap(err, "failed to unmarshal aftermarket device metadata")
+		}
+	}
+
+	if parsed.VIN != "" {
+		md.QueriedVIN = parsed.VIN
+	}
+
+	if err := amd.Metadata.Marshal(md); err != nil {
+		return err
+	}
+
+	_, err = amd.Update(ctx, tx, boil.Whitelist(models.AftermarketDeviceColumns.Metadata, models.AftermarketDeviceColumns.UpdatedAt))
+	return err
+}
+
 // GetCommandStatusFromAutoPi gets the status of a previously sent command by calling autopi. returns raw body since it can change depending on command
 func (a *autoPiAPIService) GetCommandStatusFromAutoPi(deviceID string, jobID string) ([]byte, error) {
 	res, err := a.httpClient.ExecuteRequest(fmt.Sprintf("/dongle/devices/%s/command_result/%s/", deviceID, jobID), "GET", nil)
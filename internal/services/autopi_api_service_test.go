@@ -83,7 +83,7 @@ func (s *AutoPiAPIServiceTestSuite) TestGetUserDeviceIntegrationByUnitID() {
 	err := apUdai.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	assert.NoError(s.T(), err)
 	// act
-	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff"}, s.pdb.DBS)
+	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff"}, s.pdb.DBS, test.Logger())
 	udai, err := autoPiSvc.GetUserDeviceIntegrationByUnitID(context.Background(), autoPiUnitID)
 	// assert
 	require.NoError(s.T(), err)
@@ -109,7 +109,7 @@ func (s *AutoPiAPIServiceTestSuite) TestCommandRaw() {
 	url := fmt.Sprintf("%s/dongle/devices/%s/execute_raw/", apiURL, deviceID)
 	httpmock.RegisterResponder(http.MethodPost, url, httpmock.NewStringResponder(200, respJSON))
 
-	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff", AutoPiAPIURL: apiURL}, s.pdb.DBS)
+	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff", AutoPiAPIURL: apiURL}, s.pdb.DBS, test.Logger())
 	// call method
 	commandResponse, err := autoPiSvc.CommandRaw(context.Background(), unitID, deviceID, "command", "")
 	require.NoError(s.T(), err)
@@ -140,7 +140,7 @@ func (s *AutoPiAPIServiceTestSuite) TestGetDeviceByUnitID_Should_Be_NotFound() {
 	httpmock.RegisterResponder(http.MethodGet, url, httpmock.NewStringResponder(404, `{ "status": false}`))
 
 	// act
-	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff", AutoPiAPIURL: apiURL}, s.pdb.DBS)
+	autoPiSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIToken: "fdff", AutoPiAPIURL: apiURL}, s.pdb.DBS, test.Logger())
 	_, err := autoPiSvc.GetDeviceByUnitID(unitID)
 
 	// assert
@@ -158,7 +158,7 @@ func TestUpdateState(t *testing.T) {
 	httpmock.RegisterResponder(http.MethodGet, url, httpmock.NewStringResponder(200, testDongleDeviceResp))
 	httpmock.RegisterResponder(http.MethodPatch, url, httpmock.NewStringResponder(200, `{}`))
 
-	apSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIURL: "https://mock.town"}, nil)
+	apSvc := NewAutoPiAPIService(&config.Settings{AutoPiAPIURL: "https://mock.town"}, nil, test.Logger())
 	err := apSvc.UpdateState("1c030237-af16-492c-9020-a183dad2797b", "Failed", "", "")
 	assert.NoError(t, err)
 }
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	vinutil "github.com/DIMO-Network/shared/pkg/vin"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// AutoPi job states. A job is Sent when the command goes out, and AutoPi's webhook moves it to
+// one of the others. Jobs that never hear back are timed out by the command reaper.
+const (
+	AutoPiJobStateSent     = "Sent"
+	AutoPiJobStateExecuted = "COMMAND_EXECUTED"
+	AutoPiJobStateFailed   = "COMMAND_FAILED"
+	AutoPiJobStateTimedOut = "COMMAND_TIMEOUT"
+)
+
+// autoPiJobTransitions lists the states a job in each state may move to. A timed out job can
+// still finish, since a device that was asleep may answer after we've given up on it.
+var autoPiJobTransitions = map[string][]string{
+	AutoPiJobStateSent:     {AutoPiJobStateExecuted, AutoPiJobStateFailed, AutoPiJobStateTimedOut},
+	AutoPiJobStateTimedOut: {AutoPiJobStateExecuted, AutoPiJobStateFailed},
+}
+
+// ErrInvalidAutoPiJobState is returned when a webhook reports a state we don't know, or one
+// that the job can't move to from where it is.
+var ErrInvalidAutoPiJobState = errors.New("invalid autopi job state")
+
+// defaultAutoPiJobTimeout is used when AUTOPI_JOB_TIMEOUT is not set. AutoPi gives up on
+// callbacks after two minutes, so this leaves plenty of slack.
+const defaultAutoPiJobTimeout = 10 * time.Minute
+
+// AutoPi commands whose results we know how to read.
+const (
+	AutoPiQueryVINCommand = "obd.query vin mode=09 pid=02 header=7DF bytes=20 formula='messages[0].data[3:].decode(\"ascii\")' baudrate=500000 protocol=auto verify=false force=true"
+	AutoPiSyncCommand     = "state.sls pending"
+)
+
+// ParseAutoPiJobState returns the state a webhook reports, ignoring case, or false if it isn't
+// one we know.
+func ParseAutoPiJobState(s string) (string, bool) {
+	for _, st := range []string{AutoPiJobStateSent, AutoPiJobStateExecuted, AutoPiJobStateFailed, AutoPiJobStateTimedOut} {
+		if strings.EqualFold(s, st) {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+// CheckAutoPiJobTransition returns an error wrapping ErrInvalidAutoPiJobState if a job can't
+// move between the two states. Repeating the current state is allowed, since AutoPi may send
+// the same webhook more than once. Jobs saved before states were checked may hold other values;
+// those are treated as Sent.
+func CheckAutoPiJobTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	next, ok := autoPiJobTransitions[from]
+	if !ok {
+		if _, known := ParseAutoPiJobState(from); known {
+			return fmt.Errorf("%w: job is already %s", ErrInvalidAutoPiJobState, from)
+		}
+		next = autoPiJobTransitions[AutoPiJobStateSent]
+	}
+
+	if !slices.Contains(next, to) {
+		return fmt.Errorf("%w: can't go from %s to %s", ErrInvalidAutoPiJobState, from, to)
+	}
+
+	return nil
+}
+
+// AutoPiParsedResult holds typed values read out of the result of a known command.
+type AutoPiParsedResult struct {
+	// VIN is set for VIN queries.
+	VIN string `json:"vin,omitempty"`
+}
+
+// ParseAutoPiCommandResult reads the result of a command we know. It returns nil for other
+// commands.
+func ParseAutoPiCommandResult(command string, result *AutoPiCommandResult) (*AutoPiParsedResult, error) {
+	if result == nil {
+		return nil, nil
+	}
+
+	switch command {
+	case AutoPiQueryVINCommand:
+		vin := strings.ToUpper(strings.TrimSpace(result.Value))
+		if v := vinutil.VIN(vin); !v.IsValidVIN() && !v.IsValidJapanChassis() {
+			return nil, fmt.Errorf("vin query returned %q, which is not a valid VIN", result.Value)
+		}
+		return &AutoPiParsedResult{VIN: vin}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ListAutoPiJobs returns the AutoPi jobs for a user device created at or after since, newest
+// first. Paging works as in ListDeviceCommandRequests.
+func ListAutoPiJobs(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, since time.Time, cursor string, limit int) (models.AutopiJobSlice, string, error) {
+	if limit <= 0 {
+		limit = DefaultCommandHistoryLimit
+	} else if limit > MaxCommandHistoryLimit {
+		limit = MaxCommandHistoryLimit
+	}
+
+	mods := []qm.QueryMod{
+		models.AutopiJobWhere.UserDeviceID.EQ(null.StringFrom(userDeviceID)),
+		models.AutopiJobWhere.CreatedAt.GTE(since),
+		qm.OrderBy(models.AutopiJobColumns.CreatedAt + " DESC, " + models.AutopiJobColumns.ID + " DESC"),
+		// Fetch one extra to find out whether there's another page.
+		qm.Limit(limit + 1),
+	}
+
+	if cursor != "" {
+		last, err := models.AutopiJobs(
+			models.AutopiJobWhere.ID.EQ(cursor),
+			models.AutopiJobWhere.UserDeviceID.EQ(null.StringFrom(userDeviceID)),
+			models.AutopiJobWhere.CreatedAt.GTE(since),
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, "", ErrInvalidCommandCursor
+			}
+			return nil, "", err
+		}
+
+		mods = append(mods, qm.Where("("+models.AutopiJobColumns.CreatedAt+", "+models.AutopiJobColumns.ID+") < (?, ?)", last.CreatedAt, last.ID))
+	}
+
+	jobs, err := models.AutopiJobs(mods...).All(ctx, exec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+		next = jobs[limit-1].ID
+	}
+
+	return jobs, next, nil
+}
+
+// NewAutoPiJobTimeout parses how long an AutoPi job may wait for its webhook.
+func NewAutoPiJobTimeout(settings *config.Settings) (time.Duration, error) {
+	if settings.AutoPiJobTimeout == "" {
+		return defaultAutoPiJobTimeout, nil
+	}
+
+	d, err := time.ParseDuration(settings.AutoPiJobTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse autopi job timeout: %w", err)
+	}
+
+	return d, nil
+}
+
+// ReapExpiredAutoPiJobs moves Sent jobs older than the timeout to timed out and returns how
+// many were moved.
+func ReapExpiredAutoPiJobs(ctx context.Context, dbs func() *db.ReaderWriter, timeout time.Duration) (int, error) {
+	now := time.Now()
+
+	n, err := models.AutopiJobs(
+		models.AutopiJobWhere.State.EQ(AutoPiJobStateSent),
+		models.AutopiJobWhere.CreatedAt.LT(now.Add(-timeout)),
+	).UpdateAll(ctx, dbs().Writer, models.M{
+		models.AutopiJobColumns.State:              AutoPiJobStateTimedOut,
+		models.AutopiJobColumns.CommandLastUpdated: now,
+		models.AutopiJobColumns.UpdatedAt:          now,
+	})
+
+	return int(n), err
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestCheckAutoPiJobTransition(t *testing.T) {
+	for _, tc := range []struct {
+		from, to string
+		ok       bool
+	}{
+		{AutoPiJobStateSent, AutoPiJobStateExecuted, true},
+		{AutoPiJobStateSent, AutoPiJobStateFailed, true},
+		{AutoPiJobStateSent, AutoPiJobStateTimedOut, true},
+		{AutoPiJobStateTimedOut, AutoPiJobStateExecuted, true},
+		{AutoPiJobStateExecuted, AutoPiJobStateExecuted, true},
+		{AutoPiJobStateExecuted, AutoPiJobStateFailed, false},
+		{AutoPiJobStateFailed, AutoPiJobStateExecuted, false},
+		{AutoPiJobStateExecuted, AutoPiJobStateSent, false},
+		{AutoPiJobStateTimedOut, AutoPiJobStateSent, false},
+		// Unknown states from older rows act like Sent.
+		{"QUEUED", AutoPiJobStateExecuted, true},
+		{"QUEUED", AutoPiJobStateSent, false},
+	} {
+		err := CheckAutoPiJobTransition(tc.from, tc.to)
+		if tc.ok {
+			assert.NoError(t, err, "%s -> %s", tc.from, tc.to)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidAutoPiJobState, "%s -> %s", tc.from, tc.to)
+		}
+	}
+
+	state, ok := ParseAutoPiJobState("command_executed")
+	assert.True(t, ok)
+	assert.Equal(t, AutoPiJobStateExecuted, state)
+
+	_, ok = ParseAutoPiJobState("COMMAND_RECEIVED")
+	assert.False(t, ok)
+}
+
+func TestParseAutoPiCommandResult(t *testing.T) {
+	parsed, err := ParseAutoPiCommandResult(AutoPiQueryVINCommand, &AutoPiCommandResult{Value: " 1hgcm82633a004352 ", Type: "vin"})
+	require.NoError(t, err)
+	assert.Equal(t, "1HGCM82633A004352", parsed.VIN)
+
+	_, err = ParseAutoPiCommandResult(AutoPiQueryVINCommand, &AutoPiCommandResult{Value: "\x00\x00garbage"})
+	assert.Error(t, err)
+
+	parsed, err = ParseAutoPiCommandResult(AutoPiSyncCommand, &AutoPiCommandResult{Value: "ok"})
+	require.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	parsed, err = ParseAutoPiCommandResult(AutoPiQueryVINCommand, nil)
+	require.NoError(t, err)
+	assert.Nil(t, parsed)
+}
+
+func TestNewAutoPiJobTimeout(t *testing.T) {
+	d, err := NewAutoPiJobTimeout(&config.Settings{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultAutoPiJobTimeout, d)
+
+	d, err = NewAutoPiJobTimeout(&config.Settings{AutoPiJobTimeout: "3m"})
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, d)
+
+	_, err = NewAutoPiJobTimeout(&config.Settings{AutoPiJobTimeout: "later"})
+	assert.Error(t, err)
+}
+
+func TestAutoPiJobs(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	const (
+		unitID   = "431d2e89-46f1-6884-6226-5d1ad20c84d9"
+		deviceID = "device123"
+	)
+
+	ud := test.SetupCreateUserDevice(t, "dylan", ksuid.New().String(), nil, "", pdb)
+	amd := test.SetupCreateAftermarketDevice(t, "dylan", nil, unitID, func(s string) *string { return &s }(deviceID), pdb)
+
+	now := time.Now()
+	insert := func(command, state string, createdAt time.Time) *models.AutopiJob {
+		job := &models.AutopiJob{
+			ID:             ksuid.New().String(),
+			AutopiDeviceID: deviceID,
+			AutopiUnitID:   null.StringFrom(unitID),
+			UserDeviceID:   null.StringFrom(ud.ID),
+			Command:        command,
+			State:          state,
+			CreatedAt:      createdAt,
+		}
+		require.NoError(t, job.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return job
+	}
+
+	vinJob := insert(AutoPiQueryVINCommand, AutoPiJobStateSent, now.Add(-3*time.Minute))
+	syncJob := insert(AutoPiSyncCommand, AutoPiJobStateSent, now.Add(-2*time.Minute))
+	stale := insert(AutoPiSyncCommand, AutoPiJobStateSent, now.Add(-time.Hour))
+
+	apSvc := NewAutoPiAPIService(&config.Settings{}, pdb.DBS, test.Logger())
+
+	job, err := apSvc.UpdateJob(ctx, vinJob.ID, "COMMAND_EXECUTED", &AutoPiCommandResult{Value: "1HGCM82633A004352", Type: "vin"})
+	require.NoError(t, err)
+	assert.Equal(t, AutoPiJobStateExecuted, job.State)
+
+	require.NoError(t, amd.Reload(ctx, pdb.DBS().Reader))
+	md := new(AftermarketDeviceMetadata)
+	require.NoError(t, amd.Metadata.Unmarshal(md))
+	assert.Equal(t, "1HGCM82633A004352", md.QueriedVIN)
+	assert.Equal(t, deviceID, md.AutoPiDeviceID, "Other metadata should be kept.")
+
+	// AutoPi sending the same webhook again is fine.
+	_, err = apSvc.UpdateJob(ctx, vinJob.ID, "COMMAND_EXECUTED", nil)
+	require.NoError(t, err)
+
+	_, err = apSvc.UpdateJob(ctx, vinJob.ID, "COMMAND_FAILED", nil)
+	assert.ErrorIs(t, err, ErrInvalidAutoPiJobState)
+
+	_, err = apSvc.UpdateJob(ctx, syncJob.ID, "SOMETHING_ELSE", nil)
+	assert.ErrorIs(t, err, ErrInvalidAutoPiJobState)
+
+	require.NoError(t, vinJob.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, AutoPiJobStateExecuted, vinJob.State)
+	require.NoError(t, syncJob.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, AutoPiJobStateSent, syncJob.State)
+
+	n, err := ReapExpiredAutoPiJobs(ctx, pdb.DBS, 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	require.NoError(t, stale.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, AutoPiJobStateTimedOut, stale.State)
+
+	// A device that wakes up late can still finish a timed out job.
+	_, err = apSvc.UpdateJob(ctx, stale.ID, "COMMAND_EXECUTED", nil)
+	require.NoError(t, err)
+
+	jobs, next, err := ListAutoPiJobs(ctx, pdb.DBS().Reader, ud.ID, time.Time{}, "", 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, syncJob.ID, jobs[0].ID)
+	assert.Equal(t, vinJob.ID, jobs[1].ID)
+	require.Equal(t, vinJob.ID, next)
+
+	jobs, next, err = ListAutoPiJobs(ctx, pdb.DBS().Reader, ud.ID, time.Time{}, next, 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, stale.ID, jobs[0].ID)
+	assert.Empty(t, next)
+
+	_, _, err = ListAutoPiJobs(ctx, pdb.DBS().Reader, ud.ID, time.Time{}, ksuid.New().String(), 2)
+	assert.ErrorIs(t, err, ErrInvalidCommandCursor)
+
+	// Jobs from before the cutoff are left out, and can't be used as a cursor.
+	jobs, next, err = ListAutoPiJobs(ctx, pdb.DBS().Reader, ud.ID, now.Add(-150*time.Second), "", 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, syncJob.ID, jobs[0].ID)
+	assert.Empty(t, next)
+
+	_, _, err = ListAutoPiJobs(ctx, pdb.DBS().Reader, ud.ID, now.Add(-150*time.Second), vinJob.ID, 2)
+	assert.ErrorIs(t, err, ErrInvalidCommandCursor)
+}
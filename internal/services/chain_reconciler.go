@@ -0,0 +1,473 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// ErrTokenNotFound is returned by a ChainReader when the token has never been minted or has
+// since been burned.
+var ErrTokenNotFound = errors.New("token does not exist on chain")
+
+// ChainReader is the part of the registry state that ChainReconciler compares our tables with.
+// Token ids that mean "none", like an unpaired aftermarket device's vehicle, come back as zero.
+type ChainReader interface {
+	VehicleOwner(ctx context.Context, tokenID *big.Int) (common.Address, error)
+	VehicleDeviceDefinitionID(ctx context.Context, tokenID *big.Int) (string, error)
+	AftermarketDeviceOwner(ctx context.Context, tokenID *big.Int) (common.Address, error)
+	AftermarketDeviceVehicle(ctx context.Context, tokenID *big.Int) (*big.Int, error)
+	SyntheticDeviceOwner(ctx context.Context, tokenID *big.Int) (common.Address, error)
+	SyntheticDeviceVehicle(ctx context.Context, tokenID *big.Int) (*big.Int, error)
+	SyntheticDeviceIDByAddress(ctx context.Context, addr common.Address) (*big.Int, error)
+}
+
+// ChainFixer applies registry changes the same way the contract events consumer does. It's
+// satisfied by ContractsEventsConsumer.
+type ChainFixer interface {
+	BurnVehicle(ctx context.Context, tokenID *big.Int, owner common.Address) error
+	BurnAftermarketDevice(ctx context.Context, tokenID *big.Int) error
+	BurnSyntheticDevice(ctx context.Context, tokenID *big.Int, owner common.Address) error
+	SetDeviceDefinition(ctx context.Context, tokenID *big.Int, ddID string, txHash common.Hash) error
+	TransferVehicle(ctx context.Context, tokenID *big.Int, from, to common.Address, txHash common.Hash) error
+	TransferAftermarketDevice(ctx context.Context, tokenID *big.Int, to common.Address) error
+}
+
+type contractsChainReader struct {
+	registry    *contracts.Registry
+	vehicle     *contracts.MultiPrivilege
+	aftermarket *contracts.AftermarketDeviceId
+	synthetic   *contracts.MultiPrivilege
+
+	vehicleAddr   common.Address
+	syntheticAddr common.Address
+	afterAddr     common.Address
+}
+
+// NewChainReader reads registry state through the generated contract bindings.
+func NewChainReader(backend bind.ContractBackend, settings *config.Settings) (ChainReader, error) {
+	r := &contractsChainReader{
+		vehicleAddr:   common.HexToAddress(settings.VehicleNFTAddress),
+		syntheticAddr: common.HexToAddress(settings.SyntheticDeviceNFTAddress),
+		afterAddr:     common.HexToAddress(settings.AftermarketDeviceContractAddress),
+	}
+
+	var err error
+	if r.registry, err = contracts.NewRegistry(common.HexToAddress(settings.DIMORegistryAddr), backend); err != nil {
+		return nil, err
+	}
+	if r.vehicle, err = contracts.NewMultiPrivilege(r.vehicleAddr, backend); err != nil {
+		return nil, err
+	}
+	if r.aftermarket, err = contracts.NewAftermarketDeviceId(r.afterAddr, backend); err != nil {
+		return nil, err
+	}
+	if r.synthetic, err = contracts.NewMultiPrivilege(r.syntheticAddr, backend); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ownerOfErr turns a reverted ownerOf call into ErrTokenNotFound. Providers don't agree on
+// how reverts are reported, but they all say this.
+func ownerOfErr(err error) error {
+	if err != nil && strings.Contains(err.Error(), "execution reverted") {
+		return ErrTokenNotFound
+	}
+	return err
+}
+
+func (r *contractsChainReader) VehicleOwner(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	owner, err := r.vehicle.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+	return owner, ownerOfErr(err)
+}
+
+func (r *contractsChainReader) VehicleDeviceDefinitionID(ctx context.Context, tokenID *big.Int) (string, error) {
+	return r.registry.GetDeviceDefinitionIdByVehicleId(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (r *contractsChainReader) AftermarketDeviceOwner(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	owner, err := r.aftermarket.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+	return owner, ownerOfErr(err)
+}
+
+func (r *contractsChainReader) AftermarketDeviceVehicle(ctx context.Context, tokenID *big.Int) (*big.Int, error) {
+	return r.registry.GetLink(&bind.CallOpts{Context: ctx}, r.afterAddr, tokenID)
+}
+
+func (r *contractsChainReader) SyntheticDeviceOwner(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	owner, err := r.synthetic.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+	return owner, ownerOfErr(err)
+}
+
+func (r *contractsChainReader) SyntheticDeviceVehicle(ctx context.Context, tokenID *big.Int) (*big.Int, error) {
+	return r.registry.GetNodeLink(&bind.CallOpts{Context: ctx}, r.syntheticAddr, r.vehicleAddr, tokenID)
+}
+
+func (r *contractsChainReader) SyntheticDeviceIDByAddress(ctx context.Context, addr common.Address) (*big.Int, error) {
+	return r.registry.GetSyntheticDeviceIdByAddress(&bind.CallOpts{Context: ctx}, addr)
+}
+
+type ChainMismatchKind string
+
+const (
+	// ChainMismatchOwner means the token has a different owner on chain.
+	ChainMismatchOwner ChainMismatchKind = "Owner"
+	// ChainMismatchPairedVehicle means the device is paired to a different vehicle, or to none.
+	ChainMismatchPairedVehicle ChainMismatchKind = "PairedVehicle"
+	// ChainMismatchDeviceDefinition means the vehicle has a different device definition on chain.
+	ChainMismatchDeviceDefinition ChainMismatchKind = "DeviceDefinition"
+	// ChainMismatchMissingToken means the chain has a token for a row that has none.
+	ChainMismatchMissingToken ChainMismatchKind = "MissingToken"
+	// ChainMismatchBurnedToken means the row's token doesn't exist on chain.
+	ChainMismatchBurnedToken ChainMismatchKind = "BurnedToken"
+)
+
+// ChainMismatch is one difference between a row and the chain. Database and Chain hold the two
+// values, formatted for people.
+type ChainMismatch struct {
+	Table    string            `json:"table"`
+	ID       string            `json:"id"`
+	TokenID  *big.Int          `json:"tokenId,omitempty"`
+	Kind     ChainMismatchKind `json:"kind"`
+	Database string            `json:"database"`
+	Chain    string            `json:"chain"`
+	Fixed    bool              `json:"fixed"`
+}
+
+const defaultReconcilePageSize = 100
+
+// ChainReconciler pages through user_devices, aftermarket_devices and synthetic_devices and
+// reports every row that disagrees with the registry. With Fix set, it also corrects the row.
+type ChainReconciler struct {
+	Fix      bool
+	PageSize int
+
+	db    db.Store
+	chain ChainReader
+	fixer ChainFixer
+	log   *zerolog.Logger
+}
+
+func NewChainReconciler(pdb db.Store, chain ChainReader, fixer ChainFixer, log *zerolog.Logger) *ChainReconciler {
+	return &ChainReconciler{
+		PageSize: defaultReconcilePageSize,
+		db:       pdb,
+		chain:    chain,
+		fixer:    fixer,
+		log:      log,
+	}
+}
+
+// Run checks every table, calling report for each mismatch as it's found.
+func (r *ChainReconciler) Run(ctx context.Context, report func(*ChainMismatch) error) error {
+	if err := r.reconcileVehicles(ctx, report); err != nil {
+		return fmt.Errorf("failed to reconcile vehicles: %w", err)
+	}
+	if err := r.reconcileAftermarketDevices(ctx, report); err != nil {
+		return fmt.Errorf("failed to reconcile aftermarket devices: %w", err)
+	}
+	if err := r.reconcileSyntheticDevices(ctx, report); err != nil {
+		return fmt.Errorf("failed to reconcile synthetic devices: %w", err)
+	}
+	return nil
+}
+
+// found records and, when fixing, applies a mismatch. A failed fix is logged and reported as
+// unfixed rather than stopping the run.
+func (r *ChainReconciler) found(m *ChainMismatch, report func(*ChainMismatch) error, fix func() error) error {
+	if r.Fix {
+		if err := fix(); err != nil {
+			r.log.Err(err).Str("table", m.Table).Str("id", m.ID).Str("kind", string(m.Kind)).Msg("Failed to fix mismatch.")
+		} else {
+			m.Fixed = true
+		}
+	}
+	return report(m)
+}
+
+func (r *ChainReconciler) reconcileVehicles(ctx context.Context, report func(*ChainMismatch) error) error {
+	lastID := ""
+	for {
+		uds, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.IsNotNull(),
+			models.UserDeviceWhere.ID.GT(lastID),
+			qm.OrderBy(models.UserDeviceColumns.ID),
+			qm.Limit(r.PageSize),
+		).All(ctx, r.db.DBS().Reader)
+		if err != nil {
+			return err
+		}
+
+		for _, ud := range uds {
+			if err := r.reconcileVehicle(ctx, ud, report); err != nil {
+				return err
+			}
+		}
+
+		if len(uds) < r.PageSize {
+			return nil
+		}
+		lastID = uds[len(uds)-1].ID
+	}
+}
+
+func (r *ChainReconciler) reconcileVehicle(ctx context.Context, ud *models.UserDevice, report func(*ChainMismatch) error) error {
+	tokenID := ud.TokenID.Int(nil)
+	dbOwner := common.BytesToAddress(ud.OwnerAddress.Bytes)
+
+	mismatch := func(kind ChainMismatchKind, database, chain string) *ChainMismatch {
+		return &ChainMismatch{Table: models.TableNames.UserDevices, ID: ud.ID, TokenID: tokenID, Kind: kind, Database: database, Chain: chain}
+	}
+
+	owner, err := r.chain.VehicleOwner(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return r.found(mismatch(ChainMismatchBurnedToken, tokenID.String(), ""), report, func() error {
+				return r.fixer.BurnVehicle(ctx, tokenID, dbOwner)
+			})
+		}
+		return err
+	}
+
+	if !ud.OwnerAddress.Valid || owner != dbOwner {
+		// Going through the consumer means the previous owner's data is handed over as it would
+		// have been had we seen the Transfer.
+		err := r.found(mismatch(ChainMismatchOwner, formatOptionalAddress(ud.OwnerAddress), owner.Hex()), report, func() error {
+			return r.fixer.TransferVehicle(ctx, tokenID, dbOwner, owner, common.Hash{})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	ddID, err := r.chain.VehicleDeviceDefinitionID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	// Vehicles minted before definitions moved on chain have none.
+	if ddID != "" && ddID != ud.DefinitionID {
+		return r.found(mismatch(ChainMismatchDeviceDefinition, ud.DefinitionID, ddID), report, func() error {
+			return r.fixer.SetDeviceDefinition(ctx, tokenID, ddID, common.Hash{})
+		})
+	}
+
+	return nil
+}
+
+func (r *ChainReconciler) reconcileAftermarketDevices(ctx context.Context, report func(*ChainMismatch) error) error {
+	var after *big.Int
+	for {
+		mods := []qm.QueryMod{
+			qm.OrderBy(models.AftermarketDeviceColumns.TokenID),
+			qm.Limit(r.PageSize),
+		}
+		if after != nil {
+			mods = append(mods, models.AftermarketDeviceWhere.TokenID.GT(dbtypes.IntToDecimal(after)))
+		}
+
+		ads, err := models.AftermarketDevices(mods...).All(ctx, r.db.DBS().Reader)
+		if err != nil {
+			return err
+		}
+
+		for _, ad := range ads {
+			if err := r.reconcileAftermarketDevice(ctx, ad, report); err != nil {
+				return err
+			}
+		}
+
+		if len(ads) < r.PageSize {
+			return nil
+		}
+		after = ads[len(ads)-1].TokenID.Int(nil)
+	}
+}
+
+func (r *ChainReconciler) reconcileAftermarketDevice(ctx context.Context, ad *models.AftermarketDevice, report func(*ChainMismatch) error) error {
+	tokenID := ad.TokenID.Int(nil)
+
+	mismatch := func(kind ChainMismatchKind, database, chain string) *ChainMismatch {
+		return &ChainMismatch{Table: models.TableNames.AftermarketDevices, ID: ad.Serial, TokenID: tokenID, Kind: kind, Database: database, Chain: chain}
+	}
+
+	owner, err := r.chain.AftermarketDeviceOwner(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return r.found(mismatch(ChainMismatchBurnedToken, tokenID.String(), ""), report, func() error {
+				return r.fixer.BurnAftermarketDevice(ctx, tokenID)
+			})
+		}
+		return err
+	}
+
+	// Unclaimed devices belong to the manufacturer on chain, and we don't track that.
+	if ad.OwnerAddress.Valid && common.BytesToAddress(ad.OwnerAddress.Bytes) != owner {
+		err := r.found(mismatch(ChainMismatchOwner, formatOptionalAddress(ad.OwnerAddress), owner.Hex()), report, func() error {
+			return r.fixer.TransferAftermarketDevice(ctx, tokenID, owner)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	vehicleID, err := r.chain.AftermarketDeviceVehicle(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	if dbVehicleID := nullDecimalToInt(ad.VehicleTokenID); dbVehicleID.Cmp(vehicleID) != 0 {
+		return r.found(mismatch(ChainMismatchPairedVehicle, formatTokenID(dbVehicleID), formatTokenID(vehicleID)), report, func() error {
+			vehicleTokenID, err := r.knownVehicle(ctx, vehicleID)
+			if err != nil {
+				return err
+			}
+			ad.VehicleTokenID = vehicleTokenID
+			ad.PairRequestID = null.String{}
+			cols := models.AftermarketDeviceColumns
+			_, err = ad.Update(ctx, r.db.DBS().Writer, boil.Whitelist(cols.VehicleTokenID, cols.PairRequestID, cols.UpdatedAt))
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (r *ChainReconciler) reconcileSyntheticDevices(ctx context.Context, report func(*ChainMismatch) error) error {
+	lastID := ""
+	for {
+		sds, err := models.SyntheticDevices(
+			models.SyntheticDeviceWhere.MintRequestID.GT(lastID),
+			qm.OrderBy(models.SyntheticDeviceColumns.MintRequestID),
+			qm.Limit(r.PageSize),
+		).All(ctx, r.db.DBS().Reader)
+		if err != nil {
+			return err
+		}
+
+		for _, sd := range sds {
+			if err := r.reconcileSyntheticDevice(ctx, sd, report); err != nil {
+				return err
+			}
+		}
+
+		if len(sds) < r.PageSize {
+			return nil
+		}
+		lastID = sds[len(sds)-1].MintRequestID
+	}
+}
+
+func (r *ChainReconciler) reconcileSyntheticDevice(ctx context.Context, sd *models.SyntheticDevice, report func(*ChainMismatch) error) error {
+	mismatch := func(kind ChainMismatchKind, tokenID *big.Int, database, chain string) *ChainMismatch {
+		return &ChainMismatch{Table: models.TableNames.SyntheticDevices, ID: sd.MintRequestID, TokenID: tokenID, Kind: kind, Database: database, Chain: chain}
+	}
+
+	if sd.TokenID.IsZero() {
+		tokenID, err := r.chain.SyntheticDeviceIDByAddress(ctx, common.BytesToAddress(sd.WalletAddress))
+		if err != nil {
+			return err
+		}
+
+		// Most likely the mint is still in flight, or failed.
+		if tokenID.Sign() == 0 {
+			return nil
+		}
+
+		return r.found(mismatch(ChainMismatchMissingToken, tokenID, "", tokenID.String()), report, func() error {
+			sd.TokenID = dbtypes.NullIntToDecimal(tokenID)
+			_, err := sd.Update(ctx, r.db.DBS().Writer, boil.Whitelist(models.SyntheticDeviceColumns.TokenID))
+			return err
+		})
+	}
+
+	tokenID := sd.TokenID.Int(nil)
+
+	owner, err := r.chain.SyntheticDeviceOwner(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return r.found(mismatch(ChainMismatchBurnedToken, tokenID, tokenID.String(), ""), report, func() error {
+				return r.fixer.BurnSyntheticDevice(ctx, tokenID, owner)
+			})
+		}
+		return err
+	}
+
+	vehicleID, err := r.chain.SyntheticDeviceVehicle(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	if dbVehicleID := nullDecimalToInt(sd.VehicleTokenID); dbVehicleID.Cmp(vehicleID) != 0 {
+		return r.found(mismatch(ChainMismatchPairedVehicle, tokenID, formatTokenID(dbVehicleID), formatTokenID(vehicleID)), report, func() error {
+			vehicleTokenID, err := r.knownVehicle(ctx, vehicleID)
+			if err != nil {
+				return err
+			}
+			sd.VehicleTokenID = vehicleTokenID
+			_, err = sd.Update(ctx, r.db.DBS().Writer, boil.Whitelist(models.SyntheticDeviceColumns.VehicleTokenID))
+			return err
+		})
+	}
+
+	return nil
+}
+
+// knownVehicle returns the vehicle token id as a column value, or null if the token is zero or
+// there's no user device for it. Pairing columns reference user_devices.
+func (r *ChainReconciler) knownVehicle(ctx context.Context, tokenID *big.Int) (types.NullDecimal, error) {
+	if tokenID.Sign() == 0 {
+		return types.NullDecimal{}, nil
+	}
+
+	exists, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+	).Exists(ctx, r.db.DBS().Reader)
+	if err != nil {
+		return types.NullDecimal{}, err
+	}
+	if !exists {
+		r.log.Warn().Int64("vehicleTokenId", tokenID.Int64()).Msg("Paired vehicle isn't in user_devices, clearing the pairing instead.")
+		return types.NullDecimal{}, nil
+	}
+
+	return dbtypes.NullIntToDecimal(tokenID), nil
+}
+
+func nullDecimalToInt(d types.NullDecimal) *big.Int {
+	if d.IsZero() {
+		return new(big.Int)
+	}
+	return d.Int(nil)
+}
+
+func formatTokenID(tokenID *big.Int) string {
+	if tokenID.Sign() == 0 {
+		return ""
+	}
+	return tokenID.String()
+}
+
+func formatOptionalAddress(addr null.Bytes) string {
+	if !addr.Valid {
+		return ""
+	}
+	return common.BytesToAddress(addr.Bytes).Hex()
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type fakeChain struct {
+	vehicleOwners map[int64]common.Address
+	vehicleDDs    map[int64]string
+	adOwners      map[int64]common.Address
+	adVehicles    map[int64]int64
+}
+
+func (f *fakeChain) VehicleOwner(_ context.Context, tokenID *big.Int) (common.Address, error) {
+	owner, ok := f.vehicleOwners[tokenID.Int64()]
+	if !ok {
+		return common.Address{}, ErrTokenNotFound
+	}
+	return owner, nil
+}
+
+func (f *fakeChain) VehicleDeviceDefinitionID(_ context.Context, tokenID *big.Int) (string, error) {
+	return f.vehicleDDs[tokenID.Int64()], nil
+}
+
+func (f *fakeChain) AftermarketDeviceOwner(_ context.Context, tokenID *big.Int) (common.Address, error) {
+	owner, ok := f.adOwners[tokenID.Int64()]
+	if !ok {
+		return common.Address{}, ErrTokenNotFound
+	}
+	return owner, nil
+}
+
+func (f *fakeChain) AftermarketDeviceVehicle(_ context.Context, tokenID *big.Int) (*big.Int, error) {
+	return big.NewInt(f.adVehicles[tokenID.Int64()]), nil
+}
+
+func (f *fakeChain) SyntheticDeviceOwner(context.Context, *big.Int) (common.Address, error) {
+	return common.Address{}, ErrTokenNotFound
+}
+
+func (f *fakeChain) SyntheticDeviceVehicle(context.Context, *big.Int) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (f *fakeChain) SyntheticDeviceIDByAddress(context.Context, common.Address) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+type fakeFixer struct {
+	ChainFixer
+	burnedVehicles      []int64
+	transferredVehicles map[int64][2]common.Address
+	transferredADs      map[int64]common.Address
+}
+
+func (f *fakeFixer) BurnVehicle(_ context.Context, tokenID *big.Int, _ common.Address) error {
+	f.burnedVehicles = append(f.burnedVehicles, tokenID.Int64())
+	return nil
+}
+
+func (f *fakeFixer) TransferVehicle(_ context.Context, tokenID *big.Int, from, to common.Address, _ common.Hash) error {
+	f.transferredVehicles[tokenID.Int64()] = [2]common.Address{from, to}
+	return nil
+}
+
+func (f *fakeFixer) TransferAftermarketDevice(_ context.Context, tokenID *big.Int, to common.Address) error {
+	f.transferredADs[tokenID.Int64()] = to
+	return nil
+}
+
+func TestChainReconciler(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := test.Logger()
+
+	owner, newOwner := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+
+	insertVehicle := func(tokenID int64) *models.UserDevice {
+		ud := &models.UserDevice{
+			ID:           ksuid.New().String(),
+			UserID:       "user1",
+			DefinitionID: "ford_escape_2020",
+			OwnerAddress: null.BytesFrom(owner.Bytes()),
+			TokenID:      dbtypes.NullIntToDecimal(big.NewInt(tokenID)),
+		}
+		require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return ud
+	}
+
+	transferred := insertVehicle(1)
+	insertVehicle(2)
+	burned := insertVehicle(3)
+
+	ad := &models.AftermarketDevice{
+		Serial:                    ksuid.New().String(),
+		EthereumAddress:           common.HexToAddress("0xad").Bytes(),
+		TokenID:                   dbtypes.IntToDecimal(big.NewInt(10)),
+		DeviceManufacturerTokenID: dbtypes.IntToDecimal(big.NewInt(137)),
+		OwnerAddress:              null.BytesFrom(owner.Bytes()),
+		VehicleTokenID:            dbtypes.NullIntToDecimal(big.NewInt(1)),
+	}
+	require.NoError(t, ad.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	chain := &fakeChain{
+		vehicleOwners: map[int64]common.Address{1: newOwner, 2: owner},
+		vehicleDDs:    map[int64]string{2: "ford_escape_2020"},
+		adOwners:      map[int64]common.Address{10: owner},
+		adVehicles:    map[int64]int64{10: 2},
+	}
+	fixer := &fakeFixer{
+		transferredVehicles: make(map[int64][2]common.Address),
+		transferredADs:      make(map[int64]common.Address),
+	}
+
+	rec := NewChainReconciler(pdb, chain, fixer, logger)
+	rec.PageSize = 2
+
+	var found []*ChainMismatch
+	report := func(m *ChainMismatch) error {
+		found = append(found, m)
+		return nil
+	}
+
+	require.NoError(t, rec.Run(ctx, report))
+
+	kinds := make(map[ChainMismatchKind]*ChainMismatch)
+	for _, m := range found {
+		assert.False(t, m.Fixed)
+		kinds[m.Kind] = m
+	}
+	require.Len(t, found, 3)
+	assert.Equal(t, transferred.ID, kinds[ChainMismatchOwner].ID)
+	assert.Equal(t, newOwner.Hex(), kinds[ChainMismatchOwner].Chain)
+	assert.Equal(t, burned.ID, kinds[ChainMismatchBurnedToken].ID)
+	assert.Equal(t, "1", kinds[ChainMismatchPairedVehicle].Database)
+	assert.Equal(t, "2", kinds[ChainMismatchPairedVehicle].Chain)
+	assert.Empty(t, fixer.burnedVehicles)
+
+	rec.Fix = true
+	found = nil
+	require.NoError(t, rec.Run(ctx, report))
+	require.Len(t, found, 3)
+	for _, m := range found {
+		assert.True(t, m.Fixed)
+	}
+	assert.Equal(t, []int64{3}, fixer.burnedVehicles)
+
+	assert.Equal(t, map[int64][2]common.Address{1: {owner, newOwner}}, fixer.transferredVehicles)
+	assert.Empty(t, fixer.transferredADs)
+
+	require.NoError(t, ad.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, int64(2), ad.VehicleTokenID.Int(nil).Int64())
+}
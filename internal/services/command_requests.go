@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/appmetrics"
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// DefaultCommandHistoryLimit is the page size used when the caller doesn't specify one.
+	DefaultCommandHistoryLimit = 20
+	// MaxCommandHistoryLimit caps the page size of command history queries.
+	MaxCommandHistoryLimit = 100
+)
+
+// ErrInvalidCommandCursor is returned when the pagination cursor doesn't refer to a command
+// request for the vehicle.
+var ErrInvalidCommandCursor = errors.New("invalid command history cursor")
+
+// ListDeviceCommandRequests returns the command requests for a user device, newest first. The
+// cursor is the id of the last request of the previous page, and the returned cursor is empty
+// once there are no more pages.
+func ListDeviceCommandRequests(ctx context.Context, exec boil.ContextExecutor, userDeviceID, cursor string, limit int) ([]*models.DeviceCommandRequest, string, error) {
+	if limit <= 0 {
+		limit = DefaultCommandHistoryLimit
+	} else if limit > MaxCommandHistoryLimit {
+		limit = MaxCommandHistoryLimit
+	}
+
+	mods := []qm.QueryMod{
+		models.DeviceCommandRequestWhere.UserDeviceID.EQ(userDeviceID),
+		qm.OrderBy(models.DeviceCommandRequestColumns.CreatedAt + " DESC, " + models.DeviceCommandRequestColumns.ID + " DESC"),
+		// Fetch one extra to find out whether there's another page.
+		qm.Limit(limit + 1),
+	}
+
+	if cursor != "" {
+		last, err := models.DeviceCommandRequests(
+			models.DeviceCommandRequestWhere.ID.EQ(cursor),
+			models.DeviceCommandRequestWhere.UserDeviceID.EQ(userDeviceID),
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, "", ErrInvalidCommandCursor
+			}
+			return nil, "", err
+		}
+
+		mods = append(mods, qm.Where("("+models.DeviceCommandRequestColumns.CreatedAt+", "+models.DeviceCommandRequestColumns.ID+") < (?, ?)", last.CreatedAt, last.ID))
+	}
+
+	reqs, err := models.DeviceCommandRequests(mods...).All(ctx, exec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(reqs) > limit {
+		reqs = reqs[:limit]
+		next = reqs[limit-1].ID
+	}
+
+	return reqs, next, nil
+}
+
+// defaultCommandTimeout is used when COMMAND_TIMEOUT is not set.
+const defaultCommandTimeout = 2 * time.Minute
+
+// CommandDeadlines holds how long each kind of command may stay pending.
+type CommandDeadlines struct {
+	Default   time.Duration
+	Overrides map[string]time.Duration
+}
+
+// NewCommandDeadlines parses the command timeout settings.
+func NewCommandDeadlines(settings *config.Settings) (*CommandDeadlines, error) {
+	d := &CommandDeadlines{
+		Default:   defaultCommandTimeout,
+		Overrides: make(map[string]time.Duration),
+	}
+
+	if settings.CommandTimeout != "" {
+		def, err := time.ParseDuration(settings.CommandTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse command timeout: %w", err)
+		}
+		d.Default = def
+	}
+
+	for pair := range strings.SplitSeq(settings.CommandTimeoutOverrides, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		command, durStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("command timeout override %q is not of the form command=duration", pair)
+		}
+		dur, err := time.ParseDuration(durStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse timeout for command %s: %w", command, err)
+		}
+		d.Overrides[command] = dur
+	}
+
+	return d, nil
+}
+
+// ExpiresAt returns the deadline for a command sent at the given time.
+func (d *CommandDeadlines) ExpiresAt(command string, sent time.Time) time.Time {
+	if dur, ok := d.Overrides[command]; ok {
+		return sent.Add(dur)
+	}
+	return sent.Add(d.Default)
+}
+
+// ReapExpiredCommandRequests moves pending command requests past their deadline to TimedOut
+// and returns how many were moved. Requests from before deadlines were recorded fall back to
+// the default timeout.
+func ReapExpiredCommandRequests(ctx context.Context, dbs func() *db.ReaderWriter, deadlines *CommandDeadlines) (int, error) {
+	tx, err := dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint
+
+	now := time.Now()
+
+	reqs, err := models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.Status.EQ(models.DeviceCommandRequestStatusPending),
+		qm.Expr(
+			models.DeviceCommandRequestWhere.ExpiresAt.LT(null.TimeFrom(now)),
+			qm.Or2(qm.Expr(
+				models.DeviceCommandRequestWhere.ExpiresAt.IsNull(),
+				models.DeviceCommandRequestWhere.CreatedAt.LT(now.Add(-deadlines.Default)),
+			)),
+		),
+		qm.For("UPDATE SKIP LOCKED"),
+	).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(reqs) == 0 {
+		return 0, nil
+	}
+
+	if _, err := reqs.UpdateAll(ctx, tx, models.M{
+		models.DeviceCommandRequestColumns.Status:    models.DeviceCommandRequestStatusTimedOut,
+		models.DeviceCommandRequestColumns.UpdatedAt: now,
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	for _, r := range reqs {
+		appmetrics.CommandRequestTimedOutCount.WithLabelValues(r.Command).Inc()
+	}
+
+	return len(reqs), nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/payloads"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestNewCommandDeadlines(t *testing.T) {
+	d, err := NewCommandDeadlines(&config.Settings{CommandTimeout: "1m", CommandTimeoutOverrides: "climate/on=5m, doors/unlock=30s"})
+	require.NoError(t, err)
+
+	sent := time.Now()
+	assert.Equal(t, sent.Add(time.Minute), d.ExpiresAt("charge/start", sent))
+	assert.Equal(t, sent.Add(5*time.Minute), d.ExpiresAt("climate/on", sent))
+	assert.Equal(t, sent.Add(30*time.Second), d.ExpiresAt("doors/unlock", sent))
+
+	d, err = NewCommandDeadlines(&config.Settings{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultCommandTimeout, d.Default)
+
+	_, err = NewCommandDeadlines(&config.Settings{CommandTimeoutOverrides: "climate/on"})
+	assert.Error(t, err)
+
+	_, err = NewCommandDeadlines(&config.Settings{CommandTimeout: "soon"})
+	assert.Error(t, err)
+}
+
+func TestReapExpiredCommandRequests(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	ud := test.SetupCreateUserDevice(t, "dylan", ksuid.New().String(), nil, "", pdb)
+
+	insert := func(status string, createdAt time.Time, expiresAt null.Time) *models.DeviceCommandRequest {
+		dcr := &models.DeviceCommandRequest{
+			ID:            ksuid.New().String(),
+			UserDeviceID:  ud.ID,
+			IntegrationID: ksuid.New().String(),
+			Command:       "doors/unlock",
+			Status:        status,
+			CreatedAt:     createdAt,
+			ExpiresAt:     expiresAt,
+		}
+		require.NoError(t, dcr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return dcr
+	}
+
+	now := time.Now()
+	overdue := insert(models.DeviceCommandRequestStatusPending, now.Add(-time.Minute), null.TimeFrom(now.Add(-time.Second)))
+	legacy := insert(models.DeviceCommandRequestStatusPending, now.Add(-time.Hour), null.Time{})
+	waiting := insert(models.DeviceCommandRequestStatusPending, now, null.TimeFrom(now.Add(time.Minute)))
+	done := insert(models.DeviceCommandRequestStatusComplete, now.Add(-time.Hour), null.TimeFrom(now.Add(-time.Minute)))
+
+	n, err := ReapExpiredCommandRequests(ctx, pdb.DBS, &CommandDeadlines{Default: 2 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for dcr, status := range map[*models.DeviceCommandRequest]string{
+		overdue: models.DeviceCommandRequestStatusTimedOut,
+		legacy:  models.DeviceCommandRequestStatusTimedOut,
+		waiting: models.DeviceCommandRequestStatusPending,
+		done:    models.DeviceCommandRequestStatusComplete,
+	} {
+		require.NoError(t, dcr.Reload(ctx, pdb.DBS().Reader))
+		assert.Equal(t, status, dcr.Status)
+	}
+
+	// A late status event must not resurrect the command.
+	listener := &TaskStatusListener{db: pdb.DBS, log: test.Logger()}
+	err = listener.processCommandStatusEvent(&payloads.CloudEvent[TaskStatusData]{
+		Data: TaskStatusData{SubTaskID: overdue.ID, Status: models.DeviceCommandRequestStatusComplete},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, overdue.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, models.DeviceCommandRequestStatusTimedOut, overdue.Status)
+
+	// The first status for a pending command wins.
+	for _, status := range []string{models.DeviceCommandRequestStatusComplete, models.DeviceCommandRequestStatusFailed} {
+		err = listener.processCommandStatusEvent(&payloads.CloudEvent[TaskStatusData]{
+			Data: TaskStatusData{SubTaskID: waiting.ID, Status: status},
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, waiting.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, models.DeviceCommandRequestStatusComplete, waiting.Status)
+}
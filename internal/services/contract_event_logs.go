@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logIterator is what every Filter* method in the contract bindings returns.
+type logIterator interface {
+	Next() bool
+	Error() error
+	Close() error
+}
+
+// FetchContractEvents pulls the events that ContractsEventsConsumer handles out of the given
+// block range with eth_getLogs, and shapes them the way they arrive on the contract events topic.
+// The result is in chain order.
+func FetchContractEvents(ctx context.Context, backend bind.ContractBackend, settings *config.Settings, fromBlock, toBlock uint64) ([]*ContractEventData, error) {
+	registry, err := contracts.NewRegistry(common.HexToAddress(settings.DIMORegistryAddr), backend)
+	if err != nil {
+		return nil, err
+	}
+	vehicle, err := contracts.NewMultiPrivilege(common.HexToAddress(settings.VehicleNFTAddress), backend)
+	if err != nil {
+		return nil, err
+	}
+	synthetic, err := contracts.NewMultiPrivilege(common.HexToAddress(settings.SyntheticDeviceNFTAddress), backend)
+	if err != nil {
+		return nil, err
+	}
+	aftermarket, err := contracts.NewAftermarketDeviceId(common.HexToAddress(settings.AftermarketDeviceContractAddress), backend)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx}
+	f := &eventFetcher{chainID: settings.DIMORegistryChainID}
+
+	f.fetch(PrivilegeSet, func() (logIterator, func() any, error) {
+		it, err := vehicle.FilterPrivilegeSet(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(Transfer, func() (logIterator, func() any, error) {
+		it, err := vehicle.FilterTransfer(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(Transfer, func() (logIterator, func() any, error) {
+		it, err := synthetic.FilterTransfer(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(Transfer, func() (logIterator, func() any, error) {
+		it, err := aftermarket.FilterTransfer(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceNodeMinted, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceNodeMinted(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(BeneficiarySet, func() (logIterator, func() any, error) {
+		it, err := registry.FilterBeneficiarySet(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceClaimed, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceClaimed(opts, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDevicePaired, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDevicePaired(opts, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceUnpaired, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceUnpaired(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceAttributeSet, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceAttributeSet(opts, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceAddressReset, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceAddressReset(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(VehicleNodeMintedWithDeviceDefinition, func() (logIterator, func() any, error) {
+		it, err := registry.FilterVehicleNodeMintedWithDeviceDefinition(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(VehicleNodeBurned, func() (logIterator, func() any, error) {
+		it, err := registry.FilterVehicleNodeBurned(opts, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(AftermarketDeviceNodeBurned, func() (logIterator, func() any, error) {
+		it, err := registry.FilterAftermarketDeviceNodeBurned(opts, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(SyntheticDeviceNodeBurned, func() (logIterator, func() any, error) {
+		it, err := registry.FilterSyntheticDeviceNodeBurned(opts, nil, nil, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(VehicleAttributeSet, func() (logIterator, func() any, error) {
+		it, err := registry.FilterVehicleAttributeSet(opts, nil)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(VehicleAttributeRemoved, func() (logIterator, func() any, error) {
+		it, err := registry.FilterVehicleAttributeRemoved(opts)
+		return it, func() any { return it.Event }, err
+	})
+	f.fetch(DeviceDefinitionIdSet, func() (logIterator, func() any, error) {
+		it, err := registry.FilterDeviceDefinitionIdSet(opts, nil)
+		return it, func() any { return it.Event }, err
+	})
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	sort.Slice(f.events, func(i, j int) bool {
+		a, b := f.events[i], f.events[j]
+		if c := a.Block.Number.Cmp(b.Block.Number); c != 0 {
+			return c < 0
+		}
+		return a.Index < b.Index
+	})
+
+	return f.events, nil
+}
+
+// eventFetcher collects events from a series of filter calls, stopping at the first error.
+type eventFetcher struct {
+	chainID int64
+	events  []*ContractEventData
+	err     error
+}
+
+func (f *eventFetcher) fetch(name EventName, filter func() (logIterator, func() any, error)) {
+	if f.err != nil {
+		return
+	}
+
+	it, current, err := filter()
+	if err != nil {
+		f.err = fmt.Errorf("failed to filter %s logs: %w", name, err)
+		return
+	}
+	defer it.Close() //nolint
+
+	for it.Next() {
+		ev, err := toContractEventData(f.chainID, name, current())
+		if err != nil {
+			f.err = fmt.Errorf("failed to convert %s log: %w", name, err)
+			return
+		}
+		f.events = append(f.events, ev)
+	}
+
+	if err := it.Error(); err != nil {
+		f.err = fmt.Errorf("failed to iterate over %s logs: %w", name, err)
+	}
+}
+
+// toContractEventData turns a decoded binding event into what the consumer expects. The
+// handlers unmarshal arguments into the same binding structs, and field matching is
+// case-insensitive, so the struct's own JSON is good enough once the raw log is dropped.
+func toContractEventData(chainID int64, name EventName, event any) (*ContractEventData, error) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var args map[string]json.RawMessage
+	if err := json.Unmarshal(b, &args); err != nil {
+		return nil, err
+	}
+
+	var raw types.Log
+	if err := json.Unmarshal(args["Raw"], &raw); err != nil {
+		return nil, err
+	}
+	delete(args, "Raw")
+
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventSig common.Hash
+	if len(raw.Topics) != 0 {
+		eventSig = raw.Topics[0]
+	}
+
+	return &ContractEventData{
+		ChainID:         chainID,
+		EventName:       name.String(),
+		Block:           Block{Number: new(big.Int).SetUint64(raw.BlockNumber), Hash: raw.BlockHash},
+		Index:           raw.Index,
+		Contract:        raw.Address,
+		TransactionHash: raw.TxHash,
+		EventSignature:  eventSig,
+		Arguments:       argsJSON,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToContractEventData(t *testing.T) {
+	registry := common.HexToAddress("0x881d40237659c251811cec9c364ef91dc08d300c")
+	sig := common.HexToHash("0x01")
+
+	event := &contracts.RegistryVehicleAttributeSet{
+		TokenId:   big.NewInt(13),
+		Attribute: "VIN",
+		Info:      "1FA6P8TH4J5107860",
+		Raw: types.Log{
+			Address:     registry,
+			Topics:      []common.Hash{sig},
+			BlockNumber: 50,
+			BlockHash:   common.HexToHash("0x02"),
+			TxHash:      common.HexToHash("0x03"),
+			Index:       4,
+		},
+	}
+
+	data, err := toContractEventData(137, VehicleAttributeSet, event)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(137), data.ChainID)
+	assert.Equal(t, VehicleAttributeSet.String(), data.EventName)
+	assert.Equal(t, int64(50), data.Block.Number.Int64())
+	assert.Equal(t, uint(4), data.Index)
+	assert.Equal(t, registry, data.Contract)
+	assert.Equal(t, common.HexToHash("0x03"), data.TransactionHash)
+	assert.Equal(t, sig, data.EventSignature)
+
+	// The handlers decode the arguments back into the binding struct.
+	var args contracts.RegistryVehicleAttributeSet
+	require.NoError(t, json.Unmarshal(data.Arguments, &args))
+	assert.Equal(t, int64(13), args.TokenId.Int64())
+	assert.Equal(t, "VIN", args.Attribute)
+	assert.Equal(t, "1FA6P8TH4J5107860", args.Info)
+	assert.NotContains(t, string(data.Arguments), "Raw")
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/types"
 	"google.golang.org/protobuf/proto"
@@ -49,6 +50,7 @@ type ContractsEventsConsumer struct {
 	genericInt   Integration
 	ddSvc        DeviceDefinitionService
 	teslaTask    SyntheticTaskService
+	ingestReg    IngestRegistrar
 }
 
 type EventName string
@@ -64,6 +66,20 @@ const (
 	AftermarketDeviceAttributeSet         EventName = "AftermarketDeviceAttributeSet"
 	AftermarketDeviceAddressReset         EventName = "AftermarketDeviceAddressReset"
 	VehicleNodeMintedWithDeviceDefinition EventName = "VehicleNodeMintedWithDeviceDefinition"
+	VehicleNodeBurned                     EventName = "VehicleNodeBurned"
+	AftermarketDeviceNodeBurned           EventName = "AftermarketDeviceNodeBurned"
+	SyntheticDeviceNodeBurned             EventName = "SyntheticDeviceNodeBurned"
+	VehicleAttributeSet                   EventName = "VehicleAttributeSet"
+	VehicleAttributeRemoved               EventName = "VehicleAttributeRemoved"
+	DeviceDefinitionIdSet                 EventName = "DeviceDefinitionIdSet" //nolint:revive
+)
+
+const (
+	// vehicleAttributeVIN is the registry vehicle attribute that holds the VIN.
+	vehicleAttributeVIN = "VIN"
+	// deviceDefinitionIDAttribute is the name under which we record device definition changes
+	// in vehicle_attribute_changes.
+	deviceDefinitionIDAttribute = "DeviceDefinitionId"
 )
 
 func (r EventName) String() string {
@@ -76,6 +92,7 @@ type ContractEventData struct {
 	ChainID         int64           `json:"chainId"`
 	EventName       string          `json:"eventName"`
 	Block           Block           `json:"block,omitempty"`
+	Index           uint            `json:"index,omitempty"` // Position of the log in the block.
 	Contract        common.Address  `json:"contract"`
 	TransactionHash common.Hash     `json:"transactionHash"`
 	EventSignature  common.Hash     `json:"eventSignature"`
@@ -89,7 +106,7 @@ type Block struct {
 	Time   time.Time   `json:"time,omitempty"`
 }
 
-func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *config.Settings, genericInt Integration, ddSvc DeviceDefinitionService, teslaTask SyntheticTaskService) *ContractsEventsConsumer {
+func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *config.Settings, genericInt Integration, ddSvc DeviceDefinitionService, teslaTask SyntheticTaskService, ingestReg IngestRegistrar) *ContractsEventsConsumer {
 	return &ContractsEventsConsumer{
 		db:           pdb,
 		log:          log,
@@ -98,6 +115,7 @@ func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *con
 		genericInt:   genericInt,
 		ddSvc:        ddSvc,
 		teslaTask:    teslaTask,
+		ingestReg:    ingestReg,
 	}
 }
 
@@ -133,34 +151,184 @@ func (c *ContractsEventsConsumer) processEvent(ctx context.Context, event *paylo
 		return nil
 	}
 
+	return c.handleEvent(ctx, &data, false)
+}
+
+// Replay runs the handler for an event again, even if it was processed before.
+func (c *ContractsEventsConsumer) Replay(ctx context.Context, data *ContractEventData) error {
+	return c.handleEvent(ctx, data, true)
+}
+
+// handleEvent dispatches the event to its handler. Events from the contracts we follow are
+// recorded once their handler succeeds, and later deliveries of a recorded event are skipped.
+// Delivery is at-least-once: the handler doesn't run in the transaction that records the event,
+// so two deliveries that arrive together may both run it, as may a delivery after a crash
+// between the handler and the record. Handlers have to cope with seeing an event twice. The
+// transaction that records the event also moves that contract's cursor.
+func (c *ContractsEventsConsumer) handleEvent(ctx context.Context, data *ContractEventData, replay bool) error {
+	if !c.tracked(data) {
+		return c.dispatch(ctx, data)
+	}
+
+	if !replay {
+		done, err := c.processed(ctx, data)
+		if err != nil {
+			return err
+		}
+		if done {
+			c.log.Debug().Str("event", data.EventName).Str("transactionHash", data.TransactionHash.Hex()).Uint("index", data.Index).Msg("Skipping event that was already processed.")
+			return nil
+		}
+	}
+
+	if err := c.dispatch(ctx, data); err != nil {
+		return err
+	}
+
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := c.recordEvent(ctx, tx, data); err != nil {
+		return err
+	}
+
+	if err := c.advanceCursor(ctx, tx, data); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// tracked reports whether the event comes from one of the contracts we have handlers for. Events
+// without a transaction hash can't be told apart, so they're never tracked.
+func (c *ContractsEventsConsumer) tracked(data *ContractEventData) bool {
+	if data.TransactionHash == (common.Hash{}) {
+		return false
+	}
+
+	switch data.Contract {
+	case c.registryAddr,
+		common.HexToAddress(c.settings.VehicleNFTAddress),
+		common.HexToAddress(c.settings.AftermarketDeviceContractAddress),
+		common.HexToAddress(c.settings.SyntheticDeviceNFTAddress):
+		return true
+	default:
+		return false
+	}
+}
+
+const recordContractEvent = `INSERT INTO devices_api.processed_contract_events (chain_id, transaction_hash, log_index, contract, event_name, block_number)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (chain_id, transaction_hash, log_index) DO NOTHING`
+
+const advanceContractEventCursor = `INSERT INTO devices_api.contract_event_cursors (chain_id, contract, block_number, transaction_hash, log_index)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (chain_id, contract) DO UPDATE
+SET block_number = EXCLUDED.block_number, transaction_hash = EXCLUDED.transaction_hash, log_index = EXCLUDED.log_index, updated_at = now()
+WHERE (contract_event_cursors.block_number, contract_event_cursors.log_index) < (EXCLUDED.block_number, EXCLUDED.log_index)`
+
+// processed reports whether the event has already been handled.
+func (c *ContractsEventsConsumer) processed(ctx context.Context, data *ContractEventData) (bool, error) {
+	return models.ProcessedContractEvents(
+		models.ProcessedContractEventWhere.ChainID.EQ(c.settings.DIMORegistryChainID),
+		models.ProcessedContractEventWhere.TransactionHash.EQ(data.TransactionHash.Bytes()),
+		models.ProcessedContractEventWhere.LogIndex.EQ(int(data.Index)),
+	).Exists(ctx, c.db.DBS().Writer)
+}
+
+// recordEvent records the event as processed. Recording it again, after a replay, does nothing.
+func (c *ContractsEventsConsumer) recordEvent(ctx context.Context, exec boil.ContextExecutor, data *ContractEventData) error {
+	var blockNumber null.Int64
+	if data.Block.Number != nil {
+		blockNumber = null.Int64From(data.Block.Number.Int64())
+	}
+
+	if _, err := queries.Raw(recordContractEvent,
+		c.settings.DIMORegistryChainID, data.TransactionHash.Bytes(), int(data.Index), data.Contract.Bytes(), data.EventName, blockNumber,
+	).ExecContext(ctx, exec); err != nil {
+		return fmt.Errorf("failed to record processed event: %w", err)
+	}
+
+	return nil
+}
+
+// advanceCursor moves the contract's cursor forward to the event. The cursor never moves
+// backwards, so replaying old blocks leaves it alone.
+func (c *ContractsEventsConsumer) advanceCursor(ctx context.Context, exec boil.ContextExecutor, data *ContractEventData) error {
+	if data.Block.Number == nil {
+		return nil
+	}
+
+	if _, err := queries.Raw(advanceContractEventCursor,
+		c.settings.DIMORegistryChainID, data.Contract.Bytes(), data.Block.Number.Int64(), data.TransactionHash.Bytes(), int(data.Index),
+	).ExecContext(ctx, exec); err != nil {
+		return fmt.Errorf("failed to advance cursor: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ContractsEventsConsumer) dispatch(ctx context.Context, data *ContractEventData) error {
 	switch data.EventName {
 	case PrivilegeSet.String():
 		c.log.Info().Str("event", data.EventName).Msg("Event received")
-		return c.setPrivilegeHandler(&data)
+		return c.setPrivilegeHandler(data)
 	case Transfer.String():
-		return c.routeTransferEvent(ctx, &data)
+		return c.routeTransferEvent(ctx, data)
 	case AftermarketDeviceNodeMinted.String():
 		if data.Contract == c.registryAddr {
 			c.log.Info().Str("event", data.EventName).Msg("Event received")
-			return c.setMintedAfterMarketDevice(&data)
+			return c.setMintedAfterMarketDevice(data)
 		}
 	case BeneficiarySet.String():
 		if data.Contract == c.registryAddr {
 			c.log.Info().Str("event", data.EventName).Msg("Event received")
-			return c.beneficiarySet(&data)
+			return c.beneficiarySet(data)
 		}
 	case AftermarketDeviceClaimed.String():
-		return c.aftermarketDeviceClaimed(&data)
+		return c.aftermarketDeviceClaimed(data)
 	case AftermarketDevicePaired.String():
-		return c.aftermarketDevicePaired(&data)
+		return c.aftermarketDevicePaired(data)
 	case AftermarketDeviceUnpaired.String():
-		return c.aftermarketDeviceUnpaired(&data)
+		return c.aftermarketDeviceUnpaired(data)
 	case AftermarketDeviceAttributeSet.String():
-		return c.aftermarketDeviceAttributeSet(&data)
+		return c.aftermarketDeviceAttributeSet(data)
 	case AftermarketDeviceAddressReset.String():
-		return c.aftermarketDeviceAddressReset(&data)
+		return c.aftermarketDeviceAddressReset(data)
 	case VehicleNodeMintedWithDeviceDefinition.String():
-		return c.vehicleNodeMintedWithDeviceDefinition(&data)
+		return c.vehicleNodeMintedWithDeviceDefinition(data)
+	case VehicleNodeBurned.String():
+		if data.Contract == c.registryAddr {
+			c.log.Info().Str("event", data.EventName).Msg("Event received")
+			return c.vehicleNodeBurned(ctx, data)
+		}
+	case AftermarketDeviceNodeBurned.String():
+		if data.Contract == c.registryAddr {
+			c.log.Info().Str("event", data.EventName).Msg("Event received")
+			return c.aftermarketDeviceNodeBurned(ctx, data)
+		}
+	case SyntheticDeviceNodeBurned.String():
+		if data.Contract == c.registryAddr {
+			c.log.Info().Str("event", data.EventName).Msg("Event received")
+			return c.syntheticDeviceNodeBurned(ctx, data)
+		}
+	case VehicleAttributeSet.String():
+		if data.Contract == c.registryAddr {
+			c.log.Info().Str("event", data.EventName).Msg("Event received")
+			return c.vehicleAttributeSet(ctx, data)
+		}
+	case VehicleAttributeRemoved.String():
+		if data.Contract == c.registryAddr {
+			return c.vehicleAttributeRemoved(data)
+		}
+	case DeviceDefinitionIdSet.String():
+		if data.Contract == c.registryAddr {
+			c.log.Info().Str("event", data.EventName).Msg("Event received")
+			return c.deviceDefinitionIDSet(ctx, data)
+		}
 	default:
 		c.log.Debug().Str("event", data.EventName).Msg("Handler not provided for event.")
 	}
@@ -199,120 +367,190 @@ func (c *ContractsEventsConsumer) handleSyntheticTransfer(ctx context.Context, e
 		return nil
 	}
 
-	sd, err := models.SyntheticDevices(
-		models.SyntheticDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
-		qm.Load(models.SyntheticDeviceRels.VehicleToken),
-	).One(ctx, c.db.DBS().Writer)
+	return c.BurnSyntheticDevice(ctx, args.TokenId, args.From)
+}
+
+func (c *ContractsEventsConsumer) handleVehicleTransfer(ctx context.Context, e *ContractEventData) error {
+	var args contracts.MultiPrivilegeTransfer
+	err := json.Unmarshal(e.Arguments, &args)
 	if err != nil {
-		return fmt.Errorf("couldn't find synthetic device %d to burn: %w", args.TokenId, err)
+		return err
 	}
 
-	// The most important thing is to delete the database rows to free things up.
-	_, err = sd.Delete(ctx, c.db.DBS().Writer)
-	if err != nil {
-		return fmt.Errorf("failed to delete synthetic device %d row: %w", sd.TokenID, err)
+	// Handle mints in the meta-transaction handler.
+	if IsZeroAddress(args.From) {
+		return nil
+	}
+
+	if IsZeroAddress(args.To) {
+		return c.BurnVehicle(ctx, args.TokenId, args.From)
 	}
 
-	ud := sd.R.VehicleToken
-	if ud == nil {
-		return fmt.Errorf("burning synthetic device %d with no paired vehicle", sd.TokenID)
+	return c.TransferVehicle(ctx, args.TokenId, args.From, args.To, e.TransactionHash)
+}
+
+// TransferVehicle hands the vehicle with the given token id over from one owner to another,
+// separating the previous owner's data from the new owner's. The transaction hash may be empty.
+func (c *ContractsEventsConsumer) TransferVehicle(ctx context.Context, tokenID *big.Int, from, to common.Address, txHash common.Hash) error {
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback() //nolint
 
-	intID, _ := sd.IntegrationTokenID.Uint64()
-	integ, err := c.ddSvc.GetIntegrationByTokenID(ctx, intID)
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		qm.Load(models.UserDeviceRels.UserDeviceAPIIntegrations),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	udai, err := models.FindUserDeviceAPIIntegration(ctx, c.db.DBS().Reader.DB, ud.ID, integ.Id)
+	// A transfer out of someone who no longer holds the vehicle is stale: a replay, or an event
+	// that arrived after a later one. If we never recorded an owner, there's nothing to check the
+	// transfer against, so it goes ahead.
+	if owner := common.BytesToAddress(ud.OwnerAddress.Bytes); ud.OwnerAddress.Valid && owner != from {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Skipping transfer from %s; the vehicle is recorded as owned by %s.", from, owner)
+		return nil
+	}
+
+	// Everything below separates the previous owner's data from the new owner, and is written
+	// down so that both of them can see what happened.
+	handover, err := NewVehicleHandover(ctx, tx, ud.ID, tokenID, from, to, txHash)
 	if err != nil {
-		return fmt.Errorf("failed to find job backing burned synthetic device %d: %w", sd.TokenID, err)
+		return err
 	}
 
-	_, err = udai.Delete(ctx, c.db.DBS().Writer)
+	rowsAff, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(tokenID)),
+	).DeleteAll(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to delete job backing synthetic device %d: %w", sd.TokenID, err)
+		return err
 	}
 
-	if udai.TaskID.Valid {
-		switch integ.Vendor {
-		case constants.TeslaVendor:
-			err := c.teslaTask.StopPoll(udai)
-			if err != nil {
+	if rowsAff != 0 {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Cleared %d privileges upon vehicle transfer.", rowsAff)
+		if err := RecordHandoverStep(ctx, tx, handover, HandoverPrivilegesCleared, fmt.Sprintf("Cleared %d privileges.", rowsAff)); err != nil {
+			return err
+		}
+	}
+
+	for _, udai := range ud.R.UserDeviceAPIIntegrations {
+		revoked, err := c.revokeIntegration(ctx, tx, udai)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			if err := RecordHandoverStep(ctx, tx, handover, HandoverIntegrationRevoked, fmt.Sprintf("Revoked the previous owner's credentials for integration %s.", udai.IntegrationID)); err != nil {
 				return err
 			}
-		default:
-			c.log.Warn().Msgf("Unexpected integration %s.", integ.Vendor)
 		}
 	}
 
-	c.log.Info().Int64("syntheticDeviceTokenId", args.TokenId.Int64()).Str("owner", args.From.Hex()).Msg("Burned synthetic device.")
+	// Subscriptions the previous owner made for this one vehicle would otherwise keep telling
+	// them about it.
+	rowsAff, err = models.WebhookSubscriptions(
+		models.WebhookSubscriptionWhere.VehicleTokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		models.WebhookSubscriptionWhere.DeveloperAddress.EQ(from.Bytes()),
+	).DeleteAll(ctx, tx)
+	if err != nil {
+		return err
+	}
 
-	return nil
-}
+	if rowsAff != 0 {
+		if err := RecordHandoverStep(ctx, tx, handover, HandoverWebhooksDeleted, fmt.Sprintf("Deleted %d of the previous owner's webhook subscriptions for the vehicle.", rowsAff)); err != nil {
+			return err
+		}
+	}
 
-func (c *ContractsEventsConsumer) handleVehicleTransfer(ctx context.Context, e *ContractEventData) error {
-	var args contracts.MultiPrivilegeTransfer
-	err := json.Unmarshal(e.Arguments, &args)
+	// Documents belong to the user who uploaded them, so the previous owner keeps theirs. They're
+	// just no longer attached to the vehicle.
+	rowsAff, err = models.Documents(
+		models.DocumentWhere.UserDeviceID.EQ(null.StringFrom(ud.ID)),
+	).UpdateAll(ctx, tx, models.M{models.DocumentColumns.UserDeviceID: nil})
 	if err != nil {
 		return err
 	}
 
-	// Handle mints in the meta-transaction handler.
-	if IsZeroAddress(args.From) {
-		return nil
+	if rowsAff != 0 {
+		if err := RecordHandoverStep(ctx, tx, handover, HandoverDocumentsDetached, fmt.Sprintf("Detached %d documents from the vehicle.", rowsAff)); err != nil {
+			return err
+		}
 	}
 
-	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	rowsAff, err = models.ErrorCodeQueries(
+		models.ErrorCodeQueryWhere.UserDeviceID.EQ(ud.ID),
+		models.ErrorCodeQueryWhere.HiddenAt.IsNull(),
+	).UpdateAll(ctx, tx, models.M{models.ErrorCodeQueryColumns.HiddenAt: time.Now()})
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback() //nolint
 
-	rowsAff, err := models.NFTPrivileges(
-		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(args.TokenId)),
-	).DeleteAll(ctx, tx)
+	if rowsAff != 0 {
+		if err := RecordHandoverStep(ctx, tx, handover, HandoverErrorCodesHidden, fmt.Sprintf("Hid %d error code queries.", rowsAff)); err != nil {
+			return err
+		}
+	}
+
+	// Faking a user id for a web3 user with the new owner address.
+	userID, err := addressToUserID(to)
 	if err != nil {
+		return fmt.Errorf("failed to convert address to user id: %w", err)
+	}
+
+	cols := models.UserDeviceColumns
+	ud.UserID = userID
+	ud.OwnerAddress = null.BytesFrom(to.Bytes())
+
+	if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress)); err != nil {
 		return err
 	}
 
-	if rowsAff != 0 {
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Msgf("Cleared %d privileges upon vehicle transfer.", rowsAff)
+	if err := RecordHandoverStep(ctx, tx, handover, HandoverOwnerChanged, fmt.Sprintf("Transferred from %s to %s.", from, to)); err != nil {
+		return err
 	}
 
-	ud, err := models.UserDevices(
-		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
-	).One(ctx, tx)
+	c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Str("handoverId", handover.ID).Msgf("Transferred vehicle from %s to %s.", from, to)
+
+	err = EnqueueWebhookEvent(ctx, tx, &WebhookEvent{
+		Type:           WebhookVehicleTransferred,
+		VehicleTokenID: tokenID,
+		Owners:         []common.Address{from, to},
+		Data:           WebhookVehicleData{VehicleTokenID: tokenID, Owner: to, PreviousOwner: &from},
+	})
 	if err != nil {
 		return err
 	}
 
-	if IsZeroAddress(args.To) {
-		_, err = ud.Delete(ctx, tx)
-		if err != nil {
-			return err
-		}
+	return tx.Commit()
+}
 
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Str("owner", args.From.Hex()).Msg("Burned vehicle.")
-	} else {
-		// Faking a user id for a web3 user with the new owner address.
-		userID, err := addressToUserID(args.To)
-		if err != nil {
-			return fmt.Errorf("failed to convert address to user id: %w", err)
-		}
+// revokeIntegration stops an integration that runs on the previous owner's credentials and wipes
+// them. The integration is left in AuthenticationFailure, so the new owner has to connect their
+// own account. Integrations without credentials, such as aftermarket devices, go with the
+// hardware rather than the owner and are left alone.
+func (c *ContractsEventsConsumer) revokeIntegration(ctx context.Context, exec boil.ContextExecutor, udai *models.UserDeviceAPIIntegration) (bool, error) {
+	if !udai.AccessToken.Valid && !udai.RefreshToken.Valid {
+		return false, nil
+	}
 
-		cols := models.UserDeviceColumns
-		ud.UserID = userID
-		ud.OwnerAddress = null.BytesFrom(args.To.Bytes())
+	if err := c.stopIntegration(ctx, udai); err != nil {
+		return false, err
+	}
 
-		if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress)); err != nil {
-			return err
-		}
+	udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+	udai.AccessToken = null.String{}
+	udai.RefreshToken = null.String{}
+	udai.AccessExpiresAt = null.Time{}
+	udai.TaskID = null.String{}
 
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Msgf("Transferred vehicle from %s to %s.", args.From, args.To)
+	cols := models.UserDeviceAPIIntegrationColumns
+	if _, err := udai.Update(ctx, exec, boil.Whitelist(cols.Status, cols.AccessToken, cols.RefreshToken, cols.AccessExpiresAt, cols.TaskID, cols.UpdatedAt)); err != nil {
+		return false, fmt.Errorf("failed to revoke integration %s: %w", udai.IntegrationID, err)
 	}
 
-	return tx.Commit()
+	return true, nil
 }
 
 func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEventData) error {
@@ -331,7 +569,19 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 		return nil
 	}
 
-	apUnit, err := models.AftermarketDevices(models.AftermarketDeviceWhere.TokenID.EQ(tkID)).One(context.Background(), c.db.DBS().Reader)
+	if IsZeroAddress(args.To) {
+		return c.BurnAftermarketDevice(ctx, args.TokenId)
+	}
+
+	return c.TransferAftermarketDevice(ctx, args.TokenId, args.To)
+}
+
+// TransferAftermarketDevice records the new owner of a claimed aftermarket device. The old
+// owner's user id and beneficiary don't carry over.
+func (c *ContractsEventsConsumer) TransferAftermarketDevice(ctx context.Context, tokenID *big.Int, to common.Address) error {
+	tkID := utils.BigToDecimal(tokenID)
+
+	apUnit, err := models.AftermarketDevices(models.AftermarketDeviceWhere.TokenID.EQ(tkID)).One(ctx, c.db.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.log.Err(err).Str("tokenID", tkID.String()).Msg("record not found as this might be a newly minted device")
@@ -341,29 +591,13 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 		return errors.New("error occurred transferring device")
 	}
 
-	if IsZeroAddress(args.To) {
-		// Burn.
-		c.log.Info().Msgf("Burning aftermarket device %d.", tkID)
-		_, err := models.AutopiJobs(models.AutopiJobWhere.AutopiUnitID.EQ(null.StringFrom(apUnit.Serial))).DeleteAll(ctx, c.db.DBS().Writer)
-		if err != nil {
-			return fmt.Errorf("error deleting jobs associated with aftermarket device: %w", err)
-		}
-
-		_, err = apUnit.Delete(ctx, c.db.DBS().Writer)
-		if err != nil {
-			return fmt.Errorf("error deleting aftermarket device: %w", err)
-		}
-
-		return nil
-	}
-
 	if !apUnit.OwnerAddress.Valid {
 		c.log.Debug().Str("tokenID", tkID.String()).Msg("device has not been claimed yet")
 		return nil
 	}
 
 	apUnit.UserID = null.String{}
-	apUnit.OwnerAddress = null.BytesFrom(args.To.Bytes())
+	apUnit.OwnerAddress = null.BytesFrom(to.Bytes())
 	apUnit.Beneficiary = null.Bytes{}
 
 	cols := models.AftermarketDeviceColumns
@@ -456,9 +690,22 @@ func (c *ContractsEventsConsumer) aftermarketDevicePaired(e *ContractEventData)
 	log := c.log.With().Int64("vehicleNode", args.VehicleNode.Int64()).Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Logger()
 	log.Info().Msg("Pairing aftermarket device and vehicle.")
 
+	// The integration goes first, so that if it fails the redelivery finds nothing done and
+	// subscribers aren't told twice.
+	if err := c.genericInt.Pair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode); err != nil {
+		return err
+	}
+
+	tx, err := c.db.DBS().Writer.BeginTx(context.TODO(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
 	am, err := models.AftermarketDevices(
 		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
-	).One(context.TODO(), c.db.DBS().Reader)
+		qm.For("UPDATE"),
+	).One(context.TODO(), tx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve aftermarket device: %w", err)
 	}
@@ -466,16 +713,26 @@ func (c *ContractsEventsConsumer) aftermarketDevicePaired(e *ContractEventData)
 	cols := models.AftermarketDeviceColumns
 
 	am.VehicleTokenID = types.NewNullDecimal(utils.BigToDecimal(args.VehicleNode).Big)
-	_, err = am.Update(context.TODO(), c.db.DBS().Writer, boil.Whitelist(cols.VehicleTokenID, cols.UpdatedAt))
+	_, err = am.Update(context.TODO(), tx, boil.Whitelist(cols.VehicleTokenID, cols.UpdatedAt))
 	if err != nil {
 		return fmt.Errorf("failed to update aftermarket device: %w", err)
 	}
 
-	return c.genericInt.Pair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode)
+	err = EnqueueWebhookEvent(context.TODO(), tx, &WebhookEvent{
+		Type:           WebhookAftermarketPaired,
+		VehicleTokenID: args.VehicleNode,
+		Data:           WebhookPairingData{VehicleTokenID: args.VehicleNode, AftermarketDeviceTokenID: args.AftermarketDeviceNode},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue pairing webhooks: %w", err)
+	}
+
+	return tx.Commit()
 }
 
 // aftermarketDeviceAttributeSet handles the event of the same name from the registry contract.
-// At present this is only used to grab the serial number for Macarons AND AutoPi's
+// The serial moves the device out of partial_aftermarket_devices; other attributes are kept in
+// the device's metadata.
 func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEventData) error {
 	// TODO(elffjs): Stop repeating the next eight lines in every handler.
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
@@ -488,7 +745,7 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEvent
 	}
 
 	if args.Attribute != "Serial" {
-		return nil
+		return c.setAftermarketDeviceAttribute(context.TODO(), args.TokenId, args.Attribute, args.Info)
 	}
 
 	tx, err := c.db.DBS().Writer.BeginTx(context.TODO(), nil)
@@ -512,6 +769,7 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEvent
 		EthereumAddress:           pad.EthereumAddress,
 		TokenID:                   pad.TokenID,
 		DeviceManufacturerTokenID: pad.ManufacturerTokenID,
+		Metadata:                  pad.Metadata,
 	}
 
 	err = ad.Upsert(context.TODO(), tx, false, []string{models.AftermarketDeviceColumns.EthereumAddress}, boil.Infer(), boil.Infer())
@@ -529,6 +787,82 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEvent
 	return tx.Commit()
 }
 
+// setAftermarketDeviceAttribute stores an attribute in the device's metadata. If the serial
+// hasn't been set yet, the attribute waits on the partial device. An empty value removes it.
+func (c *ContractsEventsConsumer) setAftermarketDeviceAttribute(ctx context.Context, tokenID *big.Int, attribute, info string) error {
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	tkID := utils.BigToDecimal(tokenID)
+
+	ad, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.TokenID.EQ(tkID),
+	).One(ctx, tx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		pad, err := models.PartialAftermarketDevices(
+			models.PartialAftermarketDeviceWhere.TokenID.EQ(tkID),
+		).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.log.Debug().Int64("tokenId", tokenID.Int64()).Msgf("Ignoring attribute %s for unknown aftermarket device.", attribute)
+				return nil
+			}
+			return err
+		}
+
+		if pad.Metadata, err = setAftermarketAttribute(pad.Metadata, attribute, info); err != nil {
+			return err
+		}
+
+		if _, err := pad.Update(ctx, tx, boil.Whitelist(models.PartialAftermarketDeviceColumns.Metadata)); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	if ad.Metadata, err = setAftermarketAttribute(ad.Metadata, attribute, info); err != nil {
+		return err
+	}
+
+	cols := models.AftermarketDeviceColumns
+	if _, err := ad.Update(ctx, tx, boil.Whitelist(cols.Metadata, cols.UpdatedAt)); err != nil {
+		return err
+	}
+
+	c.log.Info().Str("serial", ad.Serial).Msgf("Aftermarket device attribute %s set to %q.", attribute, info)
+
+	return tx.Commit()
+}
+
+func setAftermarketAttribute(raw null.JSON, attribute, info string) (null.JSON, error) {
+	md := new(AftermarketDeviceMetadata)
+	if raw.Valid {
+		if err := raw.Unmarshal(md); err != nil {
+			return raw, fmt.Errorf("failed to unmarshal aftermarket device metadata: %w", err)
+		}
+	}
+
+	if info == "" {
+		delete(md.Attributes, attribute)
+	} else {
+		if md.Attributes == nil {
+			md.Attributes = make(map[string]string)
+		}
+		md.Attributes[attribute] = info
+	}
+
+	err := raw.Marshal(md)
+	return raw, err
+}
+
 func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket claim from unexpected source %d/%s", e.ChainID, e.Contract)
@@ -541,9 +875,21 @@ func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData
 
 	c.log.Info().Int64("vehicleNode", args.VehicleNode.Int64()).Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msg("Unpairing aftermarket device and vehicle.")
 
+	// As with pairing, the integration goes first.
+	if err := c.genericInt.Unpair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode); err != nil {
+		return err
+	}
+
+	tx, err := c.db.DBS().Writer.BeginTx(context.TODO(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
 	am, err := models.AftermarketDevices(
 		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
-	).One(context.TODO(), c.db.DBS().Reader)
+		qm.For("UPDATE"),
+	).One(context.TODO(), tx)
 	if err != nil {
 		return err
 	}
@@ -551,12 +897,21 @@ func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData
 	am.VehicleTokenID = types.NullDecimal{}
 	am.PairRequestID = null.String{}
 
-	if _, err := am.Update(context.TODO(), c.db.DBS().Writer, boil.Whitelist(models.AftermarketDeviceColumns.VehicleTokenID, models.AftermarketDeviceColumns.PairRequestID)); err != nil {
+	if _, err := am.Update(context.TODO(), tx, boil.Whitelist(models.AftermarketDeviceColumns.VehicleTokenID, models.AftermarketDeviceColumns.PairRequestID)); err != nil {
 		return err
 	}
 
-	return c.genericInt.Unpair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode)
-}
+	err = EnqueueWebhookEvent(context.TODO(), tx, &WebhookEvent{
+		Type:           WebhookAftermarketUnpaired,
+		VehicleTokenID: args.VehicleNode,
+		Data:           WebhookPairingData{VehicleTokenID: args.VehicleNode, AftermarketDeviceTokenID: args.AftermarketDeviceNode},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue unpairing webhooks: %w", err)
+	}
+
+	return tx.Commit()
+}
 
 func (c *ContractsEventsConsumer) beneficiarySet(e *ContractEventData) error {
 	var args contracts.RegistryBeneficiarySet
@@ -665,6 +1020,456 @@ func (c *ContractsEventsConsumer) vehicleNodeMintedWithDeviceDefinition(e *Contr
 
 }
 
+func (c *ContractsEventsConsumer) vehicleNodeBurned(ctx context.Context, e *ContractEventData) error {
+	var args contracts.RegistryVehicleNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.BurnVehicle(ctx, args.VehicleNode, args.Owner)
+}
+
+func (c *ContractsEventsConsumer) aftermarketDeviceNodeBurned(ctx context.Context, e *ContractEventData) error {
+	var args contracts.RegistryAftermarketDeviceNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.BurnAftermarketDevice(ctx, args.AdNode)
+}
+
+func (c *ContractsEventsConsumer) syntheticDeviceNodeBurned(ctx context.Context, e *ContractEventData) error {
+	var args contracts.RegistrySyntheticDeviceNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.BurnSyntheticDevice(ctx, args.SyntheticDeviceNode, args.Owner)
+}
+
+// BurnVehicle removes everything we know about the vehicle with the given token id. A burn shows
+// up both as a Transfer to the zero address and as a VehicleNodeBurned event from the registry, and
+// may have happened outside of our own burn flow, so a vehicle that is already gone is not an error.
+func (c *ContractsEventsConsumer) BurnVehicle(ctx context.Context, tokenID *big.Int, owner common.Address) error {
+	log := c.log.With().Int64("vehicleTokenId", tokenID.Int64()).Logger()
+
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	rowsAff, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(tokenID)),
+	).DeleteAll(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if rowsAff != 0 {
+		log.Info().Msgf("Cleared %d privileges upon vehicle burn.", rowsAff)
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		qm.Load(models.UserDeviceRels.UserDeviceAPIIntegrations),
+		qm.Load(models.UserDeviceRels.VehicleTokenAftermarketDevice),
+		qm.Load(models.UserDeviceRels.VehicleTokenSyntheticDevice),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Info().Msg("Vehicle already burned.")
+			return tx.Commit()
+		}
+		return err
+	}
+
+	if ad := ud.R.VehicleTokenAftermarketDevice; ad != nil {
+		ad.VehicleTokenID = types.NullDecimal{}
+		ad.PairRequestID = null.String{}
+		if _, err := ad.Update(ctx, tx, boil.Whitelist(models.AftermarketDeviceColumns.VehicleTokenID, models.AftermarketDeviceColumns.PairRequestID, models.AftermarketDeviceColumns.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to unpair aftermarket device: %w", err)
+		}
+
+		if err := c.ingestReg.Deregister2(common.BytesToAddress(ad.EthereumAddress)); err != nil {
+			return fmt.Errorf("failed to deregister aftermarket device: %w", err)
+		}
+	}
+
+	if sd := ud.R.VehicleTokenSyntheticDevice; sd != nil {
+		if _, err := sd.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete synthetic device: %w", err)
+		}
+	}
+
+	for _, udai := range ud.R.UserDeviceAPIIntegrations {
+		if err := c.stopIntegration(ctx, udai); err != nil {
+			return err
+		}
+
+		if _, err := udai.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete integration %s: %w", udai.IntegrationID, err)
+		}
+	}
+
+	if _, err := ud.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	log.Info().Str("owner", owner.Hex()).Msg("Burned vehicle.")
+
+	err = EnqueueWebhookEvent(ctx, tx, &WebhookEvent{
+		Type:           WebhookVehicleBurned,
+		VehicleTokenID: tokenID,
+		Owners:         []common.Address{owner},
+		Data:           WebhookVehicleData{VehicleTokenID: tokenID, Owner: owner},
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// stopIntegration tells whatever is feeding data for the integration to stop. These calls are
+// safe to repeat, so they're made before the rows go away: if the commit fails, the retry will
+// make them again.
+func (c *ContractsEventsConsumer) stopIntegration(ctx context.Context, udai *models.UserDeviceAPIIntegration) error {
+	if !udai.TaskID.Valid && !udai.ExternalID.Valid {
+		return nil
+	}
+
+	integ, err := c.ddSvc.GetIntegrationByID(ctx, udai.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	switch integ.Vendor {
+	case constants.TeslaVendor:
+		if udai.TaskID.Valid {
+			if err := c.teslaTask.StopPoll(udai); err != nil {
+				return fmt.Errorf("failed to stop Tesla polling: %w", err)
+			}
+		}
+	case constants.AutoPiVendor:
+		if udai.ExternalID.Valid {
+			if err := c.ingestReg.Deregister(udai.ExternalID.String, udai.UserDeviceID, udai.IntegrationID); err != nil {
+				return fmt.Errorf("failed to deregister AutoPi: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BurnAftermarketDevice deletes the aftermarket device with the given token id, along with its
+// jobs and any integration it backs. As in BurnVehicle, those integrations are stopped first.
+// A device that is already gone is not an error.
+func (c *ContractsEventsConsumer) BurnAftermarketDevice(ctx context.Context, tokenID *big.Int) error {
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ad, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(tokenID)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Info().Int64("aftermarketDeviceTokenId", tokenID.Int64()).Msg("Aftermarket device already burned.")
+			return nil
+		}
+		return err
+	}
+
+	c.log.Info().Msgf("Burning aftermarket device %d.", tokenID)
+
+	udais, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.Serial.EQ(null.StringFrom(ad.Serial)),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, udai := range udais {
+		if err := c.stopIntegration(ctx, udai); err != nil {
+			return err
+		}
+
+		if _, err := udai.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete integration %s: %w", udai.IntegrationID, err)
+		}
+	}
+
+	if _, err := models.AutopiJobs(models.AutopiJobWhere.AutopiUnitID.EQ(null.StringFrom(ad.Serial))).DeleteAll(ctx, tx); err != nil {
+		return fmt.Errorf("error deleting jobs associated with aftermarket device: %w", err)
+	}
+
+	if _, err := ad.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("error deleting aftermarket device: %w", err)
+	}
+
+	// The mapping can outlive the pairing, and removing one that isn't there is harmless.
+	if err := c.ingestReg.Deregister2(common.BytesToAddress(ad.EthereumAddress)); err != nil {
+		return fmt.Errorf("failed to deregister aftermarket device: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// BurnSyntheticDevice deletes the synthetic device with the given token id and the integration
+// that was minting it. A device that is already gone is not an error.
+func (c *ContractsEventsConsumer) BurnSyntheticDevice(ctx context.Context, tokenID *big.Int, owner common.Address) error {
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	sd, err := models.SyntheticDevices(
+		models.SyntheticDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		qm.Load(models.SyntheticDeviceRels.VehicleToken),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Info().Int64("syntheticDeviceTokenId", tokenID.Int64()).Msg("Synthetic device already burned.")
+			return nil
+		}
+		return fmt.Errorf("couldn't find synthetic device %d to burn: %w", tokenID, err)
+	}
+
+	// The most important thing is to delete the database rows to free things up.
+	_, err = sd.Delete(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed to delete synthetic device %d row: %w", tokenID, err)
+	}
+
+	if ud := sd.R.VehicleToken; ud != nil {
+		intID, _ := sd.IntegrationTokenID.Uint64()
+		integ, err := c.ddSvc.GetIntegrationByTokenID(ctx, intID)
+		if err != nil {
+			return err
+		}
+
+		udai, err := models.FindUserDeviceAPIIntegration(ctx, tx, ud.ID, integ.Id)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find job backing burned synthetic device %d: %w", tokenID, err)
+		}
+
+		if udai != nil {
+			_, err = udai.Delete(ctx, tx)
+			if err != nil {
+				return fmt.Errorf("failed to delete job backing synthetic device %d: %w", tokenID, err)
+			}
+
+			if udai.TaskID.Valid {
+				switch integ.Vendor {
+				case constants.TeslaVendor:
+					err := c.teslaTask.StopPoll(udai)
+					if err != nil {
+						return err
+					}
+				default:
+					c.log.Warn().Msgf("Unexpected integration %s.", integ.Vendor)
+				}
+			}
+		}
+	} else {
+		c.log.Warn().Int64("syntheticDeviceTokenId", tokenID.Int64()).Msg("Burning synthetic device with no paired vehicle.")
+	}
+
+	c.log.Info().Int64("syntheticDeviceTokenId", tokenID.Int64()).Str("owner", owner.Hex()).Msg("Burned synthetic device.")
+
+	return tx.Commit()
+}
+
+// vehicleAttributeSet keeps our copy of an on-chain vehicle attribute in step with the registry.
+// Only the VIN has a home on user_devices; every change, to any attribute, is recorded in
+// vehicle_attribute_changes. Setting an attribute to the value it already has does nothing.
+func (c *ContractsEventsConsumer) vehicleAttributeSet(ctx context.Context, e *ContractEventData) error {
+	var args contracts.RegistryVehicleAttributeSet
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	log := c.log.With().Int64("vehicleTokenId", args.TokenId.Int64()).Str("attribute", args.Attribute).Logger()
+
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Debug().Msg("Attribute set on a vehicle we don't know about.")
+			return nil
+		}
+		return err
+	}
+
+	var previous null.String
+
+	switch args.Attribute {
+	case vehicleAttributeVIN:
+		if ud.VinConfirmed && ud.VinIdentifier.Valid && ud.VinIdentifier.String == args.Info {
+			return nil
+		}
+
+		previous = ud.VinIdentifier
+		ud.VinIdentifier = null.StringFrom(args.Info)
+		ud.VinConfirmed = true
+
+		cols := models.UserDeviceColumns
+		if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.VinIdentifier, cols.VinConfirmed, cols.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to update VIN: %w", err)
+		}
+	default:
+		last, err := models.VehicleAttributeChanges(
+			models.VehicleAttributeChangeWhere.VehicleTokenID.EQ(dbtypes.IntToDecimal(args.TokenId)),
+			models.VehicleAttributeChangeWhere.Attribute.EQ(args.Attribute),
+			qm.OrderBy(models.VehicleAttributeChangeColumns.CreatedAt+" DESC"),
+		).One(ctx, tx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if last != nil {
+			if last.Value == args.Info {
+				return nil
+			}
+			previous = null.StringFrom(last.Value)
+		}
+	}
+
+	if err := recordVehicleAttributeChange(ctx, tx, ud, args.Attribute, previous, args.Info, e.TransactionHash); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Vehicle attribute set to %s.", args.Info)
+
+	return tx.Commit()
+}
+
+// vehicleAttributeRemoved handles the registry dropping an attribute from its whitelist. The
+// event isn't tied to a vehicle and existing values stay on chain, so there is nothing to update.
+func (c *ContractsEventsConsumer) vehicleAttributeRemoved(e *ContractEventData) error {
+	var args contracts.RegistryVehicleAttributeRemoved
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	c.log.Info().Str("attribute", args.Attribute).Msg("Vehicle attribute removed from the registry whitelist.")
+
+	return nil
+}
+
+func (c *ContractsEventsConsumer) deviceDefinitionIDSet(ctx context.Context, e *ContractEventData) error {
+	var args contracts.RegistryDeviceDefinitionIdSet
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.SetDeviceDefinition(ctx, args.VehicleId, args.DdId, e.TransactionHash)
+}
+
+// SetDeviceDefinition moves the vehicle to the device definition now stored on chain. The
+// powertrain type in the metadata is re-derived from the new definition, and cleared if it has
+// none. The old style is dropped, since styles belong to a definition. The transaction hash may be empty.
+func (c *ContractsEventsConsumer) SetDeviceDefinition(ctx context.Context, tokenID *big.Int, ddID string, txHash common.Hash) error {
+	log := c.log.With().Int64("vehicleTokenId", tokenID.Int64()).Str("deviceDefinitionId", ddID).Logger()
+
+	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Debug().Msg("Device definition set on a vehicle we don't know about.")
+			return nil
+		}
+		return err
+	}
+
+	if ud.DefinitionID == ddID {
+		return nil
+	}
+
+	dd, err := c.ddSvc.GetDeviceDefinitionBySlug(ctx, ddID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve device definition %s: %w", ddID, err)
+	}
+
+	md := new(UserDeviceMetadata)
+	if ud.Metadata.Valid {
+		if err := ud.Metadata.Unmarshal(md); err != nil {
+			log.Err(err).Msg("Failed to unmarshal metadata, starting over.")
+			md = new(UserDeviceMetadata)
+		}
+	}
+
+	// A powertrain carried over from the old definition would be a guess.
+	md.PowertrainType = nil
+	for _, attr := range dd.DeviceAttributes {
+		if attr.Name == constants.PowerTrainTypeKey {
+			powertrainType := ConvertPowerTrainStringToPowertrain(attr.Value)
+			md.PowertrainType = &powertrainType
+			break
+		}
+	}
+
+	if err := ud.Metadata.Marshal(md); err != nil {
+		return err
+	}
+
+	previous := null.StringFrom(ud.DefinitionID)
+	ud.DefinitionID = ddID
+	ud.DeviceStyleID = null.String{}
+
+	cols := models.UserDeviceColumns
+	if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.DefinitionID, cols.DeviceStyleID, cols.Metadata, cols.UpdatedAt)); err != nil {
+		return fmt.Errorf("failed to update device definition: %w", err)
+	}
+
+	if err := recordVehicleAttributeChange(ctx, tx, ud, deviceDefinitionIDAttribute, previous, ddID, txHash); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Device definition changed from %s.", previous.String)
+
+	return tx.Commit()
+}
+
+func recordVehicleAttributeChange(ctx context.Context, exec boil.ContextExecutor, ud *models.UserDevice, attribute string, previous null.String, value string, txHash common.Hash) error {
+	change := models.VehicleAttributeChange{
+		ID:             ksuid.New().String(),
+		UserDeviceID:   ud.ID,
+		VehicleTokenID: types.NewDecimal(ud.TokenID.Big),
+		Attribute:      attribute,
+		PreviousValue:  previous,
+		Value:          value,
+	}
+
+	if txHash != (common.Hash{}) {
+		change.TransactionHash = null.BytesFrom(txHash.Bytes())
+	}
+
+	if err := change.Insert(ctx, exec, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to record change to %s: %w", attribute, err)
+	}
+
+	return nil
+}
+
 func addressToUserID(addr common.Address) (string, error) {
 	userIDArgs := dex.IDTokenSubject{
 		UserId: addr.Hex(),
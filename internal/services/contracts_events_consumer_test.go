@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -19,17 +20,20 @@ import (
 	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/internal/utils"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/DIMO-Network/shared/pkg/payloads"
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/volatiletech/sqlboiler/v4/types"
@@ -90,7 +94,7 @@ func TestProcessContractsEventsMessages(t *testing.T) {
 	e := privilegeEventsPayloadFactory(1, 1, "", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	s.require.NoError(err)
@@ -133,7 +137,7 @@ func TestIgnoreWrongEventNames(t *testing.T) {
 	e := privilegeEventsPayloadFactory(2, 2, "SomeEvent", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -160,7 +164,7 @@ func TestUpdatedTimestamp(t *testing.T) {
 	e := privilegeEventsPayloadFactory(3, 3, "", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -235,7 +239,7 @@ func Test_Transfer_Event_Handled_Correctly(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
 
@@ -274,7 +278,7 @@ func Test_Ignore_Transfer_Mint_Event(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -309,7 +313,7 @@ func Test_Ignore_Transfer_Claims_Event(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
 
@@ -343,7 +347,7 @@ func Test_Ignore_Transfer_Wrong_Contract(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -376,7 +380,7 @@ func Test_Ignore_Transfer_Unit_Not_Found(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	s.require.NoError(err)
 
@@ -486,7 +490,7 @@ func TestSetBeneficiary(t *testing.T) {
 		"source": "chain/%d"
 		}`, c.Address.Hex(), abi.Events["BeneficiarySet"].ID, c.Event.NodeId, c.Event.Beneficiary.Hex(), c.Event.IdProxyAddress.Hex(), s.settings.DIMORegistryChainID)
 
-		consumer := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil)
+		consumer := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil)
 
 		event, err := marshalMockPayload(payload)
 		require.NoError(t, err)
@@ -523,7 +527,7 @@ func TestVehicleTransfer(t *testing.T) {
 	}
 	_ = ud.Insert(ctx, pdb.DBS().Writer, boil.Infer())
 
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(`
 	{
 		"type": "zone.dimo.contract.event",
@@ -556,6 +560,35 @@ func TestVehicleTransfer(t *testing.T) {
 	}
 }
 
+func TestVehicleTransferNoRecordedOwner(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	settings := &config.Settings{DIMORegistryChainID: 1}
+
+	mtr := models.MetaTransactionRequest{ID: ksuid.New().String()}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ud := models.UserDevice{
+		ID:            ksuid.New().String(),
+		MintRequestID: null.StringFrom(mtr.ID),
+		TokenID:       types.NewNullDecimal(decimal.New(5, 0)),
+		DefinitionID:  "ford_escape_2020",
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	from, to := randomAddr(t), randomAddr(t)
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
+	require.NoError(t, consumer.TransferVehicle(ctx, big.NewInt(5), from, to, common.Hash{}))
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	require.True(t, ud.OwnerAddress.Valid, "A vehicle with no recorded owner should still be transferred.")
+	require.Equal(t, to, common.BytesToAddress(ud.OwnerAddress.Bytes))
+}
+
 func Test_NFTPrivileges_Cleared_On_Vehicle_Transfer(t *testing.T) {
 	ctx := context.Background()
 	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
@@ -589,7 +622,7 @@ func Test_NFTPrivileges_Cleared_On_Vehicle_Transfer(t *testing.T) {
 	}
 	_ = ud.Insert(ctx, pdb.DBS().Writer, boil.Infer())
 
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(`
 	{
 		"type": "zone.dimo.contract.event",
@@ -626,6 +659,152 @@ func Test_NFTPrivileges_Cleared_On_Vehicle_Transfer(t *testing.T) {
 	require.Equal(0, len(nftPrivileges))
 }
 
+func Test_Vehicle_Transfer_Handover(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	settings := &config.Settings{DIMORegistryChainID: 1, VehicleNFTAddress: "0x881d40237659c251811cec9c364ef91dc08d300c"}
+
+	from := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	to := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	ud := models.UserDevice{
+		ID:           ksuid.New().String(),
+		UserID:       "olduser",
+		OwnerAddress: null.BytesFrom(from.Bytes()),
+		TokenID:      types.NewNullDecimal(decimal.New(5, 0)),
+		DefinitionID: "tesla_model-3_2022",
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	tesla := models.UserDeviceAPIIntegration{
+		UserDeviceID:    ud.ID,
+		IntegrationID:   ksuid.New().String(),
+		Status:          models.UserDeviceAPIIntegrationStatusActive,
+		AccessToken:     null.StringFrom("encrypted-access"),
+		AccessExpiresAt: null.TimeFrom(time.Now().Add(time.Hour)),
+		RefreshToken:    null.StringFrom("encrypted-refresh"),
+	}
+	require.NoError(t, tesla.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	// Hardware integrations have no credentials and stay as they are.
+	hardware := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: ksuid.New().String(),
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+	}
+	require.NoError(t, hardware.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	doc := models.Document{
+		ID:           ksuid.New().String(),
+		UserID:       "olduser",
+		UserDeviceID: null.StringFrom(ud.ID),
+		Type:         "VehicleInsurance",
+		Name:         "Insurance",
+		FileName:     "file.pdf",
+		FileExt:      ".pdf",
+		ContentType:  "application/pdf",
+		FileSize:     1024,
+		S3Key:        "olduser/doc",
+		Tags:         []string{},
+	}
+	require.NoError(t, doc.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ecq := models.ErrorCodeQuery{
+		ID:           ksuid.New().String(),
+		UserDeviceID: ud.ID,
+	}
+	require.NoError(t, ecq.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	subscribe := func(developer common.Address) *models.WebhookSubscription {
+		ws := &models.WebhookSubscription{
+			ID:               ksuid.New().String(),
+			DeveloperAddress: developer.Bytes(),
+			URL:              "https://example.com/hook",
+			Secret:           "secret",
+			EventTypes:       types.StringArray{WebhookVehicleTransferred},
+			VehicleTokenID:   types.NewNullDecimal(decimal.New(5, 0)),
+		}
+		require.NoError(t, ws.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return ws
+	}
+
+	sellerHook := subscribe(from)
+	otherHook := subscribe(common.HexToAddress("0x7"))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
+	event, err := marshalMockPayload(`
+	{
+		"type": "zone.dimo.contract.event",
+		"source": "chain/1",
+		"data": {
+			"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+			"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000abc",
+			"eventName": "Transfer",
+			"arguments": {
+				"from": "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+				"to": "0x4675c7e5baafbffbca748158becba61ef3b0a263",
+				"tokenId": 5
+			}
+		}
+	}
+	`)
+	require.NoError(t, err)
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.NoError(t, tesla.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, models.UserDeviceAPIIntegrationStatusAuthenticationFailure, tesla.Status)
+	assert.False(t, tesla.AccessToken.Valid)
+	assert.False(t, tesla.RefreshToken.Valid)
+	assert.False(t, tesla.AccessExpiresAt.Valid)
+
+	require.NoError(t, hardware.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, models.UserDeviceAPIIntegrationStatusActive, hardware.Status)
+
+	require.NoError(t, doc.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, "olduser", doc.UserID)
+	assert.False(t, doc.UserDeviceID.Valid)
+
+	require.NoError(t, ecq.Reload(ctx, pdb.DBS().Reader))
+	assert.True(t, ecq.HiddenAt.Valid)
+
+	exists, err := models.WebhookSubscriptionExists(ctx, pdb.DBS().Reader, sellerHook.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = models.WebhookSubscriptionExists(ctx, pdb.DBS().Reader, otherHook.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	vh, err := models.VehicleHandovers(
+		qm.Load(models.VehicleHandoverRels.VehicleHandoverEvents, qm.OrderBy(models.VehicleHandoverEventColumns.CreatedAt)),
+	).One(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, ud.ID, vh.UserDeviceID)
+	assert.Equal(t, from.Bytes(), vh.PreviousOwner)
+	assert.Equal(t, to.Bytes(), vh.NewOwner)
+	assert.Equal(t, common.HexToHash("0xabc").Bytes(), vh.TransactionHash.Bytes)
+
+	var actions []string
+	for _, ev := range vh.R.VehicleHandoverEvents {
+		actions = append(actions, ev.Action)
+	}
+	assert.Equal(t, []string{HandoverIntegrationRevoked, HandoverWebhooksDeleted, HandoverDocumentsDetached, HandoverErrorCodesHidden, HandoverOwnerChanged}, actions)
+
+	// A stale transfer out of the previous owner changes nothing.
+	require.NoError(t, consumer.TransferVehicle(ctx, big.NewInt(5), from, common.HexToAddress("0x8"), common.Hash{}))
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, to.Bytes(), ud.OwnerAddress.Bytes)
+
+	n, err := models.VehicleHandovers().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+}
+
 func Test_RegistryAftermarketDeviceAddressReset(t *testing.T) {
 	ctx := context.Background()
 
@@ -668,7 +847,7 @@ func Test_RegistryAftermarketDeviceAddressReset(t *testing.T) {
 	err := amd.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	consumer := NewContractsEventsConsumer(s.pdb, &logger, s.settings, nil, nil, nil)
+	consumer := NewContractsEventsConsumer(s.pdb, &logger, s.settings, nil, nil, nil, nil)
 	event, err := marshalMockPayload(payload)
 	require.NoError(t, err)
 
@@ -797,7 +976,7 @@ func Test_VehicleNodeMintedWithDeviceDefinition_NoMtx(t *testing.T) {
 
 	kprod := smock.NewSyncProducer(t, nil)
 	kprod.ExpectSendMessageAndSucceed()
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, nil)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, nil, nil)
 
 	owner := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
 	ddSlug := "jeep_wrangler_2013"
@@ -913,7 +1092,7 @@ func TestBurnSyntheticDevice(t *testing.T) {
 
 	kprod := smock.NewSyncProducer(t, nil)
 	kprod.ExpectSendMessageAndSucceed()
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, teslaTask)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, teslaTask, nil)
 
 	ownerAddr := randomAddr(t)
 
@@ -942,6 +1121,589 @@ func TestBurnSyntheticDevice(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNoRows)
 }
 
+// fakeIngestRegistrar records the aftermarket devices and AutoPi units it was asked to
+// deregister.
+type fakeIngestRegistrar struct {
+	IngestRegistrar
+	deregistered         []common.Address
+	deregisteredExternal []string
+}
+
+func (f *fakeIngestRegistrar) Deregister(externalID, _, _ string) error {
+	f.deregisteredExternal = append(f.deregisteredExternal, externalID)
+	return nil
+}
+
+func (f *fakeIngestRegistrar) Deregister2(addr common.Address) error {
+	f.deregistered = append(f.deregistered, addr)
+	return nil
+}
+
+func TestBurnVehicle(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	vehicleID := 54
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+	teslaTask := NewMockSyntheticTaskService(mockCtrl)
+	ingestReg := &fakeIngestRegistrar{}
+
+	mtr := models.MetaTransactionRequest{
+		ID:     ksuid.New().String(),
+		Status: models.MetaTransactionRequestStatusConfirmed,
+	}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	owner := randomAddr(t)
+	ud := models.UserDevice{
+		ID:           ksuid.New().String(),
+		UserID:       "xdd",
+		DefinitionID: "tesla_model-x_2024",
+		OwnerAddress: null.BytesFrom(owner.Bytes()),
+		TokenID:      types.NewNullDecimal(decimal.New(int64(vehicleID), 0)),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	teslaInteg := &ddgrpc.Integration{Id: ksuid.New().String(), Vendor: constants.TeslaVendor}
+
+	udai := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: teslaInteg.Id,
+		TaskID:        null.StringFrom(ksuid.New().String()),
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+	}
+	require.NoError(t, udai.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	sd := models.SyntheticDevice{
+		VehicleTokenID:     ud.TokenID,
+		IntegrationTokenID: types.NewDecimal(decimal.New(2, 0)),
+		MintRequestID:      mtr.ID,
+		WalletChildNumber:  1,
+		WalletAddress:      randomAddr(t).Bytes(),
+		TokenID:            types.NewNullDecimal(decimal.New(4, 0)),
+	}
+	require.NoError(t, sd.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	adAddr := randomAddr(t)
+	ad := models.AftermarketDevice{
+		Serial:                    ksuid.New().String(),
+		EthereumAddress:           adAddr.Bytes(),
+		TokenID:                   types.NewDecimal(decimal.New(7, 0)),
+		VehicleTokenID:            ud.TokenID,
+		DeviceManufacturerTokenID: types.NewDecimal(decimal.New(137, 0)),
+	}
+	require.NoError(t, ad.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, teslaTask, ingestReg)
+
+	ced := ContractEventData{
+		ChainID:   settings.DIMORegistryChainID,
+		EventName: VehicleNodeBurned.String(),
+		Contract:  common.HexToAddress(settings.DIMORegistryAddr),
+		Arguments: []byte(fmt.Sprintf(`{"vehicleNode": %d, "owner": "%s"}`, vehicleID, owner)),
+	}
+	b, _ := json.Marshal(ced)
+	event := &payloads.CloudEvent[json.RawMessage]{
+		Source: fmt.Sprintf("chain/%d", settings.DIMORegistryChainID),
+		Type:   contractEventCEType,
+		Data:   b,
+	}
+
+	deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), teslaInteg.Id).Return(teslaInteg, nil)
+	teslaTask.EXPECT().StopPoll(gomock.Any())
+
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.ErrorIs(t, ud.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.ErrorIs(t, udai.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.ErrorIs(t, sd.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+
+	require.NoError(t, ad.Reload(ctx, pdb.DBS().Reader))
+	require.True(t, ad.VehicleTokenID.IsZero())
+	require.Equal(t, []common.Address{adAddr}, ingestReg.deregistered)
+
+	// Seeing the burn again, say from the Transfer to the zero address, is a no-op.
+	require.NoError(t, consumer.processEvent(ctx, event))
+	require.Len(t, ingestReg.deregistered, 1)
+}
+
+func TestBurnAftermarketDevice(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	adToken := 7
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+	ingestReg := &fakeIngestRegistrar{}
+
+	ud := models.UserDevice{
+		ID:           ksuid.New().String(),
+		UserID:       "xdd",
+		DefinitionID: "ford_escape_2020",
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	// Not paired on-chain, but still backing an integration.
+	adAddr := randomAddr(t)
+	ad := models.AftermarketDevice{
+		Serial:                    ksuid.New().String(),
+		EthereumAddress:           adAddr.Bytes(),
+		TokenID:                   types.NewDecimal(decimal.New(int64(adToken), 0)),
+		DeviceManufacturerTokenID: types.NewDecimal(decimal.New(137, 0)),
+	}
+	require.NoError(t, ad.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	autoPiInteg := &ddgrpc.Integration{Id: ksuid.New().String(), Vendor: constants.AutoPiVendor}
+
+	udai := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: autoPiInteg.Id,
+		Serial:        null.StringFrom(ad.Serial),
+		ExternalID:    null.StringFrom("device123"),
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+	}
+	require.NoError(t, udai.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	job := models.AutopiJob{
+		ID:             ksuid.New().String(),
+		AutopiDeviceID: "device123",
+		AutopiUnitID:   null.StringFrom(ad.Serial),
+		Command:        AutoPiSyncCommand,
+		State:          AutoPiJobStateSent,
+	}
+	require.NoError(t, job.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, nil, ingestReg)
+
+	ced := ContractEventData{
+		ChainID:   settings.DIMORegistryChainID,
+		EventName: AftermarketDeviceNodeBurned.String(),
+		Contract:  common.HexToAddress(settings.DIMORegistryAddr),
+		Arguments: []byte(fmt.Sprintf(`{"adNode": %d, "owner": "%s"}`, adToken, randomAddr(t))),
+	}
+	b, _ := json.Marshal(ced)
+	event := &payloads.CloudEvent[json.RawMessage]{
+		Source: fmt.Sprintf("chain/%d", settings.DIMORegistryChainID),
+		Type:   contractEventCEType,
+		Data:   b,
+	}
+
+	deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), autoPiInteg.Id).Return(autoPiInteg, nil)
+
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.ErrorIs(t, ad.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.ErrorIs(t, udai.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.ErrorIs(t, job.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+
+	assert.Equal(t, []string{"device123"}, ingestReg.deregisteredExternal)
+	assert.Equal(t, []common.Address{adAddr}, ingestReg.deregistered)
+
+	// Seeing the burn again is a no-op.
+	require.NoError(t, consumer.processEvent(ctx, event))
+	assert.Len(t, ingestReg.deregistered, 1)
+}
+
+func TestSyntheticDeviceNodeBurned(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	sdToken := 4
+	vehicleID := 54
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+	teslaTask := NewMockSyntheticTaskService(mockCtrl)
+
+	mtr := models.MetaTransactionRequest{
+		ID:     ksuid.New().String(),
+		Status: models.MetaTransactionRequestStatusConfirmed,
+	}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ud := models.UserDevice{
+		ID:           ksuid.New().String(),
+		UserID:       "xdd",
+		DefinitionID: "tesla_model-x_2024",
+		TokenID:      types.NewNullDecimal(decimal.New(int64(vehicleID), 0)),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	teslaInteg := &ddgrpc.Integration{Id: ksuid.New().String(), Vendor: constants.TeslaVendor}
+
+	udai := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: teslaInteg.Id,
+		TaskID:        null.StringFrom(ksuid.New().String()),
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+	}
+	require.NoError(t, udai.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	sd := models.SyntheticDevice{
+		VehicleTokenID:     ud.TokenID,
+		IntegrationTokenID: types.NewDecimal(decimal.New(2, 0)),
+		MintRequestID:      mtr.ID,
+		WalletChildNumber:  1,
+		WalletAddress:      randomAddr(t).Bytes(),
+		TokenID:            types.NewNullDecimal(decimal.New(int64(sdToken), 0)),
+	}
+	require.NoError(t, sd.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, teslaTask, nil)
+
+	ced := ContractEventData{
+		ChainID:   settings.DIMORegistryChainID,
+		EventName: SyntheticDeviceNodeBurned.String(),
+		Contract:  common.HexToAddress(settings.DIMORegistryAddr),
+		Arguments: []byte(fmt.Sprintf(`{"syntheticDeviceNode": %d, "vehicleNode": %d, "owner": "%s"}`, sdToken, vehicleID, randomAddr(t))),
+	}
+	b, _ := json.Marshal(ced)
+	event := &payloads.CloudEvent[json.RawMessage]{
+		Source: fmt.Sprintf("chain/%d", settings.DIMORegistryChainID),
+		Type:   contractEventCEType,
+		Data:   b,
+	}
+
+	deviceDefSvc.EXPECT().GetIntegrationByTokenID(gomock.Any(), uint64(2)).Return(teslaInteg, nil)
+	teslaTask.EXPECT().StopPoll(gomock.Any())
+
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.ErrorIs(t, sd.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.ErrorIs(t, udai.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+
+	// Seeing the burn again, say from the Transfer to the zero address, is a no-op.
+	require.NoError(t, consumer.processEvent(ctx, event))
+}
+
+func TestDeviceDefinitionIDSet(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+
+	ud := models.UserDevice{
+		ID:            ksuid.New().String(),
+		UserID:        "xdd",
+		DefinitionID:  "ford_escape_2020",
+		DeviceStyleID: null.StringFrom("escape-se"),
+		TokenID:       types.NewNullDecimal(decimal.New(13, 0)),
+		Metadata:      null.JSONFrom([]byte(`{"powertrainType": "ICE", "canProtocol": "6"}`)),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, nil, nil)
+
+	newEvent := func(eventName string, index uint, args string) *payloads.CloudEvent[json.RawMessage] {
+		b, _ := json.Marshal(ContractEventData{
+			ChainID:         settings.DIMORegistryChainID,
+			EventName:       eventName,
+			Index:           index,
+			Contract:        common.HexToAddress(settings.DIMORegistryAddr),
+			TransactionHash: common.BigToHash(big.NewInt(1)),
+			Arguments:       []byte(args),
+		})
+		return &payloads.CloudEvent[json.RawMessage]{
+			Source: fmt.Sprintf("chain/%d", settings.DIMORegistryChainID),
+			Type:   contractEventCEType,
+			Data:   b,
+		}
+	}
+
+	ddEvent := newEvent(DeviceDefinitionIdSet.String(), 0, `{"vehicleId": 13, "ddId": "ford_mustang-mach-e_2022"}`)
+
+	deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), "ford_mustang-mach-e_2022").Return(&ddgrpc.GetDeviceDefinitionItemResponse{
+		Id: "ford_mustang-mach-e_2022",
+		DeviceAttributes: []*ddgrpc.DeviceTypeAttribute{
+			{Name: constants.PowerTrainTypeKey, Value: "BEV"},
+		},
+	}, nil)
+
+	// A second delivery changes nothing.
+	require.NoError(t, consumer.processEvent(ctx, ddEvent))
+	require.NoError(t, consumer.processEvent(ctx, ddEvent))
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, "ford_mustang-mach-e_2022", ud.DefinitionID)
+	require.False(t, ud.DeviceStyleID.Valid)
+
+	var md UserDeviceMetadata
+	require.NoError(t, ud.Metadata.Unmarshal(&md))
+	require.Equal(t, BEV, *md.PowertrainType)
+	require.Equal(t, "6", *md.CANProtocol)
+
+	vinEvent := newEvent(VehicleAttributeSet.String(), 1, `{"tokenId": 13, "attribute": "VIN", "info": "1FA6P8TH4J5107860"}`)
+	require.NoError(t, consumer.processEvent(ctx, vinEvent))
+	require.NoError(t, consumer.processEvent(ctx, vinEvent))
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, "1FA6P8TH4J5107860", ud.VinIdentifier.String)
+	require.True(t, ud.VinConfirmed)
+
+	changes, err := models.VehicleAttributeChanges(
+		models.VehicleAttributeChangeWhere.UserDeviceID.EQ(ud.ID),
+		qm.OrderBy(models.VehicleAttributeChangeColumns.CreatedAt),
+	).All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	require.Equal(t, deviceDefinitionIDAttribute, changes[0].Attribute)
+	require.Equal(t, null.StringFrom("ford_escape_2020"), changes[0].PreviousValue)
+	require.Equal(t, "ford_mustang-mach-e_2022", changes[0].Value)
+	require.Equal(t, common.BigToHash(big.NewInt(1)).Bytes(), changes[0].TransactionHash.Bytes)
+
+	require.Equal(t, vehicleAttributeVIN, changes[1].Attribute)
+	require.False(t, changes[1].PreviousValue.Valid)
+	require.Equal(t, "1FA6P8TH4J5107860", changes[1].Value)
+
+	// A definition without a powertrain doesn't inherit the old one.
+	deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), "ford_f-150_2023").Return(&ddgrpc.GetDeviceDefinitionItemResponse{
+		Id: "ford_f-150_2023",
+	}, nil)
+	require.NoError(t, consumer.processEvent(ctx, newEvent(DeviceDefinitionIdSet.String(), 2, `{"vehicleId": 13, "ddId": "ford_f-150_2023"}`)))
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	md = UserDeviceMetadata{}
+	require.NoError(t, ud.Metadata.Unmarshal(&md))
+	require.Nil(t, md.PowertrainType)
+	require.Equal(t, "6", *md.CANProtocol)
+
+	// The history outlives the vehicle.
+	_, err = ud.Delete(ctx, pdb.DBS().Writer)
+	require.NoError(t, err)
+
+	n, err := models.VehicleAttributeChanges(models.VehicleAttributeChangeWhere.UserDeviceID.EQ(ud.ID)).Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+}
+
+func TestContractEventCursor(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+	registry := common.HexToAddress(settings.DIMORegistryAddr)
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
+
+	newEvent := func(block int64, txHash common.Hash, index uint) *ContractEventData {
+		return &ContractEventData{
+			ChainID:         settings.DIMORegistryChainID,
+			EventName:       VehicleAttributeRemoved.String(),
+			Block:           Block{Number: big.NewInt(block)},
+			Index:           index,
+			Contract:        registry,
+			TransactionHash: txHash,
+			Arguments:       []byte(`{"attribute": "Color"}`),
+		}
+	}
+
+	later := newEvent(10, common.BigToHash(big.NewInt(2)), 3)
+	earlier := newEvent(5, common.BigToHash(big.NewInt(1)), 7)
+
+	require.NoError(t, consumer.handleEvent(ctx, later, false))
+	require.NoError(t, consumer.handleEvent(ctx, later, false))
+	require.NoError(t, consumer.handleEvent(ctx, earlier, false))
+	require.NoError(t, consumer.Replay(ctx, later))
+
+	// A failed handler leaves the event for the redelivery.
+	broken := newEvent(11, common.BigToHash(big.NewInt(3)), 0)
+	broken.Arguments = []byte(`{`)
+	require.Error(t, consumer.handleEvent(ctx, broken, false))
+
+	count, err := models.ProcessedContractEvents().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	cursor, err := models.FindContractEventCursor(ctx, pdb.DBS().Reader, settings.DIMORegistryChainID, registry.Bytes())
+	require.NoError(t, err)
+
+	// The older event doesn't move the cursor back.
+	require.EqualValues(t, 10, cursor.BlockNumber)
+	require.Equal(t, 3, cursor.LogIndex)
+	require.Equal(t, later.TransactionHash.Bytes(), cursor.TransactionHash)
+}
+
+func TestReplayAftermarketDevicePaired(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	integ := NewMockIntegration(mockCtrl)
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+
+	ud := test.SetupCreateUserDevice(t, "dylan", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud, big.NewInt(7), null.BytesFrom(randomAddr(t).Bytes()), pdb)
+	am := test.SetupCreateMintedAftermarketDevice(t, "dylan", "macaron", big.NewInt(12), randomAddr(t), nil, pdb)
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, integ, nil, nil, nil)
+
+	ev := &ContractEventData{
+		ChainID:         settings.DIMORegistryChainID,
+		EventName:       AftermarketDevicePaired.String(),
+		Block:           Block{Number: big.NewInt(1)},
+		Contract:        common.HexToAddress(settings.DIMORegistryAddr),
+		TransactionHash: common.BigToHash(big.NewInt(1)),
+		Arguments:       []byte(`{"aftermarketDeviceNode": 12, "vehicleNode": 7}`),
+	}
+
+	// Once when the event comes in, and again for the replay.
+	integ.EXPECT().Pair(gomock.Any(), big.NewInt(12), big.NewInt(7)).Return(nil).Times(2)
+
+	require.NoError(t, consumer.handleEvent(ctx, ev, false))
+	require.NoError(t, consumer.Replay(ctx, ev))
+
+	require.NoError(t, am.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, big.NewInt(7), am.VehicleTokenID.Int(nil))
+}
+
+func TestAftermarketDevicePairedIntegrationFails(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	integ := NewMockIntegration(mockCtrl)
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+
+	ud := test.SetupCreateUserDevice(t, "dylan", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud, big.NewInt(7), null.BytesFrom(randomAddr(t).Bytes()), pdb)
+	am := test.SetupCreateMintedAftermarketDevice(t, "dylan", "macaron", big.NewInt(12), randomAddr(t), nil, pdb)
+
+	ws := &models.WebhookSubscription{
+		ID:               ksuid.New().String(),
+		DeveloperAddress: randomAddr(t).Bytes(),
+		URL:              "https://example.com/hook",
+		Secret:           "secret",
+		EventTypes:       types.StringArray{WebhookAftermarketPaired},
+		VehicleTokenID:   types.NewNullDecimal(decimal.New(7, 0)),
+	}
+	require.NoError(t, ws.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, integ, nil, nil, nil)
+
+	ev := &ContractEventData{
+		ChainID:         settings.DIMORegistryChainID,
+		EventName:       AftermarketDevicePaired.String(),
+		Block:           Block{Number: big.NewInt(1)},
+		Contract:        common.HexToAddress(settings.DIMORegistryAddr),
+		TransactionHash: common.BigToHash(big.NewInt(1)),
+		Arguments:       []byte(`{"aftermarketDeviceNode": 12, "vehicleNode": 7}`),
+	}
+
+	gomock.InOrder(
+		integ.EXPECT().Pair(gomock.Any(), big.NewInt(12), big.NewInt(7)).Return(errors.New("integration down")),
+		integ.EXPECT().Pair(gomock.Any(), big.NewInt(12), big.NewInt(7)).Return(nil),
+	)
+
+	require.Error(t, consumer.handleEvent(ctx, ev, false))
+
+	require.NoError(t, am.Reload(ctx, pdb.DBS().Reader))
+	require.True(t, am.VehicleTokenID.IsZero(), "Nothing should change if the integration fails.")
+
+	// The redelivery.
+	require.NoError(t, consumer.handleEvent(ctx, ev, false))
+
+	require.NoError(t, am.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, big.NewInt(7), am.VehicleTokenID.Int(nil))
+
+	n, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.SubscriptionID.EQ(ws.ID)).Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n, "Subscribers should only hear about the pairing once.")
+}
+
+func TestAftermarketDeviceAttributes(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: randomAddr(t).Hex()}
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil)
+
+	index := uint(0)
+	setAttribute := func(attribute, info string) {
+		index++
+		err := consumer.handleEvent(ctx, &ContractEventData{
+			ChainID:         settings.DIMORegistryChainID,
+			EventName:       AftermarketDeviceAttributeSet.String(),
+			Block:           Block{Number: big.NewInt(1)},
+			Index:           index,
+			Contract:        common.HexToAddress(settings.DIMORegistryAddr),
+			TransactionHash: common.BigToHash(big.NewInt(1)),
+			Arguments:       []byte(fmt.Sprintf(`{"tokenId": 12, "attribute": %q, "info": %q}`, attribute, info)),
+		}, false)
+		require.NoError(t, err)
+	}
+
+	pad := models.PartialAftermarketDevice{
+		TokenID:             utils.BigToDecimal(big.NewInt(12)),
+		ManufacturerTokenID: utils.BigToDecimal(big.NewInt(137)),
+		EthereumAddress:     randomAddr(t).Bytes(),
+	}
+	require.NoError(t, pad.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	// Attributes that come before the serial wait with the partial device.
+	setAttribute("HardwareRevision", "7.1")
+	setAttribute("Serial", "macaron12")
+
+	ad, err := models.FindAftermarketDevice(ctx, pdb.DBS().Reader, pad.EthereumAddress)
+	require.NoError(t, err)
+	require.Equal(t, "macaron12", ad.Serial)
+
+	var md AftermarketDeviceMetadata
+	require.NoError(t, ad.Metadata.Unmarshal(&md))
+	require.Equal(t, map[string]string{"HardwareRevision": "7.1"}, md.Attributes)
+
+	setAttribute("HardwareRevision", "7.2")
+	setAttribute("ImsiNumber", "310150123456789")
+	setAttribute("ImsiNumber", "")
+
+	require.NoError(t, ad.Reload(ctx, pdb.DBS().Reader))
+	md = AftermarketDeviceMetadata{}
+	require.NoError(t, ad.Metadata.Unmarshal(&md))
+	require.Equal(t, map[string]string{"HardwareRevision": "7.2"}, md.Attributes)
+}
+
 func initCEventsTestHelper(t *testing.T) cEventsTestHelper {
 	ctx := context.Background()
 	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
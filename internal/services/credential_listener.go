@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/DIMO-Network/shared/pkg/payloads"
@@ -27,19 +28,9 @@ func NewCredentialListener(db func() *db.ReaderWriter, log *zerolog.Logger) *Cre
 	return &CredentialListener{db: db, log: log}
 }
 
-func (i *CredentialListener) ProcessCredentialsMessages(messages <-chan *message.Message) {
-	for msg := range messages {
-		err := i.processMessage(msg)
-		if err != nil {
-			i.log.Err(err).Msg("error processing credential msg")
-		}
-	}
-}
-
-func (i *CredentialListener) processMessage(msg *message.Message) error {
-	// Keep the pipeline moving no matter what.
-	defer func() { msg.Ack() }()
-
+// ProcessCredentialsMessage handles one message from the credential topic. It doesn't ack the
+// message; that's left to the kafka.RetryHandler that wraps it.
+func (i *CredentialListener) ProcessCredentialsMessage(msg *message.Message) error {
 	// Deletion messages. We're the only actor that produces these, so ignore them.
 	if msg.Payload == nil {
 		return nil
@@ -47,7 +38,7 @@ func (i *CredentialListener) processMessage(msg *message.Message) error {
 
 	event := new(payloads.CloudEvent[sdtask.CredentialData])
 	if err := json.Unmarshal(msg.Payload, event); err != nil {
-		return errors.Wrap(err, "error parsing device event payload")
+		return kafka.Permanent(errors.Wrap(err, "error parsing device event payload"))
 	}
 
 	return i.processEvent(event)
@@ -63,7 +54,7 @@ func (i *CredentialListener) processEvent(event *payloads.CloudEvent[sdtask.Cred
 	)
 
 	if !strings.HasPrefix(event.Source, sourcePrefix) {
-		return fmt.Errorf("unexpected event source format: %s", event.Source)
+		return kafka.Permanent(fmt.Errorf("unexpected event source format: %s", event.Source))
 	}
 	integrationID := strings.TrimPrefix(event.Source, sourcePrefix)
 
@@ -80,7 +71,7 @@ func (i *CredentialListener) processEvent(event *payloads.CloudEvent[sdtask.Cred
 		refreshToken = event.Data.RefreshToken
 		expiry = event.Data.Expiry
 	default:
-		return fmt.Errorf("unexpected event type %s", event.Type)
+		return kafka.Permanent(fmt.Errorf("unexpected event type %s", event.Type))
 	}
 
 	integ, err := models.FindUserDeviceAPIIntegration(ctx, i.db().Writer, userDeviceID, integrationID)
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// DefaultDocumentsLimit is the page size used when the caller doesn't specify one.
+	DefaultDocumentsLimit = 100
+	// MaxDocumentsLimit caps the page size of document queries.
+	MaxDocumentsLimit = 500
+)
+
+// defaultPendingDocumentTTL is how long a presigned upload may stay unfinalized before it is
+// reaped, if not configured.
+const defaultPendingDocumentTTL = 24 * time.Hour
+
+// pendingDocumentReapBatch caps how many abandoned uploads are removed per reaper run.
+const pendingDocumentReapBatch = 100
+
+// ErrInvalidDocumentCursor is returned when the pagination cursor doesn't refer to one of the
+// user's documents.
+var ErrInvalidDocumentCursor = errors.New("invalid document cursor")
+
+// DocumentFilter narrows down a document listing. Zero-valued fields are ignored, apart from
+// UserID, which is required.
+type DocumentFilter struct {
+	UserID         string
+	UserDeviceID   string
+	VehicleTokenID *big.Int
+	Type           string
+	Tag            string
+	// ExpiringWithin selects documents that haven't expired yet but will within this long.
+	ExpiringWithin time.Duration
+	// Cursor is the id of the last document of the previous page.
+	Cursor string
+	Limit  int
+}
+
+// ListDocuments returns the user's documents matching the filter, newest first. Uploads that
+// haven't been finalized are left out.
+func ListDocuments(ctx context.Context, exec boil.ContextExecutor, f DocumentFilter) (models.DocumentSlice, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = DefaultDocumentsLimit
+	} else if limit > MaxDocumentsLimit {
+		limit = MaxDocumentsLimit
+	}
+
+	mods := []qm.QueryMod{
+		models.DocumentWhere.UserID.EQ(f.UserID),
+		models.DocumentWhere.Status.EQ(models.DocumentStatusReady),
+		qm.Load(models.DocumentRels.UserDevice),
+		qm.OrderBy(models.DocumentTableColumns.CreatedAt + " DESC, " + models.DocumentTableColumns.ID + " DESC"),
+		qm.Limit(limit),
+	}
+
+	if f.UserDeviceID != "" {
+		mods = append(mods, models.DocumentWhere.UserDeviceID.EQ(null.StringFrom(f.UserDeviceID)))
+	}
+	if f.VehicleTokenID != nil {
+		// Go through user_devices so that the filter follows the vehicle's current token.
+		mods = append(mods,
+			qm.InnerJoin(models.TableNames.UserDevices+" ud ON ud."+models.UserDeviceColumns.ID+" = "+models.DocumentTableColumns.UserDeviceID),
+			qm.Where("ud."+models.UserDeviceColumns.TokenID+" = ?", dbtypes.NullIntToDecimal(f.VehicleTokenID)),
+		)
+	}
+	if f.Type != "" {
+		mods = append(mods, models.DocumentWhere.Type.EQ(f.Type))
+	}
+	if f.Tag != "" {
+		mods = append(mods, qm.Where(models.DocumentTableColumns.Tags+" @> ARRAY[?]::text[]", f.Tag))
+	}
+	if f.ExpiringWithin > 0 {
+		now := time.Now()
+		mods = append(mods,
+			models.DocumentWhere.ExpiresAt.GTE(null.TimeFrom(now)),
+			models.DocumentWhere.ExpiresAt.LTE(null.TimeFrom(now.Add(f.ExpiringWithin))),
+		)
+	}
+
+	if f.Cursor != "" {
+		last, err := models.Documents(
+			models.DocumentWhere.ID.EQ(f.Cursor),
+			models.DocumentWhere.UserID.EQ(f.UserID),
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrInvalidDocumentCursor
+			}
+			return nil, err
+		}
+
+		mods = append(mods, qm.Where("("+models.DocumentTableColumns.CreatedAt+", "+models.DocumentTableColumns.ID+") < (?, ?)", last.CreatedAt, last.ID))
+	}
+
+	return models.Documents(mods...).All(ctx, exec)
+}
+
+// DocumentObjectDeleter is the part of the S3 client used to remove abandoned uploads.
+type DocumentObjectDeleter interface {
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// NewPendingDocumentTTL parses the pending document TTL from settings, falling back to
+// defaultPendingDocumentTTL.
+func NewPendingDocumentTTL(settings *config.Settings) (time.Duration, error) {
+	if settings.PendingDocumentTTL == "" {
+		return defaultPendingDocumentTTL, nil
+	}
+
+	d, err := time.ParseDuration(settings.PendingDocumentTTL)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse pending document ttl: %w", err)
+	}
+
+	return d, nil
+}
+
+// ReapPendingDocuments deletes documents that have been pending for longer than ttl, along
+// with anything the client managed to upload for them, and returns how many were removed.
+// The rows stay locked until they are gone, so a concurrent finalize either wins or finds
+// nothing.
+func ReapPendingDocuments(ctx context.Context, dbs func() *db.ReaderWriter, objects DocumentObjectDeleter, bucket string, ttl time.Duration) (int, error) {
+	tx, err := dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint
+
+	docs, err := models.Documents(
+		models.DocumentWhere.Status.EQ(models.DocumentStatusPending),
+		models.DocumentWhere.CreatedAt.LT(time.Now().Add(-ttl)),
+		qm.Limit(pendingDocumentReapBatch),
+		qm.For("UPDATE SKIP LOCKED"),
+	).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	// S3 doesn't complain about deleting keys that were never written.
+	for _, d := range docs {
+		if _, err := objects.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(d.S3Key),
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := docs.DeleteAll(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(docs), nil
+}
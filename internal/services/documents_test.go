@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestListDocuments(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	const userID = "user1"
+	ud := test.SetupCreateUserDevice(t, userID, ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud, big.NewInt(5), null.BytesFrom(test.MkAddr(1).Bytes()), pdb)
+
+	now := time.Now()
+
+	insert := func(docType string, tags []string, vehicle bool, expiresAt null.Time, createdAt time.Time) *models.Document {
+		id := ksuid.New().String()
+		doc := &models.Document{
+			ID:          id,
+			UserID:      userID,
+			Type:        docType,
+			Name:        docType,
+			FileName:    "file.pdf",
+			FileExt:     ".pdf",
+			ContentType: "application/pdf",
+			FileSize:    1024,
+			S3Key:       userID + "/" + id,
+			Tags:        tags,
+			ExpiresAt:   expiresAt,
+			CreatedAt:   createdAt,
+		}
+		if vehicle {
+			doc.UserDeviceID = null.StringFrom(ud.ID)
+		}
+		require.NoError(t, doc.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return doc
+	}
+
+	insurance := insert("VehicleInsurance", []string{"car"}, true, null.TimeFrom(now.Add(10*24*time.Hour)), now.Add(-time.Hour))
+	registration := insert("VehicleRegistration", []string{"car", "dmv"}, true, null.TimeFrom(now.Add(60*24*time.Hour)), now.Add(-2*time.Hour))
+	license := insert("DriversLicense", []string{}, false, null.TimeFrom(now.Add(-24*time.Hour)), now.Add(-3*time.Hour))
+
+	// Someone else's document.
+	other := insert("Other", []string{"car"}, false, null.Time{}, now)
+	other.UserID = "user2"
+	_, err := other.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	ids := func(docs models.DocumentSlice) []string {
+		out := make([]string, len(docs))
+		for i, d := range docs {
+			out[i] = d.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		filter DocumentFilter
+		want   []*models.Document
+	}{
+		{"all", DocumentFilter{}, []*models.Document{insurance, registration, license}},
+		{"type", DocumentFilter{Type: "DriversLicense"}, []*models.Document{license}},
+		{"vehicle", DocumentFilter{VehicleTokenID: big.NewInt(5)}, []*models.Document{insurance, registration}},
+		{"user device", DocumentFilter{UserDeviceID: ud.ID}, []*models.Document{insurance, registration}},
+		{"tag", DocumentFilter{Tag: "dmv"}, []*models.Document{registration}},
+		{"expiring", DocumentFilter{ExpiringWithin: 30 * 24 * time.Hour}, []*models.Document{insurance}},
+		{"page", DocumentFilter{Limit: 1, Cursor: insurance.ID}, []*models.Document{registration}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.UserID = userID
+			docs, err := ListDocuments(ctx, pdb.DBS().Reader, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, ids(tc.want), ids(docs))
+		})
+	}
+
+	_, err = ListDocuments(ctx, pdb.DBS().Reader, DocumentFilter{UserID: userID, Cursor: other.ID})
+	assert.ErrorIs(t, err, ErrInvalidDocumentCursor)
+}
+
+type fakeObjectDeleter struct {
+	keys []string
+}
+
+func (f *fakeObjectDeleter) DeleteObject(_ context.Context, params *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	f.keys = append(f.keys, aws.ToString(params.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestReapPendingDocuments(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	now := time.Now()
+	insert := func(status string, createdAt time.Time) *models.Document {
+		id := ksuid.New().String()
+		doc := &models.Document{
+			ID:          id,
+			UserID:      "user1",
+			Type:        "Other",
+			Name:        "file",
+			FileName:    "file.pdf",
+			FileExt:     ".pdf",
+			ContentType: "application/pdf",
+			FileSize:    1024,
+			S3Key:       "user1/" + id,
+			Tags:        []string{},
+			Status:      status,
+			CreatedAt:   createdAt,
+		}
+		require.NoError(t, doc.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return doc
+	}
+
+	abandoned := insert(models.DocumentStatusPending, now.Add(-2*time.Hour))
+	recent := insert(models.DocumentStatusPending, now.Add(-time.Minute))
+	ready := insert(models.DocumentStatusReady, now.Add(-2*time.Hour))
+
+	objects := new(fakeObjectDeleter)
+	n, err := ReapPendingDocuments(ctx, pdb.DBS, objects, "bucket", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{abandoned.S3Key}, objects.keys)
+
+	exists, err := models.DocumentExists(ctx, pdb.DBS().Reader, abandoned.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	for _, d := range []*models.Document{recent, ready} {
+		exists, err := models.DocumentExists(ctx, pdb.DBS().Reader, d.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+}
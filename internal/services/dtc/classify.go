@@ -0,0 +1,113 @@
+package dtc
+
+import (
+	"regexp"
+)
+
+// Systems, from the first character of the code.
+const (
+	SystemPowertrain = "powertrain"
+	SystemBody       = "body"
+	SystemChassis    = "chassis"
+	SystemNetwork    = "network"
+)
+
+// Severity levels, from most to least urgent.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// Classification is what can be read off a code without knowing its description.
+type Classification struct {
+	// System is one of "powertrain", "body", "chassis", or "network".
+	System string `json:"system" example:"powertrain"`
+	// ManufacturerSpecific is true for codes whose meaning is set by the manufacturer rather
+	// than by SAE J2012.
+	ManufacturerSpecific bool `json:"manufacturerSpecific"`
+	// Severity is one of "critical", "high", "medium", or "low".
+	Severity string `json:"severity" example:"high"`
+}
+
+var codeRegex = regexp.MustCompile(`^[PBCU][0-3][0-9A-F]{3}$`)
+
+// criticalCodes can damage the vehicle or endanger occupants if the vehicle keeps being driven.
+var criticalCodes = map[string]bool{
+	"P0217": true, // Engine coolant overtemperature
+	"P0218": true, // Transmission fluid overtemperature
+	"P0219": true, // Engine overspeed
+	"P0524": true, // Engine oil pressure too low
+}
+
+// Classify parses a five-character code. It returns false for anything else, including codes
+// with a failure type suffix.
+func Classify(code string) (*Classification, bool) {
+	code = normalizeCode(code)
+	if !codeRegex.MatchString(code) {
+		return nil, false
+	}
+
+	c := &Classification{
+		ManufacturerSpecific: manufacturerSpecific(code),
+	}
+
+	switch code[0] {
+	case 'P':
+		c.System = SystemPowertrain
+		c.Severity = powertrainSeverity(code)
+	case 'B':
+		c.System = SystemBody
+		// B00xx is restraints: airbags and seat belt pretensioners.
+		if code[1] == '0' && code[2] == '0' {
+			c.Severity = SeverityCritical
+		} else {
+			c.Severity = SeverityLow
+		}
+	case 'C':
+		// Brakes, steering and suspension.
+		c.System = SystemChassis
+		c.Severity = SeverityHigh
+	case 'U':
+		c.System = SystemNetwork
+		c.Severity = SeverityMedium
+	}
+
+	return c, true
+}
+
+// manufacturerSpecific follows the code ranges set aside by SAE J2012.
+func manufacturerSpecific(code string) bool {
+	switch code[0] {
+	case 'P':
+		// P1 and P30-P33 are manufacturer controlled; P0, P2 and P34-P39 are not.
+		return code[1] == '1' || (code[1] == '3' && code[2] <= '3')
+	default:
+		// B1, B2, C1, C2, U1 and U2 are manufacturer controlled.
+		return code[1] == '1' || code[1] == '2'
+	}
+}
+
+func powertrainSeverity(code string) string {
+	if criticalCodes[code] {
+		return SeverityCritical
+	}
+
+	if code[1] != '0' && code[1] != '2' {
+		return SeverityMedium
+	}
+
+	// The third character of generic powertrain codes gives the subsystem.
+	switch code[2] {
+	case '3', '7', '8', '9':
+		// Ignition and misfire, transmission.
+		return SeverityHigh
+	case '4':
+		// Auxiliary emission controls.
+		return SeverityLow
+	default:
+		// Fuel and air metering, speed and idle control, computer and output circuits.
+		return SeverityMedium
+	}
+}
@@ -0,0 +1,38 @@
+package dtc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		code string
+		want *Classification
+	}{
+		{"P0300", &Classification{System: SystemPowertrain, Severity: SeverityHigh}},
+		{"p0420", &Classification{System: SystemPowertrain, Severity: SeverityLow}},
+		{"P0217", &Classification{System: SystemPowertrain, Severity: SeverityCritical}},
+		{"P1349", &Classification{System: SystemPowertrain, ManufacturerSpecific: true, Severity: SeverityMedium}},
+		{"P3400", &Classification{System: SystemPowertrain, Severity: SeverityMedium}},
+		{"P3300", &Classification{System: SystemPowertrain, ManufacturerSpecific: true, Severity: SeverityMedium}},
+		{"P2A00", &Classification{System: SystemPowertrain, Severity: SeverityMedium}},
+		{"B0012", &Classification{System: SystemBody, Severity: SeverityCritical}},
+		{"B1318", &Classification{System: SystemBody, ManufacturerSpecific: true, Severity: SeverityLow}},
+		{"C0035", &Classification{System: SystemChassis, Severity: SeverityHigh}},
+		{"U0100", &Classification{System: SystemNetwork, Severity: SeverityMedium}},
+		{"U2100", &Classification{System: SystemNetwork, ManufacturerSpecific: true, Severity: SeverityMedium}},
+		{"P0010:30", nil},
+		{"X0100", nil},
+		{"P4100", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := Classify(tt.code)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
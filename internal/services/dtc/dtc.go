@@ -0,0 +1,93 @@
+// Package dtc describes diagnostic trouble codes without calling out to an LLM, using a table of
+// generic SAE J2012 codes and per-manufacturer overrides.
+package dtc
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed generic.yaml
+var genericRaw []byte
+
+//go:embed manufacturers.yaml
+var manufacturersRaw []byte
+
+type manufacturerCodes struct {
+	Makes []string          `yaml:"makes"`
+	Codes map[string]string `yaml:"codes"`
+}
+
+// Catalog looks up descriptions of diagnostic trouble codes.
+type Catalog struct {
+	generic map[string]string
+	// byMake is keyed by lowercased make name.
+	byMake map[string]map[string]string
+}
+
+// NewCatalog builds a catalog from YAML documents in the format of the embedded tables. The
+// generic document maps codes to descriptions; the manufacturers document is a list of
+// entries with makes and codes.
+func NewCatalog(generic, manufacturers []byte) (*Catalog, error) {
+	c := &Catalog{
+		generic: make(map[string]string),
+		byMake:  make(map[string]map[string]string),
+	}
+
+	var gen map[string]string
+	if err := yaml.Unmarshal(generic, &gen); err != nil {
+		return nil, fmt.Errorf("couldn't parse generic codes: %w", err)
+	}
+	for code, desc := range gen {
+		c.generic[normalizeCode(code)] = desc
+	}
+
+	var mfrs []manufacturerCodes
+	if err := yaml.Unmarshal(manufacturers, &mfrs); err != nil {
+		return nil, fmt.Errorf("couldn't parse manufacturer codes: %w", err)
+	}
+	for _, m := range mfrs {
+		for _, mk := range m.Makes {
+			mk = strings.ToLower(mk)
+			if c.byMake[mk] == nil {
+				c.byMake[mk] = make(map[string]string)
+			}
+			for code, desc := range m.Codes {
+				c.byMake[mk][normalizeCode(code)] = desc
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Default is the catalog built from the tables shipped with the service.
+var Default = mustDefault()
+
+func mustDefault() *Catalog {
+	c, err := NewCatalog(genericRaw, manufacturersRaw)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Lookup returns the description of the code for a vehicle of the given make. Manufacturer
+// entries take precedence over generic ones.
+func (c *Catalog) Lookup(vMake, code string) (string, bool) {
+	code = normalizeCode(code)
+
+	if desc, ok := c.byMake[strings.ToLower(vMake)][code]; ok {
+		return desc, true
+	}
+
+	desc, ok := c.generic[code]
+	return desc, ok
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
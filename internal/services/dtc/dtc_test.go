@@ -0,0 +1,42 @@
+package dtc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	desc, ok := Default.Lookup("Toyota", "P0420")
+	assert.True(t, ok)
+	assert.Equal(t, "Catalyst system efficiency below threshold (bank 1)", desc)
+
+	desc, ok = Default.Lookup("toyota", " p1349")
+	assert.True(t, ok)
+	assert.Equal(t, "Variable valve timing system malfunction (bank 1)", desc)
+
+	// Manufacturer codes don't leak across makes.
+	_, ok = Default.Lookup("Ford", "P1349")
+	assert.False(t, ok)
+
+	_, ok = Default.Lookup("Toyota", "P0010:30")
+	assert.False(t, ok)
+}
+
+func TestManufacturerOverride(t *testing.T) {
+	c, err := NewCatalog(
+		[]byte(`P1000: "Generic meaning"`),
+		[]byte(`[{makes: [Ford, Lincoln], codes: {P1000: "Ford meaning"}}]`),
+	)
+	require.NoError(t, err)
+
+	desc, _ := c.Lookup("Lincoln", "P1000")
+	assert.Equal(t, "Ford meaning", desc)
+
+	desc, _ = c.Lookup("Mazda", "P1000")
+	assert.Equal(t, "Generic meaning", desc)
+
+	_, err = NewCatalog([]byte(`[not, a, map]`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/appmetrics"
+	"github.com/DIMO-Network/devices-api/internal/config"
+	credis "github.com/DIMO-Network/shared/pkg/redis"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+)
+
+// LLMProvider explains vehicle error codes using a large language model.
+type LLMProvider interface {
+	GetErrorCodesDescription(ctx context.Context, vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error)
+}
+
+// Values of LLM_PROVIDER.
+const (
+	// LLMProviderOpenAI is any endpoint that speaks the OpenAI chat completions API with
+	// function calling.
+	LLMProviderOpenAI = "openai"
+	// LLMProviderOllama is a self-hosted Ollama server.
+	LLMProviderOllama = "ollama"
+)
+
+const (
+	defaultLLMModel    = "gpt-4o-mini"
+	defaultLLMTimeout  = 10 * time.Second
+	defaultLLMCacheTTL = 7 * 24 * time.Hour
+)
+
+// ErrorCodesFunctionCallResponse is the structured answer we ask every provider for.
+type ErrorCodesFunctionCallResponse struct {
+	ErrorCodes []struct {
+		Code        string
+		Explanation string `json:"explanation"`
+	} `json:"error_codes"`
+}
+
+func (r *ErrorCodesFunctionCallResponse) toAPI() []ErrorCodesResponse {
+	resp := []ErrorCodesResponse{}
+	for _, obj := range r.ErrorCodes {
+		resp = append(resp, ErrorCodesResponse{
+			Code:        obj.Code,
+			Description: obj.Explanation,
+		})
+	}
+	return resp
+}
+
+func errorCodesPrompt(vMake, model string, errorCodes []string) string {
+	return fmt.Sprintf("A %s %s is returning error codes %s. Return a long extensive explanation for each code.", vMake, model, strings.Join(errorCodes, ", "))
+}
+
+// NewLLMProvider builds the provider chosen in the settings. If cache is not nil, answers are
+// cached per make, model and code.
+func NewLLMProvider(logger *zerolog.Logger, settings *config.Settings, cache credis.CacheService) (LLMProvider, error) {
+	timeout := defaultLLMTimeout
+	if settings.LLMTimeout != "" {
+		t, err := time.ParseDuration(settings.LLMTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse LLM timeout: %w", err)
+		}
+		timeout = t
+	}
+
+	rateWindow := time.Hour
+	if settings.ErrorCodesRateLimitWindow != "" {
+		w, err := time.ParseDuration(settings.ErrorCodesRateLimitWindow)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse error code rate limit window: %w", err)
+		}
+		rateWindow = w
+	}
+
+	cacheTTL := defaultLLMCacheTTL
+	if settings.LLMCacheTTL != "" {
+		t, err := time.ParseDuration(settings.LLMCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse LLM cache TTL: %w", err)
+		}
+		cacheTTL = t
+	}
+
+	var (
+		provider LLMProvider
+		model    = settings.LLMModel
+	)
+
+	switch settings.LLMProvider {
+	case "", LLMProviderOpenAI:
+		url := settings.LLMURL
+		if url == "" {
+			url = settings.ChatGPTURL
+		}
+		if model == "" {
+			model = defaultLLMModel
+		}
+		provider = newOpenAI(logger, url, settings.OpenAISecretKey, model, settings.LLMTemperature, timeout)
+	case LLMProviderOllama:
+		if settings.LLMURL == "" || model == "" {
+			return nil, errors.New("the Ollama provider requires LLM_URL and LLM_MODEL")
+		}
+		provider = newOllama(logger, settings.LLMURL, model, settings.LLMTemperature, timeout)
+	default:
+		return nil, fmt.Errorf("unrecognized LLM provider %q", settings.LLMProvider)
+	}
+
+	if settings.ErrorCodesRateLimit > 0 {
+		provider = &rateLimitedLLM{
+			provider: provider,
+			limiter:  NewRateLimiter(settings.ErrorCodesRateLimit, rateWindow),
+		}
+	}
+
+	if cache == nil || cacheTTL <= 0 {
+		return provider, nil
+	}
+
+	return &cachedLLM{
+		provider:  provider,
+		cache:     cache,
+		ttl:       cacheTTL,
+		keyPrefix: "llm-error-codes:" + model,
+		logger:    logger,
+	}, nil
+}
+
+// ErrLLMRateLimited is returned when the caller has used up their lookups for the current
+// window.
+var ErrLLMRateLimited = errors.New("too many error code lookups")
+
+type llmCallerKey struct{}
+
+// WithLLMCaller identifies the caller for rate limiting. Only lookups that miss the cache and
+// reach the provider count against the limit.
+func WithLLMCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, llmCallerKey{}, caller)
+}
+
+// rateLimitedLLM caps provider calls per caller. It sits underneath the cache.
+type rateLimitedLLM struct {
+	provider LLMProvider
+	limiter  *RateLimiter
+}
+
+func (r *rateLimitedLLM) GetErrorCodesDescription(ctx context.Context, vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error) {
+	caller, _ := ctx.Value(llmCallerKey{}).(string)
+	if !r.limiter.Allow(caller) {
+		return nil, ErrLLMRateLimited
+	}
+	return r.provider.GetErrorCodesDescription(ctx, vMake, model, errorCodes)
+}
+
+// cachedLLM remembers explanations so that repeat lookups for the same vehicle make and model
+// skip the provider.
+type cachedLLM struct {
+	provider LLMProvider
+	cache    credis.CacheService
+	ttl      time.Duration
+	// keyPrefix includes the LLM model so that switching models doesn't serve old answers.
+	keyPrefix string
+	logger    *zerolog.Logger
+}
+
+func (c *cachedLLM) key(vMake, model, code string) string {
+	return strings.Join([]string{c.keyPrefix, strings.ToLower(vMake), strings.ToLower(model), strings.ToUpper(code)}, ":")
+}
+
+func (c *cachedLLM) GetErrorCodesDescription(ctx context.Context, vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error) {
+	out := make([]ErrorCodesResponse, 0, len(errorCodes))
+	var misses []string
+
+	for _, code := range errorCodes {
+		desc, err := c.cache.Get(ctx, c.key(vMake, model, code)).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				c.logger.Err(err).Str("code", code).Msg("Failed to read error code explanation from cache.")
+			}
+			misses = append(misses, code)
+			continue
+		}
+		out = append(out, ErrorCodesResponse{Code: code, Description: desc})
+	}
+
+	appmetrics.LLMCacheHitsOps.Add(float64(len(out)))
+
+	if len(misses) == 0 {
+		return out, nil
+	}
+
+	resp, err := c.provider.GetErrorCodesDescription(ctx, vMake, model, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range resp {
+		if err := c.cache.Set(ctx, c.key(vMake, model, r.Code), r.Description, c.ttl).Err(); err != nil {
+			c.logger.Err(err).Str("code", r.Code).Msg("Failed to cache error code explanation.")
+		}
+	}
+
+	return append(out, resp...), nil
+}
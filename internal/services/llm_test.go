@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	credis "github.com/DIMO-Network/shared/pkg/redis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stubExplanation = `{"error_codes":[{"code":"P1A16","explanation":"Battery fault"}]}`
+
+func TestOpenAIProvider(t *testing.T) {
+	var got chatGPTRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		resp := ChatGPTResponse{Choices: []ChatGPTResponseChoices{{FinishReason: "stop"}}}
+		resp.Choices[0].Message.FunctionCall.Arguments = stubExplanation
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	llm, err := NewLLMProvider(test.Logger(), &config.Settings{
+		LLMURL:          srv.URL,
+		LLMModel:        "gpt-test",
+		LLMTemperature:  0.2,
+		OpenAISecretKey: "sk-test",
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := llm.GetErrorCodesDescription(context.Background(), "Toyota", "Camry", []string{"P1A16"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ErrorCodesResponse{{Code: "P1A16", Description: "Battery fault"}}, resp)
+	assert.Equal(t, "gpt-test", got.Model)
+	assert.Equal(t, 0.2, got.Temperature)
+	assert.Contains(t, got.Messages[0].Content, "A Toyota Camry is returning error codes P1A16.")
+}
+
+func TestOllamaProvider(t *testing.T) {
+	var got ollamaChatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		_ = json.NewEncoder(w).Encode(ollamaChatResponse{
+			Model:   got.Model,
+			Message: chatGPTMessage{Role: "assistant", Content: stubExplanation},
+			Done:    true,
+		})
+	}))
+	defer srv.Close()
+
+	llm, err := NewLLMProvider(test.Logger(), &config.Settings{
+		LLMProvider: LLMProviderOllama,
+		LLMURL:      srv.URL + "/",
+		LLMModel:    "llama3.1",
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := llm.GetErrorCodesDescription(context.Background(), "Toyota", "Camry", []string{"P1A16"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ErrorCodesResponse{{Code: "P1A16", Description: "Battery fault"}}, resp)
+	assert.Equal(t, "llama3.1", got.Model)
+	assert.False(t, got.Stream)
+
+	_, err = NewLLMProvider(test.Logger(), &config.Settings{LLMProvider: LLMProviderOllama}, nil)
+	assert.Error(t, err)
+
+	_, err = NewLLMProvider(test.Logger(), &config.Settings{LLMProvider: "bard"}, nil)
+	assert.Error(t, err)
+}
+
+type fakeLLM struct {
+	calls [][]string
+}
+
+func (f *fakeLLM) GetErrorCodesDescription(_ context.Context, _, _ string, errorCodes []string) ([]ErrorCodesResponse, error) {
+	f.calls = append(f.calls, errorCodes)
+	var out []ErrorCodesResponse
+	for _, c := range errorCodes {
+		out = append(out, ErrorCodesResponse{Code: c, Description: "about " + c})
+	}
+	return out, nil
+}
+
+type fakeCache struct {
+	credis.CacheService
+	values map[string]string
+}
+
+func (f *fakeCache) Get(_ context.Context, key string) *redis.StringCmd {
+	v, ok := f.values[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(v, nil)
+}
+
+func (f *fakeCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) *redis.StatusCmd {
+	f.values[key] = value.(string)
+	return redis.NewStatusResult("OK", nil)
+}
+
+func TestCachedLLM(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeLLM{}
+	cache := &fakeCache{values: make(map[string]string)}
+
+	llm := &cachedLLM{
+		provider:  provider,
+		cache:     cache,
+		ttl:       time.Hour,
+		keyPrefix: "llm-error-codes:test",
+		logger:    test.Logger(),
+	}
+
+	resp, err := llm.GetErrorCodesDescription(ctx, "Toyota", "Camry", []string{"P1A16"})
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorCodesResponse{{Code: "P1A16", Description: "about P1A16"}}, resp)
+	assert.Equal(t, "about P1A16", cache.values["llm-error-codes:test:toyota:camry:P1A16"])
+
+	resp, err = llm.GetErrorCodesDescription(ctx, "toyota", "Camry", []string{"p1a16", "P1A17"})
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorCodesResponse{
+		{Code: "p1a16", Description: "about P1A16"},
+		{Code: "P1A17", Description: "about P1A17"},
+	}, resp)
+
+	// Only the miss went to the provider.
+	assert.Equal(t, [][]string{{"P1A16"}, {"P1A17"}}, provider.calls)
+
+	_, err = llm.GetErrorCodesDescription(ctx, "Toyota", "Camry", []string{"P1A16", "P1A17"})
+	require.NoError(t, err)
+	assert.Len(t, provider.calls, 2)
+}
+
+func TestRateLimitedLLM(t *testing.T) {
+	provider := &fakeLLM{}
+	llm := &cachedLLM{
+		provider:  &rateLimitedLLM{provider: provider, limiter: NewRateLimiter(1, time.Hour)},
+		cache:     &fakeCache{values: make(map[string]string)},
+		ttl:       time.Hour,
+		keyPrefix: "llm-error-codes:test",
+		logger:    test.Logger(),
+	}
+
+	alice := WithLLMCaller(context.Background(), "alice")
+
+	_, err := llm.GetErrorCodesDescription(alice, "Toyota", "Camry", []string{"P1A16"})
+	require.NoError(t, err)
+
+	// Cache hits don't use up the quota.
+	for range 3 {
+		_, err = llm.GetErrorCodesDescription(alice, "Toyota", "Camry", []string{"P1A16"})
+		require.NoError(t, err)
+	}
+
+	_, err = llm.GetErrorCodesDescription(alice, "Toyota", "Camry", []string{"P1A17"})
+	assert.ErrorIs(t, err, ErrLLMRateLimited)
+
+	_, err = llm.GetErrorCodesDescription(WithLLMCaller(context.Background(), "bob"), "Toyota", "Camry", []string{"P1A17"})
+	require.NoError(t, err)
+	assert.Len(t, provider.calls, 2)
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	r := NewRateLimiter(2, time.Minute)
+	r.now = func() time.Time { return now }
+
+	assert.True(t, r.Allow("a"))
+	assert.True(t, r.Allow("a"))
+	assert.False(t, r.Allow("a"))
+	assert.True(t, r.Allow("b"))
+
+	now = now.Add(time.Minute)
+	assert.True(t, r.Allow("a"))
+
+	assert.True(t, NewRateLimiter(0, time.Minute).Allow("a"))
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// DefaultTransactionsLimit is the page size used when the caller doesn't specify one.
+	DefaultTransactionsLimit = 20
+	// MaxTransactionsLimit caps the page size of transaction listings.
+	MaxTransactionsLimit = 100
+)
+
+// ErrInvalidTransactionCursor is returned when the pagination cursor doesn't refer to a
+// meta-transaction request in the listing.
+var ErrInvalidTransactionCursor = errors.New("invalid transaction cursor")
+
+// NewMetaTransactionRequest inserts an unsubmitted request for the given operation and starts its
+// status timeline. The user device id may be empty for operations on aftermarket devices that
+// aren't tied to a vehicle.
+func NewMetaTransactionRequest(ctx context.Context, exec boil.ContextExecutor, id, operation, userDeviceID string, owner common.Address) (*models.MetaTransactionRequest, error) {
+	mtr := &models.MetaTransactionRequest{
+		ID:           id,
+		Status:       models.MetaTransactionRequestStatusUnsubmitted,
+		Operation:    null.StringFrom(operation),
+		UserDeviceID: null.NewString(userDeviceID, userDeviceID != ""),
+		OwnerAddress: null.BytesFrom(owner.Bytes()),
+	}
+
+	if err := mtr.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	if err := RecordMetaTransactionStatus(ctx, exec, mtr); err != nil {
+		return nil, err
+	}
+
+	return mtr, nil
+}
+
+// RecordMetaTransactionStatus appends the request's current status to its timeline. Call it
+// after every status change.
+func RecordMetaTransactionStatus(ctx context.Context, exec boil.ContextExecutor, mtr *models.MetaTransactionRequest) error {
+	sc := models.MetaTransactionRequestStatusChange{
+		ID:                       ksuid.New().String(),
+		MetaTransactionRequestID: mtr.ID,
+		Status:                   mtr.Status,
+	}
+
+	return sc.Insert(ctx, exec, boil.Infer())
+}
+
+// ListMetaTransactionRequests returns the meta-transaction requests matching the given mods,
+// newest first, with their timelines and resubmissions loaded. The cursor is the id of the last
+// request of the previous page, and the returned cursor is empty once there are no more pages.
+func ListMetaTransactionRequests(ctx context.Context, exec boil.ContextExecutor, cursor string, limit int, where ...qm.QueryMod) (models.MetaTransactionRequestSlice, string, error) {
+	if limit <= 0 {
+		limit = DefaultTransactionsLimit
+	} else if limit > MaxTransactionsLimit {
+		limit = MaxTransactionsLimit
+	}
+
+	cols := models.MetaTransactionRequestColumns
+
+	mods := append([]qm.QueryMod{
+		qm.Load(
+			models.MetaTransactionRequestRels.MetaTransactionRequestStatusChanges,
+			qm.OrderBy(models.MetaTransactionRequestStatusChangeColumns.CreatedAt),
+		),
+		qm.Load(models.MetaTransactionRequestRels.RetryOfMetaTransactionRequests),
+		qm.OrderBy(cols.CreatedAt + " DESC, " + cols.ID + " DESC"),
+		// Fetch one extra to find out whether there's another page.
+		qm.Limit(limit + 1),
+	}, where...)
+
+	if cursor != "" {
+		last, err := models.MetaTransactionRequests(
+			append([]qm.QueryMod{models.MetaTransactionRequestWhere.ID.EQ(cursor)}, where...)...,
+		).One(ctx, exec)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, "", ErrInvalidTransactionCursor
+			}
+			return nil, "", err
+		}
+
+		mods = append(mods, qm.Where("("+cols.CreatedAt+", "+cols.ID+") < (?, ?)", last.CreatedAt, last.ID))
+	}
+
+	mtrs, err := models.MetaTransactionRequests(mods...).All(ctx, exec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(mtrs) > limit {
+		mtrs = mtrs[:limit]
+		next = mtrs[limit-1].ID
+	}
+
+	return mtrs, next, nil
+}
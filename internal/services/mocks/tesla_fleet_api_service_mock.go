@@ -131,6 +131,21 @@ func (mr *MockTeslaFleetAPIServiceMockRecorder) GetVehicles(ctx, token any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicles", reflect.TypeOf((*MockTeslaFleetAPIService)(nil).GetVehicles), ctx, token)
 }
 
+// RefreshToken mocks base method.
+func (m *MockTeslaFleetAPIService) RefreshToken(ctx context.Context, refreshToken string) (*services.TeslaAuthCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*services.TeslaAuthCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockTeslaFleetAPIServiceMockRecorder) RefreshToken(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockTeslaFleetAPIService)(nil).RefreshToken), ctx, refreshToken)
+}
+
 // RemoveTelemetry mocks base method.
 func (m *MockTeslaFleetAPIService) RemoveTelemetry(ctx context.Context, token, vin string) (int, error) {
 	m.ctrl.T.Helper()
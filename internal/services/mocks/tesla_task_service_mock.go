@@ -70,6 +70,66 @@ func (mr *MockTeslaTaskServiceMockRecorder) ChargeStop(udai any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeStop", reflect.TypeOf((*MockTeslaTaskService)(nil).ChargeStop), udai)
 }
 
+// ClimateOff mocks base method.
+func (m *MockTeslaTaskService) ClimateOff(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClimateOff", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClimateOff indicates an expected call of ClimateOff.
+func (mr *MockTeslaTaskServiceMockRecorder) ClimateOff(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClimateOff", reflect.TypeOf((*MockTeslaTaskService)(nil).ClimateOff), udai)
+}
+
+// ClimateOn mocks base method.
+func (m *MockTeslaTaskService) ClimateOn(udai *models.UserDeviceAPIIntegration, targetTemperature *float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClimateOn", udai, targetTemperature)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClimateOn indicates an expected call of ClimateOn.
+func (mr *MockTeslaTaskServiceMockRecorder) ClimateOn(udai, targetTemperature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClimateOn", reflect.TypeOf((*MockTeslaTaskService)(nil).ClimateOn), udai, targetTemperature)
+}
+
+// FlashLights mocks base method.
+func (m *MockTeslaTaskService) FlashLights(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlashLights", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlashLights indicates an expected call of FlashLights.
+func (mr *MockTeslaTaskServiceMockRecorder) FlashLights(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlashLights", reflect.TypeOf((*MockTeslaTaskService)(nil).FlashLights), udai)
+}
+
+// HonkHorn mocks base method.
+func (m *MockTeslaTaskService) HonkHorn(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HonkHorn", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HonkHorn indicates an expected call of HonkHorn.
+func (mr *MockTeslaTaskServiceMockRecorder) HonkHorn(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HonkHorn", reflect.TypeOf((*MockTeslaTaskService)(nil).HonkHorn), udai)
+}
+
 // LockDoors mocks base method.
 func (m *MockTeslaTaskService) LockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +175,36 @@ func (mr *MockTeslaTaskServiceMockRecorder) OpenTrunk(udai any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenTrunk", reflect.TypeOf((*MockTeslaTaskService)(nil).OpenTrunk), udai)
 }
 
+// SetChargeLimit mocks base method.
+func (m *MockTeslaTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargeLimit", udai, percent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChargeLimit indicates an expected call of SetChargeLimit.
+func (mr *MockTeslaTaskServiceMockRecorder) SetChargeLimit(udai, percent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargeLimit", reflect.TypeOf((*MockTeslaTaskService)(nil).SetChargeLimit), udai, percent)
+}
+
+// SetChargingAmps mocks base method.
+func (m *MockTeslaTaskService) SetChargingAmps(udai *models.UserDeviceAPIIntegration, amps int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargingAmps", udai, amps)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChargingAmps indicates an expected call of SetChargingAmps.
+func (mr *MockTeslaTaskServiceMockRecorder) SetChargingAmps(udai, amps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargingAmps", reflect.TypeOf((*MockTeslaTaskService)(nil).SetChargingAmps), udai, amps)
+}
+
 // StartPoll mocks base method.
 func (m *MockTeslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
 	m.ctrl.T.Helper()
@@ -157,3 +247,17 @@ func (mr *MockTeslaTaskServiceMockRecorder) UnlockDoors(udai any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockDoors", reflect.TypeOf((*MockTeslaTaskService)(nil).UnlockDoors), udai)
 }
+
+// UpdateCredentials mocks base method.
+func (m *MockTeslaTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", udai, sd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockTeslaTaskServiceMockRecorder) UpdateCredentials(udai, sd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockTeslaTaskService)(nil).UpdateCredentials), udai, sd)
+}
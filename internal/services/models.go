@@ -149,6 +149,11 @@ type UserDeviceMetadata struct {
 // AftermarketDeviceMetadata json metadata for table AftermarketDevice
 type AftermarketDeviceMetadata struct {
 	AutoPiDeviceID string `json:"autoPiDeviceId,omitempty"`
+	// Attributes are the on-chain attributes other than the serial, as set by
+	// AftermarketDeviceAttributeSet.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// QueriedVIN is the VIN the device last read from the vehicle with a VIN query command.
+	QueriedVIN string `json:"queriedVin,omitempty"`
 }
 
 // todo: consider moving below to controllers and have service just return db object
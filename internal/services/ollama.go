@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ollama talks to the chat endpoint of a self-hosted Ollama server.
+type ollama struct {
+	url         string
+	model       string
+	temperature float64
+	httpClient  *http.Client
+	logger      *zerolog.Logger
+}
+
+func newOllama(logger *zerolog.Logger, url, model string, temperature float64, timeout time.Duration) *ollama {
+	return &ollama{
+		url:         strings.TrimSuffix(url, "/") + "/api/chat",
+		model:       model,
+		temperature: temperature,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		logger: logger,
+	}
+}
+
+type ollamaChatRequest struct {
+	Model    string           `json:"model"`
+	Messages []chatGPTMessage `json:"messages"`
+	Stream   bool             `json:"stream"`
+	// Format constrains the output. We pass a JSON schema, which needs Ollama 0.5 or later.
+	Format  any `json:"format"`
+	Options struct {
+		Temperature float64 `json:"temperature"`
+	} `json:"options"`
+}
+
+type ollamaChatResponse struct {
+	Model   string         `json:"model"`
+	Message chatGPTMessage `json:"message"`
+	Done    bool           `json:"done"`
+}
+
+func (o *ollama) GetErrorCodesDescription(ctx context.Context, vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error) {
+	chatReq := ollamaChatRequest{
+		Model: o.model,
+		Messages: []chatGPTMessage{
+			{Role: "user", Content: errorCodesPrompt(vMake, model, errorCodes) + " Respond with JSON."},
+		},
+		Format: errorCodesSchema,
+	}
+	chatReq.Options.Temperature = o.temperature
+
+	body, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		o.logger.Err(err).Msg("Error code request to Ollama failed.")
+		return nil, errors.New("a temporary error occurred checking for your error codes, please try again")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		o.logger.Error().Int("status", resp.StatusCode).Str("body", string(b)).Msg("Error code request to Ollama failed.")
+		return nil, errors.New("a temporary error occurred checking for your error codes, please try again")
+	}
+
+	var chatResp ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return nil, fmt.Errorf("error decoding response json: %w", err)
+	}
+
+	var rawResp ErrorCodesFunctionCallResponse
+	if err := json.Unmarshal([]byte(chatResp.Message.Content), &rawResp); err != nil {
+		return nil, fmt.Errorf("couldn't parse model output: %w", err)
+	}
+
+	return rawResp.toAPI(), nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,25 +9,22 @@ import (
 	"net/http"
 	"net/http/httptrace"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/appmetrics"
-	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services/dtc"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 )
 
-type OpenAI interface {
-	GetErrorCodesDescription(vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error)
-}
-
 type openAI struct {
-	chatGptURL string
-	token      string
-	httpClient *http.Client
-	logger     *zerolog.Logger
+	url         string
+	token       string
+	model       string
+	temperature float64
+	httpClient  *http.Client
+	logger      *zerolog.Logger
 }
 
 type FunctionCallResponse struct {
@@ -57,36 +55,75 @@ type ChatGPTResponse struct {
 	Choices []ChatGPTResponseChoices
 }
 
+// Sources of error code descriptions.
+const (
+	ErrorCodeSourceCatalog = "catalog"
+	ErrorCodeSourceAI      = "ai"
+)
+
 type ErrorCodesResponse struct {
 	Code        string `json:"code" example:"P0148"`
 	Description string `json:"description" example:"Fuel delivery error"`
+	// Source is "catalog" if the description came from the built-in code tables and "ai" if it
+	// was generated. Empty for queries made before this was recorded.
+	Source string `json:"source,omitempty" example:"catalog"`
+	// Classification is parsed from the code. It is missing for codes that aren't in the
+	// standard five-character format.
+	Classification *dtc.Classification `json:"classification,omitempty"`
 }
 
-type ErrorCodesFunctionCallResponse struct {
-	ErrorCodes []struct {
-		Code        string
-		Explanation string `json:"explanation"`
-	} `json:"error_codes"`
-}
-
-func NewOpenAI(logger *zerolog.Logger, c config.Settings) OpenAI {
+func newOpenAI(logger *zerolog.Logger, url, token, model string, temperature float64, timeout time.Duration) *openAI {
 	return &openAI{
-		chatGptURL: c.ChatGPTURL,
-		token:      c.OpenAISecretKey,
+		url:         url,
+		token:       token,
+		model:       model,
+		temperature: temperature,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: timeout,
 		},
 		logger: logger,
 	}
 }
 
-func (o *openAI) askChatGPT(body io.Reader) (*ChatGPTResponse, error) {
-	ctx := context.Background()
-	req, err := http.NewRequest(
-		"POST",
-		o.chatGptURL,
-		body,
-	)
+type chatGPTMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatGPTFunction struct {
+	Name       string `json:"name"`
+	Parameters any    `json:"parameters"`
+}
+
+type chatGPTRequest struct {
+	Model        string            `json:"model"`
+	Temperature  float64           `json:"temperature"`
+	Messages     []chatGPTMessage  `json:"messages"`
+	FunctionCall map[string]string `json:"function_call"`
+	Functions    []chatGPTFunction `json:"functions"`
+}
+
+// errorCodesSchema is the JSON schema of ErrorCodesFunctionCallResponse.
+var errorCodesSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"error_codes": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"code":        map[string]string{"type": "string"},
+					"explanation": map[string]string{"type": "string"},
+				},
+				"required": []string{"code", "explanation"},
+			},
+		},
+	},
+	"required": []string{"error_codes"},
+}
+
+func (o *openAI) askChatGPT(ctx context.Context, body io.Reader) (*ChatGPTResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", o.url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +140,7 @@ func (o *openAI) askChatGPT(body io.Reader) (*ChatGPTResponse, error) {
 	}
 	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := o.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -127,48 +164,30 @@ func (o *openAI) askChatGPT(body io.Reader) (*ChatGPTResponse, error) {
 	return cResp, nil
 }
 
-func (o *openAI) GetErrorCodesDescription(vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error) {
-	codes := strings.Join(errorCodes, ", ")
-
-	req := fmt.Sprintf(`{
-		"model": "gpt-4o-mini",
-		"temperature": 0,
-		"messages": [
-			{
-				"role": "user", 
-				"content": "A %s %s is returning error codes %s. Return a long extensive explanation for each code."
-			}
-		],
-		"function_call": {
-			"name": "vehicle_error_codes"
+func (o *openAI) GetErrorCodesDescription(ctx context.Context, vMake, model string, errorCodes []string) ([]ErrorCodesResponse, error) {
+	const functionName = "vehicle_error_codes"
+
+	body, err := json.Marshal(chatGPTRequest{
+		Model:       o.model,
+		Temperature: o.temperature,
+		Messages: []chatGPTMessage{
+			{Role: "user", Content: errorCodesPrompt(vMake, model, errorCodes)},
+		},
+		FunctionCall: map[string]string{"name": functionName},
+		Functions: []chatGPTFunction{
+			{Name: functionName, Parameters: errorCodesSchema},
 		},
-		"functions": [
-			{
-				"name": "vehicle_error_codes",
-				"parameters": {
-					"type": "object",
-					"properties": {
-						"error_codes": {
-							"type":"array",
-							"items": {
-								"type": "object",
-								"properties": {
-									"code": { "type": "string" },
-									"explanation": { "type": "string" }
-								},
-								"required": ["code", "explanation"]
-							}
-						}
-					},
-					"required": ["error_codes"]
-				}
-			}
-		]
+	})
+	if err != nil {
+		return nil, err
 	}
-	`, vMake, model, codes)
 
-	r, err := o.askChatGPT(strings.NewReader(req))
+	// Counted here rather than by the caller, so that cache hits aren't.
+	appmetrics.OpenAITotalCallsOps.Inc()
+	r, err := o.askChatGPT(ctx, bytes.NewReader(body))
 	if err != nil {
+		appmetrics.OpenAITotalFailedCallsOps.Inc()
+		o.logger.Err(err).Msg("Error code request to ChatGPT failed.")
 		return nil, errors.New("a temporary error occurred checking for your error codes, please try again")
 	}
 
@@ -188,14 +207,5 @@ func (o *openAI) GetErrorCodesDescription(vMake, model string, errorCodes []stri
 		return nil, err
 	}
 
-	resp := []ErrorCodesResponse{}
-	for _, obj := range rawResp.ErrorCodes {
-
-		resp = append(resp, ErrorCodesResponse{
-			Code:        obj.Code,
-			Description: obj.Explanation,
-		})
-	}
-
-	return resp, nil
+	return rawResp.toAPI(), nil
 }
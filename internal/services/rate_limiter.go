@@ -0,0 +1,64 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter allows up to a fixed number of events per key in each window. State is kept in
+// memory, so each replica enforces the limit separately.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter creates a limiter. A limit of zero or less allows everything.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		now:     time.Now,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// Allow records an event for the key and reports whether it is within the limit.
+func (r *RateLimiter) Allow(key string) bool {
+	if r == nil || r.limit <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+
+	w, ok := r.windows[key]
+	if !ok || now.Sub(w.start) >= r.window {
+		// Opportunistically clear out expired windows so that the map doesn't grow forever.
+		if !ok && len(r.windows) >= 10000 {
+			for k, v := range r.windows {
+				if now.Sub(v.start) >= r.window {
+					delete(r.windows, k)
+				}
+			}
+		}
+		r.windows[key] = &rateWindow{start: now, count: 1}
+		return true
+	}
+
+	if w.count >= r.limit {
+		return false
+	}
+	w.count++
+	return true
+}
@@ -1,11 +1,15 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/DIMO-Network/shared/pkg/payloads"
 	"github.com/IBM/sarama"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,6 +23,9 @@ type Client struct {
 	Producer     sarama.SyncProducer
 	RequestTopic string
 	Contract     Contract
+	// DB, if set, is used to store the call on the request's row before sending it, so that
+	// the request can be resubmitted if it fails.
+	DB func() *db.ReaderWriter
 }
 
 type Contract struct {
@@ -389,16 +396,21 @@ func (c *Client) MintVehicleSign(requestID string, manufacturerNode *big.Int, ow
 
 // mintVehicleWithDeviceDefinitionSign(uint256 manufacturerNode, address owner, string deviceDefinitionId, (string,string)[] attrInfo, bytes signature) returns()
 func (c *Client) MintVehicleWithDeviceDefinitionSign(requestID string, manufacturerNode *big.Int, owner common.Address, deviceDefinitionID string, attrInfo []contracts.AttributeInfoPair, signature []byte) error {
-	abi, err := contracts.RegistryMetaData.GetAbi()
+	data, err := PackMintVehicleWithDeviceDefinitionSign(manufacturerNode, owner, deviceDefinitionID, attrInfo, signature)
 	if err != nil {
 		return err
 	}
+	return c.sendRequest(requestID, data)
+}
 
-	data, err := abi.Pack("mintVehicleWithDeviceDefinitionSign", manufacturerNode, owner, deviceDefinitionID, attrInfo, signature)
+// PackMintVehicleWithDeviceDefinitionSign encodes the call without storing or sending it.
+func PackMintVehicleWithDeviceDefinitionSign(manufacturerNode *big.Int, owner common.Address, deviceDefinitionID string, attrInfo []contracts.AttributeInfoPair, signature []byte) ([]byte, error) {
+	abi, err := contracts.RegistryMetaData.GetAbi()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return c.sendRequest(requestID, data)
+
+	return abi.Pack("mintVehicleWithDeviceDefinitionSign", manufacturerNode, owner, deviceDefinitionID, attrInfo, signature)
 }
 
 // function mintVehicleAndSdSign(MintVehicleAndSdInput calldata data)
@@ -447,6 +459,21 @@ func (c *Client) MintVehicleAndSdWithDeviceDefinitionSignAndSacd(requestID strin
 }
 
 func (c *Client) sendRequest(requestID string, data []byte) error {
+	if c.DB != nil {
+		_, err := models.MetaTransactionRequests(models.MetaTransactionRequestWhere.ID.EQ(requestID)).UpdateAll(context.Background(), c.DB().Writer, models.M{
+			models.MetaTransactionRequestColumns.ToAddress: c.Contract.Address.Bytes(),
+			models.MetaTransactionRequestColumns.Data:      data,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store call for request %s: %w", requestID, err)
+		}
+	}
+
+	return c.Resend(requestID, c.Contract.Address, data)
+}
+
+// Resend sends a call that has already been stored under the given request id.
+func (c *Client) Resend(requestID string, to common.Address, data []byte) error {
 	event := payloads.CloudEvent[RequestData]{
 		ID:          ksuid.New().String(),
 		Source:      "devices-api",
@@ -456,7 +483,7 @@ func (c *Client) sendRequest(requestID string, data []byte) error {
 		Type:        "zone.dimo.transaction.request",
 		Data: RequestData{
 			ID:   requestID,
-			To:   c.Contract.Address,
+			To:   to,
 			Data: data,
 		},
 	}
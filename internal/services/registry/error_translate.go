@@ -3,10 +3,12 @@ package registry
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type ABIErrorTranslator struct {
@@ -77,3 +79,44 @@ func (d *ABIErrorTranslator) Decode(data []byte) (string, error) {
 
 	return "", fmt.Errorf("unrecognized error with signature %s", hexutil.Encode(selector))
 }
+
+// FailureKind sorts failed meta-transactions by what sending the same call again would do.
+type FailureKind int
+
+const (
+	// FailureRejected means the contract turned the call down, and it will do so again.
+	FailureRejected FailureKind = iota
+	// FailureTransient means the transaction reverted without data: it ran out of gas or was
+	// dropped. Sending the same call again may succeed.
+	FailureTransient
+	// FailureNonce means a nonce signed into the call was already used. The same payload will
+	// fail again; the owner has to sign a new one.
+	FailureNonce
+)
+
+// invalidAccountNonceSelector is the selector of OpenZeppelin's
+// InvalidAccountNonce(address account, uint256 currentNonce).
+var invalidAccountNonceSelector = crypto.Keccak256([]byte("InvalidAccountNonce(address,uint256)"))[:4]
+
+// Classify decides what kind of failure the revert data describes, and returns a readable reason
+// where one can be had. Errors in decoding the reason don't change the classification.
+func (d *ABIErrorTranslator) Classify(data []byte) (FailureKind, string, error) {
+	if len(data) == 0 {
+		return FailureTransient, "", nil
+	}
+
+	if len(data) >= 4 && bytes.Equal(data[:4], invalidAccountNonceSelector) {
+		return FailureNonce, "Signature nonce already used; the request has to be signed again.", nil
+	}
+
+	// Error(string) and Panic(uint256), from require statements and failed assertions.
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		if strings.Contains(strings.ToLower(reason), "nonce") {
+			return FailureNonce, reason, nil
+		}
+		return FailureRejected, reason, nil
+	}
+
+	reason, err := d.Decode(data)
+	return FailureRejected, reason, err
+}
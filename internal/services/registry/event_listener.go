@@ -3,7 +3,9 @@ package registry
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/shared/pkg/payloads"
 	"github.com/IBM/sarama"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +24,8 @@ var failureCount = promauto.NewCounter(
 	},
 )
 
+const transactionStatusTopic = "topic.transaction.request.status"
+
 type ceLog struct {
 	Address common.Address `json:"address"`
 	Topics  []common.Hash  `json:"topics"`
@@ -51,51 +55,39 @@ type Consumer struct {
 	storage StatusProcessor
 }
 
-func (c *Consumer) Setup(sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
-	return nil
-}
+// HandleMessage processes one meta-transaction status update. Errors are left to the
+// kafka.RetryHandler that wraps it.
+func (c *Consumer) HandleMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	c.logger.Info().Int32("partition", message.Partition).Int64("offset", message.Offset).RawJSON("value", message.Value).Msg("Got message")
+	event := payloads.CloudEvent[ceData]{}
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to parse transaction event: %w", err))
+	}
 
-func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case message := <-claim.Messages():
-			c.logger.Info().Int32("partition", message.Partition).Int64("offset", message.Offset).RawJSON("value", message.Value).Msg("Got message")
-			event := payloads.CloudEvent[ceData]{}
-			err := json.Unmarshal(message.Value, &event)
-			if err != nil {
-				c.logger.Err(err).Int32("partition", message.Partition).Int64("offset", message.Offset).Msg("Failed to parse transaction event.")
-			} else {
-				err := c.storage.Handle(session.Context(), &event.Data)
-				if err != nil {
-					failureCount.Inc()
-					c.logger.Err(err).Str("requestId", event.Data.RequestID).Msg("Failed to process meta-transaction status update.")
-				}
-			}
-			session.MarkMessage(message, "")
-		case <-session.Context().Done():
-			return nil
-		}
+	if err := c.storage.Handle(ctx, &event.Data); err != nil {
+		failureCount.Inc()
+		c.logger.Err(err).Str("requestId", event.Data.RequestID).Msg("Failed to process meta-transaction status update.")
+		return err
 	}
+
+	return nil
 }
 
 // TODO(elffjs): Proper cleanup.
-func RunConsumer(ctx context.Context, client sarama.Client, logger *zerolog.Logger, s StatusProcessor) error {
+func RunConsumer(ctx context.Context, client sarama.Client, logger *zerolog.Logger, s StatusProcessor, retry *kafka.RetryHandler) error {
 	group, err := sarama.NewConsumerGroupFromClient("devices-api-transaction-consumer", client)
 	if err != nil {
 		return err
 	}
 
 	c := &Consumer{logger: logger, storage: s}
+	handler := retry.ConsumerGroupHandler(c.HandleMessage)
 
 	logger.Info().Msg("Starting transaction request status listener.")
 
 	go func() {
 		for {
-			err := group.Consume(ctx, []string{"topic.transaction.request.status"}, c)
+			err := group.Consume(ctx, []string{transactionStatusTopic, kafka.RetryTopic(transactionStatusTopic)}, handler)
 			if err != nil {
 				logger.Warn().Err(err).Msg("Consumer group session ended.")
 			}
@@ -0,0 +1,505 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/ethereum/go-ethereum/common"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// MaxMintBatchSize is the largest number of vehicles a batch may hold.
+const MaxMintBatchSize = 500
+
+var (
+	ErrMintBatchNotFound = errors.New("no mint batch with that id found")
+	ErrMintBatchSize     = fmt.Errorf("a batch must contain between 1 and %d vehicles", MaxMintBatchSize)
+)
+
+// MintBatchVehicle is a vehicle to register and mint as part of a batch.
+type MintBatchVehicle struct {
+	VIN          string
+	DefinitionID string
+	CountryCode  string
+}
+
+// MintBatchVehicleError says why a vehicle in a batch was turned down. Index is the vehicle's
+// position in the request.
+type MintBatchVehicleError struct {
+	Index  int
+	VIN    string
+	Reason string
+}
+
+// MintBatchValidationError is returned when any vehicle in a new batch is invalid. Nothing is
+// created in that case.
+type MintBatchValidationError struct {
+	Vehicles []MintBatchVehicleError
+}
+
+func (e *MintBatchValidationError) Error() string {
+	return fmt.Sprintf("%d of the vehicles in the batch are invalid", len(e.Vehicles))
+}
+
+// MintBatch is a batch and the state of each of its vehicles.
+type MintBatch struct {
+	ID        string
+	Owner     common.Address
+	CreatedAt time.Time
+	// Vehicles is sorted by VIN.
+	Vehicles []*MintBatchVehicleStatus
+}
+
+// MintBatchVehicleStatus is one vehicle in a batch, with its latest mint request.
+type MintBatchVehicleStatus struct {
+	UserDevice *models.UserDevice
+	Request    *models.MetaTransactionRequest
+	// TypedData is the EIP-712 payload for the owner to sign. It's only set while the
+	// vehicle is waiting for its signature.
+	TypedData *signer.TypedData
+}
+
+// MintBatchSignature is the owner's signature of a vehicle's mint payload.
+type MintBatchSignature struct {
+	UserDeviceID string
+	Signature    []byte
+}
+
+// MintBatchSignatureResult reports what happened to one signature. RequestID is set if the
+// mint was sent, and Error otherwise.
+type MintBatchSignatureResult struct {
+	UserDeviceID string
+	RequestID    string
+	Error        string
+}
+
+// MintBatcher registers and mints many vehicles for one owner: fleet operators send a list of
+// VINs, get back a payload to sign for each, and submit the signatures in bulk.
+type MintBatcher struct {
+	dbs         func() *db.ReaderWriter
+	ddSvc       services.DeviceDefinitionService
+	client      *Client
+	settings    *config.Settings
+	logger      *zerolog.Logger
+	sigVerifier services.SignatureVerifier
+}
+
+func NewMintBatcher(dbs func() *db.ReaderWriter, ddSvc services.DeviceDefinitionService, client *Client, settings *config.Settings, logger *zerolog.Logger, sigVerifier services.SignatureVerifier) *MintBatcher {
+	return &MintBatcher{
+		dbs:         dbs,
+		ddSvc:       ddSvc,
+		client:      client,
+		settings:    settings,
+		logger:      logger,
+		sigVerifier: sigVerifier,
+	}
+}
+
+// CreateBatch validates every vehicle and, if they all pass, creates a vehicle record and an
+// unsubmitted mint request for each. The user id may be empty when the batch is created on an
+// owner's behalf; the vehicles are then looked up by owner address, as with
+// RegisterUserDeviceFromVIN.
+func (b *MintBatcher) CreateBatch(ctx context.Context, owner common.Address, userID string, vehicles []MintBatchVehicle) (*MintBatch, error) {
+	if len(vehicles) == 0 || len(vehicles) > MaxMintBatchSize {
+		return nil, ErrMintBatchSize
+	}
+
+	defs, err := b.validate(ctx, vehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := b.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	batch := models.MintBatch{
+		ID:           ksuid.New().String(),
+		OwnerAddress: owner.Bytes(),
+		UserID:       null.NewString(userID, userID != ""),
+	}
+	if err := batch.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	requestIDs := make([]string, len(vehicles))
+
+	for i, v := range vehicles {
+		dd := defs[v.DefinitionID]
+
+		requestIDs[i] = ksuid.New().String()
+		udID := ksuid.New().String()
+
+		if _, err := services.NewMetaTransactionRequest(ctx, tx, requestIDs[i], models.MetaTransactionRequestOperationMint, udID, owner); err != nil {
+			return nil, err
+		}
+
+		ud := models.UserDevice{
+			ID:            udID,
+			UserID:        userID,
+			DefinitionID:  dd.Id,
+			CountryCode:   null.StringFrom(v.CountryCode),
+			VinIdentifier: null.StringFrom(strings.ToUpper(v.VIN)),
+			// The operator vouches for the VINs by signing for them.
+			VinConfirmed:  true,
+			MintRequestID: null.StringFrom(requestIDs[i]),
+		}
+		if userID == "" {
+			ud.OwnerAddress = null.BytesFrom(owner.Bytes())
+		}
+
+		powertrainType := services.ICE
+		for _, attr := range dd.DeviceAttributes {
+			if attr.Name == constants.PowerTrainTypeKey {
+				powertrainType = services.PowertrainType(attr.Value)
+				break
+			}
+		}
+		if err := ud.Metadata.Marshal(&services.UserDeviceMetadata{PowertrainType: &powertrainType}); err != nil {
+			return nil, err
+		}
+
+		if err := ud.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("failed to create vehicle for VIN %s: %w", ud.VinIdentifier.String, err)
+		}
+	}
+
+	_, err = models.MetaTransactionRequests(
+		models.MetaTransactionRequestWhere.ID.IN(requestIDs),
+	).UpdateAll(ctx, tx, models.M{models.MetaTransactionRequestColumns.MintBatchID: batch.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	b.logger.Info().Str("mintBatchId", batch.ID).Str("owner", owner.Hex()).Int("vehicles", len(vehicles)).Msg("Created mint batch.")
+
+	return b.GetBatch(ctx, batch.ID, owner)
+}
+
+// validate checks every vehicle, collecting all of the problems rather than stopping at the
+// first. It returns the device definitions it looked up, by id.
+func (b *MintBatcher) validate(ctx context.Context, vehicles []MintBatchVehicle) (map[string]*ddgrpc.GetDeviceDefinitionItemResponse, error) {
+	defs := make(map[string]*ddgrpc.GetDeviceDefinitionItemResponse)
+	badDefs := make(map[string]string)
+	seen := make(map[string]int)
+
+	var errs []MintBatchVehicleError
+	reject := func(i int, v MintBatchVehicle, reason string) {
+		errs = append(errs, MintBatchVehicleError{Index: i, VIN: v.VIN, Reason: reason})
+	}
+
+	vins := make([]string, 0, len(vehicles))
+
+	for i, v := range vehicles {
+		vin := strings.ToUpper(v.VIN)
+
+		if len(vin) < 13 || len(vin) > 17 || strings.IndexFunc(vin, func(r rune) bool { return (r < 'A' || r > 'Z') && (r < '0' || r > '9') }) != -1 {
+			reject(i, v, "VIN must be 13 to 17 letters and digits.")
+			continue
+		}
+		if j, ok := seen[vin]; ok {
+			reject(i, v, fmt.Sprintf("VIN also appears at position %d.", j))
+			continue
+		}
+		seen[vin] = i
+		vins = append(vins, vin)
+
+		if constants.FindCountry(v.CountryCode) == nil {
+			reject(i, v, fmt.Sprintf("Unsupported country code %q.", v.CountryCode))
+			continue
+		}
+
+		if v.DefinitionID == "" {
+			reject(i, v, "Definition id is required.")
+			continue
+		}
+
+		if reason, ok := badDefs[v.DefinitionID]; ok {
+			reject(i, v, reason)
+			continue
+		}
+
+		if _, ok := defs[v.DefinitionID]; !ok {
+			dd, err := b.ddSvc.GetDeviceDefinitionBySlug(ctx, v.DefinitionID)
+			if err != nil {
+				badDefs[v.DefinitionID] = fmt.Sprintf("Couldn't find definition %q.", v.DefinitionID)
+			} else if dd.Make == nil || dd.Make.TokenId == 0 {
+				badDefs[v.DefinitionID] = fmt.Sprintf("The make of definition %q hasn't been minted.", v.DefinitionID)
+			} else {
+				defs[v.DefinitionID] = dd
+			}
+
+			if reason, ok := badDefs[v.DefinitionID]; ok {
+				reject(i, v, reason)
+				continue
+			}
+		}
+	}
+
+	// Same rule as single registrations: only production refuses VINs that are already in use.
+	if b.settings.IsProduction() && len(vins) != 0 {
+		taken, err := models.UserDevices(
+			models.UserDeviceWhere.VinIdentifier.IN(vins),
+			models.UserDeviceWhere.VinConfirmed.EQ(true),
+		).All(ctx, b.dbs().Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ud := range taken {
+			i := seen[ud.VinIdentifier.String]
+			reject(i, vehicles[i], "VIN is in use by another vehicle.")
+		}
+	}
+
+	if len(errs) != 0 {
+		slices.SortFunc(errs, func(a, b MintBatchVehicleError) int { return a.Index - b.Index })
+		return nil, &MintBatchValidationError{Vehicles: errs}
+	}
+
+	return defs, nil
+}
+
+// GetBatch returns the batch with each vehicle's latest mint request, and the payload to sign
+// for those still waiting on a signature. It returns ErrMintBatchNotFound if the batch doesn't
+// exist or belongs to someone else.
+func (b *MintBatcher) GetBatch(ctx context.Context, batchID string, owner common.Address) (*MintBatch, error) {
+	batch, vehicles, err := b.loadBatch(ctx, batchID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]*ddgrpc.GetDeviceDefinitionItemResponse)
+
+	for _, v := range vehicles {
+		if !awaitingSignature(v.Request) {
+			continue
+		}
+
+		msg, err := b.mintMessage(ctx, defs, v.UserDevice, owner)
+		if err != nil {
+			return nil, err
+		}
+
+		v.TypedData = b.client.GetPayload(msg)
+	}
+
+	return &MintBatch{
+		ID:        batch.ID,
+		Owner:     owner,
+		CreatedAt: batch.CreatedAt,
+		Vehicles:  vehicles,
+	}, nil
+}
+
+func (b *MintBatcher) loadBatch(ctx context.Context, batchID string, owner common.Address) (*models.MintBatch, []*MintBatchVehicleStatus, error) {
+	batch, err := models.MintBatches(
+		models.MintBatchWhere.ID.EQ(batchID),
+		models.MintBatchWhere.OwnerAddress.EQ(owner.Bytes()),
+		qm.Load(models.MintBatchRels.MetaTransactionRequests),
+	).One(ctx, b.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrMintBatchNotFound
+		}
+		return nil, nil, err
+	}
+
+	// Resubmissions of a failed mint stay in the batch. Only the latest attempt counts.
+	latest := make(map[string]*models.MetaTransactionRequest)
+	for _, mtr := range batch.R.MetaTransactionRequests {
+		if cur, ok := latest[mtr.UserDeviceID.String]; !ok || mtr.Attempt > cur.Attempt {
+			latest[mtr.UserDeviceID.String] = mtr
+		}
+	}
+
+	udIDs := make([]string, 0, len(latest))
+	for id := range latest {
+		udIDs = append(udIDs, id)
+	}
+
+	uds, err := models.UserDevices(
+		models.UserDeviceWhere.ID.IN(udIDs),
+		qm.OrderBy(models.UserDeviceColumns.VinIdentifier),
+	).All(ctx, b.dbs().Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vehicles := make([]*MintBatchVehicleStatus, len(uds))
+	for i, ud := range uds {
+		vehicles[i] = &MintBatchVehicleStatus{UserDevice: ud, Request: latest[ud.ID]}
+	}
+
+	return batch, vehicles, nil
+}
+
+// awaitingSignature reports whether the request is still waiting for the owner's signature.
+// Once a signature is accepted the call is stored on the request.
+func awaitingSignature(mtr *models.MetaTransactionRequest) bool {
+	return mtr.Status == models.MetaTransactionRequestStatusUnsubmitted && !mtr.Data.Valid
+}
+
+func (b *MintBatcher) mintMessage(ctx context.Context, defs map[string]*ddgrpc.GetDeviceDefinitionItemResponse, ud *models.UserDevice, owner common.Address) (*MintVehicleWithDeviceDefinitionSign, error) {
+	dd, ok := defs[ud.DefinitionID]
+	if !ok {
+		var err error
+		dd, err = b.ddSvc.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up definition %s: %w", ud.DefinitionID, err)
+		}
+		defs[ud.DefinitionID] = dd
+	}
+
+	return &MintVehicleWithDeviceDefinitionSign{
+		ManufacturerNode:   new(big.Int).SetUint64(dd.Make.TokenId),
+		Owner:              owner,
+		DeviceDefinitionID: dd.Id,
+		Attributes:         []string{"Make", "Model", "Year"},
+		Infos:              []string{dd.Make.Name, dd.Model, strconv.Itoa(int(dd.Year))},
+	}, nil
+}
+
+func attrPairs(attrs, infos []string) []contracts.AttributeInfoPair {
+	out := make([]contracts.AttributeInfoPair, len(attrs))
+	for i := range attrs {
+		out[i] = contracts.AttributeInfoPair{Attribute: attrs[i], Info: infos[i]}
+	}
+	return out
+}
+
+// SubmitSignatures checks each signature against the vehicle's payload and sends the mints
+// whose signatures are good. Signatures are handled independently: a bad one doesn't hold up
+// the rest.
+func (b *MintBatcher) SubmitSignatures(ctx context.Context, batchID string, owner common.Address, sigs []MintBatchSignature) ([]MintBatchSignatureResult, error) {
+	_, vehicles, err := b.loadBatch(ctx, batchID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*MintBatchVehicleStatus, len(vehicles))
+	for _, v := range vehicles {
+		byID[v.UserDevice.ID] = v
+	}
+
+	defs := make(map[string]*ddgrpc.GetDeviceDefinitionItemResponse)
+	out := make([]MintBatchSignatureResult, len(sigs))
+
+	for i, s := range sigs {
+		out[i].UserDeviceID = s.UserDeviceID
+
+		// Vehicles earlier in the list may already have been sent, so a failure here is only
+		// reported for this vehicle.
+		fail := func(err error, msg string) {
+			b.logger.Err(err).Str("mintBatchId", batchID).Str("userDeviceId", s.UserDeviceID).Msg(msg)
+			out[i].Error = "Couldn't submit the mint. Try again."
+		}
+
+		v, ok := byID[s.UserDeviceID]
+		if !ok {
+			out[i].Error = "Vehicle is not part of this batch."
+			continue
+		}
+
+		if !awaitingSignature(v.Request) {
+			out[i].Error = "Vehicle's mint has already been submitted."
+			continue
+		}
+
+		msg, err := b.mintMessage(ctx, defs, v.UserDevice, owner)
+		if err != nil {
+			fail(err, "Failed to build batch mint message.")
+			continue
+		}
+
+		hash, err := b.client.Hash(msg)
+		if err != nil {
+			fail(err, "Failed to hash batch mint message.")
+			continue
+		}
+
+		if err := b.sigVerifier.VerifySignature(ctx, owner, hash, s.Signature); err != nil {
+			var sigErr *services.SignatureError
+			if errors.As(err, &sigErr) {
+				out[i].Error = sigErr.Reason
+			} else {
+				b.logger.Err(err).Str("mintBatchId", batchID).Str("userDeviceId", s.UserDeviceID).Msg("Failed to verify batch mint signature.")
+				out[i].Error = "Couldn't verify the signature. Try again."
+			}
+			continue
+		}
+
+		data, err := PackMintVehicleWithDeviceDefinitionSign(msg.ManufacturerNode, owner, msg.DeviceDefinitionID, attrPairs(msg.Attributes, msg.Infos), s.Signature)
+		if err != nil {
+			fail(err, "Failed to pack batch mint.")
+			continue
+		}
+
+		// Claim the request by storing the call on it. Of two submissions racing for the same
+		// vehicle, only the one that finds the row still empty sends.
+		cols := models.MetaTransactionRequestColumns
+		to := b.client.Contract.Address
+		rowsAff, err := models.MetaTransactionRequests(
+			models.MetaTransactionRequestWhere.ID.EQ(v.Request.ID),
+			models.MetaTransactionRequestWhere.Status.EQ(models.MetaTransactionRequestStatusUnsubmitted),
+			models.MetaTransactionRequestWhere.Data.IsNull(),
+		).UpdateAll(ctx, b.dbs().Writer, models.M{
+			cols.ToAddress: to.Bytes(),
+			cols.Data:      data,
+		})
+		if err != nil {
+			fail(err, "Failed to claim batch mint request.")
+			continue
+		}
+		if rowsAff != 1 {
+			out[i].Error = "Vehicle's mint has already been submitted."
+			continue
+		}
+
+		if err := b.client.Resend(v.Request.ID, to, data); err != nil {
+			fail(err, "Failed to send batch mint.")
+
+			// Let the owner try this one again.
+			_, err := models.MetaTransactionRequests(
+				models.MetaTransactionRequestWhere.ID.EQ(v.Request.ID),
+			).UpdateAll(ctx, b.dbs().Writer, models.M{
+				cols.ToAddress: nil,
+				cols.Data:      nil,
+			})
+			if err != nil {
+				b.logger.Err(err).Str("mintBatchId", batchID).Str("userDeviceId", s.UserDeviceID).Str("requestId", v.Request.ID).Msg("Failed to release unsent batch mint request.")
+				out[i].Error = "Couldn't submit the mint."
+			}
+			continue
+		}
+
+		out[i].RequestID = v.Request.ID
+	}
+
+	return out, nil
+}
@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/contracts"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -38,8 +40,12 @@ type proc struct {
 	ErrorTranslator *ABIErrorTranslator
 	teslaTask       services.TeslaTaskService
 	ddSvc           services.DeviceDefinitionService
+	client          *Client
+	maxAttempts     int
 }
 
+const defaultMetaTransactionMaxAttempts = 3
+
 func (p *proc) Handle(ctx context.Context, data *ceData) error {
 	logger := p.Logger.With().
 		Str("requestId", data.RequestID).
@@ -68,18 +74,20 @@ func (p *proc) Handle(ctx context.Context, data *ceData) error {
 		return err
 	}
 
+	statusChanged := mtr.Status != data.Type
 	mtr.Status = data.Type
 
 	if data.Type == models.MetaTransactionRequestStatusFailed {
-		errData := common.FromHex(data.Reason.Data)
-		if len(errData) != 0 {
-			friendlyError, err := p.ErrorTranslator.Decode(errData)
-			if err != nil {
-				logger.Err(err).Msg("Error decoding revert data.")
-			} else {
-				mtr.FailureReason = null.StringFrom(friendlyError)
-			}
+		kind, reason, err := p.ErrorTranslator.Classify(common.FromHex(data.Reason.Data))
+		if err != nil {
+			logger.Err(err).Msg("Error decoding revert data.")
 		}
+		if reason != "" {
+			mtr.FailureReason = null.StringFrom(reason)
+		}
+		// Only failures unrelated to the payload are worth sending again. A used-up nonce is
+		// signed into the call, so resending the same bytes would fail the same way.
+		mtr.FailureRetryable = null.BoolFrom(kind == FailureTransient)
 	} else {
 		mtr.Hash = null.BytesFrom(common.FromHex(data.Transaction.Hash))
 	}
@@ -89,6 +97,32 @@ func (p *proc) Handle(ctx context.Context, data *ceData) error {
 		return err
 	}
 
+	if statusChanged {
+		if err := services.RecordMetaTransactionStatus(ctx, tx, mtr); err != nil {
+			return err
+		}
+	}
+
+	if mtr.Status == models.MetaTransactionRequestStatusFailed {
+		retry, err := p.prepareRetry(ctx, tx, mtr)
+		if err != nil {
+			return fmt.Errorf("failed to prepare retry: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		if retry == nil {
+			logger.Warn().Str("failureReason", mtr.FailureReason.String).Msg("Transaction failed.")
+			return nil
+		}
+
+		logger.Info().Str("retryRequestId", retry.ID).Int("attempt", retry.Attempt).Msg("Resubmitting failed transaction.")
+
+		return p.client.Resend(retry.ID, common.BytesToAddress(retry.ToAddress.Bytes), retry.Data.Bytes)
+	}
+
 	if mtr.Status != models.MetaTransactionRequestStatusConfirmed {
 		return tx.Commit()
 	}
@@ -115,6 +149,10 @@ func (p *proc) Handle(ctx context.Context, data *ceData) error {
 					return fmt.Errorf("failed to update vehicle record: %w", err)
 				}
 
+				if err := enqueueMintWebhook(ctx, tx, event.VehicleId, event.Owner); err != nil {
+					return err
+				}
+
 				logger.Info().
 					Str("userDeviceId", mtr.R.MintRequestUserDevice.ID).
 					Int64("vehicleTokenId", event.VehicleId.Int64()).
@@ -137,6 +175,10 @@ func (p *proc) Handle(ctx context.Context, data *ceData) error {
 					return fmt.Errorf("failed to update vehicle record: %w", err)
 				}
 
+				if err := enqueueMintWebhook(ctx, tx, event.TokenId, event.Owner); err != nil {
+					return err
+				}
+
 				logger.Info().
 					Str("userDeviceId", mtr.R.MintRequestUserDevice.ID).
 					Int64("vehicleTokenId", event.TokenId.Int64()).
@@ -215,6 +257,99 @@ func (p *proc) Handle(ctx context.Context, data *ceData) error {
 	return tx.Commit()
 }
 
+// prepareRetry creates a new request carrying the same call as a failed one, and moves the rows
+// that pointed at the old request over to it. It returns nil if the failure can't be fixed by
+// sending the same payload again, as with rejections and used-up nonces, there's nothing stored
+// to send, or the request has used up its attempts. If
+// the retry already exists but hasn't been sent, it's returned again so that sending it can be
+// retried too.
+func (p *proc) prepareRetry(ctx context.Context, exec boil.ContextExecutor, mtr *models.MetaTransactionRequest) (*models.MetaTransactionRequest, error) {
+	if p.client == nil || !mtr.FailureRetryable.Bool || !mtr.ToAddress.Valid || len(mtr.Data.Bytes) == 0 {
+		return nil, nil
+	}
+
+	retry, err := models.MetaTransactionRequests(
+		models.MetaTransactionRequestWhere.RetryOf.EQ(null.StringFrom(mtr.ID)),
+	).One(ctx, exec)
+	if err == nil {
+		if retry.Status == models.MetaTransactionRequestStatusUnsubmitted {
+			return retry, nil
+		}
+		return nil, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if mtr.Attempt >= p.maxAttempts {
+		return nil, nil
+	}
+
+	retry = &models.MetaTransactionRequest{
+		ID:           ksuid.New().String(),
+		Status:       models.MetaTransactionRequestStatusUnsubmitted,
+		Operation:    mtr.Operation,
+		UserDeviceID: mtr.UserDeviceID,
+		OwnerAddress: mtr.OwnerAddress,
+		ToAddress:    mtr.ToAddress,
+		Data:         mtr.Data,
+		Attempt:      mtr.Attempt + 1,
+		RetryOf:      null.StringFrom(mtr.ID),
+		MintBatchID:  mtr.MintBatchID,
+	}
+
+	if err := retry.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	if err := services.RecordMetaTransactionStatus(ctx, exec, retry); err != nil {
+		return nil, err
+	}
+
+	oldID, newID := null.StringFrom(mtr.ID), null.StringFrom(retry.ID)
+
+	udCols := models.UserDeviceColumns
+	if _, err := models.UserDevices(models.UserDeviceWhere.MintRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{udCols.MintRequestID: newID}); err != nil {
+		return nil, err
+	}
+	if _, err := models.UserDevices(models.UserDeviceWhere.BurnRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{udCols.BurnRequestID: newID}); err != nil {
+		return nil, err
+	}
+
+	sdCols := models.SyntheticDeviceColumns
+	if _, err := models.SyntheticDevices(models.SyntheticDeviceWhere.MintRequestID.EQ(mtr.ID)).UpdateAll(ctx, exec, models.M{sdCols.MintRequestID: retry.ID}); err != nil {
+		return nil, err
+	}
+	if _, err := models.SyntheticDevices(models.SyntheticDeviceWhere.BurnRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{sdCols.BurnRequestID: newID}); err != nil {
+		return nil, err
+	}
+
+	adCols := models.AftermarketDeviceColumns
+	if _, err := models.AftermarketDevices(models.AftermarketDeviceWhere.ClaimMetaTransactionRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{adCols.ClaimMetaTransactionRequestID: newID}); err != nil {
+		return nil, err
+	}
+	if _, err := models.AftermarketDevices(models.AftermarketDeviceWhere.PairRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{adCols.PairRequestID: newID}); err != nil {
+		return nil, err
+	}
+	if _, err := models.AftermarketDevices(models.AftermarketDeviceWhere.UnpairRequestID.EQ(oldID)).UpdateAll(ctx, exec, models.M{adCols.UnpairRequestID: newID}); err != nil {
+		return nil, err
+	}
+
+	return retry, nil
+}
+
+func enqueueMintWebhook(ctx context.Context, exec boil.ContextExecutor, tokenID *big.Int, owner common.Address) error {
+	err := services.EnqueueWebhookEvent(ctx, exec, &services.WebhookEvent{
+		Type:           services.WebhookVehicleMinted,
+		VehicleTokenID: tokenID,
+		Owners:         []common.Address{owner},
+		Data:           services.WebhookVehicleData{VehicleTokenID: tokenID, Owner: owner},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue mint webhooks: %w", err)
+	}
+	return nil
+}
+
 func (p *proc) parseLog(out any, event abi.Event, log ceLog) error {
 	if len(log.Data) > 0 {
 		if err := p.ABI.UnpackIntoInterface(out, event.Name, log.Data); err != nil {
@@ -238,6 +373,7 @@ func NewProcessor(
 	settings *config.Settings,
 	teslaTask services.TeslaTaskService,
 	ddSvc services.DeviceDefinitionService,
+	client *Client,
 ) (StatusProcessor, error) {
 	regABI, err := contracts.RegistryMetaData.GetAbi()
 	if err != nil {
@@ -255,6 +391,11 @@ func NewProcessor(
 		return nil, fmt.Errorf("error constructing error translater: %w", err)
 	}
 
+	maxAttempts := defaultMetaTransactionMaxAttempts
+	if settings.MetaTransactionMaxAttempts > 0 {
+		maxAttempts = settings.MetaTransactionMaxAttempts
+	}
+
 	return &proc{
 		ABI:             regABI,
 		DB:              db,
@@ -263,5 +404,7 @@ func NewProcessor(
 		ErrorTranslator: errorTranslator,
 		teslaTask:       teslaTask,
 		ddSvc:           ddSvc,
+		client:          client,
+		maxAttempts:     maxAttempts,
 	}, nil
 }
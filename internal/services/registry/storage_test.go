@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 	"testing"
 	"time"
@@ -13,8 +14,10 @@ import (
 	"github.com/DIMO-Network/devices-api/models"
 	cipherpkg "github.com/DIMO-Network/shared/pkg/cipher"
 	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/IBM/sarama/mocks"
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
@@ -57,7 +60,7 @@ func (s *StorageTestSuite) SetupTest() {
 	s.ddSvc = mock_services.NewMockDeviceDefinitionService(s.mockCtrl)
 	s.teslaSvc = mock_services.NewMockTeslaTaskService(s.mockCtrl)
 
-	proc, err := NewProcessor(s.dbs.DBS, logger, &config.Settings{Environment: "prod"}, s.teslaSvc, s.ddSvc)
+	proc, err := NewProcessor(s.dbs.DBS, logger, &config.Settings{Environment: "prod"}, s.teslaSvc, s.ddSvc, nil)
 	if err != nil {
 		s.T().Fatal(err)
 	}
@@ -337,6 +340,139 @@ func (s *StorageTestSuite) TestVehicleNodeMintedWithDeviceDefinition() {
 	s.Equal(common.HexToAddress("7e74d0f663d58d12817b8bef762bcde3af1f63d6"), common.BytesToAddress(ud.OwnerAddress.Bytes))
 }
 
+func (s *StorageTestSuite) TestRetryTransientFailure() {
+	producer := mocks.NewSyncProducer(s.T(), nil)
+	client := &Client{Producer: producer, RequestTopic: "topic.transaction.request.send"}
+
+	proc, err := NewProcessor(s.dbs.DBS, test.Logger(), &config.Settings{MetaTransactionMaxAttempts: 2}, s.teslaSvc, s.ddSvc, client)
+	s.Require().NoError(err)
+
+	registryAddr := common.HexToAddress("0x5")
+	callData := common.FromHex("0xdeadbeef")
+
+	mtr := models.MetaTransactionRequest{
+		ID:        ksuid.New().String(),
+		Status:    models.MetaTransactionRequestStatusSubmitted,
+		ToAddress: null.BytesFrom(registryAddr.Bytes()),
+		Data:      null.BytesFrom(callData),
+	}
+	s.MustInsert(&mtr)
+
+	ud := models.UserDevice{
+		ID:            ksuid.New().String(),
+		MintRequestID: null.StringFrom(mtr.ID),
+	}
+	s.MustInsert(&ud)
+
+	var sent RequestData
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(val []byte) error {
+		var event struct {
+			Data RequestData `json:"data"`
+		}
+		if err := json.Unmarshal(val, &event); err != nil {
+			return err
+		}
+		sent = event.Data
+		return nil
+	})
+
+	// No revert data: out of gas, or the like.
+	s.Require().NoError(proc.Handle(s.ctx, &ceData{RequestID: mtr.ID, Type: "Failed"}))
+
+	s.Require().NoError(mtr.Reload(s.ctx, s.dbs.DBS().Reader))
+	s.Equal(models.MetaTransactionRequestStatusFailed, mtr.Status)
+	s.True(mtr.FailureRetryable.Bool)
+
+	retry, err := models.MetaTransactionRequests(models.MetaTransactionRequestWhere.RetryOf.EQ(null.StringFrom(mtr.ID))).One(s.ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.Equal(2, retry.Attempt)
+	s.Equal(models.MetaTransactionRequestStatusUnsubmitted, retry.Status)
+
+	s.Equal(retry.ID, sent.ID)
+	s.Equal(registryAddr, sent.To)
+	s.Equal(callData, []byte(sent.Data))
+
+	s.Require().NoError(ud.Reload(s.ctx, s.dbs.DBS().Reader))
+	s.Equal(retry.ID, ud.MintRequestID.String)
+
+	// The second failure uses up the attempts.
+	s.Require().NoError(proc.Handle(s.ctx, &ceData{RequestID: retry.ID, Type: "Failed"}))
+
+	s.Require().NoError(retry.Reload(s.ctx, s.dbs.DBS().Reader))
+	s.Equal(models.MetaTransactionRequestStatusFailed, retry.Status)
+
+	n, err := models.MetaTransactionRequests().Count(s.ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(2, n)
+}
+
+func (s *StorageTestSuite) TestPermanentFailureNotRetried() {
+	producer := mocks.NewSyncProducer(s.T(), nil)
+	client := &Client{Producer: producer, RequestTopic: "topic.transaction.request.send"}
+
+	proc, err := NewProcessor(s.dbs.DBS, test.Logger(), &config.Settings{}, s.teslaSvc, s.ddSvc, client)
+	s.Require().NoError(err)
+
+	mtr := models.MetaTransactionRequest{
+		ID:        ksuid.New().String(),
+		Status:    models.MetaTransactionRequestStatusSubmitted,
+		ToAddress: null.BytesFrom(common.HexToAddress("0x5").Bytes()),
+		Data:      null.BytesFrom(common.FromHex("0xdeadbeef")),
+	}
+	s.MustInsert(&mtr)
+
+	s.Require().NoError(proc.Handle(s.ctx, &ceData{
+		RequestID: mtr.ID,
+		Type:      "Failed",
+		Reason: ceReason{
+			// InvalidOwnerSignature()
+			Data: "0x38a85a8d",
+		},
+	}))
+
+	s.Require().NoError(mtr.Reload(s.ctx, s.dbs.DBS().Reader))
+	s.False(mtr.FailureRetryable.Bool)
+	s.Equal("Invalid owner signature.", mtr.FailureReason.String)
+
+	n, err := models.MetaTransactionRequests().Count(s.ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(1, n)
+}
+
+func (s *StorageTestSuite) TestNonceFailureNotRetried() {
+	producer := mocks.NewSyncProducer(s.T(), nil)
+	client := &Client{Producer: producer, RequestTopic: "topic.transaction.request.send"}
+
+	proc, err := NewProcessor(s.dbs.DBS, test.Logger(), &config.Settings{}, s.teslaSvc, s.ddSvc, client)
+	s.Require().NoError(err)
+
+	mtr := models.MetaTransactionRequest{
+		ID:        ksuid.New().String(),
+		Status:    models.MetaTransactionRequestStatusSubmitted,
+		ToAddress: null.BytesFrom(common.HexToAddress("0x5").Bytes()),
+		Data:      null.BytesFrom(common.FromHex("0xdeadbeef")),
+	}
+	s.MustInsert(&mtr)
+
+	// InvalidAccountNonce(0x0, 0)
+	revert := append(common.CopyBytes(invalidAccountNonceSelector), make([]byte, 64)...)
+
+	s.Require().NoError(proc.Handle(s.ctx, &ceData{
+		RequestID: mtr.ID,
+		Type:      "Failed",
+		Reason:    ceReason{Data: hexutil.Encode(revert)},
+	}))
+
+	s.Require().NoError(mtr.Reload(s.ctx, s.dbs.DBS().Reader))
+	s.False(mtr.FailureRetryable.Bool)
+	s.Equal("Signature nonce already used; the request has to be signed again.", mtr.FailureReason.String)
+
+	// The same signed payload must not go out again.
+	n, err := models.MetaTransactionRequests().Count(s.ctx, s.dbs.DBS().Reader)
+	s.Require().NoError(err)
+	s.EqualValues(1, n)
+}
+
 func (s *StorageTestSuite) MustInsert(o boilInsertable) {
 	s.Require().NoError(o.Insert(context.TODO(), s.dbs.DBS().Writer, boil.Infer()))
 }
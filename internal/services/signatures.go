@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	credis "github.com/DIMO-Network/shared/pkg/redis"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog"
+)
+
+// erc1271MagicValue is what isValidSignature returns for a good signature. It's the selector
+// of isValidSignature(bytes32,bytes).
+var erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+const (
+	defaultSignatureCacheTTL = 10 * time.Minute
+	signatureCacheValid      = "valid"
+)
+
+// SignatureVerifier checks that an account signed a hash. Externally owned accounts are
+// checked by recovering the signer; smart contract wallets are asked through ERC-1271.
+type SignatureVerifier interface {
+	// VerifySignature returns nil if signer signed hash. If the signature is bad, the error
+	// is a *SignatureError; any other error means we couldn't tell.
+	VerifySignature(ctx context.Context, signer common.Address, hash, signature []byte) error
+}
+
+// SignatureError says why a signature was rejected. Reason is meant for the client.
+type SignatureError struct {
+	Signer common.Address
+	Reason string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("signature for %s rejected: %s", e.Signer, e.Reason)
+}
+
+// NewSignatureVerifier creates a verifier that calls the chain at MAIN_RPC_URL. If cache is not
+// nil, ERC-1271 answers are cached for SIGNATURE_CACHE_TTL.
+func NewSignatureVerifier(settings *config.Settings, cache credis.CacheService, logger *zerolog.Logger) (SignatureVerifier, error) {
+	ttl := defaultSignatureCacheTTL
+	if settings.SignatureCacheTTL != "" {
+		t, err := time.ParseDuration(settings.SignatureCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse signature cache TTL: %w", err)
+		}
+		ttl = t
+	}
+
+	if ttl <= 0 {
+		cache = nil
+	}
+
+	return &signatureVerifier{
+		rpcURL: settings.MainRPCURL,
+		cache:  cache,
+		ttl:    ttl,
+		logger: logger,
+	}, nil
+}
+
+type signatureVerifier struct {
+	rpcURL string
+	cache  credis.CacheService
+	ttl    time.Duration
+	logger *zerolog.Logger
+
+	// caller is dialed the first time a contract wallet needs checking.
+	mu     sync.Mutex
+	caller bind.ContractCaller
+}
+
+func (v *signatureVerifier) VerifySignature(ctx context.Context, signer common.Address, hash, signature []byte) error {
+	if len(signature) == 0 {
+		return &SignatureError{Signer: signer, Reason: "Signature is empty."}
+	}
+
+	// Most owners have ordinary wallets, so try this before going to the chain.
+	eoaReason := "Signature is not 65 bytes long, so it can't be from an ordinary wallet."
+	if len(signature) == 65 {
+		recAddr, err := utils.Ecrecover(hash, signature)
+		if err != nil {
+			eoaReason = "Couldn't recover a signer from the signature."
+		} else if recAddr == signer {
+			return nil
+		} else {
+			eoaReason = fmt.Sprintf("Signature was made by %s, not %s.", recAddr, signer)
+		}
+	}
+
+	key := v.cacheKey(signer, hash, signature)
+	if v.cache != nil {
+		res, err := v.cache.Get(ctx, key).Result()
+		if err == nil {
+			if res == signatureCacheValid {
+				return nil
+			}
+			return &SignatureError{Signer: signer, Reason: res}
+		}
+		if !errors.Is(err, redis.Nil) {
+			v.logger.Err(err).Str("signer", signer.Hex()).Msg("Failed to read signature result from cache.")
+		}
+	}
+
+	caller, err := v.getCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	code, err := caller.CodeAt(ctx, signer, nil)
+	if err != nil {
+		return fmt.Errorf("couldn't look up code for %s: %w", signer, err)
+	}
+	if len(code) == 0 {
+		// Not cached: a counterfactual wallet may be deployed at this address later.
+		return &SignatureError{Signer: signer, Reason: eoaReason + " The address is not a smart contract wallet."}
+	}
+
+	reason, err := v.checkContract(ctx, caller, signer, hash, signature)
+	if err != nil {
+		return err
+	}
+
+	if v.cache != nil {
+		res := reason
+		if res == "" {
+			res = signatureCacheValid
+		}
+		if err := v.cache.Set(ctx, key, res, v.ttl).Err(); err != nil {
+			v.logger.Err(err).Str("signer", signer.Hex()).Msg("Failed to cache signature result.")
+		}
+	}
+
+	if reason != "" {
+		return &SignatureError{Signer: signer, Reason: reason}
+	}
+	return nil
+}
+
+// checkContract asks the wallet at signer about the signature. The returned string is empty if
+// the wallet accepted it and otherwise says why it didn't.
+func (v *signatureVerifier) checkContract(ctx context.Context, caller bind.ContractCaller, signer common.Address, hash, signature []byte) (string, error) {
+	wallet, err := sig2.NewErc1271Caller(signer, caller)
+	if err != nil {
+		return "", err
+	}
+
+	ret, err := wallet.IsValidSignature(&bind.CallOpts{Context: ctx}, common.BytesToHash(hash), signature)
+	if err != nil {
+		var dataErr rpc.DataError
+		if errors.As(err, &dataErr) || strings.Contains(err.Error(), "execution reverted") {
+			return fmt.Sprintf("Smart contract wallet %s reverted when checking the signature.", signer), nil
+		}
+		if errors.Is(err, bind.ErrNoCode) || strings.Contains(err.Error(), "abi:") {
+			return fmt.Sprintf("Contract %s does not support ERC-1271 signatures.", signer), nil
+		}
+		return "", fmt.Errorf("couldn't call isValidSignature on %s: %w", signer, err)
+	}
+
+	if ret != erc1271MagicValue {
+		return fmt.Sprintf("Smart contract wallet %s did not accept the signature.", signer), nil
+	}
+
+	return "", nil
+}
+
+func (v *signatureVerifier) getCaller(ctx context.Context) (bind.ContractCaller, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.caller == nil {
+		client, err := ethclient.DialContext(ctx, v.rpcURL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't connect to the chain: %w", err)
+		}
+		v.caller = client
+	}
+
+	return v.caller, nil
+}
+
+func (v *signatureVerifier) cacheKey(signer common.Address, hash, signature []byte) string {
+	return "signature:" + crypto.Keccak256Hash(signer.Bytes(), hash, signature).Hex()
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallet is a chain with a single ERC-1271 wallet that answers every isValidSignature
+// call with ret.
+type fakeWallet struct {
+	addr  common.Address
+	ret   [4]byte
+	calls int
+}
+
+func (f *fakeWallet) CodeAt(_ context.Context, contract common.Address, _ *big.Int) ([]byte, error) {
+	if contract == f.addr {
+		return []byte{0x60, 0x80}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeWallet) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if *call.To != f.addr {
+		return nil, errors.New("unexpected call")
+	}
+	f.calls++
+	out := make([]byte, 32)
+	copy(out, f.ret[:])
+	return out, nil
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	hash := crypto.Keccak256([]byte("payload"))
+	sig, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+	sig[64] += 27
+
+	wallet := &fakeWallet{addr: common.HexToAddress("0xc0ffee"), ret: erc1271MagicValue}
+	cache := &fakeCache{values: make(map[string]string)}
+	v := &signatureVerifier{caller: wallet, cache: cache, ttl: defaultSignatureCacheTTL, logger: test.Logger()}
+
+	// Ordinary wallets never touch the chain.
+	require.NoError(t, v.VerifySignature(ctx, owner, hash, sig))
+	assert.Zero(t, wallet.calls)
+
+	var sigErr *SignatureError
+
+	err = v.VerifySignature(ctx, common.HexToAddress("0xbeef"), hash, sig)
+	require.ErrorAs(t, err, &sigErr)
+	assert.Equal(t, "Signature was made by "+owner.Hex()+", not 0x000000000000000000000000000000000000bEEF. The address is not a smart contract wallet.", sigErr.Reason)
+
+	// Smart contract wallets are asked once and then remembered.
+	require.NoError(t, v.VerifySignature(ctx, wallet.addr, hash, []byte{1, 2, 3}))
+	require.NoError(t, v.VerifySignature(ctx, wallet.addr, hash, []byte{1, 2, 3}))
+	assert.Equal(t, 1, wallet.calls)
+
+	wallet.ret = [4]byte{}
+	err = v.VerifySignature(ctx, wallet.addr, hash, []byte{4, 5, 6})
+	require.ErrorAs(t, err, &sigErr)
+	assert.Equal(t, "Smart contract wallet "+wallet.addr.Hex()+" did not accept the signature.", sigErr.Reason)
+
+	err = v.VerifySignature(ctx, owner, hash, nil)
+	require.ErrorAs(t, err, &sigErr)
+	assert.Equal(t, "Signature is empty.", sigErr.Reason)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/internal/services/cio"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared/pkg/db"
@@ -46,22 +48,12 @@ func NewTaskStatusListener(db func() *db.ReaderWriter, log *zerolog.Logger, ddSv
 	return &TaskStatusListener{db: db, log: log, DeviceDefSvc: ddSvc, prod: prod, cioSvc: cioSvc, settings: settings}
 }
 
-func (i *TaskStatusListener) ProcessTaskUpdates(messages <-chan *message.Message) {
-	for msg := range messages {
-		err := i.processMessage(msg)
-		if err != nil {
-			i.log.Err(err).Msg("error processing task status message")
-		}
-	}
-}
-
-func (i *TaskStatusListener) processMessage(msg *message.Message) error {
-	// Keep the pipeline moving no matter what.
-	defer func() { msg.Ack() }()
-
+// ProcessTaskUpdate handles one message from the task status topic. It doesn't ack the message;
+// that's left to the kafka.RetryHandler that wraps it.
+func (i *TaskStatusListener) ProcessTaskUpdate(msg *message.Message) error {
 	event := new(payloads.CloudEvent[TaskStatusData])
 	if err := json.Unmarshal(msg.Payload, event); err != nil {
-		return errors.Wrap(err, "error parsing task status payload")
+		return kafka.Permanent(errors.Wrap(err, "error parsing task status payload"))
 	}
 
 	return i.processEvent(event)
@@ -74,7 +66,7 @@ func (i *TaskStatusListener) processEvent(event *payloads.CloudEvent[TaskStatusD
 	case commandStatusEventType:
 		return i.processCommandStatusEvent(event)
 	default:
-		return fmt.Errorf("unexpected event type %s", event.Type)
+		return kafka.Permanent(fmt.Errorf("unexpected event type %s", event.Type))
 	}
 }
 
@@ -87,13 +79,13 @@ func (i *TaskStatusListener) processTeslaPollStatusEvent(event *payloads.CloudEv
 
 	// Should we use data.integrationId instead?
 	if !strings.HasPrefix(event.Source, sourcePrefix) {
-		return fmt.Errorf("unexpected event source format: %s", event.Source)
+		return kafka.Permanent(fmt.Errorf("unexpected event source format: %s", event.Source))
 	}
 	integrationID := strings.TrimPrefix(event.Source, sourcePrefix)
 
 	// Just one case for now.
 	if event.Data.Status != models.UserDeviceAPIIntegrationStatusAuthenticationFailure {
-		return fmt.Errorf("unexpected task status %s", event.Data.Status)
+		return kafka.Permanent(fmt.Errorf("unexpected task status %s", event.Data.Status))
 	}
 
 	udai, err := models.UserDeviceAPIIntegrations(
@@ -126,6 +118,17 @@ func (i *TaskStatusListener) processTeslaPollStatusEvent(event *payloads.CloudEv
 		i.log.Err(err).Str("userDeviceID", userDeviceID).Str("integrationID", integrationID).Msg("failed up update user device api integration with failure status")
 	}
 
+	if tokenID := webhookTokenID(udai.R.UserDevice.TokenID); tokenID != nil {
+		err := EnqueueWebhookEvent(ctx, i.db().Writer, &WebhookEvent{
+			Type:           WebhookIntegrationStatusChange,
+			VehicleTokenID: tokenID,
+			Data:           WebhookIntegrationStatusData{VehicleTokenID: tokenID, IntegrationID: integrationID, Status: udai.Status},
+		})
+		if err != nil {
+			i.log.Err(err).Str("userDeviceID", userDeviceID).Str("integrationID", integrationID).Msg("failed to enqueue integration status webhooks")
+		}
+	}
+
 	if err := i.cioSvc.SoftwareDisconnectionEvent(ctx, udai); err != nil {
 		i.log.Err(err).Str("userDeviceID", userDeviceID).Str("integrationID", integrationID).Msg("failed up send status disconnection event to cio")
 		return err
@@ -135,21 +138,46 @@ func (i *TaskStatusListener) processTeslaPollStatusEvent(event *payloads.CloudEv
 }
 
 func (i *TaskStatusListener) processCommandStatusEvent(event *payloads.CloudEvent[TaskStatusData]) error {
-	dcr, err := models.FindDeviceCommandRequest(context.Background(), i.db().Writer, event.Data.SubTaskID)
-	if err != nil {
-		return fmt.Errorf("failed to find command request: %w", err)
-	}
+	ctx := context.Background()
 
 	if event.Data.Status != models.DeviceCommandRequestStatusComplete && event.Data.Status != models.DeviceCommandRequestStatusFailed {
-		return fmt.Errorf("unexpected command status %q", dcr.Status)
+		return kafka.Permanent(fmt.Errorf("unexpected command status %q", event.Data.Status))
 	}
 
-	dcr.Status = event.Data.Status
-	_, err = dcr.Update(context.Background(), i.db().Writer, boil.Infer())
+	dcr, err := models.FindDeviceCommandRequest(ctx, i.db().Writer, event.Data.SubTaskID)
+	if err != nil {
+		return fmt.Errorf("failed to find command request: %w", err)
+	}
+
+	// Only move requests that are still pending. The reaper may time this one out at the same
+	// moment; whichever update lands first wins, and the history doesn't change under clients
+	// that already saw the outcome.
+	n, err := models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.ID.EQ(dcr.ID),
+		models.DeviceCommandRequestWhere.Status.EQ(models.DeviceCommandRequestStatusPending),
+	).UpdateAll(ctx, i.db().Writer, models.M{
+		models.DeviceCommandRequestColumns.Status:    event.Data.Status,
+		models.DeviceCommandRequestColumns.UpdatedAt: time.Now(),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update command request: %w", err)
 	}
 
+	if n == 0 {
+		if err := dcr.Reload(ctx, i.db().Writer); err != nil {
+			return fmt.Errorf("failed to reload command request: %w", err)
+		}
+		i.log.Info().
+			Str("subTaskId", event.Data.SubTaskID).
+			Str("command", dcr.Command).
+			Str("status", event.Data.Status).
+			Str("currentStatus", dcr.Status).
+			Msg("Ignoring status for command request that is no longer pending.")
+		return nil
+	}
+
+	dcr.Status = event.Data.Status
+
 	i.log.Info().
 		Str("subTaskId", event.Data.SubTaskID).
 		Str("command", dcr.Command).
@@ -33,6 +33,7 @@ type TeslaVehicle struct {
 type TeslaFleetAPIService interface {
 	CompleteTeslaAuthCodeExchange(ctx context.Context, authCode, redirectURI string) (*TeslaAuthCodeResponse, error)
 	GeneratePartnerToken(ctx context.Context) (*TeslaAuthCodeResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TeslaAuthCodeResponse, error)
 	GetVehicles(ctx context.Context, token string) ([]TeslaVehicle, error)
 	GetVehicle(ctx context.Context, token string, vehicleID int) (*TeslaVehicle, error)
 	WakeUpVehicle(ctx context.Context, token string, vehicleID int) error
@@ -200,6 +201,48 @@ func (t *teslaFleetAPIService) CompleteTeslaAuthCodeExchange(ctx context.Context
 	}, nil
 }
 
+// ErrInvalidRefreshToken is returned when Tesla rejects a refresh token, typically because the
+// user revoked our access or the token was already rotated.
+var ErrInvalidRefreshToken = errors.New("refresh token invalid, expired, or revoked")
+
+// RefreshToken exchanges a stored refresh token for a new access and refresh token pair. Tesla
+// rotates refresh tokens, so the caller must persist both values from the response.
+func (t *teslaFleetAPIService) RefreshToken(ctx context.Context, refreshToken string) (*TeslaAuthCodeResponse, error) {
+	conf := oauth2.Config{
+		ClientID:     t.Settings.TeslaClientID,
+		ClientSecret: t.Settings.TeslaClientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL: t.Settings.TeslaTokenURL,
+		},
+		Scopes: teslaScopes,
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	// With no access token the source goes straight to the refresh grant.
+	tok, err := conf.TokenSource(ctxTimeout, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		var e *oauth2.RetrieveError
+		errString := err.Error()
+		if errors.As(err, &e) {
+			if e.ErrorCode == "invalid_grant" || e.ErrorCode == "login_required" {
+				return nil, ErrInvalidRefreshToken
+			}
+			t.log.Info().Str("error", e.ErrorCode).Str("errorDescription", e.ErrorDescription).Msg("Token refresh failure.")
+			errString = e.ErrorDescription
+		}
+		return nil, fmt.Errorf("error occurred refreshing token: %s", errString)
+	}
+
+	return &TeslaAuthCodeResponse{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		Expiry:       tok.Expiry,
+		TokenType:    tok.TokenType,
+	}, nil
+}
+
 // GeneratePartnerToken generates an access token for client-wide operations. Mostly we use this for
 // removing vehicles.
 func (t *teslaFleetAPIService) GeneratePartnerToken(ctx context.Context) (*TeslaAuthCodeResponse, error) {
@@ -327,6 +370,37 @@ const (
 	teslaChargingScope = "vehicle_charging_cmds"
 )
 
+var (
+	// teslaCommandScopeCommands are granted by the general command scope. The first entry was
+	// there from the start, so its presence in stored metadata implies the scope.
+	teslaCommandScopeCommands = []string{constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen, constants.ClimateOn, constants.ClimateOff, constants.HornHonk, constants.LightsFlash}
+	// teslaChargingScopeCommands are granted by either the command or the charging scope. As
+	// above, the first entry predates the others.
+	teslaChargingScopeCommands = []string{constants.ChargeLimit, constants.ChargeAmps}
+)
+
+// TeslaCommandEnabled reports whether the stored command metadata of a Tesla integration allows
+// the command. Commands are written to the metadata when the user authorizes, so integrations
+// authorized before a command was added don't list it; for those, the command is allowed if an
+// older command granted by the same scope is listed.
+func TeslaCommandEnabled(cmds *UserDeviceAPIIntegrationsMetadataCommands, command string) bool {
+	if cmds == nil {
+		return false
+	}
+
+	if slices.Contains(cmds.Enabled, command) {
+		return true
+	}
+
+	for _, group := range [][]string{teslaCommandScopeCommands, teslaChargingScopeCommands} {
+		if slices.Contains(group, command) {
+			return slices.Contains(cmds.Enabled, group[0])
+		}
+	}
+
+	return false
+}
+
 func (t *teslaFleetAPIService) GetAvailableCommands(token string) (*UserDeviceAPIIntegrationsMetadataCommands, error) {
 	var claims partialTeslaClaims
 	_, _, err := jwt.NewParser().ParseUnverified(token, &claims)
@@ -338,15 +412,15 @@ func (t *teslaFleetAPIService) GetAvailableCommands(token string) (*UserDeviceAP
 	disabled := []string{}
 
 	if slices.Contains(claims.Scopes, teslaCommandScope) {
-		enabled = append(enabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen)
+		enabled = append(enabled, teslaCommandScopeCommands...)
 	} else {
-		disabled = append(disabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen)
+		disabled = append(disabled, teslaCommandScopeCommands...)
 	}
 
 	if slices.Contains(claims.Scopes, teslaCommandScope) || slices.Contains(claims.Scopes, teslaChargingScope) {
-		enabled = append(enabled, constants.ChargeLimit)
+		enabled = append(enabled, teslaChargingScopeCommands...)
 	} else {
-		disabled = append(disabled, constants.ChargeLimit)
+		disabled = append(disabled, teslaChargingScopeCommands...)
 	}
 
 	return &UserDeviceAPIIntegrationsMetadataCommands{
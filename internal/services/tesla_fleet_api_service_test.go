@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/suite"
@@ -127,3 +128,50 @@ func (t *TeslaFleetAPIServiceTestSuite) TestSubscribeForTelemetryData_Errror_Cas
 		t.EqualError(err, tst.expectedError)
 	}
 }
+
+func (t *TeslaFleetAPIServiceTestSuite) TestRefreshToken() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tokenURL := "https://auth-mock.dimo.zone/oauth2/v3/token"
+	t.settings.TeslaTokenURL = tokenURL
+
+	httpmock.RegisterResponder(http.MethodPost, tokenURL, func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		t.Equal("refresh_token", req.PostForm.Get("grant_type"))
+		t.Equal("oldRefresh", req.PostForm.Get("refresh_token"))
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]any{
+			"access_token":  "newAccess",
+			"refresh_token": "newRefresh",
+			"token_type":    "Bearer",
+			"expires_in":    28800,
+		})
+	})
+
+	tok, err := t.SUT.RefreshToken(t.ctx, "oldRefresh")
+	t.Require().NoError(err)
+
+	t.Equal("newAccess", tok.AccessToken)
+	t.Equal("newRefresh", tok.RefreshToken)
+	t.WithinDuration(time.Now().Add(8*time.Hour), tok.Expiry, time.Minute)
+}
+
+func (t *TeslaFleetAPIServiceTestSuite) TestRefreshToken_Revoked() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tokenURL := "https://auth-mock.dimo.zone/oauth2/v3/token"
+	t.settings.TeslaTokenURL = tokenURL
+
+	resp, err := httpmock.NewJsonResponder(http.StatusUnauthorized, map[string]any{
+		"error":             "login_required",
+		"error_description": "The refresh_token is expired.",
+	})
+	t.Require().NoError(err)
+	httpmock.RegisterResponder(http.MethodPost, tokenURL, resp)
+
+	_, err = t.SUT.RefreshToken(t.ctx, "oldRefresh")
+	t.ErrorIs(err, ErrInvalidRefreshToken)
+}
@@ -20,12 +20,19 @@ import (
 type TeslaTaskService interface {
 	StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	StopPoll(udai *models.UserDeviceAPIIntegration) error
+	UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
 	LockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
 	OpenTrunk(udai *models.UserDeviceAPIIntegration) (string, error)
 	OpenFrunk(udai *models.UserDeviceAPIIntegration) (string, error)
 	ChargeStart(udai *models.UserDeviceAPIIntegration) (string, error)
 	ChargeStop(udai *models.UserDeviceAPIIntegration) (string, error)
+	SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error)
+	SetChargingAmps(udai *models.UserDeviceAPIIntegration, amps int) (string, error)
+	ClimateOn(udai *models.UserDeviceAPIIntegration, targetTemperature *float64) (string, error)
+	ClimateOff(udai *models.UserDeviceAPIIntegration) (string, error)
+	HonkHorn(udai *models.UserDeviceAPIIntegration) (string, error)
+	FlashLights(udai *models.UserDeviceAPIIntegration) (string, error)
 }
 
 func NewTeslaTaskService(settings *config.Settings, producer sarama.SyncProducer) TeslaTaskService {
@@ -93,34 +100,7 @@ func (t *teslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *
 		},
 	}
 
-	tokenID, _ := sd.TokenID.Int64()
-	integrationTokenID, _ := sd.IntegrationTokenID.Int64()
-	vehicleTokenID, _ := sd.VehicleTokenID.Int64()
-
-	tc := payloads.CloudEvent[sdtask.CredentialData]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.poll.credential.v2",
-		Data: sdtask.CredentialData{
-			TaskID:        udai.TaskID.String,
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			AccessToken:   udai.AccessToken.String,
-			Expiry:        udai.AccessExpiresAt.Time,
-			RefreshToken:  udai.RefreshToken.String,
-			Version:       meta.TeslaAPIVersion,
-			SyntheticDevice: &sdtask.SyntheticDevice{
-				TokenID:            int(tokenID),
-				Address:            common.BytesToAddress(sd.WalletAddress),
-				IntegrationTokenID: int(integrationTokenID),
-				WalletChildNumber:  sd.WalletChildNumber,
-				VehicleTokenID:     int(vehicleTokenID),
-			},
-		},
-	}
+	tc := credentialEvent(udai, sd, meta.TeslaAPIVersion)
 
 	ttb, err := json.Marshal(tt)
 	if err != nil {
@@ -150,6 +130,64 @@ func (t *teslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *
 	return err
 }
 
+// UpdateCredentials replaces the credentials held by a running poll task, for example after
+// the access token has been refreshed. The task itself is left alone.
+func (t *teslaTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	var meta UserDeviceAPIIntegrationsMetadata
+	err := udai.Metadata.Unmarshal(&meta)
+	if err != nil {
+		return fmt.Errorf("couldn't unmarshal metadata: %w", err)
+	}
+
+	tcb, err := json.Marshal(credentialEvent(udai, sd, meta.TeslaAPIVersion))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = t.Producer.SendMessage(
+		&sarama.ProducerMessage{
+			Topic: t.Settings.TaskCredentialTopic,
+			Key:   sarama.StringEncoder(udai.TaskID.String),
+			Value: sarama.ByteEncoder(tcb),
+		},
+	)
+
+	return err
+}
+
+// credentialEvent builds the compacted credential record for a task. The tokens are passed
+// along still encrypted.
+func credentialEvent(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice, version int) payloads.CloudEvent[sdtask.CredentialData] {
+	tokenID, _ := sd.TokenID.Int64()
+	integrationTokenID, _ := sd.IntegrationTokenID.Int64()
+	vehicleTokenID, _ := sd.VehicleTokenID.Int64()
+
+	return payloads.CloudEvent[sdtask.CredentialData]{
+		ID:          ksuid.New().String(),
+		Source:      "dimo/integration/" + udai.IntegrationID,
+		SpecVersion: "1.0",
+		Subject:     udai.UserDeviceID,
+		Time:        time.Now(),
+		Type:        "zone.dimo.task.tesla.poll.credential.v2",
+		Data: sdtask.CredentialData{
+			TaskID:        udai.TaskID.String,
+			UserDeviceID:  udai.UserDeviceID,
+			IntegrationID: udai.IntegrationID,
+			AccessToken:   udai.AccessToken.String,
+			Expiry:        udai.AccessExpiresAt.Time,
+			RefreshToken:  udai.RefreshToken.String,
+			Version:       version,
+			SyntheticDevice: &sdtask.SyntheticDevice{
+				TokenID:            int(tokenID),
+				Address:            common.BytesToAddress(sd.WalletAddress),
+				IntegrationTokenID: int(integrationTokenID),
+				WalletChildNumber:  sd.WalletChildNumber,
+				VehicleTokenID:     int(vehicleTokenID),
+			},
+		},
+	}
+}
+
 func (t *teslaTaskService) StopPoll(udai *models.UserDeviceAPIIntegration) error {
 	var taskKey string
 	if udai.TaskID.Valid {
@@ -206,6 +244,9 @@ type TeslaDoorTask struct {
 	IntegrationID string           `json:"integrationId"`
 	Identifiers   TeslaIdentifiers `json:"identifiers"` // Don't actually need vehicleId.
 	ChargeLimit   *float64         `json:"chargeLimit,omitempty"`
+	ChargingAmps  *int             `json:"chargingAmps,omitempty"`
+	// TargetTemperature is in degrees Celsius.
+	TargetTemperature *float64 `json:"targetTemperature,omitempty"`
 }
 
 func (t *teslaTaskService) UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
@@ -447,3 +488,81 @@ func (t *teslaTaskService) OpenFrunk(udai *models.UserDeviceAPIIntegration) (str
 
 	return tt.Data.SubTaskID, err
 }
+
+func (t *teslaTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.charge.limit", func(tt *TeslaDoorTask) {
+		tt.ChargeLimit = &percent
+	})
+}
+
+func (t *teslaTaskService) SetChargingAmps(udai *models.UserDeviceAPIIntegration, amps int) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.charge.amps", func(tt *TeslaDoorTask) {
+		tt.ChargingAmps = &amps
+	})
+}
+
+// ClimateOn starts climate control. If targetTemperature is nil then the vehicle keeps its
+// current setting.
+func (t *teslaTaskService) ClimateOn(udai *models.UserDeviceAPIIntegration, targetTemperature *float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.climate.on", func(tt *TeslaDoorTask) {
+		tt.TargetTemperature = targetTemperature
+	})
+}
+
+func (t *teslaTaskService) ClimateOff(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.climate.off", nil)
+}
+
+func (t *teslaTaskService) HonkHorn(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.horn.honk", nil)
+}
+
+func (t *teslaTaskService) FlashLights(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.lights.flash", nil)
+}
+
+// sendCommand publishes a command event of the given type to the task, returning the sub-task
+// id used to track it. The optional setParams fills in command-specific fields.
+func (t *teslaTaskService) sendCommand(udai *models.UserDeviceAPIIntegration, eventType string, setParams func(*TeslaDoorTask)) (string, error) {
+	id, err := strconv.Atoi(udai.ExternalID.String)
+	if err != nil {
+		return "", err
+	}
+
+	tt := payloads.CloudEvent[TeslaDoorTask]{
+		ID:          ksuid.New().String(),
+		Source:      "dimo/integration/" + udai.IntegrationID,
+		SpecVersion: "1.0",
+		Subject:     udai.UserDeviceID,
+		Time:        time.Now(),
+		Type:        eventType,
+		Data: TeslaDoorTask{
+			TaskID:        udai.TaskID.String,
+			SubTaskID:     ksuid.New().String(),
+			UserDeviceID:  udai.UserDeviceID,
+			IntegrationID: udai.IntegrationID,
+			Identifiers: TeslaIdentifiers{
+				ID: id,
+			},
+		},
+	}
+
+	if setParams != nil {
+		setParams(&tt.Data)
+	}
+
+	ttb, err := json.Marshal(tt)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, err = t.Producer.SendMessage(
+		&sarama.ProducerMessage{
+			Topic: t.Settings.TaskRunNowTopic,
+			Key:   sarama.StringEncoder(udai.TaskID.String),
+			Value: sarama.ByteEncoder(ttb),
+		},
+	)
+
+	return tt.Data.SubTaskID, err
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/appmetrics"
+	"github.com/DIMO-Network/devices-api/models"
+	cip "github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -source tesla_token_manager.go -destination mocks/tesla_token_manager_mock.go
+
+// TeslaTokenManager hands out usable Fleet API access tokens for Tesla integrations, refreshing
+// and storing a new token pair when the old one is about to expire.
+type TeslaTokenManager interface {
+	// GetAccessToken returns the decrypted access token for the integration, refreshing it first
+	// if necessary. The passed-in integration is updated in place.
+	GetAccessToken(ctx context.Context, udai *models.UserDeviceAPIIntegration) (string, error)
+	// RefreshExpiring renews the tokens of integrations that expire within the given window and
+	// are not owned by a polling task. It returns the number of integrations refreshed.
+	RefreshExpiring(ctx context.Context, integrationID string, window time.Duration) (int, error)
+}
+
+// teslaTokenRefreshMargin is how close to expiry we let an access token get before refreshing
+// it on use.
+const teslaTokenRefreshMargin = 5 * time.Minute
+
+// teslaTokenRefreshTimeout bounds the call to Tesla made while the integration row is locked,
+// so that a slow token endpoint doesn't leave other requests for the vehicle waiting on it.
+const teslaTokenRefreshTimeout = 10 * time.Second
+
+// ErrNoRefreshToken is returned when an integration's access token has expired and there is no
+// refresh token to renew it with.
+var ErrNoRefreshToken = errors.New("no refresh token stored for integration")
+
+func NewTeslaTokenManager(dbs func() *db.ReaderWriter, cipher cip.Cipher, teslaAPI TeslaFleetAPIService, taskSvc TeslaTaskService, logger *zerolog.Logger) TeslaTokenManager {
+	return &teslaTokenManager{
+		dbs:      dbs,
+		cipher:   cipher,
+		teslaAPI: teslaAPI,
+		taskSvc:  taskSvc,
+		log:      logger,
+	}
+}
+
+type teslaTokenManager struct {
+	dbs      func() *db.ReaderWriter
+	cipher   cip.Cipher
+	teslaAPI TeslaFleetAPIService
+	taskSvc  TeslaTaskService
+	log      *zerolog.Logger
+}
+
+func needsRefresh(udai *models.UserDeviceAPIIntegration, margin time.Duration) bool {
+	return !udai.AccessExpiresAt.Valid || time.Until(udai.AccessExpiresAt.Time) < margin
+}
+
+func (m *teslaTokenManager) GetAccessToken(ctx context.Context, udai *models.UserDeviceAPIIntegration) (string, error) {
+	if needsRefresh(udai, teslaTokenRefreshMargin) {
+		fresh, err := m.refresh(ctx, udai.UserDeviceID, udai.IntegrationID, teslaTokenRefreshMargin)
+		if err != nil {
+			return "", err
+		}
+		udai.AccessToken = fresh.AccessToken
+		udai.RefreshToken = fresh.RefreshToken
+		udai.AccessExpiresAt = fresh.AccessExpiresAt
+		udai.Status = fresh.Status
+		udai.TaskID = fresh.TaskID
+		udai.UpdatedAt = fresh.UpdatedAt
+	}
+
+	return m.cipher.Decrypt(udai.AccessToken.String)
+}
+
+func (m *teslaTokenManager) RefreshExpiring(ctx context.Context, integrationID string, window time.Duration) (int, error) {
+	// Integrations with a running task are left to the task worker, which refreshes on its own.
+	udais, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(integrationID),
+		models.UserDeviceAPIIntegrationWhere.Status.NEQ(models.UserDeviceAPIIntegrationStatusAuthenticationFailure),
+		models.UserDeviceAPIIntegrationWhere.RefreshToken.IsNotNull(),
+		models.UserDeviceAPIIntegrationWhere.TaskID.IsNull(),
+		models.UserDeviceAPIIntegrationWhere.AccessExpiresAt.LT(null.TimeFrom(time.Now().Add(window))),
+	).All(ctx, m.dbs().Reader)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, udai := range udais {
+		if _, err := m.refresh(ctx, udai.UserDeviceID, udai.IntegrationID, window); err != nil {
+			m.log.Err(err).Str("userDeviceId", udai.UserDeviceID).Msg("Failed to refresh Tesla token.")
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+// refresh renews the integration's tokens if they expire within margin. The row is locked for
+// the duration so that concurrent callers don't both spend the same refresh token; Tesla
+// rotates them and only the first exchange succeeds. The call to Tesla is cut off after
+// teslaTokenRefreshTimeout to keep the lock short.
+func (m *teslaTokenManager) refresh(ctx context.Context, userDeviceID, integrationID string, margin time.Duration) (*models.UserDeviceAPIIntegration, error) {
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	udai, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(userDeviceID),
+		models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(integrationID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone else may have refreshed while we were waiting for the lock.
+	if !needsRefresh(udai, margin) {
+		return udai, nil
+	}
+
+	if !udai.RefreshToken.Valid {
+		return nil, ErrNoRefreshToken
+	}
+
+	refreshToken, err := m.cipher.Decrypt(udai.RefreshToken.String)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decrypt refresh token: %w", err)
+	}
+
+	refreshCtx, cancel := context.WithTimeout(ctx, teslaTokenRefreshTimeout)
+	defer cancel()
+
+	tok, err := m.teslaAPI.RefreshToken(refreshCtx, refreshToken)
+	if err != nil {
+		appmetrics.TeslaTokenRefreshCount.WithLabelValues("failed").Inc()
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			// Release the lock so that a rotation written by the task worker can land.
+			_ = tx.Rollback()
+			fresh, ferr := m.handleRejectedRefresh(ctx, userDeviceID, integrationID, udai.RefreshToken.String, margin)
+			if ferr != nil {
+				return nil, ferr
+			}
+			if fresh != nil {
+				return fresh, nil
+			}
+		}
+		return nil, err
+	}
+
+	encAccess, err := m.cipher.Encrypt(tok.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encrypt access token: %w", err)
+	}
+
+	encRefresh, err := m.cipher.Encrypt(tok.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encrypt refresh token: %w", err)
+	}
+
+	udai.AccessToken = null.StringFrom(encAccess)
+	udai.RefreshToken = null.StringFrom(encRefresh)
+	udai.AccessExpiresAt = null.TimeFrom(tok.Expiry)
+
+	cols := models.UserDeviceAPIIntegrationColumns
+	if _, err := udai.Update(ctx, tx, boil.Whitelist(cols.AccessToken, cols.RefreshToken, cols.AccessExpiresAt, cols.UpdatedAt)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	appmetrics.TeslaTokenRefreshCount.WithLabelValues("success").Inc()
+
+	// The task holds its own copy of the old, now useless, refresh token.
+	if udai.TaskID.Valid {
+		if err := m.pushCredentials(ctx, udai); err != nil {
+			m.log.Err(err).Str("userDeviceId", userDeviceID).Msg("Failed to send refreshed credentials to Tesla task.")
+		}
+	}
+
+	return udai, nil
+}
+
+// handleRejectedRefresh is called after Tesla rejects the refresh token we spent. Integrations
+// owned by a task have their tokens rotated by the task worker without taking our row lock, so
+// a rejection may only mean that we lost that race. The integration is marked failed only if
+// the stored refresh token is still the one we spent. If it was rotated and the new access
+// token is good, that row is returned.
+func (m *teslaTokenManager) handleRejectedRefresh(ctx context.Context, userDeviceID, integrationID, spent string, margin time.Duration) (*models.UserDeviceAPIIntegration, error) {
+	tx, err := m.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	udai, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(userDeviceID),
+		models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(integrationID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if udai.RefreshToken.String != spent {
+		m.log.Info().Str("userDeviceId", userDeviceID).Msg("Tesla refresh token rejected, but it has since been rotated; leaving integration alone.")
+		if needsRefresh(udai, margin) {
+			return nil, nil
+		}
+		return udai, nil
+	}
+
+	m.log.Info().Str("userDeviceId", userDeviceID).Msg("Tesla refresh token rejected, marking integration failed.")
+	if err := m.markFailed(ctx, tx, udai); err != nil {
+		return nil, err
+	}
+
+	return nil, tx.Commit()
+}
+
+// markFailed sets the integration to AuthenticationFailure and shuts down any task polling
+// with the revoked credentials. The user has to go through the auth flow again.
+func (m *teslaTokenManager) markFailed(ctx context.Context, exec boil.ContextExecutor, udai *models.UserDeviceAPIIntegration) error {
+	if udai.TaskID.Valid {
+		if err := m.taskSvc.StopPoll(udai); err != nil {
+			m.log.Err(err).Str("userDeviceId", udai.UserDeviceID).Msg("Failed to stop Tesla task with revoked credentials.")
+		}
+		udai.TaskID = null.String{}
+	}
+
+	udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+
+	cols := models.UserDeviceAPIIntegrationColumns
+	_, err := udai.Update(ctx, exec, boil.Whitelist(cols.Status, cols.TaskID, cols.UpdatedAt))
+	return err
+}
+
+func (m *teslaTokenManager) pushCredentials(ctx context.Context, udai *models.UserDeviceAPIIntegration) error {
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.ID.EQ(udai.UserDeviceID),
+		qm.Load(models.UserDeviceRels.VehicleTokenSyntheticDevice),
+	).One(ctx, m.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	if ud.R.VehicleTokenSyntheticDevice == nil {
+		return sql.ErrNoRows
+	}
+
+	return m.taskSvc.UpdateCredentials(udai, ud.R.VehicleTokenSyntheticDevice)
+}
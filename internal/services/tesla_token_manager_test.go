@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	cip "github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type fakeTeslaRefresher struct {
+	TeslaFleetAPIService
+	calls int
+	resp  *TeslaAuthCodeResponse
+	err   error
+}
+
+func (f *fakeTeslaRefresher) RefreshToken(_ context.Context, _ string) (*TeslaAuthCodeResponse, error) {
+	f.calls++
+	return f.resp, f.err
+}
+
+func TestTeslaTokenManager(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	cipher := new(cip.ROT13Cipher)
+	integrationID := ksuid.New().String()
+
+	insert := func(expiry time.Time) *models.UserDeviceAPIIntegration {
+		ud := test.SetupCreateUserDevice(t, "dylan", ksuid.New().String(), nil, "", pdb)
+
+		access, _ := cipher.Encrypt("oldAccess")
+		refresh, _ := cipher.Encrypt("oldRefresh")
+
+		udai := models.UserDeviceAPIIntegration{
+			UserDeviceID:    ud.ID,
+			IntegrationID:   integrationID,
+			Status:          models.UserDeviceAPIIntegrationStatusActive,
+			AccessToken:     null.StringFrom(access),
+			RefreshToken:    null.StringFrom(refresh),
+			AccessExpiresAt: null.TimeFrom(expiry),
+		}
+		require.NoError(t, udai.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return &udai
+	}
+
+	t.Run("token still valid", func(t *testing.T) {
+		api := &fakeTeslaRefresher{}
+		tm := NewTeslaTokenManager(pdb.DBS, cipher, api, nil, test.Logger())
+
+		udai := insert(time.Now().Add(time.Hour))
+
+		tok, err := tm.GetAccessToken(ctx, udai)
+		require.NoError(t, err)
+		assert.Equal(t, "oldAccess", tok)
+		assert.Zero(t, api.calls)
+	})
+
+	t.Run("token expired", func(t *testing.T) {
+		newExpiry := time.Now().Add(8 * time.Hour).Truncate(time.Millisecond)
+		api := &fakeTeslaRefresher{resp: &TeslaAuthCodeResponse{AccessToken: "newAccess", RefreshToken: "newRefresh", Expiry: newExpiry}}
+		tm := NewTeslaTokenManager(pdb.DBS, cipher, api, nil, test.Logger())
+
+		udai := insert(time.Now().Add(-time.Minute))
+
+		tok, err := tm.GetAccessToken(ctx, udai)
+		require.NoError(t, err)
+		assert.Equal(t, "newAccess", tok)
+		assert.Equal(t, 1, api.calls)
+
+		require.NoError(t, udai.Reload(ctx, pdb.DBS().Reader))
+		refresh, err := cipher.Decrypt(udai.RefreshToken.String)
+		require.NoError(t, err)
+		assert.Equal(t, "newRefresh", refresh)
+		assert.WithinDuration(t, newExpiry, udai.AccessExpiresAt.Time, time.Second)
+	})
+
+	t.Run("refresh token revoked", func(t *testing.T) {
+		api := &fakeTeslaRefresher{err: ErrInvalidRefreshToken}
+		tm := NewTeslaTokenManager(pdb.DBS, cipher, api, nil, test.Logger())
+
+		udai := insert(time.Now().Add(-time.Minute))
+
+		_, err := tm.GetAccessToken(ctx, udai)
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		require.NoError(t, udai.Reload(ctx, pdb.DBS().Reader))
+		assert.Equal(t, models.UserDeviceAPIIntegrationStatusAuthenticationFailure, udai.Status)
+	})
+
+	t.Run("refresh token rotated elsewhere", func(t *testing.T) {
+		tm := NewTeslaTokenManager(pdb.DBS, cipher, &fakeTeslaRefresher{}, nil, test.Logger()).(*teslaTokenManager)
+
+		udai := insert(time.Now().Add(time.Hour))
+		spent, _ := cipher.Encrypt("spentRefresh")
+
+		// The task worker rotated the token after we read it, so Tesla's rejection isn't ours.
+		fresh, err := tm.handleRejectedRefresh(ctx, udai.UserDeviceID, udai.IntegrationID, spent, teslaTokenRefreshMargin)
+		require.NoError(t, err)
+		require.NotNil(t, fresh)
+		assert.Equal(t, udai.RefreshToken.String, fresh.RefreshToken.String)
+
+		require.NoError(t, udai.Reload(ctx, pdb.DBS().Reader))
+		assert.Equal(t, models.UserDeviceAPIIntegrationStatusActive, udai.Status)
+	})
+
+	t.Run("sweeper", func(t *testing.T) {
+		test.TruncateTables(pdb.DBS().Writer.DB, t)
+
+		api := &fakeTeslaRefresher{resp: &TeslaAuthCodeResponse{AccessToken: "newAccess", RefreshToken: "newRefresh", Expiry: time.Now().Add(8 * time.Hour)}}
+		tm := NewTeslaTokenManager(pdb.DBS, cipher, api, nil, test.Logger())
+
+		insert(time.Now().Add(10 * time.Minute))
+		insert(time.Now().Add(3 * time.Hour))
+
+		n, err := tm.RefreshExpiring(ctx, integrationID, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, 1, api.calls)
+	})
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Steps in a vehicle handover's audit trail.
+const (
+	HandoverPrivilegesCleared  = "PrivilegesCleared"
+	HandoverIntegrationRevoked = "IntegrationRevoked"
+	HandoverWebhooksDeleted    = "WebhooksDeleted"
+	HandoverDocumentsDetached  = "DocumentsDetached"
+	HandoverErrorCodesHidden   = "ErrorCodesHidden"
+	HandoverOwnerChanged       = "OwnerChanged"
+)
+
+// NewVehicleHandover inserts the record of a vehicle moving from one owner to another. Add the
+// steps taken with RecordHandoverStep.
+func NewVehicleHandover(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, tokenID *big.Int, from, to common.Address, txHash common.Hash) (*models.VehicleHandover, error) {
+	vh := &models.VehicleHandover{
+		ID:              ksuid.New().String(),
+		VehicleTokenID:  utils.BigToDecimal(tokenID),
+		UserDeviceID:    userDeviceID,
+		PreviousOwner:   from.Bytes(),
+		NewOwner:        to.Bytes(),
+		TransactionHash: null.NewBytes(txHash.Bytes(), txHash != common.Hash{}),
+	}
+
+	if err := vh.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return vh, nil
+}
+
+// RecordHandoverStep appends a step to the handover's audit trail. The detail may be empty.
+func RecordHandoverStep(ctx context.Context, exec boil.ContextExecutor, vh *models.VehicleHandover, action, detail string) error {
+	ev := models.VehicleHandoverEvent{
+		ID:                ksuid.New().String(),
+		VehicleHandoverID: vh.ID,
+		Action:            action,
+		Detail:            null.NewString(detail, detail != ""),
+	}
+
+	return ev.Insert(ctx, exec, boil.Infer())
+}
+
+// OwnedSince returns when the vehicle passed to its current owner, or the zero time if it never
+// changed hands. Records from before then belong to a previous owner.
+func OwnedSince(ctx context.Context, exec boil.ContextExecutor, userDeviceID string) (time.Time, error) {
+	vh, err := models.VehicleHandovers(
+		models.VehicleHandoverWhere.UserDeviceID.EQ(userDeviceID),
+		qm.OrderBy(models.VehicleHandoverColumns.CreatedAt+" DESC"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	return vh.CreatedAt, nil
+}
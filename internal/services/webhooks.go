@@ -0,0 +1,493 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/appmetrics"
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/DIMO-Network/shared/pkg/payloads"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// Vehicle lifecycle events that can be subscribed to.
+const (
+	WebhookVehicleMinted           = "zone.dimo.vehicle.minted"
+	WebhookVehicleTransferred      = "zone.dimo.vehicle.transferred"
+	WebhookVehicleBurned           = "zone.dimo.vehicle.burned"
+	WebhookAftermarketPaired       = "zone.dimo.aftermarket.device.paired"
+	WebhookAftermarketUnpaired     = "zone.dimo.aftermarket.device.unpaired"
+	WebhookIntegrationStatusChange = "zone.dimo.integration.status"
+)
+
+// WebhookEventTypes lists every event type a subscription may ask for.
+var WebhookEventTypes = []string{
+	WebhookVehicleMinted,
+	WebhookVehicleTransferred,
+	WebhookVehicleBurned,
+	WebhookAftermarketPaired,
+	WebhookAftermarketUnpaired,
+	WebhookIntegrationStatusChange,
+}
+
+const (
+	// WebhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the request body, keyed with
+	// the subscription secret.
+	WebhookSignatureHeader = "X-DIMO-Signature"
+	// WebhookDeliveryHeader carries the delivery id, which stays the same across retries.
+	WebhookDeliveryHeader = "X-DIMO-Delivery"
+
+	webhookEventSource        = "dimo/devices-api"
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBackoff     = 30 * time.Second
+	maxWebhookBackoff         = 6 * time.Hour
+	webhookBatchSize          = 50
+	// webhookLease is how far a claimed delivery is pushed into the future while it's in flight,
+	// so that a crashed dispatcher doesn't lose it.
+	webhookLease = 2 * time.Minute
+)
+
+// WebhookEvent is a vehicle lifecycle event to fan out to subscribers.
+type WebhookEvent struct {
+	Type           string
+	VehicleTokenID *big.Int
+	// Owners are the addresses whose subscriptions should see the event. For transfers this
+	// is both the old and the new owner. If empty, the current owner of the vehicle is used.
+	Owners []common.Address
+	Data   any
+}
+
+// WebhookVehicleData is the payload of vehicle mint, transfer and burn events.
+type WebhookVehicleData struct {
+	VehicleTokenID *big.Int        `json:"vehicleTokenId"`
+	Owner          common.Address  `json:"owner"`
+	PreviousOwner  *common.Address `json:"previousOwner,omitempty"`
+}
+
+// WebhookPairingData is the payload of aftermarket pair and unpair events.
+type WebhookPairingData struct {
+	VehicleTokenID           *big.Int `json:"vehicleTokenId"`
+	AftermarketDeviceTokenID *big.Int `json:"aftermarketDeviceTokenId"`
+}
+
+// WebhookIntegrationStatusData is the payload of integration status events.
+type WebhookIntegrationStatusData struct {
+	VehicleTokenID *big.Int `json:"vehicleTokenId"`
+	IntegrationID  string   `json:"integrationId"`
+	Status         string   `json:"status"`
+}
+
+// EnqueueWebhookEvent records a pending delivery for every subscription that matches the event.
+// Pass the transaction that records the change itself, so that deliveries only exist if the
+// change was committed.
+func EnqueueWebhookEvent(ctx context.Context, exec boil.ContextExecutor, event *WebhookEvent) error {
+	owners := event.Owners
+	if len(owners) == 0 {
+		ud, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(event.VehicleTokenID)),
+		).One(ctx, exec)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if ud != nil && ud.OwnerAddress.Valid {
+			owners = []common.Address{common.BytesToAddress(ud.OwnerAddress.Bytes)}
+		}
+	}
+
+	if len(owners) == 0 {
+		return nil
+	}
+
+	addrs := make([]any, len(owners))
+	for i, o := range owners {
+		addrs[i] = o.Bytes()
+	}
+
+	// Vehicle subscriptions only see events while their creator owns the vehicle, so that a
+	// seller stops hearing about it after a transfer.
+	subs, err := models.WebhookSubscriptions(
+		qm.Where("? = ANY("+models.WebhookSubscriptionColumns.EventTypes+")", event.Type),
+		qm.Expr(
+			qm.Expr(
+				models.WebhookSubscriptionWhere.VehicleTokenID.EQ(dbtypes.NullIntToDecimal(event.VehicleTokenID)),
+				qm.WhereIn(models.WebhookSubscriptionColumns.DeveloperAddress+" IN ?", addrs...),
+			),
+			qm.Or2(qm.WhereIn(models.WebhookSubscriptionColumns.OwnerAddress+" IN ?", addrs...)),
+		),
+	).All(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	ce := payloads.CloudEvent[any]{
+		ID:          ksuid.New().String(),
+		Source:      webhookEventSource,
+		SpecVersion: "1.0",
+		Subject:     event.VehicleTokenID.String(),
+		Time:        time.Now(),
+		Type:        event.Type,
+		Data:        event.Data,
+	}
+
+	payload, err := json.Marshal(ce)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		d := models.WebhookDelivery{
+			ID:             ksuid.New().String(),
+			SubscriptionID: sub.ID,
+			EventID:        ce.ID,
+			EventType:      ce.Type,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryStatusPending,
+			NextAttemptAt:  ce.Time,
+		}
+		if err := d.Insert(ctx, exec, boil.Infer()); err != nil {
+			return fmt.Errorf("failed to record delivery for subscription %s: %w", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the hex-encoded HMAC-SHA256 of the body.
+func SignWebhookPayload(secret string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ErrWebhookHostNotAllowed is returned for webhook URLs that point at loopback, private,
+// link-local or cloud metadata addresses.
+var ErrWebhookHostNotAllowed = errors.New("webhook host is not publicly routable")
+
+// sharedAddressSpace is the carrier-grade NAT range, which net.IP.IsPrivate doesn't cover.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// CheckWebhookHost rejects hostnames and IP literals that would let a subscriber point us at
+// our own network. Names are checked again against the address actually dialed when
+// delivering, since DNS can change after the subscription is created.
+func CheckWebhookHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return ErrWebhookHostNotAllowed
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return checkWebhookIP(ip)
+	}
+
+	return nil
+}
+
+func checkWebhookIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return ErrWebhookHostNotAllowed
+	}
+	return nil
+}
+
+// newWebhookClient returns a client that refuses to connect to addresses rejected by
+// checkWebhookIP. The check runs on the resolved address at dial time, so it also covers
+// redirects and DNS rebinding.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("couldn't parse dialed address %q", address)
+			}
+			return checkWebhookIP(ip)
+		},
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// No proxy: we'd be checking the proxy's address instead of the subscriber's.
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+	}
+}
+
+// WebhookDispatcher sends pending webhook deliveries to subscribers.
+type WebhookDispatcher struct {
+	dbs         func() *db.ReaderWriter
+	cipher      cipher.Cipher
+	client      *http.Client
+	log         *zerolog.Logger
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewWebhookDispatcher parses the webhook retry settings.
+func NewWebhookDispatcher(dbs func() *db.ReaderWriter, cipher cipher.Cipher, settings *config.Settings, logger *zerolog.Logger) (*WebhookDispatcher, error) {
+	d := &WebhookDispatcher{
+		dbs:         dbs,
+		cipher:      cipher,
+		client:      newWebhookClient(),
+		log:         logger,
+		maxAttempts: defaultWebhookMaxAttempts,
+		backoff:     defaultWebhookBackoff,
+	}
+
+	if settings.WebhookMaxAttempts > 0 {
+		d.maxAttempts = settings.WebhookMaxAttempts
+	}
+
+	if settings.WebhookRetryBackoff != "" {
+		b, err := time.ParseDuration(settings.WebhookRetryBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse webhook retry backoff: %w", err)
+		}
+		d.backoff = b
+	}
+
+	return d, nil
+}
+
+// retryDelay is the wait before the attempt following the given number of failures.
+func (d *WebhookDispatcher) retryDelay(failures int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < failures && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookBackoff)
+}
+
+// DeliverDue sends a batch of deliveries that are due and returns how many were attempted.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dl := range deliveries {
+		if err := d.deliver(ctx, dl); err != nil {
+			d.log.Err(err).Str("deliveryId", dl.ID).Msg("Failed to record webhook delivery attempt.")
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// claim locks a batch of due deliveries and pushes them out by the lease so that other
+// dispatchers skip them while the requests are in flight.
+func (d *WebhookDispatcher) claim(ctx context.Context) (models.WebhookDeliverySlice, error) {
+	tx, err := d.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	now := time.Now()
+
+	deliveries, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.Status.EQ(models.WebhookDeliveryStatusPending),
+		models.WebhookDeliveryWhere.NextAttemptAt.LTE(now),
+		qm.Load(models.WebhookDeliveryRels.Subscription),
+		qm.OrderBy(models.WebhookDeliveryColumns.NextAttemptAt),
+		qm.Limit(webhookBatchSize),
+		qm.For("UPDATE SKIP LOCKED"),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	if _, err := deliveries.UpdateAll(ctx, tx, models.M{
+		models.WebhookDeliveryColumns.NextAttemptAt: now.Add(webhookLease),
+	}); err != nil {
+		return nil, err
+	}
+
+	return deliveries, tx.Commit()
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, dl *models.WebhookDelivery) error {
+	sub := dl.R.Subscription
+
+	code, sendErr := d.send(ctx, sub, dl)
+
+	dl.Attempts++
+	if code != 0 {
+		dl.LastResponseCode = null.IntFrom(code)
+	}
+
+	cols := []string{
+		models.WebhookDeliveryColumns.Attempts,
+		models.WebhookDeliveryColumns.LastResponseCode,
+		models.WebhookDeliveryColumns.LastError,
+		models.WebhookDeliveryColumns.Status,
+		models.WebhookDeliveryColumns.NextAttemptAt,
+		models.WebhookDeliveryColumns.UpdatedAt,
+	}
+
+	if sendErr == nil {
+		appmetrics.WebhookDeliveryCount.WithLabelValues(dl.EventType, "delivered").Inc()
+		dl.Status = models.WebhookDeliveryStatusDelivered
+		dl.DeliveredAt = null.TimeFrom(time.Now())
+		dl.LastError = null.String{}
+		_, err := dl.Update(ctx, d.dbs().Writer, boil.Whitelist(append(cols, models.WebhookDeliveryColumns.DeliveredAt)...))
+		return err
+	}
+
+	dl.LastError = null.StringFrom(sendErr.Error())
+
+	if dl.Attempts < d.maxAttempts {
+		appmetrics.WebhookDeliveryCount.WithLabelValues(dl.EventType, "retry").Inc()
+		dl.NextAttemptAt = time.Now().Add(d.retryDelay(dl.Attempts))
+		_, err := dl.Update(ctx, d.dbs().Writer, boil.Whitelist(cols...))
+		return err
+	}
+
+	appmetrics.WebhookDeliveryCount.WithLabelValues(dl.EventType, "dead_lettered").Inc()
+	d.log.Warn().Str("deliveryId", dl.ID).Str("subscriptionId", sub.ID).Int("attempts", dl.Attempts).Msg("Giving up on webhook delivery.")
+
+	tx, err := d.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	dl.Status = models.WebhookDeliveryStatusDeadLettered
+	if _, err := dl.Update(ctx, tx, boil.Whitelist(cols...)); err != nil {
+		return err
+	}
+
+	dead := models.WebhookDeadLetter{
+		DeliveryID:     dl.ID,
+		SubscriptionID: dl.SubscriptionID,
+		Attempts:       dl.Attempts,
+		LastError:      dl.LastError,
+	}
+	if err := dead.Upsert(ctx, tx, true, []string{models.WebhookDeadLetterColumns.DeliveryID}, boil.Infer(), boil.Infer()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// send POSTs the event and returns the response code, if there was one.
+func (d *WebhookDispatcher) send(ctx context.Context, sub *models.WebhookSubscription, dl *models.WebhookDelivery) (int, error) {
+	secret, err := d.cipher.Decrypt(sub.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't decrypt subscription secret: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, dl.Payload))
+	req.Header.Set(WebhookDeliveryHeader, dl.ID)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() //nolint
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// ErrWebhookDeliveryNotFound is returned when replaying a delivery that doesn't belong to the
+// subscription.
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+// ReplayWebhookDelivery puts a delivery back in the queue with a fresh set of attempts, removing
+// it from the dead letters if it was there.
+func ReplayWebhookDelivery(ctx context.Context, dbs func() *db.ReaderWriter, subscriptionID, deliveryID string) (*models.WebhookDelivery, error) {
+	tx, err := dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	dl, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.ID.EQ(deliveryID),
+		models.WebhookDeliveryWhere.SubscriptionID.EQ(subscriptionID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	dl.Status = models.WebhookDeliveryStatusPending
+	dl.Attempts = 0
+	dl.NextAttemptAt = time.Now()
+	dl.DeliveredAt = null.Time{}
+
+	if _, err := dl.Update(ctx, tx, boil.Whitelist(
+		models.WebhookDeliveryColumns.Status,
+		models.WebhookDeliveryColumns.Attempts,
+		models.WebhookDeliveryColumns.NextAttemptAt,
+		models.WebhookDeliveryColumns.DeliveredAt,
+		models.WebhookDeliveryColumns.UpdatedAt,
+	)); err != nil {
+		return nil, err
+	}
+
+	if _, err := models.WebhookDeadLetters(models.WebhookDeadLetterWhere.DeliveryID.EQ(dl.ID)).DeleteAll(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return dl, tx.Commit()
+}
+
+// webhookTokenID converts a nullable token id column for use in webhook payloads.
+func webhookTokenID(d types.NullDecimal) *big.Int {
+	if d.IsZero() {
+		return nil
+	}
+	return d.Int(nil)
+}
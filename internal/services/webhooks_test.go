@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	cip "github.com/DIMO-Network/shared/pkg/cipher"
+	"github.com/DIMO-Network/shared/pkg/dbtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	d, err := NewWebhookDispatcher(nil, nil, &config.Settings{WebhookRetryBackoff: "10s"}, test.Logger())
+	require.NoError(t, err)
+
+	assert.Equal(t, 10*time.Second, d.retryDelay(1))
+	assert.Equal(t, 20*time.Second, d.retryDelay(2))
+	assert.Equal(t, 80*time.Second, d.retryDelay(4))
+	assert.Equal(t, maxWebhookBackoff, d.retryDelay(30))
+	assert.Equal(t, defaultWebhookMaxAttempts, d.maxAttempts)
+
+	_, err = NewWebhookDispatcher(nil, nil, &config.Settings{WebhookRetryBackoff: "later"}, test.Logger())
+	assert.Error(t, err)
+}
+
+func TestCheckWebhookHost(t *testing.T) {
+	for _, host := range []string{"localhost", "api.localhost", "metadata.google.internal", "127.0.0.1", "10.1.2.3", "192.168.0.1", "169.254.169.254", "100.64.0.1", "::1", "fd00:ec2::254", "0.0.0.0"} {
+		assert.ErrorIs(t, CheckWebhookHost(host), ErrWebhookHostNotAllowed, host)
+	}
+	for _, host := range []string{"example.com", "8.8.8.8", "2606:4700:4700::1111"} {
+		assert.NoError(t, CheckWebhookHost(host), host)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	cipher := new(cip.ROT13Cipher)
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tokenID := big.NewInt(7)
+
+	var (
+		respondWith = http.StatusOK
+		bodies      [][]byte
+		signatures  []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, b)
+		signatures = append(signatures, r.Header.Get(WebhookSignatureHeader))
+		w.WriteHeader(respondWith)
+	}))
+	defer srv.Close()
+
+	seller := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	subscribe := func(eventTypes []string, vehicle *big.Int, ownerAddr *common.Address, developer common.Address) *models.WebhookSubscription {
+		secret, _ := cipher.Encrypt("shh")
+		sub := &models.WebhookSubscription{
+			ID:               ksuid.New().String(),
+			DeveloperAddress: developer.Bytes(),
+			URL:              srv.URL,
+			Secret:           secret,
+			EventTypes:       types.StringArray(eventTypes),
+		}
+		if vehicle != nil {
+			sub.VehicleTokenID = dbtypes.NullIntToDecimal(vehicle)
+		}
+		if ownerAddr != nil {
+			sub.OwnerAddress = null.BytesFrom(ownerAddr.Bytes())
+		}
+		require.NoError(t, sub.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return sub
+	}
+
+	byVehicle := subscribe([]string{WebhookVehicleTransferred}, tokenID, nil, owner)
+	byOwner := subscribe([]string{WebhookVehicleTransferred, WebhookVehicleMinted}, nil, &owner, seller)
+	otherVehicle := subscribe([]string{WebhookVehicleTransferred}, big.NewInt(8), nil, owner)
+	otherType := subscribe([]string{WebhookVehicleBurned}, tokenID, nil, owner)
+	// Created by a previous owner of the vehicle.
+	bySeller := subscribe([]string{WebhookVehicleTransferred}, tokenID, nil, seller)
+
+	err := EnqueueWebhookEvent(ctx, pdb.DBS().Writer, &WebhookEvent{
+		Type:           WebhookVehicleTransferred,
+		VehicleTokenID: tokenID,
+		Owners:         []common.Address{owner},
+		Data:           WebhookVehicleData{VehicleTokenID: tokenID, Owner: owner},
+	})
+	require.NoError(t, err)
+
+	for sub, count := range map[*models.WebhookSubscription]int64{byVehicle: 1, byOwner: 1, otherVehicle: 0, otherType: 0, bySeller: 0} {
+		n, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.SubscriptionID.EQ(sub.ID)).Count(ctx, pdb.DBS().Reader)
+		require.NoError(t, err)
+		assert.Equal(t, count, n)
+	}
+
+	d, err := NewWebhookDispatcher(pdb.DBS, cipher, &config.Settings{WebhookMaxAttempts: 2, WebhookRetryBackoff: "1ms"}, test.Logger())
+	require.NoError(t, err)
+
+	// The real client refuses to dial the loopback test server.
+	_, err = d.client.Post(srv.URL, "text/plain", nil)
+	assert.ErrorIs(t, err, ErrWebhookHostNotAllowed)
+	d.client = srv.Client()
+
+	n, err := d.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, SignWebhookPayload("shh", bodies[0]), signatures[0])
+
+	delivered, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.Status.EQ(models.WebhookDeliveryStatusDelivered)).Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, delivered)
+
+	// Now fail until the delivery is dead-lettered.
+	respondWith = http.StatusInternalServerError
+	require.NoError(t, EnqueueWebhookEvent(ctx, pdb.DBS().Writer, &WebhookEvent{
+		Type:           WebhookVehicleMinted,
+		VehicleTokenID: tokenID,
+		Owners:         []common.Address{owner},
+	}))
+
+	for range 2 {
+		time.Sleep(5 * time.Millisecond)
+		_, err := d.DeliverDue(ctx)
+		require.NoError(t, err)
+	}
+
+	dl, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.EventType.EQ(WebhookVehicleMinted)).One(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryStatusDeadLettered, dl.Status)
+	assert.Equal(t, 2, dl.Attempts)
+	assert.Equal(t, 500, dl.LastResponseCode.Int)
+
+	exists, err := models.WebhookDeadLetterExists(ctx, pdb.DBS().Reader, dl.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Replaying puts it back in the queue.
+	_, err = ReplayWebhookDelivery(ctx, pdb.DBS, otherVehicle.ID, dl.ID)
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+
+	respondWith = http.StatusNoContent
+	_, err = ReplayWebhookDelivery(ctx, pdb.DBS, byOwner.ID, dl.ID)
+	require.NoError(t, err)
+
+	exists, err = models.WebhookDeadLetterExists(ctx, pdb.DBS().Reader, dl.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	n, err = d.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	require.NoError(t, dl.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, models.WebhookDeliveryStatusDelivered, dl.Status)
+}
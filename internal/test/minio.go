@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	minioUser     = "minioadmin"
+	minioPassword = "minioadmin"
+)
+
+// StartContainerMinIO starts a MinIO container to stand in for S3 and creates the given bucket.
+// Caller must terminate container.
+func StartContainerMinIO(ctx context.Context, t *testing.T, bucket string) (*s3.Client, testcontainers.Container) {
+	port := nat.Port("9000/tcp")
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "minio/minio:RELEASE.2024-08-17T01-24-54Z",
+			Env:          map[string]string{"MINIO_ROOT_USER": minioUser, "MINIO_ROOT_PASSWORD": minioPassword},
+			ExposedPorts: []string{string(port)},
+			Cmd:          []string{"server", "/data"},
+			WaitingFor:   wait.ForHTTP("/minio/health/live").WithPort(port),
+		},
+		Started: true,
+	})
+	if err != nil {
+		if container != nil {
+			container.Terminate(ctx) //nolint
+		}
+		t.Fatal(err)
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, port, "http")
+	if err != nil {
+		container.Terminate(ctx) //nolint
+		t.Fatal(fmt.Errorf("failed to get MinIO endpoint: %w", err))
+	}
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(endpoint),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider(minioUser, minioPassword, ""),
+	})
+
+	if _, err := client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		container.Terminate(ctx) //nolint
+		t.Fatal(fmt.Errorf("failed to create bucket: %w", err))
+	}
+
+	return client, container
+}
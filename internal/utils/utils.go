@@ -2,9 +2,12 @@
 package utils
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
@@ -45,3 +48,32 @@ func nameToConnectionID(name string) *big.Int {
 
 	return new(big.Int).SetBytes(paddedBytes)
 }
+
+const sigLen = 65
+
+// Ecrecover mimics the ecrecover opcode, returning the address that signed
+// hash with signature. sig must have length 65 and the last byte, the recovery
+// byte usually denoted v, must be 27 or 28.
+func Ecrecover(hash, sig []byte) (common.Address, error) {
+	if len(sig) != sigLen {
+		return common.Address{}, fmt.Errorf("signature has invalid length %d", len(sig))
+	}
+
+	// Defensive copy: the caller shouldn't have to worry about us modifying
+	// the signature. We adjust because crypto.Ecrecover demands 0 <= v <= 4.
+	fixedSig := make([]byte, sigLen)
+	copy(fixedSig, sig)
+	fixedSig[64] -= 27
+
+	rawPk, err := crypto.Ecrecover(hash, fixedSig)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	pk, err := crypto.UnmarshalPubkey(rawPk)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pk), nil
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+ALTER TYPE device_command_request_status ADD VALUE 'TimedOut';
+ALTER TABLE device_command_requests ADD COLUMN expires_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE device_command_requests DROP COLUMN expires_at;
+-- No easy way to subtract from an enum.
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+CREATE TABLE webhook_subscriptions (
+    id char(27) PRIMARY KEY,
+    developer_address bytea NOT NULL
+        CONSTRAINT webhook_subscriptions_developer_address_check CHECK (length(developer_address) = 20),
+    url text NOT NULL,
+    -- Encrypted with the service cipher. Used to sign deliveries.
+    secret text NOT NULL,
+    event_types text[] NOT NULL,
+    vehicle_token_id numeric(78, 0),
+    owner_address bytea
+        CONSTRAINT webhook_subscriptions_owner_address_check CHECK (length(owner_address) = 20),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    -- A subscription targets exactly one of a vehicle or an owner.
+    CONSTRAINT webhook_subscriptions_target_check CHECK ((vehicle_token_id IS NULL) <> (owner_address IS NULL))
+);
+
+CREATE INDEX webhook_subscriptions_vehicle_token_id_idx ON webhook_subscriptions (vehicle_token_id);
+CREATE INDEX webhook_subscriptions_owner_address_idx ON webhook_subscriptions (owner_address);
+CREATE INDEX webhook_subscriptions_developer_address_idx ON webhook_subscriptions (developer_address);
+
+CREATE TYPE webhook_delivery_status AS ENUM ('Pending', 'Delivered', 'DeadLettered');
+
+CREATE TABLE webhook_deliveries (
+    id char(27) PRIMARY KEY,
+    subscription_id char(27) NOT NULL
+        CONSTRAINT webhook_deliveries_subscription_id_fkey REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id varchar NOT NULL,
+    event_type text NOT NULL,
+    payload jsonb NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    last_error text,
+    last_response_code integer,
+    delivered_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'Pending';
+
+CREATE TABLE webhook_dead_letters (
+    delivery_id char(27) PRIMARY KEY
+        CONSTRAINT webhook_dead_letters_delivery_id_fkey REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    subscription_id char(27) NOT NULL
+        CONSTRAINT webhook_dead_letters_subscription_id_fkey REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    attempts integer NOT NULL,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE webhook_dead_letters;
+DROP TABLE webhook_deliveries;
+DROP TYPE webhook_delivery_status;
+DROP TABLE webhook_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+CREATE TABLE documents (
+    -- The KSUID part of the document ID. The ID shown to clients may be prefixed with the user
+    -- device ID.
+    id char(27) PRIMARY KEY,
+    user_id text NOT NULL,
+    user_device_id char(27)
+        CONSTRAINT documents_user_device_id_fkey REFERENCES user_devices (id) ON DELETE SET NULL,
+    type text NOT NULL,
+    name text NOT NULL,
+    file_name text NOT NULL,
+    file_ext text NOT NULL,
+    content_type text NOT NULL,
+    file_size bigint NOT NULL,
+    s3_key text NOT NULL,
+    tags text[] NOT NULL DEFAULT '{}',
+    expires_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX documents_user_id_created_at_idx ON documents (user_id, created_at DESC, id DESC);
+CREATE INDEX documents_user_id_expires_at_idx ON documents (user_id, expires_at) WHERE expires_at IS NOT NULL;
+-- The vehicle's token id is read through user_devices, so that it follows mints, transfers and
+-- burns.
+CREATE INDEX documents_user_device_id_idx ON documents (user_device_id);
+CREATE INDEX documents_tags_idx ON documents USING gin (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE documents;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+CREATE TYPE document_status AS ENUM ('Pending', 'Ready');
+
+-- Documents uploaded with a presigned URL stay Pending until the client finalizes them.
+ALTER TABLE documents ADD COLUMN status document_status NOT NULL DEFAULT 'Ready';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE documents DROP COLUMN status;
+DROP TYPE document_status;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- Changes to vehicle attributes and device definitions that we picked up from the registry.
+-- The history outlives the vehicle, so user_device_id has no foreign key.
+CREATE TABLE vehicle_attribute_changes (
+    id char(27) PRIMARY KEY,
+    user_device_id char(27) NOT NULL,
+    vehicle_token_id numeric(78, 0) NOT NULL,
+    attribute text NOT NULL,
+    previous_value text,
+    value text NOT NULL,
+    transaction_hash bytea
+        CONSTRAINT vehicle_attribute_changes_transaction_hash_check CHECK (length(transaction_hash) = 32),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX vehicle_attribute_changes_vehicle_token_id_created_at_idx ON vehicle_attribute_changes (vehicle_token_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE vehicle_attribute_changes;
+-- +goose StatementEnd
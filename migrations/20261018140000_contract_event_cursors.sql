@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- The last contract event we processed, per contract.
+CREATE TABLE contract_event_cursors (
+    chain_id bigint NOT NULL,
+    contract bytea NOT NULL
+        CONSTRAINT contract_event_cursors_contract_check CHECK (length(contract) = 20),
+    block_number bigint NOT NULL,
+    transaction_hash bytea NOT NULL
+        CONSTRAINT contract_event_cursors_transaction_hash_check CHECK (length(transaction_hash) = 32),
+    log_index integer NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (chain_id, contract)
+);
+
+-- Contract events that have been handled, so that redeliveries can be skipped.
+CREATE TABLE processed_contract_events (
+    chain_id bigint NOT NULL,
+    transaction_hash bytea NOT NULL
+        CONSTRAINT processed_contract_events_transaction_hash_check CHECK (length(transaction_hash) = 32),
+    log_index integer NOT NULL,
+    contract bytea NOT NULL,
+    event_name text NOT NULL,
+    block_number bigint,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (chain_id, transaction_hash, log_index)
+);
+
+CREATE INDEX processed_contract_events_created_at_idx ON processed_contract_events (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE processed_contract_events;
+DROP TABLE contract_event_cursors;
+-- +goose StatementEnd
@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+CREATE TYPE meta_transaction_request_operation AS ENUM ('Mint', 'Burn', 'Claim', 'Pair', 'Unpair', 'SyntheticMint', 'SyntheticBurn');
+
+-- What the request does and whom it's for, so that requests can be listed without going through
+-- every table that points at them. The vehicle may be deleted after a burn, so there is no key.
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN operation meta_transaction_request_operation,
+    ADD COLUMN user_device_id char(27),
+    ADD COLUMN owner_address bytea
+        CONSTRAINT meta_transaction_requests_owner_address_check CHECK (length(owner_address) = 20);
+
+CREATE INDEX meta_transaction_requests_user_device_id_idx ON meta_transaction_requests (user_device_id, created_at DESC);
+CREATE INDEX meta_transaction_requests_owner_address_idx ON meta_transaction_requests (owner_address, created_at DESC);
+
+UPDATE meta_transaction_requests m
+SET operation = 'SyntheticMint', user_device_id = ud.id, owner_address = ud.owner_address
+FROM synthetic_devices sd
+JOIN user_devices ud ON ud.token_id = sd.vehicle_token_id
+WHERE sd.mint_request_id = m.id;
+
+UPDATE meta_transaction_requests m
+SET operation = 'SyntheticBurn', user_device_id = ud.id, owner_address = ud.owner_address
+FROM synthetic_devices sd
+JOIN user_devices ud ON ud.token_id = sd.vehicle_token_id
+WHERE sd.burn_request_id = m.id;
+
+-- Vehicle mints can carry a synthetic device mint in the same transaction.
+UPDATE meta_transaction_requests m
+SET operation = 'Mint', user_device_id = ud.id, owner_address = ud.owner_address
+FROM user_devices ud
+WHERE ud.mint_request_id = m.id;
+
+UPDATE meta_transaction_requests m
+SET operation = 'Burn', user_device_id = ud.id, owner_address = ud.owner_address
+FROM user_devices ud
+WHERE ud.burn_request_id = m.id;
+
+UPDATE meta_transaction_requests m
+SET operation = 'Claim', owner_address = ad.owner_address
+FROM aftermarket_devices ad
+WHERE ad.claim_meta_transaction_request_id = m.id;
+
+UPDATE meta_transaction_requests m
+SET operation = 'Pair', owner_address = ad.owner_address
+FROM aftermarket_devices ad
+WHERE ad.pair_request_id = m.id;
+
+UPDATE meta_transaction_requests m
+SET operation = 'Unpair', owner_address = ad.owner_address
+FROM aftermarket_devices ad
+WHERE ad.unpair_request_id = m.id;
+
+-- Every status a request has been in. Requests from before this table only have their current one.
+CREATE TABLE meta_transaction_request_status_changes (
+    id char(27) PRIMARY KEY,
+    meta_transaction_request_id char(27) NOT NULL
+        CONSTRAINT meta_transaction_request_status_changes_request_id_fkey REFERENCES meta_transaction_requests (id) ON DELETE CASCADE,
+    status meta_transaction_request_status NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX meta_transaction_request_status_changes_request_id_idx ON meta_transaction_request_status_changes (meta_transaction_request_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE meta_transaction_request_status_changes;
+
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN operation,
+    DROP COLUMN user_device_id,
+    DROP COLUMN owner_address;
+
+DROP TYPE meta_transaction_request_operation;
+-- +goose StatementEnd
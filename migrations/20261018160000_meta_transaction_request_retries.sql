@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- The signed call we sent, so that a request that failed for reasons unrelated to its contents
+-- can be sent again. A retry is a new request pointing back at the one it replaces.
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN to_address bytea
+        CONSTRAINT meta_transaction_requests_to_address_check CHECK (length(to_address) = 20),
+    ADD COLUMN data bytea,
+    ADD COLUMN attempt int NOT NULL DEFAULT 1,
+    ADD COLUMN retry_of char(27)
+        CONSTRAINT meta_transaction_requests_retry_of_fkey REFERENCES meta_transaction_requests (id) ON DELETE SET NULL,
+    ADD COLUMN failure_retryable boolean;
+
+CREATE INDEX meta_transaction_requests_retry_of_idx ON meta_transaction_requests (retry_of);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE meta_transaction_requests
+    DROP COLUMN to_address,
+    DROP COLUMN data,
+    DROP COLUMN attempt,
+    DROP COLUMN retry_of,
+    DROP COLUMN failure_retryable;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- Vehicles registered and minted together for one owner. Each vehicle gets its own mint
+-- request, which carries the batch id and the vehicle's status.
+CREATE TABLE mint_batches (
+    id char(27) PRIMARY KEY,
+    owner_address bytea NOT NULL
+        CONSTRAINT mint_batches_owner_address_check CHECK (length(owner_address) = 20),
+    user_id text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX mint_batches_owner_address_idx ON mint_batches (owner_address, created_at DESC);
+
+ALTER TABLE meta_transaction_requests
+    ADD COLUMN mint_batch_id char(27)
+        CONSTRAINT meta_transaction_requests_mint_batch_id_fkey REFERENCES mint_batches (id) ON DELETE SET NULL;
+
+CREATE INDEX meta_transaction_requests_mint_batch_id_idx ON meta_transaction_requests (mint_batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE meta_transaction_requests DROP COLUMN mint_batch_id;
+DROP TABLE mint_batches;
+-- +goose StatementEnd
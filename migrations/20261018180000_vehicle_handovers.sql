@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- A vehicle NFT changing owners, and what we did to separate the previous owner's data from the
+-- new owner. There is no key to user_devices so that the record outlives a later burn.
+CREATE TABLE vehicle_handovers (
+    id char(27) PRIMARY KEY,
+    vehicle_token_id numeric(78, 0) NOT NULL,
+    user_device_id char(27) NOT NULL,
+    previous_owner bytea NOT NULL
+        CONSTRAINT vehicle_handovers_previous_owner_check CHECK (length(previous_owner) = 20),
+    new_owner bytea NOT NULL
+        CONSTRAINT vehicle_handovers_new_owner_check CHECK (length(new_owner) = 20),
+    transaction_hash bytea
+        CONSTRAINT vehicle_handovers_transaction_hash_check CHECK (length(transaction_hash) = 32),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX vehicle_handovers_previous_owner_idx ON vehicle_handovers (previous_owner, created_at DESC);
+CREATE INDEX vehicle_handovers_new_owner_idx ON vehicle_handovers (new_owner, created_at DESC);
+CREATE INDEX vehicle_handovers_vehicle_token_id_idx ON vehicle_handovers (vehicle_token_id, created_at DESC);
+
+-- The audit trail of a handover, one row per step.
+CREATE TABLE vehicle_handover_events (
+    id char(27) PRIMARY KEY,
+    vehicle_handover_id char(27) NOT NULL
+        CONSTRAINT vehicle_handover_events_vehicle_handover_id_fkey REFERENCES vehicle_handovers (id) ON DELETE CASCADE,
+    action text NOT NULL,
+    detail text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX vehicle_handover_events_vehicle_handover_id_idx ON vehicle_handover_events (vehicle_handover_id, created_at);
+
+-- Error code queries made by a previous owner are kept, but not shown to later owners.
+ALTER TABLE error_code_queries ADD COLUMN hidden_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE error_code_queries DROP COLUMN hidden_at;
+DROP TABLE vehicle_handover_events;
+DROP TABLE vehicle_handovers;
+-- +goose StatementEnd
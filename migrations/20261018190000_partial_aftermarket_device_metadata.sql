@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- Attributes other than the serial can be set before the serial is, so hold on to them until
+-- the device moves to aftermarket_devices.
+ALTER TABLE partial_aftermarket_devices ADD COLUMN metadata jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+ALTER TABLE partial_aftermarket_devices DROP COLUMN metadata;
+-- +goose StatementEnd
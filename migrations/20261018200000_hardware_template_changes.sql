@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+-- Every hardware template applied to an aftermarket device, so that support can tell how a
+-- device ended up on its template. There are no keys to the device or the vehicle so that
+-- the history outlives both.
+CREATE TABLE hardware_template_changes (
+    id char(27) PRIMARY KEY,
+    serial text NOT NULL,
+    user_device_id char(27),
+    old_template_id text,
+    new_template_id text NOT NULL,
+    reason text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX hardware_template_changes_serial_idx ON hardware_template_changes (serial, created_at DESC);
+CREATE INDEX hardware_template_changes_user_device_id_idx ON hardware_template_changes (user_device_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE hardware_template_changes;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = devices_api, public;
+
+CREATE TYPE template_migration_status AS ENUM ('Running', 'Paused', 'Completed');
+
+-- A bulk move of AutoPi devices onto a new hardware template. The devices are fixed when the
+-- migration is created and worked through in batches.
+CREATE TABLE template_migrations (
+    id char(27) PRIMARY KEY,
+    -- The template the devices were listed from. Null when the devices were given directly.
+    source_template_id integer,
+    target_template_id integer NOT NULL,
+    status template_migration_status NOT NULL DEFAULT 'Running',
+    -- Set while a worker is processing a batch, so that other workers leave the migration alone.
+    lease_expires_at timestamptz,
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX template_migrations_running_idx ON template_migrations (created_at) WHERE status = 'Running';
+
+CREATE TYPE template_migration_device_status AS ENUM ('Pending', 'Succeeded', 'Failed');
+
+CREATE TABLE template_migration_devices (
+    id char(27) PRIMARY KEY,
+    template_migration_id char(27) NOT NULL
+        CONSTRAINT template_migration_devices_template_migration_id_fkey REFERENCES template_migrations (id) ON DELETE CASCADE,
+    serial text NOT NULL,
+    status template_migration_device_status NOT NULL DEFAULT 'Pending',
+    error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT template_migration_devices_template_migration_id_serial_key UNIQUE (template_migration_id, serial)
+);
+
+CREATE INDEX template_migration_devices_pending_idx ON template_migration_devices (template_migration_id, id) WHERE status = 'Pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = devices_api, public;
+DROP TABLE template_migration_devices;
+DROP TYPE template_migration_device_status;
+DROP TABLE template_migrations;
+DROP TYPE template_migration_status;
+-- +goose StatementEnd
@@ -4,25 +4,53 @@
 package models
 
 var TableNames = struct {
-	AftermarketDevices        string
-	AutopiJobs                string
-	DeviceCommandRequests     string
-	ErrorCodeQueries          string
-	MetaTransactionRequests   string
-	NFTPrivileges             string
-	PartialAftermarketDevices string
-	SyntheticDevices          string
-	UserDeviceAPIIntegrations string
-	UserDevices               string
+	AftermarketDevices                  string
+	AutopiJobs                          string
+	ContractEventCursors                string
+	DeviceCommandRequests               string
+	Documents                           string
+	ErrorCodeQueries                    string
+	HardwareTemplateChanges             string
+	MetaTransactionRequestStatusChanges string
+	MetaTransactionRequests             string
+	MintBatches                         string
+	NFTPrivileges                       string
+	PartialAftermarketDevices           string
+	ProcessedContractEvents             string
+	SyntheticDevices                    string
+	TemplateMigrationDevices            string
+	TemplateMigrations                  string
+	UserDeviceAPIIntegrations           string
+	UserDevices                         string
+	VehicleAttributeChanges             string
+	VehicleHandoverEvents               string
+	VehicleHandovers                    string
+	WebhookDeadLetters                  string
+	WebhookDeliveries                   string
+	WebhookSubscriptions                string
 }{
-	AftermarketDevices:        "aftermarket_devices",
-	AutopiJobs:                "autopi_jobs",
-	DeviceCommandRequests:     "device_command_requests",
-	ErrorCodeQueries:          "error_code_queries",
-	MetaTransactionRequests:   "meta_transaction_requests",
-	NFTPrivileges:             "nft_privileges",
-	PartialAftermarketDevices: "partial_aftermarket_devices",
-	SyntheticDevices:          "synthetic_devices",
-	UserDeviceAPIIntegrations: "user_device_api_integrations",
-	UserDevices:               "user_devices",
+	AftermarketDevices:                  "aftermarket_devices",
+	AutopiJobs:                          "autopi_jobs",
+	ContractEventCursors:                "contract_event_cursors",
+	DeviceCommandRequests:               "device_command_requests",
+	Documents:                           "documents",
+	ErrorCodeQueries:                    "error_code_queries",
+	HardwareTemplateChanges:             "hardware_template_changes",
+	MetaTransactionRequestStatusChanges: "meta_transaction_request_status_changes",
+	MetaTransactionRequests:             "meta_transaction_requests",
+	MintBatches:                         "mint_batches",
+	NFTPrivileges:                       "nft_privileges",
+	PartialAftermarketDevices:           "partial_aftermarket_devices",
+	ProcessedContractEvents:             "processed_contract_events",
+	SyntheticDevices:                    "synthetic_devices",
+	TemplateMigrationDevices:            "template_migration_devices",
+	TemplateMigrations:                  "template_migrations",
+	UserDeviceAPIIntegrations:           "user_device_api_integrations",
+	UserDevices:                         "user_devices",
+	VehicleAttributeChanges:             "vehicle_attribute_changes",
+	VehicleHandoverEvents:               "vehicle_handover_events",
+	VehicleHandovers:                    "vehicle_handovers",
+	WebhookDeadLetters:                  "webhook_dead_letters",
+	WebhookDeliveries:                   "webhook_deliveries",
+	WebhookSubscriptions:                "webhook_subscriptions",
 }
@@ -56,6 +56,7 @@ const (
 	DeviceCommandRequestStatusPending  string = "Pending"
 	DeviceCommandRequestStatusComplete string = "Complete"
 	DeviceCommandRequestStatusFailed   string = "Failed"
+	DeviceCommandRequestStatusTimedOut string = "TimedOut"
 )
 
 func AllDeviceCommandRequestStatus() []string {
@@ -63,6 +64,20 @@ func AllDeviceCommandRequestStatus() []string {
 		DeviceCommandRequestStatusPending,
 		DeviceCommandRequestStatusComplete,
 		DeviceCommandRequestStatusFailed,
+		DeviceCommandRequestStatusTimedOut,
+	}
+}
+
+// Enum values for DocumentStatus
+const (
+	DocumentStatusPending string = "Pending"
+	DocumentStatusReady   string = "Ready"
+)
+
+func AllDocumentStatus() []string {
+	return []string{
+		DocumentStatusPending,
+		DocumentStatusReady,
 	}
 }
 
@@ -85,6 +100,59 @@ func AllMetaTransactionRequestStatus() []string {
 	}
 }
 
+// Enum values for MetaTransactionRequestOperation
+const (
+	MetaTransactionRequestOperationMint          string = "Mint"
+	MetaTransactionRequestOperationBurn          string = "Burn"
+	MetaTransactionRequestOperationClaim         string = "Claim"
+	MetaTransactionRequestOperationPair          string = "Pair"
+	MetaTransactionRequestOperationUnpair        string = "Unpair"
+	MetaTransactionRequestOperationSyntheticMint string = "SyntheticMint"
+	MetaTransactionRequestOperationSyntheticBurn string = "SyntheticBurn"
+)
+
+func AllMetaTransactionRequestOperation() []string {
+	return []string{
+		MetaTransactionRequestOperationMint,
+		MetaTransactionRequestOperationBurn,
+		MetaTransactionRequestOperationClaim,
+		MetaTransactionRequestOperationPair,
+		MetaTransactionRequestOperationUnpair,
+		MetaTransactionRequestOperationSyntheticMint,
+		MetaTransactionRequestOperationSyntheticBurn,
+	}
+}
+
+// Enum values for TemplateMigrationStatus
+const (
+	TemplateMigrationStatusRunning   string = "Running"
+	TemplateMigrationStatusPaused    string = "Paused"
+	TemplateMigrationStatusCompleted string = "Completed"
+)
+
+func AllTemplateMigrationStatus() []string {
+	return []string{
+		TemplateMigrationStatusRunning,
+		TemplateMigrationStatusPaused,
+		TemplateMigrationStatusCompleted,
+	}
+}
+
+// Enum values for TemplateMigrationDeviceStatus
+const (
+	TemplateMigrationDeviceStatusPending   string = "Pending"
+	TemplateMigrationDeviceStatusSucceeded string = "Succeeded"
+	TemplateMigrationDeviceStatusFailed    string = "Failed"
+)
+
+func AllTemplateMigrationDeviceStatus() []string {
+	return []string{
+		TemplateMigrationDeviceStatusPending,
+		TemplateMigrationDeviceStatusSucceeded,
+		TemplateMigrationDeviceStatusFailed,
+	}
+}
+
 // Enum values for UserDeviceAPIIntegrationStatus
 const (
 	UserDeviceAPIIntegrationStatusPending               string = "Pending"
@@ -105,3 +173,18 @@ func AllUserDeviceAPIIntegrationStatus() []string {
 		UserDeviceAPIIntegrationStatusAuthenticationFailure,
 	}
 }
+
+// Enum values for WebhookDeliveryStatus
+const (
+	WebhookDeliveryStatusPending      string = "Pending"
+	WebhookDeliveryStatusDelivered    string = "Delivered"
+	WebhookDeliveryStatusDeadLettered string = "DeadLettered"
+)
+
+func AllWebhookDeliveryStatus() []string {
+	return []string{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusDeadLettered,
+	}
+}
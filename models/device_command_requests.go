@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -30,6 +31,7 @@ type DeviceCommandRequest struct {
 	Status        string    `boil:"status" json:"status" toml:"status" yaml:"status"`
 	CreatedAt     time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt     time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ExpiresAt     null.Time `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
 
 	R *deviceCommandRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L deviceCommandRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -43,6 +45,7 @@ var DeviceCommandRequestColumns = struct {
 	Status        string
 	CreatedAt     string
 	UpdatedAt     string
+	ExpiresAt     string
 }{
 	ID:            "id",
 	UserDeviceID:  "user_device_id",
@@ -51,6 +54,7 @@ var DeviceCommandRequestColumns = struct {
 	Status:        "status",
 	CreatedAt:     "created_at",
 	UpdatedAt:     "updated_at",
+	ExpiresAt:     "expires_at",
 }
 
 var DeviceCommandRequestTableColumns = struct {
@@ -61,6 +65,7 @@ var DeviceCommandRequestTableColumns = struct {
 	Status        string
 	CreatedAt     string
 	UpdatedAt     string
+	ExpiresAt     string
 }{
 	ID:            "device_command_requests.id",
 	UserDeviceID:  "device_command_requests.user_device_id",
@@ -69,6 +74,7 @@ var DeviceCommandRequestTableColumns = struct {
 	Status:        "device_command_requests.status",
 	CreatedAt:     "device_command_requests.created_at",
 	UpdatedAt:     "device_command_requests.updated_at",
+	ExpiresAt:     "device_command_requests.expires_at",
 }
 
 // Generated where
@@ -81,6 +87,7 @@ var DeviceCommandRequestWhere = struct {
 	Status        whereHelperstring
 	CreatedAt     whereHelpertime_Time
 	UpdatedAt     whereHelpertime_Time
+	ExpiresAt     whereHelpernull_Time
 }{
 	ID:            whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"id\""},
 	UserDeviceID:  whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"user_device_id\""},
@@ -89,6 +96,7 @@ var DeviceCommandRequestWhere = struct {
 	Status:        whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"status\""},
 	CreatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"device_command_requests\".\"created_at\""},
 	UpdatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"device_command_requests\".\"updated_at\""},
+	ExpiresAt:     whereHelpernull_Time{field: "\"devices_api\".\"device_command_requests\".\"expires_at\""},
 }
 
 // DeviceCommandRequestRels is where relationship names are stored.
@@ -119,9 +127,9 @@ func (r *deviceCommandRequestR) GetUserDevice() *UserDevice {
 type deviceCommandRequestL struct{}
 
 var (
-	deviceCommandRequestAllColumns            = []string{"id", "user_device_id", "integration_id", "command", "status", "created_at", "updated_at"}
+	deviceCommandRequestAllColumns            = []string{"id", "user_device_id", "integration_id", "command", "status", "created_at", "updated_at", "expires_at"}
 	deviceCommandRequestColumnsWithoutDefault = []string{"id", "user_device_id", "integration_id", "command", "status"}
-	deviceCommandRequestColumnsWithDefault    = []string{"created_at", "updated_at"}
+	deviceCommandRequestColumnsWithDefault    = []string{"created_at", "updated_at", "expires_at"}
 	deviceCommandRequestPrimaryKeyColumns     = []string{"id"}
 	deviceCommandRequestGeneratedColumns      = []string{}
 )
@@ -32,6 +32,7 @@ type ErrorCodeQuery struct {
 	CodesQueryResponse null.JSON         `boil:"codes_query_response" json:"codes_query_response,omitempty" toml:"codes_query_response" yaml:"codes_query_response,omitempty"`
 	ClearedAt          null.Time         `boil:"cleared_at" json:"cleared_at,omitempty" toml:"cleared_at" yaml:"cleared_at,omitempty"`
 	VehicleTokenID     types.NullDecimal `boil:"vehicle_token_id" json:"vehicle_token_id,omitempty" toml:"vehicle_token_id" yaml:"vehicle_token_id,omitempty"`
+	HiddenAt           null.Time         `boil:"hidden_at" json:"hidden_at,omitempty" toml:"hidden_at" yaml:"hidden_at,omitempty"`
 
 	R *errorCodeQueryR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L errorCodeQueryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -45,6 +46,7 @@ var ErrorCodeQueryColumns = struct {
 	CodesQueryResponse string
 	ClearedAt          string
 	VehicleTokenID     string
+	HiddenAt           string
 }{
 	ID:                 "id",
 	UserDeviceID:       "user_device_id",
@@ -53,6 +55,7 @@ var ErrorCodeQueryColumns = struct {
 	CodesQueryResponse: "codes_query_response",
 	ClearedAt:          "cleared_at",
 	VehicleTokenID:     "vehicle_token_id",
+	HiddenAt:           "hidden_at",
 }
 
 var ErrorCodeQueryTableColumns = struct {
@@ -63,6 +66,7 @@ var ErrorCodeQueryTableColumns = struct {
 	CodesQueryResponse string
 	ClearedAt          string
 	VehicleTokenID     string
+	HiddenAt           string
 }{
 	ID:                 "error_code_queries.id",
 	UserDeviceID:       "error_code_queries.user_device_id",
@@ -71,6 +75,7 @@ var ErrorCodeQueryTableColumns = struct {
 	CodesQueryResponse: "error_code_queries.codes_query_response",
 	ClearedAt:          "error_code_queries.cleared_at",
 	VehicleTokenID:     "error_code_queries.vehicle_token_id",
+	HiddenAt:           "error_code_queries.hidden_at",
 }
 
 // Generated where
@@ -83,6 +88,7 @@ var ErrorCodeQueryWhere = struct {
 	CodesQueryResponse whereHelpernull_JSON
 	ClearedAt          whereHelpernull_Time
 	VehicleTokenID     whereHelpertypes_NullDecimal
+	HiddenAt           whereHelpernull_Time
 }{
 	ID:                 whereHelperstring{field: "\"devices_api\".\"error_code_queries\".\"id\""},
 	UserDeviceID:       whereHelperstring{field: "\"devices_api\".\"error_code_queries\".\"user_device_id\""},
@@ -91,6 +97,7 @@ var ErrorCodeQueryWhere = struct {
 	CodesQueryResponse: whereHelpernull_JSON{field: "\"devices_api\".\"error_code_queries\".\"codes_query_response\""},
 	ClearedAt:          whereHelpernull_Time{field: "\"devices_api\".\"error_code_queries\".\"cleared_at\""},
 	VehicleTokenID:     whereHelpertypes_NullDecimal{field: "\"devices_api\".\"error_code_queries\".\"vehicle_token_id\""},
+	HiddenAt:           whereHelpernull_Time{field: "\"devices_api\".\"error_code_queries\".\"hidden_at\""},
 }
 
 // ErrorCodeQueryRels is where relationship names are stored.
@@ -131,9 +138,9 @@ func (r *errorCodeQueryR) GetVehicleToken() *UserDevice {
 type errorCodeQueryL struct{}
 
 var (
-	errorCodeQueryAllColumns            = []string{"id", "user_device_id", "created_at", "updated_at", "codes_query_response", "cleared_at", "vehicle_token_id"}
+	errorCodeQueryAllColumns            = []string{"id", "user_device_id", "created_at", "updated_at", "codes_query_response", "cleared_at", "vehicle_token_id", "hidden_at"}
 	errorCodeQueryColumnsWithoutDefault = []string{"id", "user_device_id"}
-	errorCodeQueryColumnsWithDefault    = []string{"created_at", "updated_at", "codes_query_response", "cleared_at", "vehicle_token_id"}
+	errorCodeQueryColumnsWithDefault    = []string{"created_at", "updated_at", "codes_query_response", "cleared_at", "vehicle_token_id", "hidden_at"}
 	errorCodeQueryPrimaryKeyColumns     = []string{"id"}
 	errorCodeQueryGeneratedColumns      = []string{}
 )
@@ -24,69 +24,158 @@ import (
 
 // MetaTransactionRequest is an object representing the database table.
 type MetaTransactionRequest struct {
-	ID            string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Status        string      `boil:"status" json:"status" toml:"status" yaml:"status"`
-	Hash          null.Bytes  `boil:"hash" json:"hash,omitempty" toml:"hash" yaml:"hash,omitempty"`
-	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	FailureReason null.String `boil:"failure_reason" json:"failure_reason,omitempty" toml:"failure_reason" yaml:"failure_reason,omitempty"`
+	ID               string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Status           string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Hash             null.Bytes  `boil:"hash" json:"hash,omitempty" toml:"hash" yaml:"hash,omitempty"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	FailureReason    null.String `boil:"failure_reason" json:"failure_reason,omitempty" toml:"failure_reason" yaml:"failure_reason,omitempty"`
+	Operation        null.String `boil:"operation" json:"operation,omitempty" toml:"operation" yaml:"operation,omitempty"`
+	UserDeviceID     null.String `boil:"user_device_id" json:"user_device_id,omitempty" toml:"user_device_id" yaml:"user_device_id,omitempty"`
+	OwnerAddress     null.Bytes  `boil:"owner_address" json:"owner_address,omitempty" toml:"owner_address" yaml:"owner_address,omitempty"`
+	ToAddress        null.Bytes  `boil:"to_address" json:"to_address,omitempty" toml:"to_address" yaml:"to_address,omitempty"`
+	Data             null.Bytes  `boil:"data" json:"data,omitempty" toml:"data" yaml:"data,omitempty"`
+	Attempt          int         `boil:"attempt" json:"attempt" toml:"attempt" yaml:"attempt"`
+	RetryOf          null.String `boil:"retry_of" json:"retry_of,omitempty" toml:"retry_of" yaml:"retry_of,omitempty"`
+	FailureRetryable null.Bool   `boil:"failure_retryable" json:"failure_retryable,omitempty" toml:"failure_retryable" yaml:"failure_retryable,omitempty"`
+	MintBatchID      null.String `boil:"mint_batch_id" json:"mint_batch_id,omitempty" toml:"mint_batch_id" yaml:"mint_batch_id,omitempty"`
 
 	R *metaTransactionRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L metaTransactionRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var MetaTransactionRequestColumns = struct {
-	ID            string
-	Status        string
-	Hash          string
-	CreatedAt     string
-	UpdatedAt     string
-	FailureReason string
+	ID               string
+	Status           string
+	Hash             string
+	CreatedAt        string
+	UpdatedAt        string
+	FailureReason    string
+	Operation        string
+	UserDeviceID     string
+	OwnerAddress     string
+	ToAddress        string
+	Data             string
+	Attempt          string
+	RetryOf          string
+	FailureRetryable string
+	MintBatchID      string
 }{
-	ID:            "id",
-	Status:        "status",
-	Hash:          "hash",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
-	FailureReason: "failure_reason",
+	ID:               "id",
+	Status:           "status",
+	Hash:             "hash",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	FailureReason:    "failure_reason",
+	Operation:        "operation",
+	UserDeviceID:     "user_device_id",
+	OwnerAddress:     "owner_address",
+	ToAddress:        "to_address",
+	Data:             "data",
+	Attempt:          "attempt",
+	RetryOf:          "retry_of",
+	FailureRetryable: "failure_retryable",
+	MintBatchID:      "mint_batch_id",
 }
 
 var MetaTransactionRequestTableColumns = struct {
-	ID            string
-	Status        string
-	Hash          string
-	CreatedAt     string
-	UpdatedAt     string
-	FailureReason string
+	ID               string
+	Status           string
+	Hash             string
+	CreatedAt        string
+	UpdatedAt        string
+	FailureReason    string
+	Operation        string
+	UserDeviceID     string
+	OwnerAddress     string
+	ToAddress        string
+	Data             string
+	Attempt          string
+	RetryOf          string
+	FailureRetryable string
+	MintBatchID      string
 }{
-	ID:            "meta_transaction_requests.id",
-	Status:        "meta_transaction_requests.status",
-	Hash:          "meta_transaction_requests.hash",
-	CreatedAt:     "meta_transaction_requests.created_at",
-	UpdatedAt:     "meta_transaction_requests.updated_at",
-	FailureReason: "meta_transaction_requests.failure_reason",
+	ID:               "meta_transaction_requests.id",
+	Status:           "meta_transaction_requests.status",
+	Hash:             "meta_transaction_requests.hash",
+	CreatedAt:        "meta_transaction_requests.created_at",
+	UpdatedAt:        "meta_transaction_requests.updated_at",
+	FailureReason:    "meta_transaction_requests.failure_reason",
+	Operation:        "meta_transaction_requests.operation",
+	UserDeviceID:     "meta_transaction_requests.user_device_id",
+	OwnerAddress:     "meta_transaction_requests.owner_address",
+	ToAddress:        "meta_transaction_requests.to_address",
+	Data:             "meta_transaction_requests.data",
+	Attempt:          "meta_transaction_requests.attempt",
+	RetryOf:          "meta_transaction_requests.retry_of",
+	FailureRetryable: "meta_transaction_requests.failure_retryable",
+	MintBatchID:      "meta_transaction_requests.mint_batch_id",
 }
 
 // Generated where
 
+type whereHelpernull_Bool struct{ field string }
+
+func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bool) NEQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bool) LT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bool) LTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bool) GT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var MetaTransactionRequestWhere = struct {
-	ID            whereHelperstring
-	Status        whereHelperstring
-	Hash          whereHelpernull_Bytes
-	CreatedAt     whereHelpertime_Time
-	UpdatedAt     whereHelpertime_Time
-	FailureReason whereHelpernull_String
+	ID               whereHelperstring
+	Status           whereHelperstring
+	Hash             whereHelpernull_Bytes
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	FailureReason    whereHelpernull_String
+	Operation        whereHelpernull_String
+	UserDeviceID     whereHelpernull_String
+	OwnerAddress     whereHelpernull_Bytes
+	ToAddress        whereHelpernull_Bytes
+	Data             whereHelpernull_Bytes
+	Attempt          whereHelperint
+	RetryOf          whereHelpernull_String
+	FailureRetryable whereHelpernull_Bool
+	MintBatchID      whereHelpernull_String
 }{
-	ID:            whereHelperstring{field: "\"devices_api\".\"meta_transaction_requests\".\"id\""},
-	Status:        whereHelperstring{field: "\"devices_api\".\"meta_transaction_requests\".\"status\""},
-	Hash:          whereHelpernull_Bytes{field: "\"devices_api\".\"meta_transaction_requests\".\"hash\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"meta_transaction_requests\".\"created_at\""},
-	UpdatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"meta_transaction_requests\".\"updated_at\""},
-	FailureReason: whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"failure_reason\""},
+	ID:               whereHelperstring{field: "\"devices_api\".\"meta_transaction_requests\".\"id\""},
+	Status:           whereHelperstring{field: "\"devices_api\".\"meta_transaction_requests\".\"status\""},
+	Hash:             whereHelpernull_Bytes{field: "\"devices_api\".\"meta_transaction_requests\".\"hash\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"devices_api\".\"meta_transaction_requests\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"devices_api\".\"meta_transaction_requests\".\"updated_at\""},
+	FailureReason:    whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"failure_reason\""},
+	Operation:        whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"operation\""},
+	UserDeviceID:     whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"user_device_id\""},
+	OwnerAddress:     whereHelpernull_Bytes{field: "\"devices_api\".\"meta_transaction_requests\".\"owner_address\""},
+	ToAddress:        whereHelpernull_Bytes{field: "\"devices_api\".\"meta_transaction_requests\".\"to_address\""},
+	Data:             whereHelpernull_Bytes{field: "\"devices_api\".\"meta_transaction_requests\".\"data\""},
+	Attempt:          whereHelperint{field: "\"devices_api\".\"meta_transaction_requests\".\"attempt\""},
+	RetryOf:          whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"retry_of\""},
+	FailureRetryable: whereHelpernull_Bool{field: "\"devices_api\".\"meta_transaction_requests\".\"failure_retryable\""},
+	MintBatchID:      whereHelpernull_String{field: "\"devices_api\".\"meta_transaction_requests\".\"mint_batch_id\""},
 }
 
 // MetaTransactionRequestRels is where relationship names are stored.
 var MetaTransactionRequestRels = struct {
+	RetryOfMetaTransactionRequest                string
+	MintBatch                                    string
 	ClaimMetaTransactionRequestAftermarketDevice string
 	PairRequestAftermarketDevice                 string
 	UnpairRequestAftermarketDevice               string
@@ -94,7 +183,11 @@ var MetaTransactionRequestRels = struct {
 	BurnRequestSyntheticDevice                   string
 	BurnRequestUserDevice                        string
 	MintRequestUserDevice                        string
+	MetaTransactionRequestStatusChanges          string
+	RetryOfMetaTransactionRequests               string
 }{
+	RetryOfMetaTransactionRequest: "RetryOfMetaTransactionRequest",
+	MintBatch:                     "MintBatch",
 	ClaimMetaTransactionRequestAftermarketDevice: "ClaimMetaTransactionRequestAftermarketDevice",
 	PairRequestAftermarketDevice:                 "PairRequestAftermarketDevice",
 	UnpairRequestAftermarketDevice:               "UnpairRequestAftermarketDevice",
@@ -102,17 +195,23 @@ var MetaTransactionRequestRels = struct {
 	BurnRequestSyntheticDevice:                   "BurnRequestSyntheticDevice",
 	BurnRequestUserDevice:                        "BurnRequestUserDevice",
 	MintRequestUserDevice:                        "MintRequestUserDevice",
+	MetaTransactionRequestStatusChanges:          "MetaTransactionRequestStatusChanges",
+	RetryOfMetaTransactionRequests:               "RetryOfMetaTransactionRequests",
 }
 
 // metaTransactionRequestR is where relationships are stored.
 type metaTransactionRequestR struct {
-	ClaimMetaTransactionRequestAftermarketDevice *AftermarketDevice `boil:"ClaimMetaTransactionRequestAftermarketDevice" json:"ClaimMetaTransactionRequestAftermarketDevice" toml:"ClaimMetaTransactionRequestAftermarketDevice" yaml:"ClaimMetaTransactionRequestAftermarketDevice"`
-	PairRequestAftermarketDevice                 *AftermarketDevice `boil:"PairRequestAftermarketDevice" json:"PairRequestAftermarketDevice" toml:"PairRequestAftermarketDevice" yaml:"PairRequestAftermarketDevice"`
-	UnpairRequestAftermarketDevice               *AftermarketDevice `boil:"UnpairRequestAftermarketDevice" json:"UnpairRequestAftermarketDevice" toml:"UnpairRequestAftermarketDevice" yaml:"UnpairRequestAftermarketDevice"`
-	MintRequestSyntheticDevice                   *SyntheticDevice   `boil:"MintRequestSyntheticDevice" json:"MintRequestSyntheticDevice" toml:"MintRequestSyntheticDevice" yaml:"MintRequestSyntheticDevice"`
-	BurnRequestSyntheticDevice                   *SyntheticDevice   `boil:"BurnRequestSyntheticDevice" json:"BurnRequestSyntheticDevice" toml:"BurnRequestSyntheticDevice" yaml:"BurnRequestSyntheticDevice"`
-	BurnRequestUserDevice                        *UserDevice        `boil:"BurnRequestUserDevice" json:"BurnRequestUserDevice" toml:"BurnRequestUserDevice" yaml:"BurnRequestUserDevice"`
-	MintRequestUserDevice                        *UserDevice        `boil:"MintRequestUserDevice" json:"MintRequestUserDevice" toml:"MintRequestUserDevice" yaml:"MintRequestUserDevice"`
+	RetryOfMetaTransactionRequest                *MetaTransactionRequest                 `boil:"RetryOfMetaTransactionRequest" json:"RetryOfMetaTransactionRequest" toml:"RetryOfMetaTransactionRequest" yaml:"RetryOfMetaTransactionRequest"`
+	MintBatch                                    *MintBatch                              `boil:"MintBatch" json:"MintBatch" toml:"MintBatch" yaml:"MintBatch"`
+	ClaimMetaTransactionRequestAftermarketDevice *AftermarketDevice                      `boil:"ClaimMetaTransactionRequestAftermarketDevice" json:"ClaimMetaTransactionRequestAftermarketDevice" toml:"ClaimMetaTransactionRequestAftermarketDevice" yaml:"ClaimMetaTransactionRequestAftermarketDevice"`
+	PairRequestAftermarketDevice                 *AftermarketDevice                      `boil:"PairRequestAftermarketDevice" json:"PairRequestAftermarketDevice" toml:"PairRequestAftermarketDevice" yaml:"PairRequestAftermarketDevice"`
+	UnpairRequestAftermarketDevice               *AftermarketDevice                      `boil:"UnpairRequestAftermarketDevice" json:"UnpairRequestAftermarketDevice" toml:"UnpairRequestAftermarketDevice" yaml:"UnpairRequestAftermarketDevice"`
+	MintRequestSyntheticDevice                   *SyntheticDevice                        `boil:"MintRequestSyntheticDevice" json:"MintRequestSyntheticDevice" toml:"MintRequestSyntheticDevice" yaml:"MintRequestSyntheticDevice"`
+	BurnRequestSyntheticDevice                   *SyntheticDevice                        `boil:"BurnRequestSyntheticDevice" json:"BurnRequestSyntheticDevice" toml:"BurnRequestSyntheticDevice" yaml:"BurnRequestSyntheticDevice"`
+	BurnRequestUserDevice                        *UserDevice                             `boil:"BurnRequestUserDevice" json:"BurnRequestUserDevice" toml:"BurnRequestUserDevice" yaml:"BurnRequestUserDevice"`
+	MintRequestUserDevice                        *UserDevice                             `boil:"MintRequestUserDevice" json:"MintRequestUserDevice" toml:"MintRequestUserDevice" yaml:"MintRequestUserDevice"`
+	MetaTransactionRequestStatusChanges          MetaTransactionRequestStatusChangeSlice `boil:"MetaTransactionRequestStatusChanges" json:"MetaTransactionRequestStatusChanges" toml:"MetaTransactionRequestStatusChanges" yaml:"MetaTransactionRequestStatusChanges"`
+	RetryOfMetaTransactionRequests               MetaTransactionRequestSlice             `boil:"RetryOfMetaTransactionRequests" json:"RetryOfMetaTransactionRequests" toml:"RetryOfMetaTransactionRequests" yaml:"RetryOfMetaTransactionRequests"`
 }
 
 // NewStruct creates a new relationship struct
@@ -120,6 +219,20 @@ func (*metaTransactionRequestR) NewStruct() *metaTransactionRequestR {
 	return &metaTransactionRequestR{}
 }
 
+func (r *metaTransactionRequestR) GetRetryOfMetaTransactionRequest() *MetaTransactionRequest {
+	if r == nil {
+		return nil
+	}
+	return r.RetryOfMetaTransactionRequest
+}
+
+func (r *metaTransactionRequestR) GetMintBatch() *MintBatch {
+	if r == nil {
+		return nil
+	}
+	return r.MintBatch
+}
+
 func (r *metaTransactionRequestR) GetClaimMetaTransactionRequestAftermarketDevice() *AftermarketDevice {
 	if r == nil {
 		return nil
@@ -169,13 +282,27 @@ func (r *metaTransactionRequestR) GetMintRequestUserDevice() *UserDevice {
 	return r.MintRequestUserDevice
 }
 
+func (r *metaTransactionRequestR) GetMetaTransactionRequestStatusChanges() MetaTransactionRequestStatusChangeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MetaTransactionRequestStatusChanges
+}
+
+func (r *metaTransactionRequestR) GetRetryOfMetaTransactionRequests() MetaTransactionRequestSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RetryOfMetaTransactionRequests
+}
+
 // metaTransactionRequestL is where Load methods for each relationship are stored.
 type metaTransactionRequestL struct{}
 
 var (
-	metaTransactionRequestAllColumns            = []string{"id", "status", "hash", "created_at", "updated_at", "failure_reason"}
+	metaTransactionRequestAllColumns            = []string{"id", "status", "hash", "created_at", "updated_at", "failure_reason", "operation", "user_device_id", "owner_address", "to_address", "data", "attempt", "retry_of", "failure_retryable", "mint_batch_id"}
 	metaTransactionRequestColumnsWithoutDefault = []string{"id"}
-	metaTransactionRequestColumnsWithDefault    = []string{"status", "hash", "created_at", "updated_at", "failure_reason"}
+	metaTransactionRequestColumnsWithDefault    = []string{"status", "hash", "created_at", "updated_at", "failure_reason", "operation", "user_device_id", "owner_address", "to_address", "data", "attempt", "retry_of", "failure_retryable", "mint_batch_id"}
 	metaTransactionRequestPrimaryKeyColumns     = []string{"id"}
 	metaTransactionRequestGeneratedColumns      = []string{}
 )
@@ -485,6 +612,28 @@ func (q metaTransactionRequestQuery) Exists(ctx context.Context, exec boil.Conte
 	return count > 0, nil
 }
 
+// RetryOfMetaTransactionRequest pointed to by the foreign key.
+func (o *MetaTransactionRequest) RetryOfMetaTransactionRequest(mods ...qm.QueryMod) metaTransactionRequestQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.RetryOf),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return MetaTransactionRequests(queryMods...)
+}
+
+// MintBatch pointed to by the foreign key.
+func (o *MetaTransactionRequest) MintBatch(mods ...qm.QueryMod) mintBatchQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.MintBatchID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return MintBatches(queryMods...)
+}
+
 // ClaimMetaTransactionRequestAftermarketDevice pointed to by the foreign key.
 func (o *MetaTransactionRequest) ClaimMetaTransactionRequestAftermarketDevice(mods ...qm.QueryMod) aftermarketDeviceQuery {
 	queryMods := []qm.QueryMod{
@@ -562,9 +711,37 @@ func (o *MetaTransactionRequest) MintRequestUserDevice(mods ...qm.QueryMod) user
 	return UserDevices(queryMods...)
 }
 
-// LoadClaimMetaTransactionRequestAftermarketDevice allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+// MetaTransactionRequestStatusChanges retrieves all the meta_transaction_request_status_change's MetaTransactionRequestStatusChanges with an executor.
+func (o *MetaTransactionRequest) MetaTransactionRequestStatusChanges(mods ...qm.QueryMod) metaTransactionRequestStatusChangeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"meta_transaction_request_status_changes\".\"meta_transaction_request_id\"=?", o.ID),
+	)
+
+	return MetaTransactionRequestStatusChanges(queryMods...)
+}
+
+// RetryOfMetaTransactionRequests retrieves all the meta_transaction_request's MetaTransactionRequests with an executor via retry_of column.
+func (o *MetaTransactionRequest) RetryOfMetaTransactionRequests(mods ...qm.QueryMod) metaTransactionRequestQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"meta_transaction_requests\".\"retry_of\"=?", o.ID),
+	)
+
+	return MetaTransactionRequests(queryMods...)
+}
+
+// LoadRetryOfMetaTransactionRequest allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (metaTransactionRequestL) LoadRetryOfMetaTransactionRequest(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -595,14 +772,20 @@ func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(
 		if object.R == nil {
 			object.R = &metaTransactionRequestR{}
 		}
-		args[object.ID] = struct{}{}
+		if !queries.IsNil(object.RetryOf) {
+			args[object.RetryOf] = struct{}{}
+		}
+
 	} else {
 		for _, obj := range slice {
 			if obj.R == nil {
 				obj.R = &metaTransactionRequestR{}
 			}
 
-			args[obj.ID] = struct{}{}
+			if !queries.IsNil(obj.RetryOf) {
+				args[obj.RetryOf] = struct{}{}
+			}
+
 		}
 	}
 
@@ -618,8 +801,8 @@ func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.aftermarket_devices`),
-		qm.WhereIn(`devices_api.aftermarket_devices.claim_meta_transaction_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.meta_transaction_requests`),
+		qm.WhereIn(`devices_api.meta_transaction_requests.id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -627,22 +810,22 @@ func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load AftermarketDevice")
+		return errors.Wrap(err, "failed to eager load MetaTransactionRequest")
 	}
 
-	var resultSlice []*AftermarketDevice
+	var resultSlice []*MetaTransactionRequest
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice AftermarketDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice MetaTransactionRequest")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for aftermarket_devices")
+		return errors.Wrap(err, "failed to close results of eager load for meta_transaction_requests")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for meta_transaction_requests")
 	}
 
-	if len(aftermarketDeviceAfterSelectHooks) != 0 {
+	if len(metaTransactionRequestAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -656,21 +839,22 @@ func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.ClaimMetaTransactionRequestAftermarketDevice = foreign
+		object.R.RetryOfMetaTransactionRequest = foreign
 		if foreign.R == nil {
-			foreign.R = &aftermarketDeviceR{}
+			foreign.R = &metaTransactionRequestR{}
 		}
-		foreign.R.ClaimMetaTransactionRequest = object
+		foreign.R.RetryOfMetaTransactionRequests = append(foreign.R.RetryOfMetaTransactionRequests, object)
+		return nil
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.ClaimMetaTransactionRequestID) {
-				local.R.ClaimMetaTransactionRequestAftermarketDevice = foreign
+			if queries.Equal(local.RetryOf, foreign.ID) {
+				local.R.RetryOfMetaTransactionRequest = foreign
 				if foreign.R == nil {
-					foreign.R = &aftermarketDeviceR{}
+					foreign.R = &metaTransactionRequestR{}
 				}
-				foreign.R.ClaimMetaTransactionRequest = local
+				foreign.R.RetryOfMetaTransactionRequests = append(foreign.R.RetryOfMetaTransactionRequests, local)
 				break
 			}
 		}
@@ -679,9 +863,9 @@ func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(
 	return nil
 }
 
-// LoadPairRequestAftermarketDevice allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+// LoadMintBatch allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (metaTransactionRequestL) LoadMintBatch(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -712,14 +896,20 @@ func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Cont
 		if object.R == nil {
 			object.R = &metaTransactionRequestR{}
 		}
-		args[object.ID] = struct{}{}
+		if !queries.IsNil(object.MintBatchID) {
+			args[object.MintBatchID] = struct{}{}
+		}
+
 	} else {
 		for _, obj := range slice {
 			if obj.R == nil {
 				obj.R = &metaTransactionRequestR{}
 			}
 
-			args[obj.ID] = struct{}{}
+			if !queries.IsNil(obj.MintBatchID) {
+				args[obj.MintBatchID] = struct{}{}
+			}
+
 		}
 	}
 
@@ -735,8 +925,8 @@ func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Cont
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.aftermarket_devices`),
-		qm.WhereIn(`devices_api.aftermarket_devices.pair_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.mint_batches`),
+		qm.WhereIn(`devices_api.mint_batches.id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -744,22 +934,22 @@ func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Cont
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load AftermarketDevice")
+		return errors.Wrap(err, "failed to eager load MintBatch")
 	}
 
-	var resultSlice []*AftermarketDevice
+	var resultSlice []*MintBatch
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice AftermarketDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice MintBatch")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for aftermarket_devices")
+		return errors.Wrap(err, "failed to close results of eager load for mint_batches")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for mint_batches")
 	}
 
-	if len(aftermarketDeviceAfterSelectHooks) != 0 {
+	if len(mintBatchAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -773,21 +963,22 @@ func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Cont
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.PairRequestAftermarketDevice = foreign
+		object.R.MintBatch = foreign
 		if foreign.R == nil {
-			foreign.R = &aftermarketDeviceR{}
+			foreign.R = &mintBatchR{}
 		}
-		foreign.R.PairRequest = object
+		foreign.R.MetaTransactionRequests = append(foreign.R.MetaTransactionRequests, object)
+		return nil
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.PairRequestID) {
-				local.R.PairRequestAftermarketDevice = foreign
+			if queries.Equal(local.MintBatchID, foreign.ID) {
+				local.R.MintBatch = foreign
 				if foreign.R == nil {
-					foreign.R = &aftermarketDeviceR{}
+					foreign.R = &mintBatchR{}
 				}
-				foreign.R.PairRequest = local
+				foreign.R.MetaTransactionRequests = append(foreign.R.MetaTransactionRequests, local)
 				break
 			}
 		}
@@ -796,9 +987,9 @@ func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Cont
 	return nil
 }
 
-// LoadUnpairRequestAftermarketDevice allows an eager lookup of values, cached into the
+// LoadClaimMetaTransactionRequestAftermarketDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadUnpairRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -853,7 +1044,7 @@ func (metaTransactionRequestL) LoadUnpairRequestAftermarketDevice(ctx context.Co
 
 	query := NewQuery(
 		qm.From(`devices_api.aftermarket_devices`),
-		qm.WhereIn(`devices_api.aftermarket_devices.unpair_request_id in ?`, argsSlice...),
+		qm.WhereIn(`devices_api.aftermarket_devices.claim_meta_transaction_request_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -890,21 +1081,21 @@ func (metaTransactionRequestL) LoadUnpairRequestAftermarketDevice(ctx context.Co
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.UnpairRequestAftermarketDevice = foreign
+		object.R.ClaimMetaTransactionRequestAftermarketDevice = foreign
 		if foreign.R == nil {
 			foreign.R = &aftermarketDeviceR{}
 		}
-		foreign.R.UnpairRequest = object
+		foreign.R.ClaimMetaTransactionRequest = object
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.UnpairRequestID) {
-				local.R.UnpairRequestAftermarketDevice = foreign
+			if queries.Equal(local.ID, foreign.ClaimMetaTransactionRequestID) {
+				local.R.ClaimMetaTransactionRequestAftermarketDevice = foreign
 				if foreign.R == nil {
 					foreign.R = &aftermarketDeviceR{}
 				}
-				foreign.R.UnpairRequest = local
+				foreign.R.ClaimMetaTransactionRequest = local
 				break
 			}
 		}
@@ -913,9 +1104,9 @@ func (metaTransactionRequestL) LoadUnpairRequestAftermarketDevice(ctx context.Co
 	return nil
 }
 
-// LoadMintRequestSyntheticDevice allows an eager lookup of values, cached into the
+// LoadPairRequestAftermarketDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+func (metaTransactionRequestL) LoadPairRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -969,8 +1160,8 @@ func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Contex
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.synthetic_devices`),
-		qm.WhereIn(`devices_api.synthetic_devices.mint_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.aftermarket_devices`),
+		qm.WhereIn(`devices_api.aftermarket_devices.pair_request_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -978,22 +1169,22 @@ func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Contex
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load SyntheticDevice")
+		return errors.Wrap(err, "failed to eager load AftermarketDevice")
 	}
 
-	var resultSlice []*SyntheticDevice
+	var resultSlice []*AftermarketDevice
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice SyntheticDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice AftermarketDevice")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for synthetic_devices")
+		return errors.Wrap(err, "failed to close results of eager load for aftermarket_devices")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for synthetic_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_devices")
 	}
 
-	if len(syntheticDeviceAfterSelectHooks) != 0 {
+	if len(aftermarketDeviceAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1007,21 +1198,21 @@ func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Contex
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.MintRequestSyntheticDevice = foreign
+		object.R.PairRequestAftermarketDevice = foreign
 		if foreign.R == nil {
-			foreign.R = &syntheticDeviceR{}
+			foreign.R = &aftermarketDeviceR{}
 		}
-		foreign.R.MintRequest = object
+		foreign.R.PairRequest = object
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if local.ID == foreign.MintRequestID {
-				local.R.MintRequestSyntheticDevice = foreign
+			if queries.Equal(local.ID, foreign.PairRequestID) {
+				local.R.PairRequestAftermarketDevice = foreign
 				if foreign.R == nil {
-					foreign.R = &syntheticDeviceR{}
+					foreign.R = &aftermarketDeviceR{}
 				}
-				foreign.R.MintRequest = local
+				foreign.R.PairRequest = local
 				break
 			}
 		}
@@ -1030,9 +1221,9 @@ func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Contex
 	return nil
 }
 
-// LoadBurnRequestSyntheticDevice allows an eager lookup of values, cached into the
+// LoadUnpairRequestAftermarketDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+func (metaTransactionRequestL) LoadUnpairRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -1086,8 +1277,8 @@ func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Contex
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.synthetic_devices`),
-		qm.WhereIn(`devices_api.synthetic_devices.burn_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.aftermarket_devices`),
+		qm.WhereIn(`devices_api.aftermarket_devices.unpair_request_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1095,22 +1286,22 @@ func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Contex
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load SyntheticDevice")
+		return errors.Wrap(err, "failed to eager load AftermarketDevice")
 	}
 
-	var resultSlice []*SyntheticDevice
+	var resultSlice []*AftermarketDevice
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice SyntheticDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice AftermarketDevice")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for synthetic_devices")
+		return errors.Wrap(err, "failed to close results of eager load for aftermarket_devices")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for synthetic_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_devices")
 	}
 
-	if len(syntheticDeviceAfterSelectHooks) != 0 {
+	if len(aftermarketDeviceAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1124,21 +1315,21 @@ func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Contex
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.BurnRequestSyntheticDevice = foreign
+		object.R.UnpairRequestAftermarketDevice = foreign
 		if foreign.R == nil {
-			foreign.R = &syntheticDeviceR{}
+			foreign.R = &aftermarketDeviceR{}
 		}
-		foreign.R.BurnRequest = object
+		foreign.R.UnpairRequest = object
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.BurnRequestID) {
-				local.R.BurnRequestSyntheticDevice = foreign
+			if queries.Equal(local.ID, foreign.UnpairRequestID) {
+				local.R.UnpairRequestAftermarketDevice = foreign
 				if foreign.R == nil {
-					foreign.R = &syntheticDeviceR{}
+					foreign.R = &aftermarketDeviceR{}
 				}
-				foreign.R.BurnRequest = local
+				foreign.R.UnpairRequest = local
 				break
 			}
 		}
@@ -1147,9 +1338,9 @@ func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Contex
 	return nil
 }
 
-// LoadBurnRequestUserDevice allows an eager lookup of values, cached into the
+// LoadMintRequestSyntheticDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+func (metaTransactionRequestL) LoadMintRequestSyntheticDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -1203,8 +1394,8 @@ func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.user_devices`),
-		qm.WhereIn(`devices_api.user_devices.burn_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.synthetic_devices`),
+		qm.WhereIn(`devices_api.synthetic_devices.mint_request_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1212,22 +1403,22 @@ func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load UserDevice")
+		return errors.Wrap(err, "failed to eager load SyntheticDevice")
 	}
 
-	var resultSlice []*UserDevice
+	var resultSlice []*SyntheticDevice
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice UserDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice SyntheticDevice")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for user_devices")
+		return errors.Wrap(err, "failed to close results of eager load for synthetic_devices")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for synthetic_devices")
 	}
 
-	if len(userDeviceAfterSelectHooks) != 0 {
+	if len(syntheticDeviceAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1241,21 +1432,21 @@ func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.BurnRequestUserDevice = foreign
+		object.R.MintRequestSyntheticDevice = foreign
 		if foreign.R == nil {
-			foreign.R = &userDeviceR{}
+			foreign.R = &syntheticDeviceR{}
 		}
-		foreign.R.BurnRequest = object
+		foreign.R.MintRequest = object
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.BurnRequestID) {
-				local.R.BurnRequestUserDevice = foreign
+			if local.ID == foreign.MintRequestID {
+				local.R.MintRequestSyntheticDevice = foreign
 				if foreign.R == nil {
-					foreign.R = &userDeviceR{}
+					foreign.R = &syntheticDeviceR{}
 				}
-				foreign.R.BurnRequest = local
+				foreign.R.MintRequest = local
 				break
 			}
 		}
@@ -1264,9 +1455,9 @@ func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e
 	return nil
 }
 
-// LoadMintRequestUserDevice allows an eager lookup of values, cached into the
+// LoadBurnRequestSyntheticDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+func (metaTransactionRequestL) LoadBurnRequestSyntheticDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
 	var slice []*MetaTransactionRequest
 	var object *MetaTransactionRequest
 
@@ -1320,8 +1511,8 @@ func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.user_devices`),
-		qm.WhereIn(`devices_api.user_devices.mint_request_id in ?`, argsSlice...),
+		qm.From(`devices_api.synthetic_devices`),
+		qm.WhereIn(`devices_api.synthetic_devices.burn_request_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1329,22 +1520,22 @@ func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load UserDevice")
+		return errors.Wrap(err, "failed to eager load SyntheticDevice")
 	}
 
-	var resultSlice []*UserDevice
+	var resultSlice []*SyntheticDevice
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice UserDevice")
+		return errors.Wrap(err, "failed to bind eager loaded slice SyntheticDevice")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for user_devices")
+		return errors.Wrap(err, "failed to close results of eager load for synthetic_devices")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_devices")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for synthetic_devices")
 	}
 
-	if len(userDeviceAfterSelectHooks) != 0 {
+	if len(syntheticDeviceAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1358,19 +1549,253 @@ func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.MintRequestUserDevice = foreign
+		object.R.BurnRequestSyntheticDevice = foreign
 		if foreign.R == nil {
-			foreign.R = &userDeviceR{}
+			foreign.R = &syntheticDeviceR{}
 		}
-		foreign.R.MintRequest = object
+		foreign.R.BurnRequest = object
 	}
 
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if queries.Equal(local.ID, foreign.MintRequestID) {
-				local.R.MintRequestUserDevice = foreign
+			if queries.Equal(local.ID, foreign.BurnRequestID) {
+				local.R.BurnRequestSyntheticDevice = foreign
 				if foreign.R == nil {
-					foreign.R = &userDeviceR{}
+					foreign.R = &syntheticDeviceR{}
+				}
+				foreign.R.BurnRequest = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadBurnRequestUserDevice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (metaTransactionRequestL) LoadBurnRequestUserDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+	var slice []*MetaTransactionRequest
+	var object *MetaTransactionRequest
+
+	if singular {
+		var ok bool
+		object, ok = maybeMetaTransactionRequest.(*MetaTransactionRequest)
+		if !ok {
+			object = new(MetaTransactionRequest)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMetaTransactionRequest))
+			}
+		}
+	} else {
+		s, ok := maybeMetaTransactionRequest.(*[]*MetaTransactionRequest)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMetaTransactionRequest))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &metaTransactionRequestR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &metaTransactionRequestR{}
+			}
+
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.user_devices`),
+		qm.WhereIn(`devices_api.user_devices.burn_request_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load UserDevice")
+	}
+
+	var resultSlice []*UserDevice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice UserDevice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_devices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_devices")
+	}
+
+	if len(userDeviceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.BurnRequestUserDevice = foreign
+		if foreign.R == nil {
+			foreign.R = &userDeviceR{}
+		}
+		foreign.R.BurnRequest = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ID, foreign.BurnRequestID) {
+				local.R.BurnRequestUserDevice = foreign
+				if foreign.R == nil {
+					foreign.R = &userDeviceR{}
+				}
+				foreign.R.BurnRequest = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadMintRequestUserDevice allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+	var slice []*MetaTransactionRequest
+	var object *MetaTransactionRequest
+
+	if singular {
+		var ok bool
+		object, ok = maybeMetaTransactionRequest.(*MetaTransactionRequest)
+		if !ok {
+			object = new(MetaTransactionRequest)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMetaTransactionRequest))
+			}
+		}
+	} else {
+		s, ok := maybeMetaTransactionRequest.(*[]*MetaTransactionRequest)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMetaTransactionRequest))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &metaTransactionRequestR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &metaTransactionRequestR{}
+			}
+
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.user_devices`),
+		qm.WhereIn(`devices_api.user_devices.mint_request_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load UserDevice")
+	}
+
+	var resultSlice []*UserDevice
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice UserDevice")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user_devices")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_devices")
+	}
+
+	if len(userDeviceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.MintRequestUserDevice = foreign
+		if foreign.R == nil {
+			foreign.R = &userDeviceR{}
+		}
+		foreign.R.MintRequest = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ID, foreign.MintRequestID) {
+				local.R.MintRequestUserDevice = foreign
+				if foreign.R == nil {
+					foreign.R = &userDeviceR{}
 				}
 				foreign.R.MintRequest = local
 				break
@@ -1381,6 +1806,392 @@ func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e
 	return nil
 }
 
+// LoadMetaTransactionRequestStatusChanges allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (metaTransactionRequestL) LoadMetaTransactionRequestStatusChanges(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+	var slice []*MetaTransactionRequest
+	var object *MetaTransactionRequest
+
+	if singular {
+		var ok bool
+		object, ok = maybeMetaTransactionRequest.(*MetaTransactionRequest)
+		if !ok {
+			object = new(MetaTransactionRequest)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMetaTransactionRequest))
+			}
+		}
+	} else {
+		s, ok := maybeMetaTransactionRequest.(*[]*MetaTransactionRequest)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMetaTransactionRequest))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &metaTransactionRequestR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &metaTransactionRequestR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.meta_transaction_request_status_changes`),
+		qm.WhereIn(`devices_api.meta_transaction_request_status_changes.meta_transaction_request_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load meta_transaction_request_status_changes")
+	}
+
+	var resultSlice []*MetaTransactionRequestStatusChange
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice meta_transaction_request_status_changes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on meta_transaction_request_status_changes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for meta_transaction_request_status_changes")
+	}
+
+	if len(metaTransactionRequestStatusChangeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.MetaTransactionRequestStatusChanges = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &metaTransactionRequestStatusChangeR{}
+			}
+			foreign.R.MetaTransactionRequest = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.MetaTransactionRequestID {
+				local.R.MetaTransactionRequestStatusChanges = append(local.R.MetaTransactionRequestStatusChanges, foreign)
+				if foreign.R == nil {
+					foreign.R = &metaTransactionRequestStatusChangeR{}
+				}
+				foreign.R.MetaTransactionRequest = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRetryOfMetaTransactionRequests allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (metaTransactionRequestL) LoadRetryOfMetaTransactionRequests(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+	var slice []*MetaTransactionRequest
+	var object *MetaTransactionRequest
+
+	if singular {
+		var ok bool
+		object, ok = maybeMetaTransactionRequest.(*MetaTransactionRequest)
+		if !ok {
+			object = new(MetaTransactionRequest)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMetaTransactionRequest))
+			}
+		}
+	} else {
+		s, ok := maybeMetaTransactionRequest.(*[]*MetaTransactionRequest)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMetaTransactionRequest))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &metaTransactionRequestR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &metaTransactionRequestR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.meta_transaction_requests`),
+		qm.WhereIn(`devices_api.meta_transaction_requests.retry_of in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load meta_transaction_requests")
+	}
+
+	var resultSlice []*MetaTransactionRequest
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice meta_transaction_requests")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on meta_transaction_requests")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for meta_transaction_requests")
+	}
+
+	if len(metaTransactionRequestAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RetryOfMetaTransactionRequests = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &metaTransactionRequestR{}
+			}
+			foreign.R.RetryOfMetaTransactionRequest = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.RetryOf) {
+				local.R.RetryOfMetaTransactionRequests = append(local.R.RetryOfMetaTransactionRequests, foreign)
+				if foreign.R == nil {
+					foreign.R = &metaTransactionRequestR{}
+				}
+				foreign.R.RetryOfMetaTransactionRequest = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetRetryOfMetaTransactionRequest of the metaTransactionRequest to the related item.
+// Sets o.R.RetryOfMetaTransactionRequest to related.
+// Adds o to related.R.RetryOfMetaTransactionRequests.
+func (o *MetaTransactionRequest) SetRetryOfMetaTransactionRequest(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MetaTransactionRequest) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"devices_api\".\"meta_transaction_requests\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"retry_of"}),
+		strmangle.WhereClause("\"", "\"", 2, metaTransactionRequestPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.RetryOf, related.ID)
+	if o.R == nil {
+		o.R = &metaTransactionRequestR{
+			RetryOfMetaTransactionRequest: related,
+		}
+	} else {
+		o.R.RetryOfMetaTransactionRequest = related
+	}
+
+	if related.R == nil {
+		related.R = &metaTransactionRequestR{
+			RetryOfMetaTransactionRequests: MetaTransactionRequestSlice{o},
+		}
+	} else {
+		related.R.RetryOfMetaTransactionRequests = append(related.R.RetryOfMetaTransactionRequests, o)
+	}
+
+	return nil
+}
+
+// RemoveRetryOfMetaTransactionRequest relationship.
+// Sets o.R.RetryOfMetaTransactionRequest to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *MetaTransactionRequest) RemoveRetryOfMetaTransactionRequest(ctx context.Context, exec boil.ContextExecutor, related *MetaTransactionRequest) error {
+	var err error
+
+	queries.SetScanner(&o.RetryOf, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("retry_of")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.RetryOfMetaTransactionRequest = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.RetryOfMetaTransactionRequests {
+		if queries.Equal(o.RetryOf, ri.RetryOf) {
+			continue
+		}
+
+		ln := len(related.R.RetryOfMetaTransactionRequests)
+		if ln > 1 && i < ln-1 {
+			related.R.RetryOfMetaTransactionRequests[i] = related.R.RetryOfMetaTransactionRequests[ln-1]
+		}
+		related.R.RetryOfMetaTransactionRequests = related.R.RetryOfMetaTransactionRequests[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetMintBatch of the metaTransactionRequest to the related item.
+// Sets o.R.MintBatch to related.
+// Adds o to related.R.MetaTransactionRequests.
+func (o *MetaTransactionRequest) SetMintBatch(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MintBatch) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"devices_api\".\"meta_transaction_requests\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"mint_batch_id"}),
+		strmangle.WhereClause("\"", "\"", 2, metaTransactionRequestPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.MintBatchID, related.ID)
+	if o.R == nil {
+		o.R = &metaTransactionRequestR{
+			MintBatch: related,
+		}
+	} else {
+		o.R.MintBatch = related
+	}
+
+	if related.R == nil {
+		related.R = &mintBatchR{
+			MetaTransactionRequests: MetaTransactionRequestSlice{o},
+		}
+	} else {
+		related.R.MetaTransactionRequests = append(related.R.MetaTransactionRequests, o)
+	}
+
+	return nil
+}
+
+// RemoveMintBatch relationship.
+// Sets o.R.MintBatch to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *MetaTransactionRequest) RemoveMintBatch(ctx context.Context, exec boil.ContextExecutor, related *MintBatch) error {
+	var err error
+
+	queries.SetScanner(&o.MintBatchID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("mint_batch_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.MintBatch = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.MetaTransactionRequests {
+		if queries.Equal(o.MintBatchID, ri.MintBatchID) {
+			continue
+		}
+
+		ln := len(related.R.MetaTransactionRequests)
+		if ln > 1 && i < ln-1 {
+			related.R.MetaTransactionRequests[i] = related.R.MetaTransactionRequests[ln-1]
+		}
+		related.R.MetaTransactionRequests = related.R.MetaTransactionRequests[:ln-1]
+		break
+	}
+	return nil
+}
+
 // SetClaimMetaTransactionRequestAftermarketDevice of the metaTransactionRequest to the related item.
 // Sets o.R.ClaimMetaTransactionRequestAftermarketDevice to related.
 // Adds o to related.R.ClaimMetaTransactionRequest.
@@ -1875,6 +2686,186 @@ func (o *MetaTransactionRequest) RemoveMintRequestUserDevice(ctx context.Context
 	return nil
 }
 
+// AddMetaTransactionRequestStatusChanges adds the given related objects to the existing relationships
+// of the meta_transaction_request, optionally inserting them as new records.
+// Appends related to o.R.MetaTransactionRequestStatusChanges.
+// Sets related.R.MetaTransactionRequest appropriately.
+func (o *MetaTransactionRequest) AddMetaTransactionRequestStatusChanges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MetaTransactionRequestStatusChange) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.MetaTransactionRequestID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"meta_transaction_request_status_changes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"meta_transaction_request_id"}),
+				strmangle.WhereClause("\"", "\"", 2, metaTransactionRequestStatusChangePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.MetaTransactionRequestID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &metaTransactionRequestR{
+			MetaTransactionRequestStatusChanges: related,
+		}
+	} else {
+		o.R.MetaTransactionRequestStatusChanges = append(o.R.MetaTransactionRequestStatusChanges, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &metaTransactionRequestStatusChangeR{
+				MetaTransactionRequest: o,
+			}
+		} else {
+			rel.R.MetaTransactionRequest = o
+		}
+	}
+	return nil
+}
+
+// AddRetryOfMetaTransactionRequests adds the given related objects to the existing relationships
+// of the meta_transaction_request, optionally inserting them as new records.
+// Appends related to o.R.RetryOfMetaTransactionRequests.
+// Sets related.R.RetryOfMetaTransactionRequest appropriately.
+func (o *MetaTransactionRequest) AddRetryOfMetaTransactionRequests(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MetaTransactionRequest) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.RetryOf, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"meta_transaction_requests\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"retry_of"}),
+				strmangle.WhereClause("\"", "\"", 2, metaTransactionRequestPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.RetryOf, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &metaTransactionRequestR{
+			RetryOfMetaTransactionRequests: related,
+		}
+	} else {
+		o.R.RetryOfMetaTransactionRequests = append(o.R.RetryOfMetaTransactionRequests, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &metaTransactionRequestR{
+				RetryOfMetaTransactionRequest: o,
+			}
+		} else {
+			rel.R.RetryOfMetaTransactionRequest = o
+		}
+	}
+	return nil
+}
+
+// SetRetryOfMetaTransactionRequests removes all previously related items of the
+// meta_transaction_request replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.RetryOfMetaTransactionRequest's RetryOfMetaTransactionRequests accordingly.
+// Replaces o.R.RetryOfMetaTransactionRequests with related.
+// Sets related.R.RetryOfMetaTransactionRequest's RetryOfMetaTransactionRequests accordingly.
+func (o *MetaTransactionRequest) SetRetryOfMetaTransactionRequests(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MetaTransactionRequest) error {
+	query := "update \"devices_api\".\"meta_transaction_requests\" set \"retry_of\" = null where \"retry_of\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.RetryOfMetaTransactionRequests {
+			queries.SetScanner(&rel.RetryOf, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.RetryOfMetaTransactionRequest = nil
+		}
+		o.R.RetryOfMetaTransactionRequests = nil
+	}
+
+	return o.AddRetryOfMetaTransactionRequests(ctx, exec, insert, related...)
+}
+
+// RemoveRetryOfMetaTransactionRequests relationships from objects passed in.
+// Removes related items from R.RetryOfMetaTransactionRequests (uses pointer comparison, removal does not keep order)
+// Sets related.R.RetryOfMetaTransactionRequest.
+func (o *MetaTransactionRequest) RemoveRetryOfMetaTransactionRequests(ctx context.Context, exec boil.ContextExecutor, related ...*MetaTransactionRequest) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.RetryOf, nil)
+		if rel.R != nil {
+			rel.R.RetryOfMetaTransactionRequest = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("retry_of")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.RetryOfMetaTransactionRequests {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.RetryOfMetaTransactionRequests)
+			if ln > 1 && i < ln-1 {
+				o.R.RetryOfMetaTransactionRequests[i] = o.R.RetryOfMetaTransactionRequests[ln-1]
+			}
+			o.R.RetryOfMetaTransactionRequests = o.R.RetryOfMetaTransactionRequests[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // MetaTransactionRequests retrieves all the records using an executor.
 func MetaTransactionRequests(mods ...qm.QueryMod) metaTransactionRequestQuery {
 	mods = append(mods, qm.From("\"devices_api\".\"meta_transaction_requests\""))
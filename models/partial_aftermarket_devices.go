@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -27,6 +28,7 @@ type PartialAftermarketDevice struct {
 	TokenID             types.Decimal `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
 	ManufacturerTokenID types.Decimal `boil:"manufacturer_token_id" json:"manufacturer_token_id" toml:"manufacturer_token_id" yaml:"manufacturer_token_id"`
 	EthereumAddress     []byte        `boil:"ethereum_address" json:"ethereum_address" toml:"ethereum_address" yaml:"ethereum_address"`
+	Metadata            null.JSON     `boil:"metadata" json:"metadata,omitempty" toml:"metadata" yaml:"metadata,omitempty"`
 
 	R *partialAftermarketDeviceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L partialAftermarketDeviceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -36,20 +38,24 @@ var PartialAftermarketDeviceColumns = struct {
 	TokenID             string
 	ManufacturerTokenID string
 	EthereumAddress     string
+	Metadata            string
 }{
 	TokenID:             "token_id",
 	ManufacturerTokenID: "manufacturer_token_id",
 	EthereumAddress:     "ethereum_address",
+	Metadata:            "metadata",
 }
 
 var PartialAftermarketDeviceTableColumns = struct {
 	TokenID             string
 	ManufacturerTokenID string
 	EthereumAddress     string
+	Metadata            string
 }{
 	TokenID:             "partial_aftermarket_devices.token_id",
 	ManufacturerTokenID: "partial_aftermarket_devices.manufacturer_token_id",
 	EthereumAddress:     "partial_aftermarket_devices.ethereum_address",
+	Metadata:            "partial_aftermarket_devices.metadata",
 }
 
 // Generated where
@@ -58,10 +64,12 @@ var PartialAftermarketDeviceWhere = struct {
 	TokenID             whereHelpertypes_Decimal
 	ManufacturerTokenID whereHelpertypes_Decimal
 	EthereumAddress     whereHelper__byte
+	Metadata            whereHelpernull_JSON
 }{
 	TokenID:             whereHelpertypes_Decimal{field: "\"devices_api\".\"partial_aftermarket_devices\".\"token_id\""},
 	ManufacturerTokenID: whereHelpertypes_Decimal{field: "\"devices_api\".\"partial_aftermarket_devices\".\"manufacturer_token_id\""},
 	EthereumAddress:     whereHelper__byte{field: "\"devices_api\".\"partial_aftermarket_devices\".\"ethereum_address\""},
+	Metadata:            whereHelpernull_JSON{field: "\"devices_api\".\"partial_aftermarket_devices\".\"metadata\""},
 }
 
 // PartialAftermarketDeviceRels is where relationship names are stored.
@@ -81,9 +89,9 @@ func (*partialAftermarketDeviceR) NewStruct() *partialAftermarketDeviceR {
 type partialAftermarketDeviceL struct{}
 
 var (
-	partialAftermarketDeviceAllColumns            = []string{"token_id", "manufacturer_token_id", "ethereum_address"}
+	partialAftermarketDeviceAllColumns            = []string{"token_id", "manufacturer_token_id", "ethereum_address", "metadata"}
 	partialAftermarketDeviceColumnsWithoutDefault = []string{"token_id", "manufacturer_token_id", "ethereum_address"}
-	partialAftermarketDeviceColumnsWithDefault    = []string{}
+	partialAftermarketDeviceColumnsWithDefault    = []string{"metadata"}
 	partialAftermarketDevicePrimaryKeyColumns     = []string{"token_id"}
 	partialAftermarketDeviceGeneratedColumns      = []string{}
 )
@@ -187,6 +187,7 @@ var UserDeviceRels = struct {
 	VehicleTokenSyntheticDevice   string
 	AutopiJobs                    string
 	DeviceCommandRequests         string
+	Documents                     string
 	ErrorCodeQueries              string
 	VehicleTokenErrorCodeQueries  string
 	UserDeviceAPIIntegrations     string
@@ -197,6 +198,7 @@ var UserDeviceRels = struct {
 	VehicleTokenSyntheticDevice:   "VehicleTokenSyntheticDevice",
 	AutopiJobs:                    "AutopiJobs",
 	DeviceCommandRequests:         "DeviceCommandRequests",
+	Documents:                     "Documents",
 	ErrorCodeQueries:              "ErrorCodeQueries",
 	VehicleTokenErrorCodeQueries:  "VehicleTokenErrorCodeQueries",
 	UserDeviceAPIIntegrations:     "UserDeviceAPIIntegrations",
@@ -210,6 +212,7 @@ type userDeviceR struct {
 	VehicleTokenSyntheticDevice   *SyntheticDevice              `boil:"VehicleTokenSyntheticDevice" json:"VehicleTokenSyntheticDevice" toml:"VehicleTokenSyntheticDevice" yaml:"VehicleTokenSyntheticDevice"`
 	AutopiJobs                    AutopiJobSlice                `boil:"AutopiJobs" json:"AutopiJobs" toml:"AutopiJobs" yaml:"AutopiJobs"`
 	DeviceCommandRequests         DeviceCommandRequestSlice     `boil:"DeviceCommandRequests" json:"DeviceCommandRequests" toml:"DeviceCommandRequests" yaml:"DeviceCommandRequests"`
+	Documents                     DocumentSlice                 `boil:"Documents" json:"Documents" toml:"Documents" yaml:"Documents"`
 	ErrorCodeQueries              ErrorCodeQuerySlice           `boil:"ErrorCodeQueries" json:"ErrorCodeQueries" toml:"ErrorCodeQueries" yaml:"ErrorCodeQueries"`
 	VehicleTokenErrorCodeQueries  ErrorCodeQuerySlice           `boil:"VehicleTokenErrorCodeQueries" json:"VehicleTokenErrorCodeQueries" toml:"VehicleTokenErrorCodeQueries" yaml:"VehicleTokenErrorCodeQueries"`
 	UserDeviceAPIIntegrations     UserDeviceAPIIntegrationSlice `boil:"UserDeviceAPIIntegrations" json:"UserDeviceAPIIntegrations" toml:"UserDeviceAPIIntegrations" yaml:"UserDeviceAPIIntegrations"`
@@ -262,6 +265,13 @@ func (r *userDeviceR) GetDeviceCommandRequests() DeviceCommandRequestSlice {
 	return r.DeviceCommandRequests
 }
 
+func (r *userDeviceR) GetDocuments() DocumentSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Documents
+}
+
 func (r *userDeviceR) GetErrorCodeQueries() ErrorCodeQuerySlice {
 	if r == nil {
 		return nil
@@ -671,6 +681,20 @@ func (o *UserDevice) DeviceCommandRequests(mods ...qm.QueryMod) deviceCommandReq
 	return DeviceCommandRequests(queryMods...)
 }
 
+// Documents retrieves all the document's Documents with an executor.
+func (o *UserDevice) Documents(mods ...qm.QueryMod) documentQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"documents\".\"user_device_id\"=?", o.ID),
+	)
+
+	return Documents(queryMods...)
+}
+
 // ErrorCodeQueries retrieves all the error_code_query's ErrorCodeQueries with an executor.
 func (o *UserDevice) ErrorCodeQueries(mods ...qm.QueryMod) errorCodeQueryQuery {
 	var queryMods []qm.QueryMod
@@ -1421,6 +1445,119 @@ func (userDeviceL) LoadDeviceCommandRequests(ctx context.Context, e boil.Context
 	return nil
 }
 
+// LoadDocuments allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userDeviceL) LoadDocuments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+	var slice []*UserDevice
+	var object *UserDevice
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserDevice.(*UserDevice)
+		if !ok {
+			object = new(UserDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserDevice))
+			}
+		}
+	} else {
+		s, ok := maybeUserDevice.(*[]*UserDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserDevice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userDeviceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userDeviceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.documents`),
+		qm.WhereIn(`devices_api.documents.user_device_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load documents")
+	}
+
+	var resultSlice []*Document
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice documents")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on documents")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for documents")
+	}
+
+	if len(documentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Documents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &documentR{}
+			}
+			foreign.R.UserDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserDeviceID) {
+				local.R.Documents = append(local.R.Documents, foreign)
+				if foreign.R == nil {
+					foreign.R = &documentR{}
+				}
+				foreign.R.UserDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadErrorCodeQueries allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userDeviceL) LoadErrorCodeQueries(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
@@ -2226,6 +2363,133 @@ func (o *UserDevice) AddDeviceCommandRequests(ctx context.Context, exec boil.Con
 	return nil
 }
 
+// AddDocuments adds the given related objects to the existing relationships
+// of the user_device, optionally inserting them as new records.
+// Appends related to o.R.Documents.
+// Sets related.R.UserDevice appropriately.
+func (o *UserDevice) AddDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserDeviceID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"documents\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_device_id"}),
+				strmangle.WhereClause("\"", "\"", 2, documentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserDeviceID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userDeviceR{
+			Documents: related,
+		}
+	} else {
+		o.R.Documents = append(o.R.Documents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &documentR{
+				UserDevice: o,
+			}
+		} else {
+			rel.R.UserDevice = o
+		}
+	}
+	return nil
+}
+
+// SetDocuments removes all previously related items of the
+// user_device replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.UserDevice's Documents accordingly.
+// Replaces o.R.Documents with related.
+// Sets related.R.UserDevice's Documents accordingly.
+func (o *UserDevice) SetDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
+	query := "update \"devices_api\".\"documents\" set \"user_device_id\" = null where \"user_device_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Documents {
+			queries.SetScanner(&rel.UserDeviceID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.UserDevice = nil
+		}
+		o.R.Documents = nil
+	}
+
+	return o.AddDocuments(ctx, exec, insert, related...)
+}
+
+// RemoveDocuments relationships from objects passed in.
+// Removes related items from R.Documents (uses pointer comparison, removal does not keep order)
+// Sets related.R.UserDevice.
+func (o *UserDevice) RemoveDocuments(ctx context.Context, exec boil.ContextExecutor, related ...*Document) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserDeviceID, nil)
+		if rel.R != nil {
+			rel.R.UserDevice = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_device_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Documents {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Documents)
+			if ln > 1 && i < ln-1 {
+				o.R.Documents[i] = o.R.Documents[ln-1]
+			}
+			o.R.Documents = o.R.Documents[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // AddErrorCodeQueries adds the given related objects to the existing relationships
 // of the user_device, optionally inserting them as new records.
 // Appends related to o.R.ErrorCodeQueries.
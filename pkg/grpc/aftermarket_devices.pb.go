@@ -109,21 +109,213 @@ func (x *ListAftermarketDevicesForUserResponse) GetAftermarketDevices() []*After
 	return nil
 }
 
+type ListAftermarketDevicesForOwnerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerAddress  []byte                 `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAftermarketDevicesForOwnerRequest) Reset() {
+	*x = ListAftermarketDevicesForOwnerRequest{}
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAftermarketDevicesForOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAftermarketDevicesForOwnerRequest) ProtoMessage() {}
+
+func (x *ListAftermarketDevicesForOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAftermarketDevicesForOwnerRequest.ProtoReflect.Descriptor instead.
+func (*ListAftermarketDevicesForOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAftermarketDevicesForOwnerRequest) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+type ListAftermarketDevicesForOwnerResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AftermarketDevices []*AftermarketDevice   `protobuf:"bytes,1,rep,name=aftermarket_devices,json=aftermarketDevices,proto3" json:"aftermarket_devices,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListAftermarketDevicesForOwnerResponse) Reset() {
+	*x = ListAftermarketDevicesForOwnerResponse{}
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAftermarketDevicesForOwnerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAftermarketDevicesForOwnerResponse) ProtoMessage() {}
+
+func (x *ListAftermarketDevicesForOwnerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAftermarketDevicesForOwnerResponse.ProtoReflect.Descriptor instead.
+func (*ListAftermarketDevicesForOwnerResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAftermarketDevicesForOwnerResponse) GetAftermarketDevices() []*AftermarketDevice {
+	if x != nil {
+		return x.AftermarketDevices
+	}
+	return nil
+}
+
+type GetAftermarketDeviceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Lookup:
+	//
+	//	*GetAftermarketDeviceRequest_Serial
+	//	*GetAftermarketDeviceRequest_TokenId
+	//	*GetAftermarketDeviceRequest_EthereumAddress
+	Lookup        isGetAftermarketDeviceRequest_Lookup `protobuf_oneof:"lookup"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAftermarketDeviceRequest) Reset() {
+	*x = GetAftermarketDeviceRequest{}
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAftermarketDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAftermarketDeviceRequest) ProtoMessage() {}
+
+func (x *GetAftermarketDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAftermarketDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetAftermarketDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAftermarketDeviceRequest) GetLookup() isGetAftermarketDeviceRequest_Lookup {
+	if x != nil {
+		return x.Lookup
+	}
+	return nil
+}
+
+func (x *GetAftermarketDeviceRequest) GetSerial() string {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetAftermarketDeviceRequest_Serial); ok {
+			return x.Serial
+		}
+	}
+	return ""
+}
+
+func (x *GetAftermarketDeviceRequest) GetTokenId() uint64 {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetAftermarketDeviceRequest_TokenId); ok {
+			return x.TokenId
+		}
+	}
+	return 0
+}
+
+func (x *GetAftermarketDeviceRequest) GetEthereumAddress() []byte {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetAftermarketDeviceRequest_EthereumAddress); ok {
+			return x.EthereumAddress
+		}
+	}
+	return nil
+}
+
+type isGetAftermarketDeviceRequest_Lookup interface {
+	isGetAftermarketDeviceRequest_Lookup()
+}
+
+type GetAftermarketDeviceRequest_Serial struct {
+	Serial string `protobuf:"bytes,1,opt,name=serial,proto3,oneof"`
+}
+
+type GetAftermarketDeviceRequest_TokenId struct {
+	TokenId uint64 `protobuf:"varint,2,opt,name=token_id,json=tokenId,proto3,oneof"`
+}
+
+type GetAftermarketDeviceRequest_EthereumAddress struct {
+	EthereumAddress []byte `protobuf:"bytes,3,opt,name=ethereum_address,json=ethereumAddress,proto3,oneof"`
+}
+
+func (*GetAftermarketDeviceRequest_Serial) isGetAftermarketDeviceRequest_Lookup() {}
+
+func (*GetAftermarketDeviceRequest_TokenId) isGetAftermarketDeviceRequest_Lookup() {}
+
+func (*GetAftermarketDeviceRequest_EthereumAddress) isGetAftermarketDeviceRequest_Lookup() {}
+
 type AftermarketDevice struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Serial              string                 `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
-	UserId              *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
-	OwnerAddress        []byte                 `protobuf:"bytes,3,opt,name=owner_address,json=ownerAddress,proto3,oneof" json:"owner_address,omitempty"`
-	TokenId             uint64                 `protobuf:"varint,4,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
-	ManufacturerTokenId uint64                 `protobuf:"varint,5,opt,name=manufacturer_token_id,json=manufacturerTokenId,proto3" json:"manufacturer_token_id,omitempty"`
-	Beneficiary         []byte                 `protobuf:"bytes,6,opt,name=beneficiary,proto3,oneof" json:"beneficiary,omitempty"`
+	state               protoimpl.MessageState        `protogen:"open.v1"`
+	Serial              string                        `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	UserId              *string                       `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	OwnerAddress        []byte                        `protobuf:"bytes,3,opt,name=owner_address,json=ownerAddress,proto3,oneof" json:"owner_address,omitempty"`
+	TokenId             uint64                        `protobuf:"varint,4,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	ManufacturerTokenId uint64                        `protobuf:"varint,5,opt,name=manufacturer_token_id,json=manufacturerTokenId,proto3" json:"manufacturer_token_id,omitempty"`
+	Beneficiary         []byte                        `protobuf:"bytes,6,opt,name=beneficiary,proto3,oneof" json:"beneficiary,omitempty"`
+	EthereumAddress     []byte                        `protobuf:"bytes,7,opt,name=ethereum_address,json=ethereumAddress,proto3" json:"ethereum_address,omitempty"`
+	VehicleTokenId      *uint64                       `protobuf:"varint,8,opt,name=vehicle_token_id,json=vehicleTokenId,proto3,oneof" json:"vehicle_token_id,omitempty"`
+	ClaimRequest        *AftermarketDeviceTransaction `protobuf:"bytes,9,opt,name=claim_request,json=claimRequest,proto3" json:"claim_request,omitempty"`
+	PairRequest         *AftermarketDeviceTransaction `protobuf:"bytes,10,opt,name=pair_request,json=pairRequest,proto3" json:"pair_request,omitempty"`
+	UnpairRequest       *AftermarketDeviceTransaction `protobuf:"bytes,11,opt,name=unpair_request,json=unpairRequest,proto3" json:"unpair_request,omitempty"`
+	Attributes          map[string]string             `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *AftermarketDevice) Reset() {
 	*x = AftermarketDevice{}
-	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[2]
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -135,7 +327,7 @@ func (x *AftermarketDevice) String() string {
 func (*AftermarketDevice) ProtoMessage() {}
 
 func (x *AftermarketDevice) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[2]
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -148,7 +340,7 @@ func (x *AftermarketDevice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AftermarketDevice.ProtoReflect.Descriptor instead.
 func (*AftermarketDevice) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{2}
+	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AftermarketDevice) GetSerial() string {
@@ -193,6 +385,116 @@ func (x *AftermarketDevice) GetBeneficiary() []byte {
 	return nil
 }
 
+func (x *AftermarketDevice) GetEthereumAddress() []byte {
+	if x != nil {
+		return x.EthereumAddress
+	}
+	return nil
+}
+
+func (x *AftermarketDevice) GetVehicleTokenId() uint64 {
+	if x != nil && x.VehicleTokenId != nil {
+		return *x.VehicleTokenId
+	}
+	return 0
+}
+
+func (x *AftermarketDevice) GetClaimRequest() *AftermarketDeviceTransaction {
+	if x != nil {
+		return x.ClaimRequest
+	}
+	return nil
+}
+
+func (x *AftermarketDevice) GetPairRequest() *AftermarketDeviceTransaction {
+	if x != nil {
+		return x.PairRequest
+	}
+	return nil
+}
+
+func (x *AftermarketDevice) GetUnpairRequest() *AftermarketDeviceTransaction {
+	if x != nil {
+		return x.UnpairRequest
+	}
+	return nil
+}
+
+func (x *AftermarketDevice) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type AftermarketDeviceTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,3,opt,name=hash,proto3,oneof" json:"hash,omitempty"`
+	FailureReason *string                `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AftermarketDeviceTransaction) Reset() {
+	*x = AftermarketDeviceTransaction{}
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AftermarketDeviceTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AftermarketDeviceTransaction) ProtoMessage() {}
+
+func (x *AftermarketDeviceTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_aftermarket_devices_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AftermarketDeviceTransaction.ProtoReflect.Descriptor instead.
+func (*AftermarketDeviceTransaction) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AftermarketDeviceTransaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AftermarketDeviceTransaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AftermarketDeviceTransaction) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *AftermarketDeviceTransaction) GetFailureReason() string {
+	if x != nil && x.FailureReason != nil {
+		return *x.FailureReason
+	}
+	return ""
+}
+
 var File_pkg_grpc_aftermarket_devices_proto protoreflect.FileDescriptor
 
 const file_pkg_grpc_aftermarket_devices_proto_rawDesc = "" +
@@ -201,20 +503,51 @@ const file_pkg_grpc_aftermarket_devices_proto_rawDesc = "" +
 	"$ListAftermarketDevicesForUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"t\n" +
 	"%ListAftermarketDevicesForUserResponse\x12K\n" +
-	"\x13aftermarket_devices\x18\x01 \x03(\v2\x1a.devices.AftermarketDeviceR\x12aftermarketDevices\"\x97\x02\n" +
+	"\x13aftermarket_devices\x18\x01 \x03(\v2\x1a.devices.AftermarketDeviceR\x12aftermarketDevices\"L\n" +
+	"%ListAftermarketDevicesForOwnerRequest\x12#\n" +
+	"\rowner_address\x18\x01 \x01(\fR\fownerAddress\"u\n" +
+	"&ListAftermarketDevicesForOwnerResponse\x12K\n" +
+	"\x13aftermarket_devices\x18\x01 \x03(\v2\x1a.devices.AftermarketDeviceR\x12aftermarketDevices\"\x8b\x01\n" +
+	"\x1bGetAftermarketDeviceRequest\x12\x18\n" +
+	"\x06serial\x18\x01 \x01(\tH\x00R\x06serial\x12\x1b\n" +
+	"\btoken_id\x18\x02 \x01(\x04H\x00R\atokenId\x12+\n" +
+	"\x10ethereum_address\x18\x03 \x01(\fH\x00R\x0fethereumAddressB\b\n" +
+	"\x06lookup\"\xf5\x05\n" +
 	"\x11AftermarketDevice\x12\x16\n" +
 	"\x06serial\x18\x01 \x01(\tR\x06serial\x12\x1c\n" +
 	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12(\n" +
 	"\rowner_address\x18\x03 \x01(\fH\x01R\fownerAddress\x88\x01\x01\x12\x19\n" +
 	"\btoken_id\x18\x04 \x01(\x04R\atokenId\x122\n" +
 	"\x15manufacturer_token_id\x18\x05 \x01(\x04R\x13manufacturerTokenId\x12%\n" +
-	"\vbeneficiary\x18\x06 \x01(\fH\x02R\vbeneficiary\x88\x01\x01B\n" +
+	"\vbeneficiary\x18\x06 \x01(\fH\x02R\vbeneficiary\x88\x01\x01\x12)\n" +
+	"\x10ethereum_address\x18\a \x01(\fR\x0fethereumAddress\x12-\n" +
+	"\x10vehicle_token_id\x18\b \x01(\x04H\x03R\x0evehicleTokenId\x88\x01\x01\x12J\n" +
+	"\rclaim_request\x18\t \x01(\v2%.devices.AftermarketDeviceTransactionR\fclaimRequest\x12H\n" +
+	"\fpair_request\x18\n" +
+	" \x01(\v2%.devices.AftermarketDeviceTransactionR\vpairRequest\x12L\n" +
+	"\x0eunpair_request\x18\v \x01(\v2%.devices.AftermarketDeviceTransactionR\runpairRequest\x12J\n" +
+	"\n" +
+	"attributes\x18\f \x03(\v2*.devices.AftermarketDevice.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
 	"\n" +
 	"\b_user_idB\x10\n" +
 	"\x0e_owner_addressB\x0e\n" +
-	"\f_beneficiary2\x9a\x01\n" +
+	"\f_beneficiaryB\x13\n" +
+	"\x11_vehicle_token_id\"\xa7\x01\n" +
+	"\x1cAftermarketDeviceTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x17\n" +
+	"\x04hash\x18\x03 \x01(\fH\x00R\x04hash\x88\x01\x01\x12*\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tH\x01R\rfailureReason\x88\x01\x01B\a\n" +
+	"\x05_hashB\x11\n" +
+	"\x0f_failure_reason2\xf8\x02\n" +
 	"\x18AftermarketDeviceService\x12~\n" +
-	"\x1dListAftermarketDevicesForUser\x12-.devices.ListAftermarketDevicesForUserRequest\x1a..devices.ListAftermarketDevicesForUserResponseB.Z,github.com/DIMO-Network/devices-api/pkg/grpcb\x06proto3"
+	"\x1dListAftermarketDevicesForUser\x12-.devices.ListAftermarketDevicesForUserRequest\x1a..devices.ListAftermarketDevicesForUserResponse\x12\x81\x01\n" +
+	"\x1eListAftermarketDevicesForOwner\x12..devices.ListAftermarketDevicesForOwnerRequest\x1a/.devices.ListAftermarketDevicesForOwnerResponse\x12X\n" +
+	"\x14GetAftermarketDevice\x12$.devices.GetAftermarketDeviceRequest\x1a\x1a.devices.AftermarketDeviceB.Z,github.com/DIMO-Network/devices-api/pkg/grpcb\x06proto3"
 
 var (
 	file_pkg_grpc_aftermarket_devices_proto_rawDescOnce sync.Once
@@ -228,21 +561,35 @@ func file_pkg_grpc_aftermarket_devices_proto_rawDescGZIP() []byte {
 	return file_pkg_grpc_aftermarket_devices_proto_rawDescData
 }
 
-var file_pkg_grpc_aftermarket_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_grpc_aftermarket_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_pkg_grpc_aftermarket_devices_proto_goTypes = []any{
-	(*ListAftermarketDevicesForUserRequest)(nil),  // 0: devices.ListAftermarketDevicesForUserRequest
-	(*ListAftermarketDevicesForUserResponse)(nil), // 1: devices.ListAftermarketDevicesForUserResponse
-	(*AftermarketDevice)(nil),                     // 2: devices.AftermarketDevice
+	(*ListAftermarketDevicesForUserRequest)(nil),   // 0: devices.ListAftermarketDevicesForUserRequest
+	(*ListAftermarketDevicesForUserResponse)(nil),  // 1: devices.ListAftermarketDevicesForUserResponse
+	(*ListAftermarketDevicesForOwnerRequest)(nil),  // 2: devices.ListAftermarketDevicesForOwnerRequest
+	(*ListAftermarketDevicesForOwnerResponse)(nil), // 3: devices.ListAftermarketDevicesForOwnerResponse
+	(*GetAftermarketDeviceRequest)(nil),            // 4: devices.GetAftermarketDeviceRequest
+	(*AftermarketDevice)(nil),                      // 5: devices.AftermarketDevice
+	(*AftermarketDeviceTransaction)(nil),           // 6: devices.AftermarketDeviceTransaction
+	nil,                                            // 7: devices.AftermarketDevice.AttributesEntry
 }
 var file_pkg_grpc_aftermarket_devices_proto_depIdxs = []int32{
-	2, // 0: devices.ListAftermarketDevicesForUserResponse.aftermarket_devices:type_name -> devices.AftermarketDevice
-	0, // 1: devices.AftermarketDeviceService.ListAftermarketDevicesForUser:input_type -> devices.ListAftermarketDevicesForUserRequest
-	1, // 2: devices.AftermarketDeviceService.ListAftermarketDevicesForUser:output_type -> devices.ListAftermarketDevicesForUserResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 0: devices.ListAftermarketDevicesForUserResponse.aftermarket_devices:type_name -> devices.AftermarketDevice
+	5, // 1: devices.ListAftermarketDevicesForOwnerResponse.aftermarket_devices:type_name -> devices.AftermarketDevice
+	6, // 2: devices.AftermarketDevice.claim_request:type_name -> devices.AftermarketDeviceTransaction
+	6, // 3: devices.AftermarketDevice.pair_request:type_name -> devices.AftermarketDeviceTransaction
+	6, // 4: devices.AftermarketDevice.unpair_request:type_name -> devices.AftermarketDeviceTransaction
+	7, // 5: devices.AftermarketDevice.attributes:type_name -> devices.AftermarketDevice.AttributesEntry
+	0, // 6: devices.AftermarketDeviceService.ListAftermarketDevicesForUser:input_type -> devices.ListAftermarketDevicesForUserRequest
+	2, // 7: devices.AftermarketDeviceService.ListAftermarketDevicesForOwner:input_type -> devices.ListAftermarketDevicesForOwnerRequest
+	4, // 8: devices.AftermarketDeviceService.GetAftermarketDevice:input_type -> devices.GetAftermarketDeviceRequest
+	1, // 9: devices.AftermarketDeviceService.ListAftermarketDevicesForUser:output_type -> devices.ListAftermarketDevicesForUserResponse
+	3, // 10: devices.AftermarketDeviceService.ListAftermarketDevicesForOwner:output_type -> devices.ListAftermarketDevicesForOwnerResponse
+	5, // 11: devices.AftermarketDeviceService.GetAftermarketDevice:output_type -> devices.AftermarketDevice
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_pkg_grpc_aftermarket_devices_proto_init() }
@@ -250,14 +597,20 @@ func file_pkg_grpc_aftermarket_devices_proto_init() {
 	if File_pkg_grpc_aftermarket_devices_proto != nil {
 		return
 	}
-	file_pkg_grpc_aftermarket_devices_proto_msgTypes[2].OneofWrappers = []any{}
+	file_pkg_grpc_aftermarket_devices_proto_msgTypes[4].OneofWrappers = []any{
+		(*GetAftermarketDeviceRequest_Serial)(nil),
+		(*GetAftermarketDeviceRequest_TokenId)(nil),
+		(*GetAftermarketDeviceRequest_EthereumAddress)(nil),
+	}
+	file_pkg_grpc_aftermarket_devices_proto_msgTypes[5].OneofWrappers = []any{}
+	file_pkg_grpc_aftermarket_devices_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_grpc_aftermarket_devices_proto_rawDesc), len(file_pkg_grpc_aftermarket_devices_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AftermarketDeviceService_ListAftermarketDevicesForUser_FullMethodName = "/devices.AftermarketDeviceService/ListAftermarketDevicesForUser"
+	AftermarketDeviceService_ListAftermarketDevicesForUser_FullMethodName  = "/devices.AftermarketDeviceService/ListAftermarketDevicesForUser"
+	AftermarketDeviceService_ListAftermarketDevicesForOwner_FullMethodName = "/devices.AftermarketDeviceService/ListAftermarketDevicesForOwner"
+	AftermarketDeviceService_GetAftermarketDevice_FullMethodName           = "/devices.AftermarketDeviceService/GetAftermarketDevice"
 )
 
 // AftermarketDeviceServiceClient is the client API for AftermarketDeviceService service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AftermarketDeviceServiceClient interface {
 	ListAftermarketDevicesForUser(ctx context.Context, in *ListAftermarketDevicesForUserRequest, opts ...grpc.CallOption) (*ListAftermarketDevicesForUserResponse, error)
+	ListAftermarketDevicesForOwner(ctx context.Context, in *ListAftermarketDevicesForOwnerRequest, opts ...grpc.CallOption) (*ListAftermarketDevicesForOwnerResponse, error)
+	GetAftermarketDevice(ctx context.Context, in *GetAftermarketDeviceRequest, opts ...grpc.CallOption) (*AftermarketDevice, error)
 }
 
 type aftermarketDeviceServiceClient struct {
@@ -47,11 +51,33 @@ func (c *aftermarketDeviceServiceClient) ListAftermarketDevicesForUser(ctx conte
 	return out, nil
 }
 
+func (c *aftermarketDeviceServiceClient) ListAftermarketDevicesForOwner(ctx context.Context, in *ListAftermarketDevicesForOwnerRequest, opts ...grpc.CallOption) (*ListAftermarketDevicesForOwnerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAftermarketDevicesForOwnerResponse)
+	err := c.cc.Invoke(ctx, AftermarketDeviceService_ListAftermarketDevicesForOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aftermarketDeviceServiceClient) GetAftermarketDevice(ctx context.Context, in *GetAftermarketDeviceRequest, opts ...grpc.CallOption) (*AftermarketDevice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AftermarketDevice)
+	err := c.cc.Invoke(ctx, AftermarketDeviceService_GetAftermarketDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AftermarketDeviceServiceServer is the server API for AftermarketDeviceService service.
 // All implementations must embed UnimplementedAftermarketDeviceServiceServer
 // for forward compatibility.
 type AftermarketDeviceServiceServer interface {
 	ListAftermarketDevicesForUser(context.Context, *ListAftermarketDevicesForUserRequest) (*ListAftermarketDevicesForUserResponse, error)
+	ListAftermarketDevicesForOwner(context.Context, *ListAftermarketDevicesForOwnerRequest) (*ListAftermarketDevicesForOwnerResponse, error)
+	GetAftermarketDevice(context.Context, *GetAftermarketDeviceRequest) (*AftermarketDevice, error)
 	mustEmbedUnimplementedAftermarketDeviceServiceServer()
 }
 
@@ -65,6 +91,12 @@ type UnimplementedAftermarketDeviceServiceServer struct{}
 func (UnimplementedAftermarketDeviceServiceServer) ListAftermarketDevicesForUser(context.Context, *ListAftermarketDevicesForUserRequest) (*ListAftermarketDevicesForUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAftermarketDevicesForUser not implemented")
 }
+func (UnimplementedAftermarketDeviceServiceServer) ListAftermarketDevicesForOwner(context.Context, *ListAftermarketDevicesForOwnerRequest) (*ListAftermarketDevicesForOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAftermarketDevicesForOwner not implemented")
+}
+func (UnimplementedAftermarketDeviceServiceServer) GetAftermarketDevice(context.Context, *GetAftermarketDeviceRequest) (*AftermarketDevice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAftermarketDevice not implemented")
+}
 func (UnimplementedAftermarketDeviceServiceServer) mustEmbedUnimplementedAftermarketDeviceServiceServer() {
 }
 func (UnimplementedAftermarketDeviceServiceServer) testEmbeddedByValue() {}
@@ -105,6 +137,42 @@ func _AftermarketDeviceService_ListAftermarketDevicesForUser_Handler(srv interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AftermarketDeviceService_ListAftermarketDevicesForOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAftermarketDevicesForOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AftermarketDeviceServiceServer).ListAftermarketDevicesForOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AftermarketDeviceService_ListAftermarketDevicesForOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AftermarketDeviceServiceServer).ListAftermarketDevicesForOwner(ctx, req.(*ListAftermarketDevicesForOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AftermarketDeviceService_GetAftermarketDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAftermarketDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AftermarketDeviceServiceServer).GetAftermarketDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AftermarketDeviceService_GetAftermarketDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AftermarketDeviceServiceServer).GetAftermarketDevice(ctx, req.(*GetAftermarketDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AftermarketDeviceService_ServiceDesc is the grpc.ServiceDesc for AftermarketDeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +184,14 @@ var AftermarketDeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAftermarketDevicesForUser",
 			Handler:    _AftermarketDeviceService_ListAftermarketDevicesForUser_Handler,
 		},
+		{
+			MethodName: "ListAftermarketDevicesForOwner",
+			Handler:    _AftermarketDeviceService_ListAftermarketDevicesForOwner_Handler,
+		},
+		{
+			MethodName: "GetAftermarketDevice",
+			Handler:    _AftermarketDeviceService_GetAftermarketDevice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/grpc/aftermarket_devices.proto",
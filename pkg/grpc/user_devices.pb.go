@@ -920,8 +920,10 @@ type ApplyHardwareTemplateRequest struct {
 	UserDeviceId       string                 `protobuf:"bytes,2,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
 	AutoApiUnitId      string                 `protobuf:"bytes,3,opt,name=auto_api_unit_id,json=autoApiUnitId,proto3" json:"auto_api_unit_id,omitempty"`
 	HardwareTemplateId string                 `protobuf:"bytes,4,opt,name=hardware_template_id,json=hardwareTemplateId,proto3" json:"hardware_template_id,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Why the template is being applied. Kept in the device's template history.
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ApplyHardwareTemplateRequest) Reset() {
@@ -982,6 +984,13 @@ func (x *ApplyHardwareTemplateRequest) GetHardwareTemplateId() string {
 	return ""
 }
 
+func (x *ApplyHardwareTemplateRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type ApplyHardwareTemplateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Applied       bool                   `protobuf:"varint,1,opt,name=Applied,proto3" json:"Applied,omitempty"`
@@ -1740,140 +1749,1585 @@ func (x *DeleteUnMintedUserDeviceRequest) GetUserDeviceId() string {
 	return ""
 }
 
-var File_pkg_grpc_user_devices_proto protoreflect.FileDescriptor
+type ListVehicleCommandsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TokenId uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// Page size. Defaults to 20, at most 100.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor returned with the previous page.
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_pkg_grpc_user_devices_proto_rawDesc = "" +
-	"\n" +
-	"\x1bpkg/grpc/user_devices.proto\x12\adevices\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\"pkg/grpc/aftermarket_devices.proto\";\n" +
-	"\x1eGetVehicleByTokenIdFastRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\rR\atokenId\"3\n" +
-	"\x1fGetVehicleByTokenIdFastResponse\x12\x10\n" +
-	"\x03vin\x18\x01 \x01(\tR\x03vin\"4\n" +
-	"\"GetUserDeviceByAutoPIUnitIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
-	"\x14GetUserDeviceRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
-	"\x19GetUserDeviceByVINRequest\x12\x10\n" +
-	"\x03vin\x18\x01 \x01(\tR\x03vin\":\n" +
-	"\x1dGetUserDeviceByEthAddrRequest\x12\x19\n" +
-	"\beth_addr\x18\x01 \x01(\fR\aethAddr\":\n" +
-	"\x1dGetUserDeviceByTokenIdRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\x03R\atokenId\"\x9f\x02\n" +
-	"\x1fUpdateUserDeviceMetadataRequest\x12$\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12$\n" +
-	"\vpostal_code\x18\x02 \x01(\tH\x00R\n" +
-	"postalCode\x88\x01\x01\x123\n" +
-	"\x13geo_decoded_country\x18\x03 \x01(\tH\x01R\x11geoDecodedCountry\x88\x01\x01\x128\n" +
-	"\x16geo_decoded_state_prov\x18\x04 \x01(\tH\x02R\x13geoDecodedStateProv\x88\x01\x01B\x0e\n" +
-	"\f_postal_codeB\x16\n" +
-	"\x14_geo_decoded_countryB\x19\n" +
-	"\x17_geo_decoded_state_prov\"\xad\n" +
-	"\n" +
-	"\n" +
-	"UserDevice\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1e\n" +
-	"\btoken_id\x18\x03 \x01(\x04H\x00R\atokenId\x88\x01\x01\x12?\n" +
-	"\vopted_in_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\toptedInAt\x88\x01\x01\x12(\n" +
-	"\rowner_address\x18\x05 \x01(\fH\x02R\fownerAddress\x88\x01\x01\x12F\n" +
-	"\x1baftermarket_device_token_id\x18\x06 \x01(\x04B\x02\x18\x01H\x03R\x18aftermarketDeviceTokenId\x88\x01\x01\x12B\n" +
-	"\fintegrations\x18\a \x03(\v2\x1e.devices.UserDeviceIntegrationR\fintegrations\x12\x15\n" +
-	"\x03vin\x18\b \x01(\tH\x04R\x03vin\x88\x01\x01\x124\n" +
-	"\x14device_definition_id\x18\t \x01(\tB\x02\x18\x01R\x12deviceDefinitionId\x12+\n" +
-	"\x0fdevice_style_id\x18\n" +
-	" \x01(\tH\x05R\rdeviceStyleId\x88\x01\x01\x12\\\n" +
-	"&aftermarket_device_beneficiary_address\x18\v \x01(\fB\x02\x18\x01H\x06R#aftermarketDeviceBeneficiaryAddress\x88\x01\x01\x12S\n" +
-	"\x15latest_vin_credential\x18\f \x01(\v2\x16.devices.VinCredentialB\x02\x18\x01H\aR\x13latestVinCredential\x88\x01\x01\x12#\n" +
-	"\rvin_confirmed\x18\r \x01(\bR\fvinConfirmed\x12!\n" +
-	"\fcountry_code\x18\x0e \x01(\tR\vcountryCode\x12(\n" +
-	"\x10power_train_type\x18\x0f \x01(\tR\x0epowerTrainType\x12!\n" +
-	"\fCAN_protocol\x18\x10 \x01(\tR\vCANProtocol\x12\x1f\n" +
-	"\vpostal_code\x18\x11 \x01(\tR\n" +
-	"postalCode\x12.\n" +
-	"\x13geo_decoded_country\x18\x12 \x01(\tR\x11geoDecodedCountry\x123\n" +
-	"\x16geo_decoded_state_prov\x18\x13 \x01(\tR\x13geoDecodedStateProv\x12N\n" +
-	"\x12aftermarket_device\x18\x14 \x01(\v2\x1a.devices.AftermarketDeviceH\bR\x11aftermarketDevice\x88\x01\x01\x12G\n" +
-	"\x0fsyntheticDevice\x18\x15 \x01(\v2\x18.devices.SyntheticDeviceH\tR\x0fsyntheticDevice\x88\x01\x01\x12#\n" +
-	"\rdefinition_id\x18\x16 \x01(\tR\fdefinitionIdB\v\n" +
-	"\t_token_idB\x0e\n" +
-	"\f_opted_in_atB\x10\n" +
-	"\x0e_owner_addressB\x1e\n" +
-	"\x1c_aftermarket_device_token_idB\x06\n" +
-	"\x04_vinB\x12\n" +
-	"\x10_device_style_idB)\n" +
-	"'_aftermarket_device_beneficiary_addressB\x18\n" +
-	"\x16_latest_vin_credentialB\x15\n" +
-	"\x13_aftermarket_deviceB\x12\n" +
-	"\x10_syntheticDevice\"\x93\x01\n" +
-	"\x0fSyntheticDevice\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x120\n" +
-	"\x14integration_token_id\x18\x02 \x01(\x04R\x12integrationTokenId\x123\n" +
-	"\x16wallet_child_number_id\x18\x03 \x01(\x04R\x13walletChildNumberId\"`\n" +
-	"\x15UserDeviceIntegration\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
-	"\vexternal_id\x18\x03 \x01(\tR\n" +
-	"externalId\"\xb7\x01\n" +
-	"\x1cUserDeviceAutoPIUnitResponse\x12$\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
-	"\x14device_definition_id\x18\x03 \x01(\tR\x12deviceDefinitionId\x12&\n" +
-	"\x0fdevice_style_id\x18\x04 \x01(\tR\rdeviceStyleId\"c\n" +
-	"\x1dListUserDevicesForUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
-	"\x10ethereum_address\x18\x02 \x01(\tR\x0fethereumAddress\"X\n" +
-	"\x1eListUserDevicesForUserResponse\x126\n" +
-	"\fuser_devices\x18\x01 \x03(\v2\x13.devices.UserDeviceR\vuserDevices\"\xb8\x01\n" +
-	"\x1cApplyHardwareTemplateRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
-	"\x0euser_device_id\x18\x02 \x01(\tR\fuserDeviceId\x12'\n" +
-	"\x10auto_api_unit_id\x18\x03 \x01(\tR\rautoApiUnitId\x120\n" +
-	"\x14hardware_template_id\x18\x04 \x01(\tR\x12hardwareTemplateId\"9\n" +
-	"\x1dApplyHardwareTemplateResponse\x12\x18\n" +
-	"\aApplied\x18\x01 \x01(\bR\aApplied\"w\n" +
-	"\x15ClaimedVehiclesGrowth\x122\n" +
-	"\x14TotalClaimedVehicles\x18\x01 \x01(\x03R\x14TotalClaimedVehicles\x12*\n" +
-	"\x10GrowthPercentage\x18\x02 \x01(\x02R\x10GrowthPercentage\"C\n" +
-	"\x15CreateTemplateRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06parent\x18\x02 \x01(\x03R\x06parent\"(\n" +
-	"\x16CreateTemplateResponse\x12\x0e\n" +
-	"\x02Id\x18\x01 \x01(\x03R\x02Id\"\xca\x01\n" +
-	" RegisterUserDeviceFromVINRequest\x12(\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tB\x02\x18\x01R\fuserDeviceId\x12\x10\n" +
-	"\x03vin\x18\x02 \x01(\tR\x03vin\x12!\n" +
-	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x12\"\n" +
-	"\fvinConfirmed\x18\x04 \x01(\bR\fvinConfirmed\x12#\n" +
-	"\rowner_address\x18\x05 \x01(\fR\fownerAddress\"=\n" +
-	"!RegisterUserDeviceFromVINResponse\x12\x18\n" +
-	"\acreated\x18\x01 \x01(\bR\acreated\"[\n" +
-	"\rVinCredential\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12:\n" +
-	"\n" +
-	"expiration\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"expiration\"\x8b\x01\n" +
-	"$UpdateDeviceIntegrationStatusRequest\x12$\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12%\n" +
-	"\x0eintegration_id\x18\x02 \x01(\tR\rintegrationId\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"\x83\x01\n" +
-	"\x19IssueVinCredentialRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x10\n" +
-	"\x03vin\x18\x02 \x01(\tR\x03vin\x129\n" +
-	"\n" +
-	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"A\n" +
-	"\x1aIssueVinCredentialResponse\x12#\n" +
-	"\rcredential_id\x18\x01 \x01(\tR\fcredentialId\"+\n" +
-	"\x17GetAllUserDeviceRequest\x12\x10\n" +
-	"\x03wmi\x18\x01 \x01(\tR\x03wmi\"6\n" +
-	"$ClearMetaTransactionRequestsResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
-	" StopUserDeviceIntegrationRequest\x12$\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12%\n" +
-	"\x0eintegration_id\x18\x02 \x01(\tR\rintegrationId\"1\n" +
-	"\x14DeleteVehicleRequest\x12\x19\n" +
-	"\btoken_id\x18\x01 \x01(\x04R\atokenId\"G\n" +
-	"\x1fDeleteUnMintedUserDeviceRequest\x12$\n" +
-	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId2\xa1\r\n" +
+func (x *ListVehicleCommandsRequest) Reset() {
+	*x = ListVehicleCommandsRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehicleCommandsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehicleCommandsRequest) ProtoMessage() {}
+
+func (x *ListVehicleCommandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehicleCommandsRequest.ProtoReflect.Descriptor instead.
+func (*ListVehicleCommandsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListVehicleCommandsRequest) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *ListVehicleCommandsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListVehicleCommandsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type VehicleCommand struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Command path, e.g., "doors/unlock".
+	Command       string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	IntegrationId string `protobuf:"bytes,3,opt,name=integration_id,json=integrationId,proto3" json:"integration_id,omitempty"`
+	// One of "Pending", "Complete", "Failed", or "TimedOut".
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleCommand) Reset() {
+	*x = VehicleCommand{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleCommand) ProtoMessage() {}
+
+func (x *VehicleCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleCommand.ProtoReflect.Descriptor instead.
+func (*VehicleCommand) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VehicleCommand) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *VehicleCommand) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *VehicleCommand) GetIntegrationId() string {
+	if x != nil {
+		return x.IntegrationId
+	}
+	return ""
+}
+
+func (x *VehicleCommand) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VehicleCommand) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *VehicleCommand) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListVehicleCommandsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Commands []*VehicleCommand      `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVehicleCommandsResponse) Reset() {
+	*x = ListVehicleCommandsResponse{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehicleCommandsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehicleCommandsResponse) ProtoMessage() {}
+
+func (x *ListVehicleCommandsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehicleCommandsResponse.ProtoReflect.Descriptor instead.
+func (*ListVehicleCommandsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListVehicleCommandsResponse) GetCommands() []*VehicleCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *ListVehicleCommandsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetVehicleCommandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       uint64                 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleCommandRequest) Reset() {
+	*x = GetVehicleCommandRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleCommandRequest) ProtoMessage() {}
+
+func (x *GetVehicleCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleCommandRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleCommandRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetVehicleCommandRequest) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *GetVehicleCommandRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type MintBatchVehicleInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Vin   string                 `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	// Device definition slug, e.g., "honda_accord_2003".
+	DefinitionId  string `protobuf:"bytes,2,opt,name=definition_id,json=definitionId,proto3" json:"definition_id,omitempty"`
+	CountryCode   string `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintBatchVehicleInput) Reset() {
+	*x = MintBatchVehicleInput{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintBatchVehicleInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintBatchVehicleInput) ProtoMessage() {}
+
+func (x *MintBatchVehicleInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintBatchVehicleInput.ProtoReflect.Descriptor instead.
+func (*MintBatchVehicleInput) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MintBatchVehicleInput) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *MintBatchVehicleInput) GetDefinitionId() string {
+	if x != nil {
+		return x.DefinitionId
+	}
+	return ""
+}
+
+func (x *MintBatchVehicleInput) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type CreateMintBatchRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	OwnerAddress  []byte                   `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	Vehicles      []*MintBatchVehicleInput `protobuf:"bytes,2,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMintBatchRequest) Reset() {
+	*x = CreateMintBatchRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMintBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMintBatchRequest) ProtoMessage() {}
+
+func (x *CreateMintBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMintBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateMintBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateMintBatchRequest) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *CreateMintBatchRequest) GetVehicles() []*MintBatchVehicleInput {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type GetMintBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerAddress  []byte                 `protobuf:"bytes,2,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMintBatchRequest) Reset() {
+	*x = GetMintBatchRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMintBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMintBatchRequest) ProtoMessage() {}
+
+func (x *GetMintBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMintBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetMintBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetMintBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetMintBatchRequest) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+type MintBatchVehicle struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserDeviceId string                 `protobuf:"bytes,1,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
+	Vin          string                 `protobuf:"bytes,2,opt,name=vin,proto3" json:"vin,omitempty"`
+	DefinitionId string                 `protobuf:"bytes,3,opt,name=definition_id,json=definitionId,proto3" json:"definition_id,omitempty"`
+	RequestId    string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// One of "Unsubmitted", "Submitted", "Mined", "Confirmed", or "Failed".
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	FailureReason string `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// JSON-encoded EIP-712 payload to sign. Only set while the vehicle awaits its signature.
+	TypedData     string `protobuf:"bytes,7,opt,name=typed_data,json=typedData,proto3" json:"typed_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintBatchVehicle) Reset() {
+	*x = MintBatchVehicle{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintBatchVehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintBatchVehicle) ProtoMessage() {}
+
+func (x *MintBatchVehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintBatchVehicle.ProtoReflect.Descriptor instead.
+func (*MintBatchVehicle) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *MintBatchVehicle) GetUserDeviceId() string {
+	if x != nil {
+		return x.UserDeviceId
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetDefinitionId() string {
+	if x != nil {
+		return x.DefinitionId
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *MintBatchVehicle) GetTypedData() string {
+	if x != nil {
+		return x.TypedData
+	}
+	return ""
+}
+
+type MintBatch struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerAddress []byte                 `protobuf:"bytes,2,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Sorted by VIN.
+	Vehicles      []*MintBatchVehicle `protobuf:"bytes,4,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintBatch) Reset() {
+	*x = MintBatch{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintBatch) ProtoMessage() {}
+
+func (x *MintBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintBatch.ProtoReflect.Descriptor instead.
+func (*MintBatch) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MintBatch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MintBatch) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *MintBatch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MintBatch) GetVehicles() []*MintBatchVehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type MintBatchSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserDeviceId  string                 `protobuf:"bytes,1,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintBatchSignature) Reset() {
+	*x = MintBatchSignature{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintBatchSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintBatchSignature) ProtoMessage() {}
+
+func (x *MintBatchSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintBatchSignature.ProtoReflect.Descriptor instead.
+func (*MintBatchSignature) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *MintBatchSignature) GetUserDeviceId() string {
+	if x != nil {
+		return x.UserDeviceId
+	}
+	return ""
+}
+
+func (x *MintBatchSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SubmitMintBatchSignaturesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerAddress  []byte                 `protobuf:"bytes,2,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	Signatures    []*MintBatchSignature  `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitMintBatchSignaturesRequest) Reset() {
+	*x = SubmitMintBatchSignaturesRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitMintBatchSignaturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitMintBatchSignaturesRequest) ProtoMessage() {}
+
+func (x *SubmitMintBatchSignaturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitMintBatchSignaturesRequest.ProtoReflect.Descriptor instead.
+func (*SubmitMintBatchSignaturesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SubmitMintBatchSignaturesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitMintBatchSignaturesRequest) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *SubmitMintBatchSignaturesRequest) GetSignatures() []*MintBatchSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type MintBatchSignatureResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserDeviceId string                 `protobuf:"bytes,1,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
+	// Set if the mint was sent.
+	RequestId string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Set if the signature was turned down or the mint couldn't be sent.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintBatchSignatureResult) Reset() {
+	*x = MintBatchSignatureResult{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintBatchSignatureResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintBatchSignatureResult) ProtoMessage() {}
+
+func (x *MintBatchSignatureResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintBatchSignatureResult.ProtoReflect.Descriptor instead.
+func (*MintBatchSignatureResult) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MintBatchSignatureResult) GetUserDeviceId() string {
+	if x != nil {
+		return x.UserDeviceId
+	}
+	return ""
+}
+
+func (x *MintBatchSignatureResult) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *MintBatchSignatureResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SubmitMintBatchSignaturesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Results       []*MintBatchSignatureResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitMintBatchSignaturesResponse) Reset() {
+	*x = SubmitMintBatchSignaturesResponse{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitMintBatchSignaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitMintBatchSignaturesResponse) ProtoMessage() {}
+
+func (x *SubmitMintBatchSignaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitMintBatchSignaturesResponse.ProtoReflect.Descriptor instead.
+func (*SubmitMintBatchSignaturesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SubmitMintBatchSignaturesResponse) GetResults() []*MintBatchSignatureResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type PreviewHardwareTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserDeviceId  string                 `protobuf:"bytes,1,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewHardwareTemplateRequest) Reset() {
+	*x = PreviewHardwareTemplateRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewHardwareTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewHardwareTemplateRequest) ProtoMessage() {}
+
+func (x *PreviewHardwareTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewHardwareTemplateRequest.ProtoReflect.Descriptor instead.
+func (*PreviewHardwareTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PreviewHardwareTemplateRequest) GetUserDeviceId() string {
+	if x != nil {
+		return x.UserDeviceId
+	}
+	return ""
+}
+
+type HardwareTemplateRuleCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "DeviceStyle", "DeviceDefinition", "Make", "Powertrain", "IntegrationDefault", or
+	// "Fallback".
+	Rule string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// The template the rule would give, if any.
+	TemplateId    string `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Matched       bool   `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HardwareTemplateRuleCheck) Reset() {
+	*x = HardwareTemplateRuleCheck{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HardwareTemplateRuleCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HardwareTemplateRuleCheck) ProtoMessage() {}
+
+func (x *HardwareTemplateRuleCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HardwareTemplateRuleCheck.ProtoReflect.Descriptor instead.
+func (*HardwareTemplateRuleCheck) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *HardwareTemplateRuleCheck) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *HardwareTemplateRuleCheck) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *HardwareTemplateRuleCheck) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *HardwareTemplateRuleCheck) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PreviewHardwareTemplateResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	HardwareTemplateId string                 `protobuf:"bytes,1,opt,name=hardware_template_id,json=hardwareTemplateId,proto3" json:"hardware_template_id,omitempty"`
+	// The rule that chose the template.
+	Rule string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Rules in the order they were checked. Checking stops at the first match.
+	Checks        []*HardwareTemplateRuleCheck `protobuf:"bytes,3,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewHardwareTemplateResponse) Reset() {
+	*x = PreviewHardwareTemplateResponse{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewHardwareTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewHardwareTemplateResponse) ProtoMessage() {}
+
+func (x *PreviewHardwareTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewHardwareTemplateResponse.ProtoReflect.Descriptor instead.
+func (*PreviewHardwareTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PreviewHardwareTemplateResponse) GetHardwareTemplateId() string {
+	if x != nil {
+		return x.HardwareTemplateId
+	}
+	return ""
+}
+
+func (x *PreviewHardwareTemplateResponse) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *PreviewHardwareTemplateResponse) GetChecks() []*HardwareTemplateRuleCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type CreateTemplateMigrationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Move every device currently on this template. Leave at 0 when giving device_addresses.
+	SourceTemplateId int64 `protobuf:"varint,1,opt,name=source_template_id,json=sourceTemplateId,proto3" json:"source_template_id,omitempty"`
+	// Addresses of the aftermarket devices to move, instead of a source template.
+	DeviceAddresses  [][]byte `protobuf:"bytes,2,rep,name=device_addresses,json=deviceAddresses,proto3" json:"device_addresses,omitempty"`
+	TargetTemplateId int64    `protobuf:"varint,3,opt,name=target_template_id,json=targetTemplateId,proto3" json:"target_template_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateTemplateMigrationRequest) Reset() {
+	*x = CreateTemplateMigrationRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateMigrationRequest) ProtoMessage() {}
+
+func (x *CreateTemplateMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateMigrationRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateTemplateMigrationRequest) GetSourceTemplateId() int64 {
+	if x != nil {
+		return x.SourceTemplateId
+	}
+	return 0
+}
+
+func (x *CreateTemplateMigrationRequest) GetDeviceAddresses() [][]byte {
+	if x != nil {
+		return x.DeviceAddresses
+	}
+	return nil
+}
+
+func (x *CreateTemplateMigrationRequest) GetTargetTemplateId() int64 {
+	if x != nil {
+		return x.TargetTemplateId
+	}
+	return 0
+}
+
+type GetTemplateMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateMigrationRequest) Reset() {
+	*x = GetTemplateMigrationRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateMigrationRequest) ProtoMessage() {}
+
+func (x *GetTemplateMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateMigrationRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetTemplateMigrationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseTemplateMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseTemplateMigrationRequest) Reset() {
+	*x = PauseTemplateMigrationRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseTemplateMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseTemplateMigrationRequest) ProtoMessage() {}
+
+func (x *PauseTemplateMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseTemplateMigrationRequest.ProtoReflect.Descriptor instead.
+func (*PauseTemplateMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PauseTemplateMigrationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeTemplateMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeTemplateMigrationRequest) Reset() {
+	*x = ResumeTemplateMigrationRequest{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeTemplateMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeTemplateMigrationRequest) ProtoMessage() {}
+
+func (x *ResumeTemplateMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeTemplateMigrationRequest.ProtoReflect.Descriptor instead.
+func (*ResumeTemplateMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ResumeTemplateMigrationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TemplateMigrationFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serial        string                 `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateMigrationFailure) Reset() {
+	*x = TemplateMigrationFailure{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateMigrationFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateMigrationFailure) ProtoMessage() {}
+
+func (x *TemplateMigrationFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateMigrationFailure.ProtoReflect.Descriptor instead.
+func (*TemplateMigrationFailure) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TemplateMigrationFailure) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *TemplateMigrationFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TemplateMigration struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Zero when the devices were given directly.
+	SourceTemplateId int64 `protobuf:"varint,2,opt,name=source_template_id,json=sourceTemplateId,proto3" json:"source_template_id,omitempty"`
+	TargetTemplateId int64 `protobuf:"varint,3,opt,name=target_template_id,json=targetTemplateId,proto3" json:"target_template_id,omitempty"`
+	// One of "Running", "Paused", or "Completed".
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// Device counts by status.
+	Pending   int64 `protobuf:"varint,5,opt,name=pending,proto3" json:"pending,omitempty"`
+	Succeeded int64 `protobuf:"varint,6,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed    int64 `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The most recent failures, up to 100.
+	Failures      []*TemplateMigrationFailure `protobuf:"bytes,8,rep,name=failures,proto3" json:"failures,omitempty"`
+	CreatedAt     *timestamppb.Timestamp      `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp      `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateMigration) Reset() {
+	*x = TemplateMigration{}
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateMigration) ProtoMessage() {}
+
+func (x *TemplateMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateMigration.ProtoReflect.Descriptor instead.
+func (*TemplateMigration) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TemplateMigration) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TemplateMigration) GetSourceTemplateId() int64 {
+	if x != nil {
+		return x.SourceTemplateId
+	}
+	return 0
+}
+
+func (x *TemplateMigration) GetTargetTemplateId() int64 {
+	if x != nil {
+		return x.TargetTemplateId
+	}
+	return 0
+}
+
+func (x *TemplateMigration) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TemplateMigration) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *TemplateMigration) GetSucceeded() int64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *TemplateMigration) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TemplateMigration) GetFailures() []*TemplateMigrationFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *TemplateMigration) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TemplateMigration) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+var File_pkg_grpc_user_devices_proto protoreflect.FileDescriptor
+
+const file_pkg_grpc_user_devices_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpkg/grpc/user_devices.proto\x12\adevices\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\"pkg/grpc/aftermarket_devices.proto\";\n" +
+	"\x1eGetVehicleByTokenIdFastRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\rR\atokenId\"3\n" +
+	"\x1fGetVehicleByTokenIdFastResponse\x12\x10\n" +
+	"\x03vin\x18\x01 \x01(\tR\x03vin\"4\n" +
+	"\"GetUserDeviceByAutoPIUnitIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14GetUserDeviceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x19GetUserDeviceByVINRequest\x12\x10\n" +
+	"\x03vin\x18\x01 \x01(\tR\x03vin\":\n" +
+	"\x1dGetUserDeviceByEthAddrRequest\x12\x19\n" +
+	"\beth_addr\x18\x01 \x01(\fR\aethAddr\":\n" +
+	"\x1dGetUserDeviceByTokenIdRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x03R\atokenId\"\x9f\x02\n" +
+	"\x1fUpdateUserDeviceMetadataRequest\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12$\n" +
+	"\vpostal_code\x18\x02 \x01(\tH\x00R\n" +
+	"postalCode\x88\x01\x01\x123\n" +
+	"\x13geo_decoded_country\x18\x03 \x01(\tH\x01R\x11geoDecodedCountry\x88\x01\x01\x128\n" +
+	"\x16geo_decoded_state_prov\x18\x04 \x01(\tH\x02R\x13geoDecodedStateProv\x88\x01\x01B\x0e\n" +
+	"\f_postal_codeB\x16\n" +
+	"\x14_geo_decoded_countryB\x19\n" +
+	"\x17_geo_decoded_state_prov\"\xad\n" +
+	"\n" +
+	"\n" +
+	"UserDevice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1e\n" +
+	"\btoken_id\x18\x03 \x01(\x04H\x00R\atokenId\x88\x01\x01\x12?\n" +
+	"\vopted_in_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\toptedInAt\x88\x01\x01\x12(\n" +
+	"\rowner_address\x18\x05 \x01(\fH\x02R\fownerAddress\x88\x01\x01\x12F\n" +
+	"\x1baftermarket_device_token_id\x18\x06 \x01(\x04B\x02\x18\x01H\x03R\x18aftermarketDeviceTokenId\x88\x01\x01\x12B\n" +
+	"\fintegrations\x18\a \x03(\v2\x1e.devices.UserDeviceIntegrationR\fintegrations\x12\x15\n" +
+	"\x03vin\x18\b \x01(\tH\x04R\x03vin\x88\x01\x01\x124\n" +
+	"\x14device_definition_id\x18\t \x01(\tB\x02\x18\x01R\x12deviceDefinitionId\x12+\n" +
+	"\x0fdevice_style_id\x18\n" +
+	" \x01(\tH\x05R\rdeviceStyleId\x88\x01\x01\x12\\\n" +
+	"&aftermarket_device_beneficiary_address\x18\v \x01(\fB\x02\x18\x01H\x06R#aftermarketDeviceBeneficiaryAddress\x88\x01\x01\x12S\n" +
+	"\x15latest_vin_credential\x18\f \x01(\v2\x16.devices.VinCredentialB\x02\x18\x01H\aR\x13latestVinCredential\x88\x01\x01\x12#\n" +
+	"\rvin_confirmed\x18\r \x01(\bR\fvinConfirmed\x12!\n" +
+	"\fcountry_code\x18\x0e \x01(\tR\vcountryCode\x12(\n" +
+	"\x10power_train_type\x18\x0f \x01(\tR\x0epowerTrainType\x12!\n" +
+	"\fCAN_protocol\x18\x10 \x01(\tR\vCANProtocol\x12\x1f\n" +
+	"\vpostal_code\x18\x11 \x01(\tR\n" +
+	"postalCode\x12.\n" +
+	"\x13geo_decoded_country\x18\x12 \x01(\tR\x11geoDecodedCountry\x123\n" +
+	"\x16geo_decoded_state_prov\x18\x13 \x01(\tR\x13geoDecodedStateProv\x12N\n" +
+	"\x12aftermarket_device\x18\x14 \x01(\v2\x1a.devices.AftermarketDeviceH\bR\x11aftermarketDevice\x88\x01\x01\x12G\n" +
+	"\x0fsyntheticDevice\x18\x15 \x01(\v2\x18.devices.SyntheticDeviceH\tR\x0fsyntheticDevice\x88\x01\x01\x12#\n" +
+	"\rdefinition_id\x18\x16 \x01(\tR\fdefinitionIdB\v\n" +
+	"\t_token_idB\x0e\n" +
+	"\f_opted_in_atB\x10\n" +
+	"\x0e_owner_addressB\x1e\n" +
+	"\x1c_aftermarket_device_token_idB\x06\n" +
+	"\x04_vinB\x12\n" +
+	"\x10_device_style_idB)\n" +
+	"'_aftermarket_device_beneficiary_addressB\x18\n" +
+	"\x16_latest_vin_credentialB\x15\n" +
+	"\x13_aftermarket_deviceB\x12\n" +
+	"\x10_syntheticDevice\"\x93\x01\n" +
+	"\x0fSyntheticDevice\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x120\n" +
+	"\x14integration_token_id\x18\x02 \x01(\x04R\x12integrationTokenId\x123\n" +
+	"\x16wallet_child_number_id\x18\x03 \x01(\x04R\x13walletChildNumberId\"`\n" +
+	"\x15UserDeviceIntegration\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vexternal_id\x18\x03 \x01(\tR\n" +
+	"externalId\"\xb7\x01\n" +
+	"\x1cUserDeviceAutoPIUnitResponse\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x14device_definition_id\x18\x03 \x01(\tR\x12deviceDefinitionId\x12&\n" +
+	"\x0fdevice_style_id\x18\x04 \x01(\tR\rdeviceStyleId\"c\n" +
+	"\x1dListUserDevicesForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x10ethereum_address\x18\x02 \x01(\tR\x0fethereumAddress\"X\n" +
+	"\x1eListUserDevicesForUserResponse\x126\n" +
+	"\fuser_devices\x18\x01 \x03(\v2\x13.devices.UserDeviceR\vuserDevices\"\xd0\x01\n" +
+	"\x1cApplyHardwareTemplateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0euser_device_id\x18\x02 \x01(\tR\fuserDeviceId\x12'\n" +
+	"\x10auto_api_unit_id\x18\x03 \x01(\tR\rautoApiUnitId\x120\n" +
+	"\x14hardware_template_id\x18\x04 \x01(\tR\x12hardwareTemplateId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"9\n" +
+	"\x1dApplyHardwareTemplateResponse\x12\x18\n" +
+	"\aApplied\x18\x01 \x01(\bR\aApplied\"w\n" +
+	"\x15ClaimedVehiclesGrowth\x122\n" +
+	"\x14TotalClaimedVehicles\x18\x01 \x01(\x03R\x14TotalClaimedVehicles\x12*\n" +
+	"\x10GrowthPercentage\x18\x02 \x01(\x02R\x10GrowthPercentage\"C\n" +
+	"\x15CreateTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06parent\x18\x02 \x01(\x03R\x06parent\"(\n" +
+	"\x16CreateTemplateResponse\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\"\xca\x01\n" +
+	" RegisterUserDeviceFromVINRequest\x12(\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tB\x02\x18\x01R\fuserDeviceId\x12\x10\n" +
+	"\x03vin\x18\x02 \x01(\tR\x03vin\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x12\"\n" +
+	"\fvinConfirmed\x18\x04 \x01(\bR\fvinConfirmed\x12#\n" +
+	"\rowner_address\x18\x05 \x01(\fR\fownerAddress\"=\n" +
+	"!RegisterUserDeviceFromVINResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\"[\n" +
+	"\rVinCredential\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12:\n" +
+	"\n" +
+	"expiration\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiration\"\x8b\x01\n" +
+	"$UpdateDeviceIntegrationStatusRequest\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12%\n" +
+	"\x0eintegration_id\x18\x02 \x01(\tR\rintegrationId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x83\x01\n" +
+	"\x19IssueVinCredentialRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x10\n" +
+	"\x03vin\x18\x02 \x01(\tR\x03vin\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"A\n" +
+	"\x1aIssueVinCredentialResponse\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\tR\fcredentialId\"+\n" +
+	"\x17GetAllUserDeviceRequest\x12\x10\n" +
+	"\x03wmi\x18\x01 \x01(\tR\x03wmi\"6\n" +
+	"$ClearMetaTransactionRequestsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	" StopUserDeviceIntegrationRequest\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12%\n" +
+	"\x0eintegration_id\x18\x02 \x01(\tR\rintegrationId\"1\n" +
+	"\x14DeleteVehicleRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\"G\n" +
+	"\x1fDeleteUnMintedUserDeviceRequest\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\"e\n" +
+	"\x1aListVehicleCommandsRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\xfe\x01\n" +
+	"\x0eVehicleCommand\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12%\n" +
+	"\x0eintegration_id\x18\x03 \x01(\tR\rintegrationId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"s\n" +
+	"\x1bListVehicleCommandsResponse\x123\n" +
+	"\bcommands\x18\x01 \x03(\v2\x17.devices.VehicleCommandR\bcommands\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"T\n" +
+	"\x18GetVehicleCommandRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\x04R\atokenId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"q\n" +
+	"\x15MintBatchVehicleInput\x12\x10\n" +
+	"\x03vin\x18\x01 \x01(\tR\x03vin\x12#\n" +
+	"\rdefinition_id\x18\x02 \x01(\tR\fdefinitionId\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\"y\n" +
+	"\x16CreateMintBatchRequest\x12#\n" +
+	"\rowner_address\x18\x01 \x01(\fR\fownerAddress\x12:\n" +
+	"\bvehicles\x18\x02 \x03(\v2\x1e.devices.MintBatchVehicleInputR\bvehicles\"J\n" +
+	"\x13GetMintBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rowner_address\x18\x02 \x01(\fR\fownerAddress\"\xec\x01\n" +
+	"\x10MintBatchVehicle\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12\x10\n" +
+	"\x03vin\x18\x02 \x01(\tR\x03vin\x12#\n" +
+	"\rdefinition_id\x18\x03 \x01(\tR\fdefinitionId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\x06 \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"typed_data\x18\a \x01(\tR\ttypedData\"\xb2\x01\n" +
+	"\tMintBatch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rowner_address\x18\x02 \x01(\fR\fownerAddress\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\bvehicles\x18\x04 \x03(\v2\x19.devices.MintBatchVehicleR\bvehicles\"X\n" +
+	"\x12MintBatchSignature\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\"\x94\x01\n" +
+	" SubmitMintBatchSignaturesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rowner_address\x18\x02 \x01(\fR\fownerAddress\x12;\n" +
+	"\n" +
+	"signatures\x18\x03 \x03(\v2\x1b.devices.MintBatchSignatureR\n" +
+	"signatures\"u\n" +
+	"\x18MintBatchSignatureResult\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"`\n" +
+	"!SubmitMintBatchSignaturesResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.devices.MintBatchSignatureResultR\aresults\"F\n" +
+	"\x1ePreviewHardwareTemplateRequest\x12$\n" +
+	"\x0euser_device_id\x18\x01 \x01(\tR\fuserDeviceId\"\x82\x01\n" +
+	"\x19HardwareTemplateRuleCheck\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\amatched\x18\x03 \x01(\bR\amatched\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xa3\x01\n" +
+	"\x1fPreviewHardwareTemplateResponse\x120\n" +
+	"\x14hardware_template_id\x18\x01 \x01(\tR\x12hardwareTemplateId\x12\x12\n" +
+	"\x04rule\x18\x02 \x01(\tR\x04rule\x12:\n" +
+	"\x06checks\x18\x03 \x03(\v2\".devices.HardwareTemplateRuleCheckR\x06checks\"\xa7\x01\n" +
+	"\x1eCreateTemplateMigrationRequest\x12,\n" +
+	"\x12source_template_id\x18\x01 \x01(\x03R\x10sourceTemplateId\x12)\n" +
+	"\x10device_addresses\x18\x02 \x03(\fR\x0fdeviceAddresses\x12,\n" +
+	"\x12target_template_id\x18\x03 \x01(\x03R\x10targetTemplateId\"-\n" +
+	"\x1bGetTemplateMigrationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x1dPauseTemplateMigrationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x1eResumeTemplateMigrationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x18TemplateMigrationFailure\x12\x16\n" +
+	"\x06serial\x18\x01 \x01(\tR\x06serial\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xa0\x03\n" +
+	"\x11TemplateMigration\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x12source_template_id\x18\x02 \x01(\x03R\x10sourceTemplateId\x12,\n" +
+	"\x12target_template_id\x18\x03 \x01(\x03R\x10targetTemplateId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\apending\x18\x05 \x01(\x03R\apending\x12\x1c\n" +
+	"\tsucceeded\x18\x06 \x01(\x03R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x03R\x06failed\x12=\n" +
+	"\bfailures\x18\b \x03(\v2!.devices.TemplateMigrationFailureR\bfailures\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt2\xb8\x14\n" +
 	"\x11UserDeviceService\x12C\n" +
 	"\rGetUserDevice\x12\x1d.devices.GetUserDeviceRequest\x1a\x13.devices.UserDevice\x12U\n" +
 	"\x16GetUserDeviceByTokenId\x12&.devices.GetUserDeviceByTokenIdRequest\x1a\x13.devices.UserDevice\x12M\n" +
@@ -1892,7 +3346,17 @@ const file_pkg_grpc_user_devices_proto_rawDesc = "" +
 	"\x19StopUserDeviceIntegration\x12).devices.StopUserDeviceIntegrationRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\rDeleteVehicle\x12\x1d.devices.DeleteVehicleRequest\x1a\x16.google.protobuf.Empty\x12\\\n" +
 	"\x18DeleteUnMintedUserDevice\x12(.devices.DeleteUnMintedUserDeviceRequest\x1a\x16.google.protobuf.Empty\x12l\n" +
-	"\x17GetVehicleByTokenIdFast\x12'.devices.GetVehicleByTokenIdFastRequest\x1a(.devices.GetVehicleByTokenIdFastResponseB.Z,github.com/DIMO-Network/devices-api/pkg/grpcb\x06proto3"
+	"\x17GetVehicleByTokenIdFast\x12'.devices.GetVehicleByTokenIdFastRequest\x1a(.devices.GetVehicleByTokenIdFastResponse\x12`\n" +
+	"\x13ListVehicleCommands\x12#.devices.ListVehicleCommandsRequest\x1a$.devices.ListVehicleCommandsResponse\x12O\n" +
+	"\x11GetVehicleCommand\x12!.devices.GetVehicleCommandRequest\x1a\x17.devices.VehicleCommand\x12F\n" +
+	"\x0fCreateMintBatch\x12\x1f.devices.CreateMintBatchRequest\x1a\x12.devices.MintBatch\x12@\n" +
+	"\fGetMintBatch\x12\x1c.devices.GetMintBatchRequest\x1a\x12.devices.MintBatch\x12r\n" +
+	"\x19SubmitMintBatchSignatures\x12).devices.SubmitMintBatchSignaturesRequest\x1a*.devices.SubmitMintBatchSignaturesResponse\x12l\n" +
+	"\x17PreviewHardwareTemplate\x12'.devices.PreviewHardwareTemplateRequest\x1a(.devices.PreviewHardwareTemplateResponse\x12^\n" +
+	"\x17CreateTemplateMigration\x12'.devices.CreateTemplateMigrationRequest\x1a\x1a.devices.TemplateMigration\x12X\n" +
+	"\x14GetTemplateMigration\x12$.devices.GetTemplateMigrationRequest\x1a\x1a.devices.TemplateMigration\x12\\\n" +
+	"\x16PauseTemplateMigration\x12&.devices.PauseTemplateMigrationRequest\x1a\x1a.devices.TemplateMigration\x12^\n" +
+	"\x17ResumeTemplateMigration\x12'.devices.ResumeTemplateMigrationRequest\x1a\x1a.devices.TemplateMigrationB.Z,github.com/DIMO-Network/devices-api/pkg/grpcb\x06proto3"
 
 var (
 	file_pkg_grpc_user_devices_proto_rawDescOnce sync.Once
@@ -1906,7 +3370,7 @@ func file_pkg_grpc_user_devices_proto_rawDescGZIP() []byte {
 	return file_pkg_grpc_user_devices_proto_rawDescData
 }
 
-var file_pkg_grpc_user_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_pkg_grpc_user_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_pkg_grpc_user_devices_proto_goTypes = []any{
 	(*GetVehicleByTokenIdFastRequest)(nil),       // 0: devices.GetVehicleByTokenIdFastRequest
 	(*GetVehicleByTokenIdFastResponse)(nil),      // 1: devices.GetVehicleByTokenIdFastResponse
@@ -1938,60 +3402,114 @@ var file_pkg_grpc_user_devices_proto_goTypes = []any{
 	(*StopUserDeviceIntegrationRequest)(nil),     // 27: devices.StopUserDeviceIntegrationRequest
 	(*DeleteVehicleRequest)(nil),                 // 28: devices.DeleteVehicleRequest
 	(*DeleteUnMintedUserDeviceRequest)(nil),      // 29: devices.DeleteUnMintedUserDeviceRequest
-	(*timestamppb.Timestamp)(nil),                // 30: google.protobuf.Timestamp
-	(*AftermarketDevice)(nil),                    // 31: devices.AftermarketDevice
-	(*emptypb.Empty)(nil),                        // 32: google.protobuf.Empty
+	(*ListVehicleCommandsRequest)(nil),           // 30: devices.ListVehicleCommandsRequest
+	(*VehicleCommand)(nil),                       // 31: devices.VehicleCommand
+	(*ListVehicleCommandsResponse)(nil),          // 32: devices.ListVehicleCommandsResponse
+	(*GetVehicleCommandRequest)(nil),             // 33: devices.GetVehicleCommandRequest
+	(*MintBatchVehicleInput)(nil),                // 34: devices.MintBatchVehicleInput
+	(*CreateMintBatchRequest)(nil),               // 35: devices.CreateMintBatchRequest
+	(*GetMintBatchRequest)(nil),                  // 36: devices.GetMintBatchRequest
+	(*MintBatchVehicle)(nil),                     // 37: devices.MintBatchVehicle
+	(*MintBatch)(nil),                            // 38: devices.MintBatch
+	(*MintBatchSignature)(nil),                   // 39: devices.MintBatchSignature
+	(*SubmitMintBatchSignaturesRequest)(nil),     // 40: devices.SubmitMintBatchSignaturesRequest
+	(*MintBatchSignatureResult)(nil),             // 41: devices.MintBatchSignatureResult
+	(*SubmitMintBatchSignaturesResponse)(nil),    // 42: devices.SubmitMintBatchSignaturesResponse
+	(*PreviewHardwareTemplateRequest)(nil),       // 43: devices.PreviewHardwareTemplateRequest
+	(*HardwareTemplateRuleCheck)(nil),            // 44: devices.HardwareTemplateRuleCheck
+	(*PreviewHardwareTemplateResponse)(nil),      // 45: devices.PreviewHardwareTemplateResponse
+	(*CreateTemplateMigrationRequest)(nil),       // 46: devices.CreateTemplateMigrationRequest
+	(*GetTemplateMigrationRequest)(nil),          // 47: devices.GetTemplateMigrationRequest
+	(*PauseTemplateMigrationRequest)(nil),        // 48: devices.PauseTemplateMigrationRequest
+	(*ResumeTemplateMigrationRequest)(nil),       // 49: devices.ResumeTemplateMigrationRequest
+	(*TemplateMigrationFailure)(nil),             // 50: devices.TemplateMigrationFailure
+	(*TemplateMigration)(nil),                    // 51: devices.TemplateMigration
+	(*timestamppb.Timestamp)(nil),                // 52: google.protobuf.Timestamp
+	(*AftermarketDevice)(nil),                    // 53: devices.AftermarketDevice
+	(*emptypb.Empty)(nil),                        // 54: google.protobuf.Empty
 }
 var file_pkg_grpc_user_devices_proto_depIdxs = []int32{
-	30, // 0: devices.UserDevice.opted_in_at:type_name -> google.protobuf.Timestamp
+	52, // 0: devices.UserDevice.opted_in_at:type_name -> google.protobuf.Timestamp
 	10, // 1: devices.UserDevice.integrations:type_name -> devices.UserDeviceIntegration
 	21, // 2: devices.UserDevice.latest_vin_credential:type_name -> devices.VinCredential
-	31, // 3: devices.UserDevice.aftermarket_device:type_name -> devices.AftermarketDevice
+	53, // 3: devices.UserDevice.aftermarket_device:type_name -> devices.AftermarketDevice
 	9,  // 4: devices.UserDevice.syntheticDevice:type_name -> devices.SyntheticDevice
 	8,  // 5: devices.ListUserDevicesForUserResponse.user_devices:type_name -> devices.UserDevice
-	30, // 6: devices.VinCredential.expiration:type_name -> google.protobuf.Timestamp
-	30, // 7: devices.IssueVinCredentialRequest.expires_at:type_name -> google.protobuf.Timestamp
-	3,  // 8: devices.UserDeviceService.GetUserDevice:input_type -> devices.GetUserDeviceRequest
-	6,  // 9: devices.UserDeviceService.GetUserDeviceByTokenId:input_type -> devices.GetUserDeviceByTokenIdRequest
-	4,  // 10: devices.UserDeviceService.GetUserDeviceByVIN:input_type -> devices.GetUserDeviceByVINRequest
-	5,  // 11: devices.UserDeviceService.GetUserDeviceByEthAddr:input_type -> devices.GetUserDeviceByEthAddrRequest
-	12, // 12: devices.UserDeviceService.ListUserDevicesForUser:input_type -> devices.ListUserDevicesForUserRequest
-	14, // 13: devices.UserDeviceService.ApplyHardwareTemplate:input_type -> devices.ApplyHardwareTemplateRequest
-	2,  // 14: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:input_type -> devices.GetUserDeviceByAutoPIUnitIdRequest
-	32, // 15: devices.UserDeviceService.GetClaimedVehiclesGrowth:input_type -> google.protobuf.Empty
-	17, // 16: devices.UserDeviceService.CreateTemplate:input_type -> devices.CreateTemplateRequest
-	19, // 17: devices.UserDeviceService.RegisterUserDeviceFromVIN:input_type -> devices.RegisterUserDeviceFromVINRequest
-	22, // 18: devices.UserDeviceService.UpdateDeviceIntegrationStatus:input_type -> devices.UpdateDeviceIntegrationStatusRequest
-	25, // 19: devices.UserDeviceService.GetAllUserDevice:input_type -> devices.GetAllUserDeviceRequest
-	7,  // 20: devices.UserDeviceService.UpdateUserDeviceMetadata:input_type -> devices.UpdateUserDeviceMetadataRequest
-	32, // 21: devices.UserDeviceService.ClearMetaTransactionRequests:input_type -> google.protobuf.Empty
-	27, // 22: devices.UserDeviceService.StopUserDeviceIntegration:input_type -> devices.StopUserDeviceIntegrationRequest
-	28, // 23: devices.UserDeviceService.DeleteVehicle:input_type -> devices.DeleteVehicleRequest
-	29, // 24: devices.UserDeviceService.DeleteUnMintedUserDevice:input_type -> devices.DeleteUnMintedUserDeviceRequest
-	0,  // 25: devices.UserDeviceService.GetVehicleByTokenIdFast:input_type -> devices.GetVehicleByTokenIdFastRequest
-	8,  // 26: devices.UserDeviceService.GetUserDevice:output_type -> devices.UserDevice
-	8,  // 27: devices.UserDeviceService.GetUserDeviceByTokenId:output_type -> devices.UserDevice
-	8,  // 28: devices.UserDeviceService.GetUserDeviceByVIN:output_type -> devices.UserDevice
-	8,  // 29: devices.UserDeviceService.GetUserDeviceByEthAddr:output_type -> devices.UserDevice
-	13, // 30: devices.UserDeviceService.ListUserDevicesForUser:output_type -> devices.ListUserDevicesForUserResponse
-	15, // 31: devices.UserDeviceService.ApplyHardwareTemplate:output_type -> devices.ApplyHardwareTemplateResponse
-	11, // 32: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:output_type -> devices.UserDeviceAutoPIUnitResponse
-	16, // 33: devices.UserDeviceService.GetClaimedVehiclesGrowth:output_type -> devices.ClaimedVehiclesGrowth
-	18, // 34: devices.UserDeviceService.CreateTemplate:output_type -> devices.CreateTemplateResponse
-	20, // 35: devices.UserDeviceService.RegisterUserDeviceFromVIN:output_type -> devices.RegisterUserDeviceFromVINResponse
-	8,  // 36: devices.UserDeviceService.UpdateDeviceIntegrationStatus:output_type -> devices.UserDevice
-	8,  // 37: devices.UserDeviceService.GetAllUserDevice:output_type -> devices.UserDevice
-	32, // 38: devices.UserDeviceService.UpdateUserDeviceMetadata:output_type -> google.protobuf.Empty
-	26, // 39: devices.UserDeviceService.ClearMetaTransactionRequests:output_type -> devices.ClearMetaTransactionRequestsResponse
-	32, // 40: devices.UserDeviceService.StopUserDeviceIntegration:output_type -> google.protobuf.Empty
-	32, // 41: devices.UserDeviceService.DeleteVehicle:output_type -> google.protobuf.Empty
-	32, // 42: devices.UserDeviceService.DeleteUnMintedUserDevice:output_type -> google.protobuf.Empty
-	1,  // 43: devices.UserDeviceService.GetVehicleByTokenIdFast:output_type -> devices.GetVehicleByTokenIdFastResponse
-	26, // [26:44] is the sub-list for method output_type
-	8,  // [8:26] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	52, // 6: devices.VinCredential.expiration:type_name -> google.protobuf.Timestamp
+	52, // 7: devices.IssueVinCredentialRequest.expires_at:type_name -> google.protobuf.Timestamp
+	52, // 8: devices.VehicleCommand.created_at:type_name -> google.protobuf.Timestamp
+	52, // 9: devices.VehicleCommand.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 10: devices.ListVehicleCommandsResponse.commands:type_name -> devices.VehicleCommand
+	34, // 11: devices.CreateMintBatchRequest.vehicles:type_name -> devices.MintBatchVehicleInput
+	52, // 12: devices.MintBatch.created_at:type_name -> google.protobuf.Timestamp
+	37, // 13: devices.MintBatch.vehicles:type_name -> devices.MintBatchVehicle
+	39, // 14: devices.SubmitMintBatchSignaturesRequest.signatures:type_name -> devices.MintBatchSignature
+	41, // 15: devices.SubmitMintBatchSignaturesResponse.results:type_name -> devices.MintBatchSignatureResult
+	44, // 16: devices.PreviewHardwareTemplateResponse.checks:type_name -> devices.HardwareTemplateRuleCheck
+	50, // 17: devices.TemplateMigration.failures:type_name -> devices.TemplateMigrationFailure
+	52, // 18: devices.TemplateMigration.created_at:type_name -> google.protobuf.Timestamp
+	52, // 19: devices.TemplateMigration.completed_at:type_name -> google.protobuf.Timestamp
+	3,  // 20: devices.UserDeviceService.GetUserDevice:input_type -> devices.GetUserDeviceRequest
+	6,  // 21: devices.UserDeviceService.GetUserDeviceByTokenId:input_type -> devices.GetUserDeviceByTokenIdRequest
+	4,  // 22: devices.UserDeviceService.GetUserDeviceByVIN:input_type -> devices.GetUserDeviceByVINRequest
+	5,  // 23: devices.UserDeviceService.GetUserDeviceByEthAddr:input_type -> devices.GetUserDeviceByEthAddrRequest
+	12, // 24: devices.UserDeviceService.ListUserDevicesForUser:input_type -> devices.ListUserDevicesForUserRequest
+	14, // 25: devices.UserDeviceService.ApplyHardwareTemplate:input_type -> devices.ApplyHardwareTemplateRequest
+	2,  // 26: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:input_type -> devices.GetUserDeviceByAutoPIUnitIdRequest
+	54, // 27: devices.UserDeviceService.GetClaimedVehiclesGrowth:input_type -> google.protobuf.Empty
+	17, // 28: devices.UserDeviceService.CreateTemplate:input_type -> devices.CreateTemplateRequest
+	19, // 29: devices.UserDeviceService.RegisterUserDeviceFromVIN:input_type -> devices.RegisterUserDeviceFromVINRequest
+	22, // 30: devices.UserDeviceService.UpdateDeviceIntegrationStatus:input_type -> devices.UpdateDeviceIntegrationStatusRequest
+	25, // 31: devices.UserDeviceService.GetAllUserDevice:input_type -> devices.GetAllUserDeviceRequest
+	7,  // 32: devices.UserDeviceService.UpdateUserDeviceMetadata:input_type -> devices.UpdateUserDeviceMetadataRequest
+	54, // 33: devices.UserDeviceService.ClearMetaTransactionRequests:input_type -> google.protobuf.Empty
+	27, // 34: devices.UserDeviceService.StopUserDeviceIntegration:input_type -> devices.StopUserDeviceIntegrationRequest
+	28, // 35: devices.UserDeviceService.DeleteVehicle:input_type -> devices.DeleteVehicleRequest
+	29, // 36: devices.UserDeviceService.DeleteUnMintedUserDevice:input_type -> devices.DeleteUnMintedUserDeviceRequest
+	0,  // 37: devices.UserDeviceService.GetVehicleByTokenIdFast:input_type -> devices.GetVehicleByTokenIdFastRequest
+	30, // 38: devices.UserDeviceService.ListVehicleCommands:input_type -> devices.ListVehicleCommandsRequest
+	33, // 39: devices.UserDeviceService.GetVehicleCommand:input_type -> devices.GetVehicleCommandRequest
+	35, // 40: devices.UserDeviceService.CreateMintBatch:input_type -> devices.CreateMintBatchRequest
+	36, // 41: devices.UserDeviceService.GetMintBatch:input_type -> devices.GetMintBatchRequest
+	40, // 42: devices.UserDeviceService.SubmitMintBatchSignatures:input_type -> devices.SubmitMintBatchSignaturesRequest
+	43, // 43: devices.UserDeviceService.PreviewHardwareTemplate:input_type -> devices.PreviewHardwareTemplateRequest
+	46, // 44: devices.UserDeviceService.CreateTemplateMigration:input_type -> devices.CreateTemplateMigrationRequest
+	47, // 45: devices.UserDeviceService.GetTemplateMigration:input_type -> devices.GetTemplateMigrationRequest
+	48, // 46: devices.UserDeviceService.PauseTemplateMigration:input_type -> devices.PauseTemplateMigrationRequest
+	49, // 47: devices.UserDeviceService.ResumeTemplateMigration:input_type -> devices.ResumeTemplateMigrationRequest
+	8,  // 48: devices.UserDeviceService.GetUserDevice:output_type -> devices.UserDevice
+	8,  // 49: devices.UserDeviceService.GetUserDeviceByTokenId:output_type -> devices.UserDevice
+	8,  // 50: devices.UserDeviceService.GetUserDeviceByVIN:output_type -> devices.UserDevice
+	8,  // 51: devices.UserDeviceService.GetUserDeviceByEthAddr:output_type -> devices.UserDevice
+	13, // 52: devices.UserDeviceService.ListUserDevicesForUser:output_type -> devices.ListUserDevicesForUserResponse
+	15, // 53: devices.UserDeviceService.ApplyHardwareTemplate:output_type -> devices.ApplyHardwareTemplateResponse
+	11, // 54: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:output_type -> devices.UserDeviceAutoPIUnitResponse
+	16, // 55: devices.UserDeviceService.GetClaimedVehiclesGrowth:output_type -> devices.ClaimedVehiclesGrowth
+	18, // 56: devices.UserDeviceService.CreateTemplate:output_type -> devices.CreateTemplateResponse
+	20, // 57: devices.UserDeviceService.RegisterUserDeviceFromVIN:output_type -> devices.RegisterUserDeviceFromVINResponse
+	8,  // 58: devices.UserDeviceService.UpdateDeviceIntegrationStatus:output_type -> devices.UserDevice
+	8,  // 59: devices.UserDeviceService.GetAllUserDevice:output_type -> devices.UserDevice
+	54, // 60: devices.UserDeviceService.UpdateUserDeviceMetadata:output_type -> google.protobuf.Empty
+	26, // 61: devices.UserDeviceService.ClearMetaTransactionRequests:output_type -> devices.ClearMetaTransactionRequestsResponse
+	54, // 62: devices.UserDeviceService.StopUserDeviceIntegration:output_type -> google.protobuf.Empty
+	54, // 63: devices.UserDeviceService.DeleteVehicle:output_type -> google.protobuf.Empty
+	54, // 64: devices.UserDeviceService.DeleteUnMintedUserDevice:output_type -> google.protobuf.Empty
+	1,  // 65: devices.UserDeviceService.GetVehicleByTokenIdFast:output_type -> devices.GetVehicleByTokenIdFastResponse
+	32, // 66: devices.UserDeviceService.ListVehicleCommands:output_type -> devices.ListVehicleCommandsResponse
+	31, // 67: devices.UserDeviceService.GetVehicleCommand:output_type -> devices.VehicleCommand
+	38, // 68: devices.UserDeviceService.CreateMintBatch:output_type -> devices.MintBatch
+	38, // 69: devices.UserDeviceService.GetMintBatch:output_type -> devices.MintBatch
+	42, // 70: devices.UserDeviceService.SubmitMintBatchSignatures:output_type -> devices.SubmitMintBatchSignaturesResponse
+	45, // 71: devices.UserDeviceService.PreviewHardwareTemplate:output_type -> devices.PreviewHardwareTemplateResponse
+	51, // 72: devices.UserDeviceService.CreateTemplateMigration:output_type -> devices.TemplateMigration
+	51, // 73: devices.UserDeviceService.GetTemplateMigration:output_type -> devices.TemplateMigration
+	51, // 74: devices.UserDeviceService.PauseTemplateMigration:output_type -> devices.TemplateMigration
+	51, // 75: devices.UserDeviceService.ResumeTemplateMigration:output_type -> devices.TemplateMigration
+	48, // [48:76] is the sub-list for method output_type
+	20, // [20:48] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_pkg_grpc_user_devices_proto_init() }
@@ -2008,7 +3526,7 @@ func file_pkg_grpc_user_devices_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_grpc_user_devices_proto_rawDesc), len(file_pkg_grpc_user_devices_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   30,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
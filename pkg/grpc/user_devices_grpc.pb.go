@@ -38,6 +38,16 @@ const (
 	UserDeviceService_DeleteVehicle_FullMethodName                 = "/devices.UserDeviceService/DeleteVehicle"
 	UserDeviceService_DeleteUnMintedUserDevice_FullMethodName      = "/devices.UserDeviceService/DeleteUnMintedUserDevice"
 	UserDeviceService_GetVehicleByTokenIdFast_FullMethodName       = "/devices.UserDeviceService/GetVehicleByTokenIdFast"
+	UserDeviceService_ListVehicleCommands_FullMethodName           = "/devices.UserDeviceService/ListVehicleCommands"
+	UserDeviceService_GetVehicleCommand_FullMethodName             = "/devices.UserDeviceService/GetVehicleCommand"
+	UserDeviceService_CreateMintBatch_FullMethodName               = "/devices.UserDeviceService/CreateMintBatch"
+	UserDeviceService_GetMintBatch_FullMethodName                  = "/devices.UserDeviceService/GetMintBatch"
+	UserDeviceService_SubmitMintBatchSignatures_FullMethodName     = "/devices.UserDeviceService/SubmitMintBatchSignatures"
+	UserDeviceService_PreviewHardwareTemplate_FullMethodName       = "/devices.UserDeviceService/PreviewHardwareTemplate"
+	UserDeviceService_CreateTemplateMigration_FullMethodName       = "/devices.UserDeviceService/CreateTemplateMigration"
+	UserDeviceService_GetTemplateMigration_FullMethodName          = "/devices.UserDeviceService/GetTemplateMigration"
+	UserDeviceService_PauseTemplateMigration_FullMethodName        = "/devices.UserDeviceService/PauseTemplateMigration"
+	UserDeviceService_ResumeTemplateMigration_FullMethodName       = "/devices.UserDeviceService/ResumeTemplateMigration"
 )
 
 // UserDeviceServiceClient is the client API for UserDeviceService service.
@@ -65,6 +75,25 @@ type UserDeviceServiceClient interface {
 	// used by dimo admin to delete unminted user_device records
 	DeleteUnMintedUserDevice(ctx context.Context, in *DeleteUnMintedUserDeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetVehicleByTokenIdFast(ctx context.Context, in *GetVehicleByTokenIdFastRequest, opts ...grpc.CallOption) (*GetVehicleByTokenIdFastResponse, error)
+	// Command history for a vehicle, newest first.
+	ListVehicleCommands(ctx context.Context, in *ListVehicleCommandsRequest, opts ...grpc.CallOption) (*ListVehicleCommandsResponse, error)
+	GetVehicleCommand(ctx context.Context, in *GetVehicleCommandRequest, opts ...grpc.CallOption) (*VehicleCommand, error)
+	// Registers vehicles for an owner and returns a mint payload to sign for each. If any
+	// vehicle is invalid, none are created.
+	CreateMintBatch(ctx context.Context, in *CreateMintBatchRequest, opts ...grpc.CallOption) (*MintBatch, error)
+	GetMintBatch(ctx context.Context, in *GetMintBatchRequest, opts ...grpc.CallOption) (*MintBatch, error)
+	// Sends the mints whose signatures check out. Each signature is handled independently.
+	SubmitMintBatchSignatures(ctx context.Context, in *SubmitMintBatchSignaturesRequest, opts ...grpc.CallOption) (*SubmitMintBatchSignaturesResponse, error)
+	// Works out which hardware template the vehicle's device should be on, and why, without
+	// applying it.
+	PreviewHardwareTemplate(ctx context.Context, in *PreviewHardwareTemplateRequest, opts ...grpc.CallOption) (*PreviewHardwareTemplateResponse, error)
+	// Starts moving AutoPi devices onto a hardware template in the background. Progress is kept, so
+	// the migration carries on after a restart.
+	CreateTemplateMigration(ctx context.Context, in *CreateTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error)
+	GetTemplateMigration(ctx context.Context, in *GetTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error)
+	// Stops a migration after the device in flight, if any.
+	PauseTemplateMigration(ctx context.Context, in *PauseTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error)
+	ResumeTemplateMigration(ctx context.Context, in *ResumeTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error)
 }
 
 type userDeviceServiceClient struct {
@@ -264,6 +293,106 @@ func (c *userDeviceServiceClient) GetVehicleByTokenIdFast(ctx context.Context, i
 	return out, nil
 }
 
+func (c *userDeviceServiceClient) ListVehicleCommands(ctx context.Context, in *ListVehicleCommandsRequest, opts ...grpc.CallOption) (*ListVehicleCommandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVehicleCommandsResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_ListVehicleCommands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) GetVehicleCommand(ctx context.Context, in *GetVehicleCommandRequest, opts ...grpc.CallOption) (*VehicleCommand, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VehicleCommand)
+	err := c.cc.Invoke(ctx, UserDeviceService_GetVehicleCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) CreateMintBatch(ctx context.Context, in *CreateMintBatchRequest, opts ...grpc.CallOption) (*MintBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintBatch)
+	err := c.cc.Invoke(ctx, UserDeviceService_CreateMintBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) GetMintBatch(ctx context.Context, in *GetMintBatchRequest, opts ...grpc.CallOption) (*MintBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintBatch)
+	err := c.cc.Invoke(ctx, UserDeviceService_GetMintBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) SubmitMintBatchSignatures(ctx context.Context, in *SubmitMintBatchSignaturesRequest, opts ...grpc.CallOption) (*SubmitMintBatchSignaturesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitMintBatchSignaturesResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_SubmitMintBatchSignatures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) PreviewHardwareTemplate(ctx context.Context, in *PreviewHardwareTemplateRequest, opts ...grpc.CallOption) (*PreviewHardwareTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewHardwareTemplateResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_PreviewHardwareTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) CreateTemplateMigration(ctx context.Context, in *CreateTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateMigration)
+	err := c.cc.Invoke(ctx, UserDeviceService_CreateTemplateMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) GetTemplateMigration(ctx context.Context, in *GetTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateMigration)
+	err := c.cc.Invoke(ctx, UserDeviceService_GetTemplateMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) PauseTemplateMigration(ctx context.Context, in *PauseTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateMigration)
+	err := c.cc.Invoke(ctx, UserDeviceService_PauseTemplateMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) ResumeTemplateMigration(ctx context.Context, in *ResumeTemplateMigrationRequest, opts ...grpc.CallOption) (*TemplateMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateMigration)
+	err := c.cc.Invoke(ctx, UserDeviceService_ResumeTemplateMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserDeviceServiceServer is the server API for UserDeviceService service.
 // All implementations must embed UnimplementedUserDeviceServiceServer
 // for forward compatibility.
@@ -289,6 +418,25 @@ type UserDeviceServiceServer interface {
 	// used by dimo admin to delete unminted user_device records
 	DeleteUnMintedUserDevice(context.Context, *DeleteUnMintedUserDeviceRequest) (*emptypb.Empty, error)
 	GetVehicleByTokenIdFast(context.Context, *GetVehicleByTokenIdFastRequest) (*GetVehicleByTokenIdFastResponse, error)
+	// Command history for a vehicle, newest first.
+	ListVehicleCommands(context.Context, *ListVehicleCommandsRequest) (*ListVehicleCommandsResponse, error)
+	GetVehicleCommand(context.Context, *GetVehicleCommandRequest) (*VehicleCommand, error)
+	// Registers vehicles for an owner and returns a mint payload to sign for each. If any
+	// vehicle is invalid, none are created.
+	CreateMintBatch(context.Context, *CreateMintBatchRequest) (*MintBatch, error)
+	GetMintBatch(context.Context, *GetMintBatchRequest) (*MintBatch, error)
+	// Sends the mints whose signatures check out. Each signature is handled independently.
+	SubmitMintBatchSignatures(context.Context, *SubmitMintBatchSignaturesRequest) (*SubmitMintBatchSignaturesResponse, error)
+	// Works out which hardware template the vehicle's device should be on, and why, without
+	// applying it.
+	PreviewHardwareTemplate(context.Context, *PreviewHardwareTemplateRequest) (*PreviewHardwareTemplateResponse, error)
+	// Starts moving AutoPi devices onto a hardware template in the background. Progress is kept, so
+	// the migration carries on after a restart.
+	CreateTemplateMigration(context.Context, *CreateTemplateMigrationRequest) (*TemplateMigration, error)
+	GetTemplateMigration(context.Context, *GetTemplateMigrationRequest) (*TemplateMigration, error)
+	// Stops a migration after the device in flight, if any.
+	PauseTemplateMigration(context.Context, *PauseTemplateMigrationRequest) (*TemplateMigration, error)
+	ResumeTemplateMigration(context.Context, *ResumeTemplateMigrationRequest) (*TemplateMigration, error)
 	mustEmbedUnimplementedUserDeviceServiceServer()
 }
 
@@ -353,6 +501,36 @@ func (UnimplementedUserDeviceServiceServer) DeleteUnMintedUserDevice(context.Con
 func (UnimplementedUserDeviceServiceServer) GetVehicleByTokenIdFast(context.Context, *GetVehicleByTokenIdFastRequest) (*GetVehicleByTokenIdFastResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleByTokenIdFast not implemented")
 }
+func (UnimplementedUserDeviceServiceServer) ListVehicleCommands(context.Context, *ListVehicleCommandsRequest) (*ListVehicleCommandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVehicleCommands not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) GetVehicleCommand(context.Context, *GetVehicleCommandRequest) (*VehicleCommand, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleCommand not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) CreateMintBatch(context.Context, *CreateMintBatchRequest) (*MintBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMintBatch not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) GetMintBatch(context.Context, *GetMintBatchRequest) (*MintBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMintBatch not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) SubmitMintBatchSignatures(context.Context, *SubmitMintBatchSignaturesRequest) (*SubmitMintBatchSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitMintBatchSignatures not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) PreviewHardwareTemplate(context.Context, *PreviewHardwareTemplateRequest) (*PreviewHardwareTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewHardwareTemplate not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) CreateTemplateMigration(context.Context, *CreateTemplateMigrationRequest) (*TemplateMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplateMigration not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) GetTemplateMigration(context.Context, *GetTemplateMigrationRequest) (*TemplateMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplateMigration not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) PauseTemplateMigration(context.Context, *PauseTemplateMigrationRequest) (*TemplateMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseTemplateMigration not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) ResumeTemplateMigration(context.Context, *ResumeTemplateMigrationRequest) (*TemplateMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeTemplateMigration not implemented")
+}
 func (UnimplementedUserDeviceServiceServer) mustEmbedUnimplementedUserDeviceServiceServer() {}
 func (UnimplementedUserDeviceServiceServer) testEmbeddedByValue()                           {}
 
@@ -691,6 +869,186 @@ func _UserDeviceService_GetVehicleByTokenIdFast_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserDeviceService_ListVehicleCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVehicleCommandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).ListVehicleCommands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_ListVehicleCommands_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).ListVehicleCommands(ctx, req.(*ListVehicleCommandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_GetVehicleCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).GetVehicleCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_GetVehicleCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).GetVehicleCommand(ctx, req.(*GetVehicleCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_CreateMintBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMintBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).CreateMintBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_CreateMintBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).CreateMintBatch(ctx, req.(*CreateMintBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_GetMintBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMintBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).GetMintBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_GetMintBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).GetMintBatch(ctx, req.(*GetMintBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_SubmitMintBatchSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitMintBatchSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).SubmitMintBatchSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_SubmitMintBatchSignatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).SubmitMintBatchSignatures(ctx, req.(*SubmitMintBatchSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_PreviewHardwareTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewHardwareTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).PreviewHardwareTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_PreviewHardwareTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).PreviewHardwareTemplate(ctx, req.(*PreviewHardwareTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_CreateTemplateMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).CreateTemplateMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_CreateTemplateMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).CreateTemplateMigration(ctx, req.(*CreateTemplateMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_GetTemplateMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).GetTemplateMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_GetTemplateMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).GetTemplateMigration(ctx, req.(*GetTemplateMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_PauseTemplateMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseTemplateMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).PauseTemplateMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_PauseTemplateMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).PauseTemplateMigration(ctx, req.(*PauseTemplateMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_ResumeTemplateMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeTemplateMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).ResumeTemplateMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_ResumeTemplateMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).ResumeTemplateMigration(ctx, req.(*ResumeTemplateMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserDeviceService_ServiceDesc is the grpc.ServiceDesc for UserDeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -766,6 +1124,46 @@ var UserDeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVehicleByTokenIdFast",
 			Handler:    _UserDeviceService_GetVehicleByTokenIdFast_Handler,
 		},
+		{
+			MethodName: "ListVehicleCommands",
+			Handler:    _UserDeviceService_ListVehicleCommands_Handler,
+		},
+		{
+			MethodName: "GetVehicleCommand",
+			Handler:    _UserDeviceService_GetVehicleCommand_Handler,
+		},
+		{
+			MethodName: "CreateMintBatch",
+			Handler:    _UserDeviceService_CreateMintBatch_Handler,
+		},
+		{
+			MethodName: "GetMintBatch",
+			Handler:    _UserDeviceService_GetMintBatch_Handler,
+		},
+		{
+			MethodName: "SubmitMintBatchSignatures",
+			Handler:    _UserDeviceService_SubmitMintBatchSignatures_Handler,
+		},
+		{
+			MethodName: "PreviewHardwareTemplate",
+			Handler:    _UserDeviceService_PreviewHardwareTemplate_Handler,
+		},
+		{
+			MethodName: "CreateTemplateMigration",
+			Handler:    _UserDeviceService_CreateTemplateMigration_Handler,
+		},
+		{
+			MethodName: "GetTemplateMigration",
+			Handler:    _UserDeviceService_GetTemplateMigration_Handler,
+		},
+		{
+			MethodName: "PauseTemplateMigration",
+			Handler:    _UserDeviceService_PauseTemplateMigration_Handler,
+		},
+		{
+			MethodName: "ResumeTemplateMigration",
+			Handler:    _UserDeviceService_ResumeTemplateMigration_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{